@@ -42,6 +42,18 @@ func main() {
 	rootCmd.AddCommand(commands.NewVersionCommand(buildVersion, buildDate, buildCommit))
 	rootCmd.AddCommand(commands.NewAuthCommands())
 	rootCmd.AddCommand(commands.NewDataCommands())
+	rootCmd.AddCommand(commands.NewTypeCommands())
+	rootCmd.AddCommand(commands.NewFolderCommands())
+	rootCmd.AddCommand(commands.NewTagCommands())
+	rootCmd.AddCommand(commands.NewTrashCommands())
+	rootCmd.AddCommand(commands.NewAttachCommands())
+	rootCmd.AddCommand(commands.NewSSHKeyCommands())
+	rootCmd.AddCommand(commands.NewSSHAgentCommand())
+	rootCmd.AddCommand(commands.NewTOTPCommand())
+	rootCmd.AddCommand(commands.NewCertificateCommands())
+	rootCmd.AddCommand(commands.NewEnvCommands())
+	rootCmd.AddCommand(commands.NewRunCommand())
+	rootCmd.AddCommand(commands.NewInjectCommand())
 
 	// Устанавливаем контекст для команды
 	rootCmd.SetContext(ctx)
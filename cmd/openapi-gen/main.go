@@ -0,0 +1,40 @@
+// Package main содержит генератор типизированного клиента API по спецификации OpenAPI.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/openapi"
+	"github.com/tempizhere/vaultfactory/internal/shared/openapi/codegen"
+)
+
+func main() {
+	packageName := flag.String("package", "api", "Package name of the generated code")
+	output := flag.String("o", "", "Output file (stdout if empty)")
+	flag.Parse()
+
+	if err := run(*packageName, *output); err != nil {
+		fmt.Fprintf(os.Stderr, "openapi-gen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(packageName, output string) error {
+	doc, err := openapi.Load()
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI specification: %w", err)
+	}
+
+	source, err := codegen.Generate(doc, packageName)
+	if err != nil {
+		return fmt.Errorf("failed to generate client: %w", err)
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(source)
+		return err
+	}
+	return os.WriteFile(output, source, 0644)
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"database/sql"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
@@ -16,8 +17,10 @@ import (
 	"github.com/uptrace/bun/dialect/pgdialect"
 	"github.com/uptrace/bun/extra/bundebug"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
 
 	"github.com/tempizhere/vaultfactory/internal/server/container"
+	"github.com/tempizhere/vaultfactory/internal/server/jobs"
 	"github.com/tempizhere/vaultfactory/internal/shared/config"
 	"github.com/tempizhere/vaultfactory/internal/shared/constants"
 	"github.com/tempizhere/vaultfactory/internal/shared/logger"
@@ -61,7 +64,10 @@ func main() {
 		appLogger.Fatal("Failed to run migrations", zap.Error(err))
 	}
 
-	container := container.NewContainer(cfg, appLogger, db, sqldb)
+	container, err := container.NewContainer(cfg, appLogger, db, sqldb)
+	if err != nil {
+		appLogger.Fatal("Failed to create container", zap.Error(err))
+	}
 
 	server := &http.Server{
 		Addr:         cfg.GetServerAddr(),
@@ -71,6 +77,10 @@ func main() {
 		IdleTimeout:  time.Duration(constants.IdleTimeoutSeconds) * time.Second,
 	}
 
+	jobsCtx, stopJobs := context.WithCancel(context.Background())
+	defer stopJobs()
+	startJobs(jobsCtx, container, appLogger)
+
 	go func() {
 		appLogger.Info("Starting server", zap.String("addr", server.Addr))
 		if err := server.ListenAndServe(); err != nil && err != http.ErrServerClosed {
@@ -78,11 +88,24 @@ func main() {
 		}
 	}()
 
+	grpcListener, err := net.Listen("tcp", cfg.GetGRPCAddr())
+	if err != nil {
+		appLogger.Fatal("Failed to listen for gRPC", zap.Error(err))
+	}
+
+	go func() {
+		appLogger.Info("Starting gRPC server", zap.String("addr", grpcListener.Addr().String()))
+		if err := container.GRPCServer.Serve(grpcListener); err != nil {
+			appLogger.Fatal("Failed to start gRPC server", zap.Error(err))
+		}
+	}()
+
 	quit := make(chan os.Signal, 1)
 	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 	<-quit
 
 	appLogger.Info("Shutting down server...")
+	stopJobs()
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	defer cancel()
@@ -90,10 +113,52 @@ func main() {
 	if err := server.Shutdown(ctx); err != nil {
 		appLogger.Fatal("Server forced to shutdown", zap.Error(err))
 	}
+	stopGRPCServer(ctx, container.GRPCServer)
 
 	appLogger.Info("Server exited")
 }
 
+// startJobs запускает фоновые задачи сервера.
+func startJobs(ctx context.Context, c *container.Container, appLogger logger.Logger) {
+	go jobs.RunPeriodic(ctx, appLogger, "trash_purge", time.Duration(constants.TrashPurgeIntervalMinutes)*time.Minute, func(ctx context.Context) error {
+		purged, err := c.TrashService.PurgeExpired(ctx)
+		if err != nil {
+			return err
+		}
+		if purged > 0 {
+			appLogger.Info("Purged expired trash items", zap.Int("count", purged))
+		}
+		return nil
+	})
+
+	go jobs.RunPeriodic(ctx, appLogger, "self_destruct_purge", time.Duration(constants.SelfDestructPurgeIntervalMinutes)*time.Minute, func(ctx context.Context) error {
+		purged, err := c.DataService.PurgeExpired(ctx)
+		if err != nil {
+			return err
+		}
+		if purged > 0 {
+			appLogger.Info("Purged self-destructed data items", zap.Int("count", purged))
+		}
+		return nil
+	})
+}
+
+// stopGRPCServer дожидается завершения активных вызовов gRPC, а по истечении ctx
+// закрывает оставшиеся соединения.
+func stopGRPCServer(ctx context.Context, server *grpc.Server) {
+	stopped := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		server.Stop()
+	}
+}
+
 func openDB(dsn string) *sql.DB {
 	sqldb, err := sql.Open("pgx", dsn)
 	if err != nil {
@@ -127,6 +192,150 @@ func runMigrations(db *bun.DB) error {
 		return err
 	}
 
+	_, err = db.NewCreateTable().Model((*models.CustomType)(nil)).IfNotExists().Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Пользовательские типы данных не ограничены списком встроенных типов
+	_, err = db.ExecContext(ctx, "ALTER TABLE data_items DROP CONSTRAINT IF EXISTS data_items_type_check")
+	if err != nil {
+		return err
+	}
+
+	_, err = db.NewCreateTable().Model((*models.Folder)(nil)).IfNotExists().Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.NewAddColumn().Model((*models.DataItem)(nil)).IfNotExists().ColumnExpr("folder_id UUID REFERENCES folders(id) ON DELETE SET NULL").Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.NewCreateTable().Model((*models.Tag)(nil)).IfNotExists().Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.NewCreateTable().Model((*models.DataItemTag)(nil)).IfNotExists().
+		ForeignKey("(data_id) REFERENCES data_items (id) ON DELETE CASCADE").
+		ForeignKey("(tag_id) REFERENCES tags (id) ON DELETE CASCADE").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Удаленные элементы данных хранятся в корзине
+	_, err = db.NewAddColumn().Model((*models.DataItem)(nil)).IfNotExists().ColumnExpr("deleted_at TIMESTAMP WITH TIME ZONE").Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.NewCreateTable().Model((*models.Attachment)(nil)).IfNotExists().
+		ForeignKey("(data_id) REFERENCES data_items (id) ON DELETE CASCADE").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Самоуничтожающиеся элементы данных
+	for _, column := range []string{
+		"expires_at TIMESTAMP WITH TIME ZONE",
+		"max_reads INTEGER",
+		"read_count INTEGER NOT NULL DEFAULT 0",
+	} {
+		_, err = db.NewAddColumn().Model((*models.DataItem)(nil)).IfNotExists().ColumnExpr(column).Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = db.NewCreateTable().Model((*models.DataTombstone)(nil)).IfNotExists().
+		ForeignKey("(user_id) REFERENCES users (id) ON DELETE CASCADE").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Ротация паролей
+	for _, column := range []string{
+		"password_changed_at TIMESTAMP WITH TIME ZONE",
+		"rotation_days INTEGER",
+	} {
+		_, err = db.NewAddColumn().Model((*models.DataItem)(nil)).IfNotExists().ColumnExpr(column).Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = db.NewAddColumn().Model((*models.Folder)(nil)).IfNotExists().ColumnExpr("rotation_days INTEGER").Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.NewCreateTable().Model((*models.PasswordHistoryEntry)(nil)).IfNotExists().
+		ForeignKey("(data_id) REFERENCES data_items (id) ON DELETE CASCADE").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Сведения о сертификатах
+	_, err = db.NewAddColumn().Model((*models.DataItem)(nil)).IfNotExists().ColumnExpr("certificate JSONB").Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Полнотекстовый поиск по метаданным
+	_, err = db.NewAddColumn().Model((*models.DataItem)(nil)).IfNotExists().
+		ColumnExpr("metadata_tsv TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', coalesce(metadata, ''))) STORED").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Слепые индексы для поиска по зашифрованным полям
+	_, err = db.NewCreateTable().Model((*models.BlindIndexEntry)(nil)).IfNotExists().
+		ForeignKey("(data_id) REFERENCES data_items (id) ON DELETE CASCADE").
+		ForeignKey("(user_id) REFERENCES users (id) ON DELETE CASCADE").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Версии хранят полное содержимое элемента данных
+	for _, column := range []string{
+		"name VARCHAR(255)",
+		"metadata TEXT",
+		"encrypted_data BYTEA",
+		"restored_from BIGINT",
+	} {
+		_, err = db.NewAddColumn().Model((*models.DataVersion)(nil)).IfNotExists().ColumnExpr(column).Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	// Содержимое вложений хранится по частям; ранее загруженные вложения сохраняют
+	// содержимое целиком в encrypted_content
+	_, err = db.NewCreateTable().Model((*models.AttachmentChunk)(nil)).IfNotExists().
+		ForeignKey("(attachment_id) REFERENCES attachments (id) ON DELETE CASCADE").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.NewAddColumn().Model((*models.Attachment)(nil)).IfNotExists().ColumnExpr("chunks INTEGER NOT NULL DEFAULT 0").Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = db.ExecContext(ctx, "ALTER TABLE attachments ALTER COLUMN encrypted_content DROP NOT NULL")
+	if err != nil {
+		return err
+	}
+
 	return nil
 }
 
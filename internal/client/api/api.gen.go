@@ -0,0 +1,924 @@
+// Code generated by openapi-gen. DO NOT EDIT.
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// AuthResponse - токены доступа; user заполняется при регистрации и входе.
+type AuthResponse struct {
+	User         *models.User `json:"user,omitempty"`
+	AccessToken  string       `json:"access_token"`
+	RefreshToken string       `json:"refresh_token,omitempty"`
+	ExpiresAt    time.Time    `json:"expires_at"`
+}
+
+// BatchOperationResult - результат операции пакета.
+type BatchOperationResult struct {
+	Index int `json:"index"`
+	// HTTP статус, который вернул бы одиночный запрос.
+	Status int                  `json:"status"`
+	Item   *models.DataItem     `json:"item,omitempty"`
+	Error  *apperrors.ErrorBody `json:"error,omitempty"`
+}
+
+// BatchResponse - результаты операций пакета в порядке операций.
+type BatchResponse struct {
+	Committed bool                    `json:"committed"`
+	Results   []*BatchOperationResult `json:"results"`
+}
+
+// BlindIndexKey - ключ слепого индекса пользователя.
+type BlindIndexKey struct {
+	Key []byte `json:"key"`
+}
+
+// ChangePasswordRequest - текущий и новый пароль.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+// CreateDataRequest - новый элемент данных.
+type CreateDataRequest struct {
+	// Встроенный или пользовательский тип данных.
+	Type     string `json:"type"`
+	Name     string `json:"name"`
+	Metadata string `json:"metadata,omitempty"`
+	// Содержимое элемента в формате, определяемом его типом.
+	Data json.RawMessage `json:"data,omitempty"`
+	// Время самоуничтожения элемента.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// Количество чтений содержимого до самоуничтожения.
+	MaxReads *int `json:"max_reads,omitempty"`
+}
+
+// CreateFolderRequest - новая папка.
+type CreateFolderRequest struct {
+	// Путь папки; недостающие родительские папки создаются.
+	Path string `json:"path"`
+}
+
+// Credentials - email и пароль пользователя.
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// CustomTypeRequest - описание пользовательского типа; при обновлении имя берется из пути.
+type CustomTypeRequest struct {
+	Name        string                `json:"name,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Fields      []*models.CustomField `json:"fields,omitempty"`
+}
+
+// DataList - страница элементов данных без содержимого.
+type DataList struct {
+	Items []*models.DataItem `json:"items"`
+	// Курсор следующей страницы; отсутствует на последней странице.
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// EmptyTrashResult - количество удаленных элементов.
+type EmptyTrashResult struct {
+	Deleted int `json:"deleted"`
+}
+
+// LogoutRequest - токен обновления, который нужно отозвать.
+type LogoutRequest struct {
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// MergeTagsRequest - исходные теги и целевой тег.
+type MergeTagsRequest struct {
+	Sources []string `json:"sources"`
+	Target  string   `json:"target"`
+}
+
+// MoveDataRequest - папка назначения.
+type MoveDataRequest struct {
+	// ID или путь папки; пустая строка означает корень.
+	Folder string `json:"folder,omitempty"`
+}
+
+// RebuildBlindIndexResult - количество проиндексированных элементов.
+type RebuildBlindIndexResult struct {
+	Indexed int `json:"indexed"`
+}
+
+// RefreshRequest - токен обновления.
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// RenameTagRequest - новое имя тега.
+type RenameTagRequest struct {
+	Name string `json:"name"`
+}
+
+// SetExpiryRequest - условия самоуничтожения; отсутствующие условия снимаются.
+type SetExpiryRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	MaxReads  *int       `json:"max_reads,omitempty"`
+}
+
+// SetRotationRequest - интервал ротации пароля.
+type SetRotationRequest struct {
+	// Интервал ротации в днях; null снимает интервал.
+	RotationDays *int `json:"rotation_days,omitempty"`
+}
+
+// TagsRequest - имена тегов.
+type TagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// UpdateDataRequest - новые имя, метаданные и содержимое элемента данных.
+type UpdateDataRequest struct {
+	Name     string `json:"name,omitempty"`
+	Metadata string `json:"metadata,omitempty"`
+	// Содержимое элемента в формате, определяемом его типом.
+	Data json.RawMessage `json:"data,omitempty"`
+	// Ожидаемая версия элемента; альтернатива заголовку If-Match.
+	Version *int64 `json:"version,omitempty"`
+}
+
+// UpdateFolderRequest - новое имя и (или) родительская папка.
+type UpdateFolderRequest struct {
+	Name string `json:"name,omitempty"`
+	// ID или путь родительской папки; пустая строка означает корень.
+	Parent *string `json:"parent,omitempty"`
+}
+
+// Login выполняет операцию POST /auth/login: войти.
+// Операция не требует аутентификации.
+func (c *Client) Login(ctx context.Context, body Credentials, editors ...RequestEditorFn) (*AuthResponse, error) {
+	path := "/auth/login"
+	var result AuthResponse
+	if err := c.doJSON(ctx, "POST", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Logout выполняет операцию POST /auth/logout: отозвать токен обновления.
+// Операция не требует аутентификации.
+func (c *Client) Logout(ctx context.Context, body LogoutRequest, editors ...RequestEditorFn) error {
+	path := "/auth/logout"
+	return c.doJSON(ctx, "POST", path, nil, nil, body, nil, editors)
+}
+
+// ChangePassword выполняет операцию POST /auth/password: сменить пароль.
+// После смены пароля все сессии пользователя завершаются.
+func (c *Client) ChangePassword(ctx context.Context, body ChangePasswordRequest, editors ...RequestEditorFn) error {
+	path := "/auth/password"
+	return c.doJSON(ctx, "POST", path, nil, nil, body, nil, editors)
+}
+
+// Refresh выполняет операцию POST /auth/refresh: обновить токен доступа.
+// Операция не требует аутентификации.
+func (c *Client) Refresh(ctx context.Context, body RefreshRequest, editors ...RequestEditorFn) (*AuthResponse, error) {
+	path := "/auth/refresh"
+	var result AuthResponse
+	if err := c.doJSON(ctx, "POST", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Register выполняет операцию POST /auth/register: зарегистрировать пользователя.
+// Операция не требует аутентификации.
+func (c *Client) Register(ctx context.Context, body Credentials, editors ...RequestEditorFn) (*AuthResponse, error) {
+	path := "/auth/register"
+	var result AuthResponse
+	if err := c.doJSON(ctx, "POST", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListDataParams содержит параметры запроса и заголовки операции ListData.
+type ListDataParams struct {
+	// Тип данных.
+	Type string
+	// ID или путь папки.
+	Folder string
+	// Включать вложенные папки.
+	Recursive *bool
+	// Теги; тег с префиксом ! исключает помеченные им элементы.
+	Tag []string
+	// Требовать любой или все включаемые теги.
+	TagMatch string
+	// Размер страницы.
+	Limit *int
+	// Курсор страницы из next_cursor.
+	Cursor string
+	// Поле сортировки.
+	Sort string
+	// Направление сортировки.
+	Order string
+}
+
+// ListData выполняет операцию GET /data: получить страницу элементов данных.
+func (c *Client) ListData(ctx context.Context, params *ListDataParams, editors ...RequestEditorFn) (*DataList, error) {
+	path := "/data"
+	query := url.Values{}
+	if params != nil {
+		if params.Type != "" {
+			query.Set("type", params.Type)
+		}
+		if params.Folder != "" {
+			query.Set("folder", params.Folder)
+		}
+		if params.Recursive != nil {
+			query.Set("recursive", strconv.FormatBool(*params.Recursive))
+		}
+		for _, value := range params.Tag {
+			query.Add("tag", value)
+		}
+		if params.TagMatch != "" {
+			query.Set("tag_match", params.TagMatch)
+		}
+		if params.Limit != nil {
+			query.Set("limit", strconv.Itoa(*params.Limit))
+		}
+		if params.Cursor != "" {
+			query.Set("cursor", params.Cursor)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Order != "" {
+			query.Set("order", params.Order)
+		}
+	}
+	var result DataList
+	if err := c.doJSON(ctx, "GET", path, query, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// CreateData выполняет операцию POST /data: создать элемент данных.
+func (c *Client) CreateData(ctx context.Context, body CreateDataRequest, editors ...RequestEditorFn) (*models.DataItem, error) {
+	path := "/data"
+	var result models.DataItem
+	if err := c.doJSON(ctx, "POST", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExecuteBatch выполняет операцию POST /data/batch: выполнить пакет операций.
+// Выполненный пакет возвращается со статусом 200 даже при отмене атомарного пакета.
+func (c *Client) ExecuteBatch(ctx context.Context, body models.DataBatch, editors ...RequestEditorFn) (*BatchResponse, error) {
+	path := "/data/batch"
+	var result BatchResponse
+	if err := c.doJSON(ctx, "POST", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetBlindIndexKey выполняет операцию GET /data/blind-index/key: получить ключ слепого индекса.
+func (c *Client) GetBlindIndexKey(ctx context.Context, editors ...RequestEditorFn) (*BlindIndexKey, error) {
+	path := "/data/blind-index/key"
+	var result BlindIndexKey
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RebuildBlindIndex выполняет операцию POST /data/blind-index/rebuild: перестроить слепые индексы.
+func (c *Client) RebuildBlindIndex(ctx context.Context, editors ...RequestEditorFn) (*RebuildBlindIndexResult, error) {
+	path := "/data/blind-index/rebuild"
+	var result RebuildBlindIndexResult
+	if err := c.doJSON(ctx, "POST", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// BlindSearchDataParams содержит параметры запроса и заголовки операции BlindSearchData.
+type BlindSearchDataParams struct {
+	// name или field:<имя>.
+	Field string
+	// Режим индекса.
+	Mode string
+	// Текст запроса.
+	Q string
+	// Токены, вычисленные клиентом, в hex.
+	Token []string
+	// Тип данных.
+	Type string
+	// ID или путь папки.
+	Folder string
+	// Включать вложенные папки.
+	Recursive *bool
+	// Теги; тег с префиксом ! исключает помеченные им элементы.
+	Tag []string
+	// Требовать любой или все включаемые теги.
+	TagMatch string
+	// Размер страницы.
+	Limit *int
+	// Курсор страницы из next_cursor.
+	Cursor string
+	// Поле сортировки.
+	Sort string
+	// Направление сортировки.
+	Order string
+}
+
+// BlindSearchData выполняет операцию GET /data/blind-search: найти элементы по слепым индексам.
+func (c *Client) BlindSearchData(ctx context.Context, params *BlindSearchDataParams, editors ...RequestEditorFn) (*DataList, error) {
+	path := "/data/blind-search"
+	query := url.Values{}
+	if params != nil {
+		if params.Field != "" {
+			query.Set("field", params.Field)
+		}
+		if params.Mode != "" {
+			query.Set("mode", params.Mode)
+		}
+		if params.Q != "" {
+			query.Set("q", params.Q)
+		}
+		for _, value := range params.Token {
+			query.Add("token", value)
+		}
+		if params.Type != "" {
+			query.Set("type", params.Type)
+		}
+		if params.Folder != "" {
+			query.Set("folder", params.Folder)
+		}
+		if params.Recursive != nil {
+			query.Set("recursive", strconv.FormatBool(*params.Recursive))
+		}
+		for _, value := range params.Tag {
+			query.Add("tag", value)
+		}
+		if params.TagMatch != "" {
+			query.Set("tag_match", params.TagMatch)
+		}
+		if params.Limit != nil {
+			query.Set("limit", strconv.Itoa(*params.Limit))
+		}
+		if params.Cursor != "" {
+			query.Set("cursor", params.Cursor)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Order != "" {
+			query.Set("order", params.Order)
+		}
+	}
+	var result DataList
+	if err := c.doJSON(ctx, "GET", path, query, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListExpiringCertificatesParams содержит параметры запроса и заголовки операции ListExpiringCertificates.
+type ListExpiringCertificatesParams struct {
+	// Горизонт в днях; по умолчанию 30.
+	Days *int
+}
+
+// ListExpiringCertificates выполняет операцию GET /data/certificates/expiring: получить истекающие сертификаты.
+func (c *Client) ListExpiringCertificates(ctx context.Context, params *ListExpiringCertificatesParams, editors ...RequestEditorFn) ([]*models.CertificateStatus, error) {
+	path := "/data/certificates/expiring"
+	query := url.Values{}
+	if params != nil {
+		if params.Days != nil {
+			query.Set("days", strconv.Itoa(*params.Days))
+		}
+	}
+	var result []*models.CertificateStatus
+	if err := c.doJSON(ctx, "GET", path, query, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListOverdueRotations выполняет операцию GET /data/rotation/overdue: получить элементы с просроченной ротацией пароля.
+func (c *Client) ListOverdueRotations(ctx context.Context, editors ...RequestEditorFn) ([]*models.RotationStatus, error) {
+	path := "/data/rotation/overdue"
+	var result []*models.RotationStatus
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SearchDataParams содержит параметры запроса и заголовки операции SearchData.
+type SearchDataParams struct {
+	// Текст запроса.
+	Q string
+	// Режим совпадения.
+	Match string
+	// RFC3339 или YYYY-MM-DD.
+	CreatedAfter string
+	// RFC3339 или YYYY-MM-DD.
+	CreatedBefore string
+	// RFC3339 или YYYY-MM-DD.
+	UpdatedAfter string
+	// RFC3339 или YYYY-MM-DD.
+	UpdatedBefore string
+	// Тип данных.
+	Type string
+	// ID или путь папки.
+	Folder string
+	// Включать вложенные папки.
+	Recursive *bool
+	// Теги; тег с префиксом ! исключает помеченные им элементы.
+	Tag []string
+	// Требовать любой или все включаемые теги.
+	TagMatch string
+	// Размер страницы.
+	Limit *int
+	// Курсор страницы из next_cursor.
+	Cursor string
+	// Поле сортировки.
+	Sort string
+	// Направление сортировки.
+	Order string
+}
+
+// SearchData выполняет операцию GET /data/search: найти элементы по имени и метаданным.
+func (c *Client) SearchData(ctx context.Context, params *SearchDataParams, editors ...RequestEditorFn) (*DataList, error) {
+	path := "/data/search"
+	query := url.Values{}
+	if params != nil {
+		if params.Q != "" {
+			query.Set("q", params.Q)
+		}
+		if params.Match != "" {
+			query.Set("match", params.Match)
+		}
+		if params.CreatedAfter != "" {
+			query.Set("created_after", params.CreatedAfter)
+		}
+		if params.CreatedBefore != "" {
+			query.Set("created_before", params.CreatedBefore)
+		}
+		if params.UpdatedAfter != "" {
+			query.Set("updated_after", params.UpdatedAfter)
+		}
+		if params.UpdatedBefore != "" {
+			query.Set("updated_before", params.UpdatedBefore)
+		}
+		if params.Type != "" {
+			query.Set("type", params.Type)
+		}
+		if params.Folder != "" {
+			query.Set("folder", params.Folder)
+		}
+		if params.Recursive != nil {
+			query.Set("recursive", strconv.FormatBool(*params.Recursive))
+		}
+		for _, value := range params.Tag {
+			query.Add("tag", value)
+		}
+		if params.TagMatch != "" {
+			query.Set("tag_match", params.TagMatch)
+		}
+		if params.Limit != nil {
+			query.Set("limit", strconv.Itoa(*params.Limit))
+		}
+		if params.Cursor != "" {
+			query.Set("cursor", params.Cursor)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Order != "" {
+			query.Set("order", params.Order)
+		}
+	}
+	var result DataList
+	if err := c.doJSON(ctx, "GET", path, query, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// SyncDataParams содержит параметры запроса и заголовки операции SyncData.
+type SyncDataParams struct {
+	// Время последней синхронизации в RFC3339.
+	LastSync time.Time
+	// Размер страницы.
+	Limit *int
+	// Курсор страницы из next_cursor.
+	Cursor string
+	// Поле сортировки.
+	Sort string
+	// Направление сортировки.
+	Order string
+}
+
+// SyncData выполняет операцию GET /data/sync: получить элементы, измененные после момента синхронизации.
+// Возвращает и удаленные элементы.
+func (c *Client) SyncData(ctx context.Context, params *SyncDataParams, editors ...RequestEditorFn) (*DataList, error) {
+	path := "/data/sync"
+	query := url.Values{}
+	if params != nil {
+		query.Set("last_sync", params.LastSync.Format(time.RFC3339))
+		if params.Limit != nil {
+			query.Set("limit", strconv.Itoa(*params.Limit))
+		}
+		if params.Cursor != "" {
+			query.Set("cursor", params.Cursor)
+		}
+		if params.Sort != "" {
+			query.Set("sort", params.Sort)
+		}
+		if params.Order != "" {
+			query.Set("order", params.Order)
+		}
+	}
+	var result DataList
+	if err := c.doJSON(ctx, "GET", path, query, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListTrash выполняет операцию GET /data/trash: получить элементы в корзине.
+func (c *Client) ListTrash(ctx context.Context, editors ...RequestEditorFn) ([]*models.DataItem, error) {
+	path := "/data/trash"
+	var result []*models.DataItem
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// EmptyTrash выполняет операцию DELETE /data/trash: очистить корзину.
+func (c *Client) EmptyTrash(ctx context.Context, editors ...RequestEditorFn) (*EmptyTrashResult, error) {
+	path := "/data/trash"
+	var result EmptyTrashResult
+	if err := c.doJSON(ctx, "DELETE", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// PurgeData выполняет операцию DELETE /data/trash/{id}: окончательно удалить элемент из корзины.
+func (c *Client) PurgeData(ctx context.Context, id string, editors ...RequestEditorFn) error {
+	path := "/data/trash/" + url.PathEscape(id)
+	return c.doJSON(ctx, "DELETE", path, nil, nil, nil, nil, editors)
+}
+
+// RestoreData выполняет операцию POST /data/trash/{id}/restore: восстановить элемент из корзины.
+func (c *Client) RestoreData(ctx context.Context, id string, editors ...RequestEditorFn) (*models.DataItem, error) {
+	path := "/data/trash/" + url.PathEscape(id) + "/restore"
+	var result models.DataItem
+	if err := c.doJSON(ctx, "POST", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetData выполняет операцию GET /data/{id}: получить элемент данных с содержимым.
+func (c *Client) GetData(ctx context.Context, id string, editors ...RequestEditorFn) (*models.DataItem, error) {
+	path := "/data/" + url.PathEscape(id)
+	var result models.DataItem
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateDataParams содержит параметры запроса и заголовки операции UpdateData.
+type UpdateDataParams struct {
+	// Ожидаемая версия элемента в кавычках или * для изменения без проверки.
+	IfMatch string
+}
+
+// UpdateData выполняет операцию PUT /data/{id}: обновить элемент данных.
+// Ожидаемая версия передается заголовком If-Match или полем version; без нее возвращается 428.
+func (c *Client) UpdateData(ctx context.Context, id string, params *UpdateDataParams, body UpdateDataRequest, editors ...RequestEditorFn) (*models.DataItem, error) {
+	path := "/data/" + url.PathEscape(id)
+	header := http.Header{}
+	if params != nil {
+		if params.IfMatch != "" {
+			header.Set("If-Match", params.IfMatch)
+		}
+	}
+	var result models.DataItem
+	if err := c.doJSON(ctx, "PUT", path, nil, header, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteDataParams содержит параметры запроса и заголовки операции DeleteData.
+type DeleteDataParams struct {
+	// Ожидаемая версия элемента в кавычках или * для изменения без проверки.
+	IfMatch string
+}
+
+// DeleteData выполняет операцию DELETE /data/{id}: переместить элемент в корзину.
+// Ожидаемая версия передается заголовком If-Match; без него возвращается 428.
+func (c *Client) DeleteData(ctx context.Context, id string, params *DeleteDataParams, editors ...RequestEditorFn) error {
+	path := "/data/" + url.PathEscape(id)
+	header := http.Header{}
+	if params != nil {
+		if params.IfMatch != "" {
+			header.Set("If-Match", params.IfMatch)
+		}
+	}
+	return c.doJSON(ctx, "DELETE", path, nil, header, nil, nil, editors)
+}
+
+// ListAttachments выполняет операцию GET /data/{id}/attachments: получить вложения элемента.
+func (c *Client) ListAttachments(ctx context.Context, id string, editors ...RequestEditorFn) ([]*models.Attachment, error) {
+	path := "/data/" + url.PathEscape(id) + "/attachments"
+	var result []*models.Attachment
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// UploadAttachmentParams содержит параметры запроса и заголовки операции UploadAttachment.
+type UploadAttachmentParams struct {
+	// Имя файла.
+	Filename string
+}
+
+// UploadAttachment выполняет операцию POST /data/{id}/attachments: загрузить вложение.
+func (c *Client) UploadAttachment(ctx context.Context, id string, params *UploadAttachmentParams, contentType string, body io.Reader, editors ...RequestEditorFn) (*models.Attachment, error) {
+	path := "/data/" + url.PathEscape(id) + "/attachments"
+	query := url.Values{}
+	if params != nil {
+		query.Set("filename", params.Filename)
+	}
+	resp, err := c.do(ctx, "POST", path, query, nil, body, contentType, editors)
+	if err != nil {
+		return nil, err
+	}
+	var result models.Attachment
+	if err := decodeResponse(resp, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DownloadAttachment выполняет операцию GET /data/{id}/attachments/{attachment}: скачать вложение.
+// Тело ответа читается и закрывается вызывающей стороной.
+func (c *Client) DownloadAttachment(ctx context.Context, id string, attachment string, editors ...RequestEditorFn) (*http.Response, error) {
+	path := "/data/" + url.PathEscape(id) + "/attachments/" + url.PathEscape(attachment)
+	return c.do(ctx, "GET", path, nil, nil, nil, "", editors)
+}
+
+// DeleteAttachment выполняет операцию DELETE /data/{id}/attachments/{attachment}: удалить вложение.
+func (c *Client) DeleteAttachment(ctx context.Context, id string, attachment string, editors ...RequestEditorFn) error {
+	path := "/data/" + url.PathEscape(id) + "/attachments/" + url.PathEscape(attachment)
+	return c.doJSON(ctx, "DELETE", path, nil, nil, nil, nil, editors)
+}
+
+// SetExpiry выполняет операцию PUT /data/{id}/expiry: задать условия самоуничтожения.
+func (c *Client) SetExpiry(ctx context.Context, id string, body SetExpiryRequest, editors ...RequestEditorFn) (*models.DataItem, error) {
+	path := "/data/" + url.PathEscape(id) + "/expiry"
+	var result models.DataItem
+	if err := c.doJSON(ctx, "PUT", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// MoveData выполняет операцию PUT /data/{id}/folder: переместить элемент в папку.
+func (c *Client) MoveData(ctx context.Context, id string, body MoveDataRequest, editors ...RequestEditorFn) (*models.DataItem, error) {
+	path := "/data/" + url.PathEscape(id) + "/folder"
+	var result models.DataItem
+	if err := c.doJSON(ctx, "PUT", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetPasswordHistory выполняет операцию GET /data/{id}/password-history: получить историю паролей.
+func (c *Client) GetPasswordHistory(ctx context.Context, id string, editors ...RequestEditorFn) ([]*models.PasswordHistoryEntry, error) {
+	path := "/data/" + url.PathEscape(id) + "/password-history"
+	var result []*models.PasswordHistoryEntry
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SetDataRotation выполняет операцию PUT /data/{id}/rotation: задать интервал ротации пароля элемента.
+func (c *Client) SetDataRotation(ctx context.Context, id string, body SetRotationRequest, editors ...RequestEditorFn) (*models.DataItem, error) {
+	path := "/data/" + url.PathEscape(id) + "/rotation"
+	var result models.DataItem
+	if err := c.doJSON(ctx, "PUT", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// AddTags выполняет операцию POST /data/{id}/tags: пометить элемент тегами.
+func (c *Client) AddTags(ctx context.Context, id string, body TagsRequest, editors ...RequestEditorFn) (*models.DataItem, error) {
+	path := "/data/" + url.PathEscape(id) + "/tags"
+	var result models.DataItem
+	if err := c.doJSON(ctx, "POST", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RemoveTag выполняет операцию DELETE /data/{id}/tags/{tag}: снять тег с элемента.
+func (c *Client) RemoveTag(ctx context.Context, id string, tag string, editors ...RequestEditorFn) (*models.DataItem, error) {
+	path := "/data/" + url.PathEscape(id) + "/tags/" + url.PathEscape(tag)
+	var result models.DataItem
+	if err := c.doJSON(ctx, "DELETE", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListVersions выполняет операцию GET /data/{id}/versions: получить историю версий.
+func (c *Client) ListVersions(ctx context.Context, id string, editors ...RequestEditorFn) ([]*models.DataVersion, error) {
+	path := "/data/" + url.PathEscape(id) + "/versions"
+	var result []*models.DataVersion
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetVersion выполняет операцию GET /data/{id}/versions/{version}: получить версию с содержимым.
+func (c *Client) GetVersion(ctx context.Context, id string, version int64, editors ...RequestEditorFn) (*models.DataVersion, error) {
+	path := "/data/" + url.PathEscape(id) + "/versions/" + strconv.FormatInt(version, 10)
+	var result models.DataVersion
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// RestoreVersion выполняет операцию POST /data/{id}/versions/{version}/restore: восстановить элемент из версии.
+func (c *Client) RestoreVersion(ctx context.Context, id string, version int64, editors ...RequestEditorFn) (*models.DataItem, error) {
+	path := "/data/" + url.PathEscape(id) + "/versions/" + strconv.FormatInt(version, 10) + "/restore"
+	var result models.DataItem
+	if err := c.doJSON(ctx, "POST", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListFolders выполняет операцию GET /folders: получить папки.
+func (c *Client) ListFolders(ctx context.Context, editors ...RequestEditorFn) ([]*models.Folder, error) {
+	path := "/folders"
+	var result []*models.Folder
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateFolder выполняет операцию POST /folders: создать папку.
+func (c *Client) CreateFolder(ctx context.Context, body CreateFolderRequest, editors ...RequestEditorFn) (*models.Folder, error) {
+	path := "/folders"
+	var result models.Folder
+	if err := c.doJSON(ctx, "POST", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateFolder выполняет операцию PUT /folders/{id}: переименовать или переместить папку.
+func (c *Client) UpdateFolder(ctx context.Context, id string, body UpdateFolderRequest, editors ...RequestEditorFn) (*models.Folder, error) {
+	path := "/folders/" + url.PathEscape(id)
+	var result models.Folder
+	if err := c.doJSON(ctx, "PUT", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteFolderParams содержит параметры запроса и заголовки операции DeleteFolder.
+type DeleteFolderParams struct {
+	// Удалить непустую папку вместе с содержимым.
+	Recursive *bool
+}
+
+// DeleteFolder выполняет операцию DELETE /folders/{id}: удалить папку.
+func (c *Client) DeleteFolder(ctx context.Context, id string, params *DeleteFolderParams, editors ...RequestEditorFn) error {
+	path := "/folders/" + url.PathEscape(id)
+	query := url.Values{}
+	if params != nil {
+		if params.Recursive != nil {
+			query.Set("recursive", strconv.FormatBool(*params.Recursive))
+		}
+	}
+	return c.doJSON(ctx, "DELETE", path, query, nil, nil, nil, editors)
+}
+
+// SetFolderRotation выполняет операцию PUT /folders/{id}/rotation: задать интервал ротации паролей папки.
+func (c *Client) SetFolderRotation(ctx context.Context, id string, body SetRotationRequest, editors ...RequestEditorFn) (*models.Folder, error) {
+	path := "/folders/" + url.PathEscape(id) + "/rotation"
+	var result models.Folder
+	if err := c.doJSON(ctx, "PUT", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetOpenAPI выполняет операцию GET /openapi.json: получить спецификацию API.
+// Операция не требует аутентификации.
+func (c *Client) GetOpenAPI(ctx context.Context, editors ...RequestEditorFn) (json.RawMessage, error) {
+	path := "/openapi.json"
+	var result json.RawMessage
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ListTags выполняет операцию GET /tags: получить теги.
+func (c *Client) ListTags(ctx context.Context, editors ...RequestEditorFn) ([]*models.Tag, error) {
+	path := "/tags"
+	var result []*models.Tag
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// MergeTags выполняет операцию POST /tags/merge: объединить теги.
+func (c *Client) MergeTags(ctx context.Context, body MergeTagsRequest, editors ...RequestEditorFn) error {
+	path := "/tags/merge"
+	return c.doJSON(ctx, "POST", path, nil, nil, body, nil, editors)
+}
+
+// RenameTag выполняет операцию PUT /tags/{name}: переименовать тег.
+func (c *Client) RenameTag(ctx context.Context, name string, body RenameTagRequest, editors ...RequestEditorFn) (*models.Tag, error) {
+	path := "/tags/" + url.PathEscape(name)
+	var result models.Tag
+	if err := c.doJSON(ctx, "PUT", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ListTypes выполняет операцию GET /types: получить пользовательские типы.
+func (c *Client) ListTypes(ctx context.Context, editors ...RequestEditorFn) ([]*models.CustomType, error) {
+	path := "/types"
+	var result []*models.CustomType
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// CreateType выполняет операцию POST /types: создать пользовательский тип.
+func (c *Client) CreateType(ctx context.Context, body CustomTypeRequest, editors ...RequestEditorFn) (*models.CustomType, error) {
+	path := "/types"
+	var result models.CustomType
+	if err := c.doJSON(ctx, "POST", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// GetType выполняет операцию GET /types/{name}: получить пользовательский тип.
+func (c *Client) GetType(ctx context.Context, name string, editors ...RequestEditorFn) (*models.CustomType, error) {
+	path := "/types/" + url.PathEscape(name)
+	var result models.CustomType
+	if err := c.doJSON(ctx, "GET", path, nil, nil, nil, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// UpdateType выполняет операцию PUT /types/{name}: обновить пользовательский тип.
+func (c *Client) UpdateType(ctx context.Context, name string, body CustomTypeRequest, editors ...RequestEditorFn) (*models.CustomType, error) {
+	path := "/types/" + url.PathEscape(name)
+	var result models.CustomType
+	if err := c.doJSON(ctx, "PUT", path, nil, nil, body, &result, editors); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// DeleteType выполняет операцию DELETE /types/{name}: удалить пользовательский тип.
+func (c *Client) DeleteType(ctx context.Context, name string, editors ...RequestEditorFn) error {
+	path := "/types/" + url.PathEscape(name)
+	return c.doJSON(ctx, "DELETE", path, nil, nil, nil, nil, editors)
+}
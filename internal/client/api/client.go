@@ -0,0 +1,161 @@
+// Package api содержит типизированный клиент HTTP API сервера. Методы операций и типы
+// запросов генерируются из спецификации OpenAPI (internal/shared/openapi) в api.gen.go.
+package api
+
+//go:generate go run ../../../cmd/openapi-gen -package api -o api.gen.go
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RequestEditorFn изменяет запрос перед отправкой, например добавляет заголовок авторизации.
+// Ошибка редактора прерывает запрос и возвращается без изменений.
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// ErrorDecoder преобразует ответ сервера со статусом 4xx или 5xx в ошибку.
+type ErrorDecoder func(statusCode int, body []byte) error
+
+// ResponseError описывает ошибочный ответ сервера, если ErrorDecoder не задан.
+type ResponseError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, string(e.Body))
+}
+
+// Client выполняет операции API относительно адреса server (например, http://localhost:8080/api/v1).
+type Client struct {
+	server         string
+	httpClient     *http.Client
+	requestEditors []RequestEditorFn
+	decodeError    ErrorDecoder
+}
+
+// ClientOption настраивает Client.
+type ClientOption func(*Client)
+
+// WithHTTPClient задает HTTP клиент для выполнения запросов.
+func WithHTTPClient(httpClient *http.Client) ClientOption {
+	return func(c *Client) {
+		c.httpClient = httpClient
+	}
+}
+
+// WithRequestEditorFn добавляет редактор, применяемый ко всем запросам клиента.
+func WithRequestEditorFn(editor RequestEditorFn) ClientOption {
+	return func(c *Client) {
+		c.requestEditors = append(c.requestEditors, editor)
+	}
+}
+
+// WithErrorDecoder задает преобразование ошибочных ответов сервера в ошибки.
+func WithErrorDecoder(decoder ErrorDecoder) ClientOption {
+	return func(c *Client) {
+		c.decodeError = decoder
+	}
+}
+
+// NewClient создает новый экземпляр Client.
+func NewClient(server string, options ...ClientOption) *Client {
+	c := &Client{
+		server:     strings.TrimSuffix(server, "/"),
+		httpClient: http.DefaultClient,
+		decodeError: func(statusCode int, body []byte) error {
+			return &ResponseError{StatusCode: statusCode, Body: body}
+		},
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// do выполняет запрос и возвращает успешный ответ, тело которого читается и закрывается
+// вызывающей стороной. Ошибочный ответ преобразуется в ошибку через ErrorDecoder.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, header http.Header, body io.Reader, contentType string, editors []RequestEditorFn) (*http.Response, error) {
+	target := c.server + path
+	if len(query) > 0 {
+		target += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, target, body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if body != nil && contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	for _, editor := range append(append([]RequestEditorFn(nil), c.requestEditors...), editors...) {
+		if err := editor(ctx, req); err != nil {
+			return nil, err
+		}
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+
+	if resp.StatusCode >= 400 {
+		defer resp.Body.Close()
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read response: %w", err)
+		}
+		return nil, c.decodeError(resp.StatusCode, respBody)
+	}
+
+	return resp, nil
+}
+
+// doJSON выполняет запрос с телом body в формате JSON (nil — без тела) и разбирает ответ в
+// result (nil — ответ не разбирается).
+func (c *Client) doJSON(ctx context.Context, method, path string, query url.Values, header http.Header, body interface{}, result interface{}, editors []RequestEditorFn) error {
+	var reqBody io.Reader
+	if body != nil {
+		jsonData, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to marshal request body: %w", err)
+		}
+		reqBody = bytes.NewReader(jsonData)
+	}
+
+	resp, err := c.do(ctx, method, path, query, header, reqBody, "application/json", editors)
+	if err != nil {
+		return err
+	}
+
+	return decodeResponse(resp, result)
+}
+
+// decodeResponse разбирает JSON тело ответа в result и закрывает его.
+func decodeResponse(resp *http.Response, result interface{}) error {
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if result == nil {
+		return nil
+	}
+
+	if err := json.Unmarshal(respBody, result); err != nil {
+		return fmt.Errorf("failed to parse response: %w", err)
+	}
+	return nil
+}
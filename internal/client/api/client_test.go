@@ -0,0 +1,109 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/shared/openapi"
+	"github.com/tempizhere/vaultfactory/internal/shared/openapi/codegen"
+)
+
+// TestGeneratedClientIsUpToDate проверяет, что api.gen.go сгенерирован из текущей
+// спецификации; после ее изменения нужно выполнить go generate ./internal/client/api.
+func TestGeneratedClientIsUpToDate(t *testing.T) {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	expected, err := codegen.Generate(doc, "api")
+	require.NoError(t, err)
+
+	actual, err := os.ReadFile("api.gen.go")
+	require.NoError(t, err)
+
+	assert.Equal(t, string(expected), string(actual), "api.gen.go is outdated, run go generate ./internal/client/api")
+}
+
+func TestClient_Requests(t *testing.T) {
+	var received *http.Request
+	var body map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = r
+		body = nil
+		_ = json.NewDecoder(r.Body).Decode(&body)
+
+		switch r.URL.EscapedPath() {
+		case "/api/v1/data/sync":
+			_, _ = w.Write([]byte(`{"items":[{"id":"7f0c4e1e-4b7a-4f4c-9a57-0b1f3b2d9c10","name":"mail"}],"next_cursor":"next"}`))
+		case "/api/v1/folders/work%2Fmail/rotation":
+			_, _ = w.Write([]byte(`{"path":"work/mail","rotation_days":30}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	authorize := func(ctx context.Context, req *http.Request) error {
+		req.Header.Set("Authorization", "Bearer token")
+		return nil
+	}
+	client := NewClient(server.URL+"/api/v1/", WithHTTPClient(server.Client()), WithRequestEditorFn(authorize))
+	ctx := context.Background()
+
+	t.Run("query parameters", func(t *testing.T) {
+		limit := 10
+		page, err := client.SyncData(ctx, &SyncDataParams{
+			LastSync: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+			Limit:    &limit,
+			Cursor:   "abc",
+		})
+		require.NoError(t, err)
+
+		require.Len(t, page.Items, 1)
+		assert.Equal(t, "mail", page.Items[0].Name)
+		assert.Equal(t, "next", page.NextCursor)
+		assert.Equal(t, "GET", received.Method)
+		assert.Equal(t, "2024-01-02T03:04:05Z", received.URL.Query().Get("last_sync"))
+		assert.Equal(t, "10", received.URL.Query().Get("limit"))
+		assert.Equal(t, "abc", received.URL.Query().Get("cursor"))
+		assert.False(t, received.URL.Query().Has("sort"))
+		assert.Equal(t, "Bearer token", received.Header.Get("Authorization"))
+	})
+
+	t.Run("path parameters and body", func(t *testing.T) {
+		days := 30
+		folder, err := client.SetFolderRotation(ctx, "work/mail", SetRotationRequest{RotationDays: &days})
+		require.NoError(t, err)
+
+		assert.Equal(t, "work/mail", folder.Path)
+		assert.Equal(t, "PUT", received.Method)
+		assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+		assert.Equal(t, map[string]interface{}{"rotation_days": float64(30)}, body)
+	})
+
+	t.Run("error response", func(t *testing.T) {
+		_, err := client.GetData(ctx, "missing")
+
+		var responseErr *ResponseError
+		require.True(t, errors.As(err, &responseErr), "expected response error, got %v", err)
+		assert.Equal(t, http.StatusNotFound, responseErr.StatusCode)
+		assert.JSONEq(t, `{"error":"not found"}`, string(responseErr.Body))
+	})
+
+	t.Run("request editor error", func(t *testing.T) {
+		editorErr := errors.New("not authenticated")
+		_, err := client.GetData(ctx, "id", func(ctx context.Context, req *http.Request) error {
+			return editorErr
+		})
+
+		assert.Equal(t, editorErr, err)
+	})
+}
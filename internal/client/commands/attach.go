@@ -0,0 +1,189 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// NewAttachCommands создает команды для управления вложениями элементов данных.
+func NewAttachCommands() *cobra.Command {
+	attachCmd := &cobra.Command{
+		Use:   "attach",
+		Short: "Data item attachment commands",
+	}
+
+	var name string
+	var mimeType string
+	addCmd := &cobra.Command{
+		Use:   "add [id] [file]",
+		Short: "Attach file to data item",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			file, err := os.Open(args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to open file: %v\n", err)
+				os.Exit(1)
+			}
+			defer file.Close()
+
+			info, err := file.Stat()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read file: %v\n", err)
+				os.Exit(1)
+			}
+
+			filename := name
+			if filename == "" {
+				filename = filepath.Base(args[1])
+			}
+
+			contentType := mimeType
+			if contentType == "" {
+				contentType = mime.TypeByExtension(filepath.Ext(filename))
+			}
+
+			client := service.NewClientService()
+			attachment, err := client.UploadAttachment(cmd.Context(), args[0], filename, contentType, file, info.Size())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to attach file: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("File attached successfully: %s (%s)\n", attachment.Filename, attachment.ID)
+		},
+	}
+	addCmd.Flags().StringVarP(&name, "name", "n", "", "Attachment filename (defaults to the file name)")
+	addCmd.Flags().StringVar(&mimeType, "mime", "", "MIME type (detected by default)")
+
+	listCmd := &cobra.Command{
+		Use:   "list [id]",
+		Short: "List data item attachments",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			attachments, err := client.ListAttachments(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to list attachments: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(attachments) == 0 {
+				fmt.Println("No attachments found")
+				return
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "ID\tFILENAME\tSIZE\tMIME TYPE\tCREATED\n")
+			for _, attachment := range attachments {
+				fmt.Fprintf(w, "%s\t%s\t%d\t%s\t%s\n", attachment.ID, attachment.Filename, attachment.Size, attachment.MimeType, attachment.CreatedAt.Format(time.RFC3339))
+			}
+			_ = w.Flush()
+		},
+	}
+
+	var outputFile string
+	getCmd := &cobra.Command{
+		Use:   "get [id] [attachment]",
+		Short: "Download attachment by ID or filename",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			attachment, err := findAttachment(cmd.Context(), client, args[0], args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get attachment: %v\n", err)
+				os.Exit(1)
+			}
+
+			path := outputFile
+			if path == "" {
+				path = attachment.Filename
+			}
+
+			out := os.Stdout
+			if path != "-" {
+				out, err = os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to create file: %v\n", err)
+					os.Exit(1)
+				}
+				defer out.Close()
+			}
+
+			if err := client.DownloadAttachment(cmd.Context(), args[0], attachment.ID.String(), out); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to download attachment: %v\n", err)
+				if path != "-" {
+					_ = os.Remove(path)
+				}
+				os.Exit(1)
+			}
+
+			if path != "-" {
+				fmt.Printf("Attachment saved to %s\n", path)
+			}
+		},
+	}
+	getCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Output file (defaults to the attachment filename, - for stdout)")
+
+	rmCmd := &cobra.Command{
+		Use:   "rm [id] [attachment]",
+		Short: "Remove attachment by ID or filename",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			attachment, err := findAttachment(cmd.Context(), client, args[0], args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get attachment: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := client.DeleteAttachment(cmd.Context(), args[0], attachment.ID.String()); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to remove attachment: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Attachment removed successfully: %s\n", attachment.Filename)
+		},
+	}
+
+	attachCmd.AddCommand(addCmd)
+	attachCmd.AddCommand(listCmd)
+	attachCmd.AddCommand(getCmd)
+	attachCmd.AddCommand(rmCmd)
+
+	return attachCmd
+}
+
+// findAttachment находит вложение элемента данных по ID или имени файла.
+func findAttachment(ctx context.Context, client *service.ClientService, id, ref string) (*models.Attachment, error) {
+	attachments, err := client.ListAttachments(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	var found *models.Attachment
+	for _, attachment := range attachments {
+		if attachment.ID.String() == ref {
+			return attachment, nil
+		}
+		if attachment.Filename == ref {
+			if found != nil {
+				return nil, fmt.Errorf("several attachments are named %s, use the attachment ID", ref)
+			}
+			found = attachment
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("attachment %s not found", ref)
+	}
+	return found, nil
+}
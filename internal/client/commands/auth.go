@@ -2,11 +2,13 @@
 package commands
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
 )
 
 // NewAuthCommands создает команды для аутентификации.
@@ -28,6 +30,7 @@ func NewAuthCommands() *cobra.Command {
 			user, err := client.Register(cmd.Context(), email, password)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Registration failed: %v\n", err)
+				printPasswordFeedback(err)
 				os.Exit(1)
 			}
 
@@ -71,9 +74,50 @@ func NewAuthCommands() *cobra.Command {
 		},
 	}
 
+	changePasswordCmd := &cobra.Command{
+		Use:   "change-password [current-password] [new-password]",
+		Short: "Change user password",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			currentPassword := args[0]
+			newPassword := args[1]
+
+			client := service.NewClientService()
+			if err := client.ChangePassword(cmd.Context(), currentPassword, newPassword); err != nil {
+				fmt.Fprintf(os.Stderr, "Password change failed: %v\n", err)
+				printPasswordFeedback(err)
+				os.Exit(1)
+			}
+
+			_ = client.Logout()
+			fmt.Println("Password changed successfully, please login again")
+		},
+	}
+
 	authCmd.AddCommand(registerCmd)
 	authCmd.AddCommand(loginCmd)
 	authCmd.AddCommand(logoutCmd)
+	authCmd.AddCommand(changePasswordCmd)
 
 	return authCmd
 }
+
+// printPasswordFeedback выводит рекомендации по паролю, если сервер отклонил его по политике.
+func printPasswordFeedback(err error) {
+	var policyErr *validator.PasswordPolicyError
+	if !errors.As(err, &policyErr) {
+		return
+	}
+
+	feedback := policyErr.Feedback
+	fmt.Fprintf(os.Stderr, "Password strength: %d/4 (required: %d)\n", feedback.Score, feedback.MinScore)
+	if feedback.Breached {
+		fmt.Fprintf(os.Stderr, "This password was found in %d known data breaches\n", feedback.BreachCount)
+	}
+	if feedback.Warning != "" {
+		fmt.Fprintf(os.Stderr, "Warning: %s\n", feedback.Warning)
+	}
+	for _, suggestion := range feedback.Suggestions {
+		fmt.Fprintf(os.Stderr, "  - %s\n", suggestion)
+	}
+}
@@ -0,0 +1,143 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// newDataBatchCommand создает команду выполнения пакета операций над данными.
+func newDataBatchCommand() *cobra.Command {
+	var mode string
+	batchCmd := &cobra.Command{
+		Use:   "batch [file]",
+		Short: "Create, update and delete data items in one request",
+		Long: `Execute a batch of create, update and delete operations read from a JSON file
+(or standard input if no file is given):
+
+  {"operations": [
+    {"op": "create", "type": "text_data", "name": "note", "data": {"text": "hello"}},
+    {"op": "update", "id": "<uuid>", "version": 3, "name": "renamed", "data": {"text": "updated"}},
+    {"op": "delete", "id": "<uuid>", "version": 1}
+  ]}
+
+Update and delete operations require the version of the item they were based on
+(0 applies them to any version); a changed item fails with status 409.
+
+Modes:
+  atomic       all operations succeed or none is applied (default)
+  independent  each operation is applied on its own; failures do not affect the others
+
+Prints the status of every operation and exits with code 1 if any operation failed.`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var path string
+			if len(args) > 0 {
+				path = args[0]
+			}
+
+			batch, err := readBatch(path)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read batch: %v\n", err)
+				os.Exit(1)
+			}
+			if cmd.Flags().Changed("mode") || batch.Mode == "" {
+				batch.Mode = models.BatchMode(mode)
+			}
+
+			client := service.NewClientService()
+			result, err := client.ExecuteBatch(cmd.Context(), *batch)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to execute batch: %v\n", err)
+				os.Exit(1)
+			}
+
+			if !printBatchResult(batch.Operations, result) {
+				os.Exit(1)
+			}
+		},
+	}
+	batchCmd.Flags().StringVar(&mode, "mode", string(models.BatchAtomic), "Batch mode: atomic or independent")
+
+	return batchCmd
+}
+
+// deleteDataItems перемещает в корзину несколько элементов одним независимым пакетом
+// без проверки версий.
+func deleteDataItems(cmd *cobra.Command, ids []string) {
+	batch := models.DataBatch{Mode: models.BatchIndependent}
+	for _, id := range ids {
+		itemID, err := uuid.Parse(id)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Invalid data ID %q: %v\n", id, err)
+			os.Exit(1)
+		}
+		batch.Operations = append(batch.Operations, models.BatchOperation{Op: models.BatchDelete, ID: itemID, Force: true})
+	}
+
+	client := service.NewClientService()
+	result, err := client.ExecuteBatch(cmd.Context(), batch)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to delete data: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !printBatchResult(batch.Operations, result) {
+		os.Exit(1)
+	}
+}
+
+func readBatch(path string) (*models.DataBatch, error) {
+	var data []byte
+	var err error
+	if path == "" || path == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var batch models.DataBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, fmt.Errorf("invalid batch JSON: %w", err)
+	}
+
+	return &batch, nil
+}
+
+// printBatchResult печатает результаты операций пакета и сообщает, выполнены ли все операции.
+func printBatchResult(operations []models.BatchOperation, result *service.BatchResult) bool {
+	succeeded := result.Committed
+	for _, status := range result.Results {
+		op := models.BatchOperation{}
+		if status.Index >= 0 && status.Index < len(operations) {
+			op = operations[status.Index]
+		}
+
+		if status.Failed() {
+			succeeded = false
+			fmt.Printf("#%d %s: %d %v\n", status.Index, op.Op, status.Status, status.Err())
+			continue
+		}
+
+		if status.Item != nil {
+			fmt.Printf("#%d %s: %d ID: %s, Name: %s\n", status.Index, op.Op, status.Status, status.Item.ID, status.Item.Name)
+		} else {
+			fmt.Printf("#%d %s: %d ID: %s\n", status.Index, op.Op, status.Status, op.ID)
+		}
+	}
+
+	if !result.Committed {
+		fmt.Fprintln(os.Stderr, "Batch rolled back: no changes were applied")
+	}
+
+	return succeeded && len(result.Results) == len(operations) && result.Committed
+}
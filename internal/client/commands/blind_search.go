@@ -0,0 +1,145 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/blindindex"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// newDataBlindSearchCommand создает команду поиска по слепым индексам зашифрованных полей.
+func newDataBlindSearchCommand() *cobra.Command {
+	var field string
+	var mode string
+	var local bool
+	var searchType string
+	var folder string
+	var recursive bool
+	var tags []string
+	var tagMatch string
+	var limit int
+	var sort string
+	var order string
+	blindSearchCmd := &cobra.Command{
+		Use:   "blind-search [query]",
+		Short: "Search data items by blind indexes of encrypted fields",
+		Long: `Search data items by blind indexes: keyed hashes of the item name and of text and
+URL extra fields, which the server matches without storing the values. Secret, boolean
+and date fields are never indexed.
+
+Modes:
+  exact  the whole value, ignoring case and extra spaces (default)
+  token  values containing all words of the query
+  ngram  values containing the query as part of words (at least 3 characters)
+
+With --local the search tokens are computed on this machine with your blind index key,
+so the query text is never sent to the server. Blind indexes reveal to the server which
+items share values or words and how often; see "vaultfactory data reindex" to index
+items created before blind indexes were enabled.
+
+Example:
+  vaultfactory data blind-search "prod db"
+  vaultfactory data blind-search alice --field owner --mode token --local`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			search := models.BlindSearch{
+				Field: models.BlindIndexNameField,
+				Mode:  models.BlindIndexMode(mode),
+				Filter: models.DataFilter{
+					Type:      models.DataType(searchType),
+					Recursive: recursive,
+					Tags:      parseTagArgs(tags),
+				},
+			}
+			if field != "" && field != models.BlindIndexNameField {
+				search.Field = blindindex.FieldName(field)
+			}
+			search.Filter.Tags.Match = models.TagMatch(tagMatch)
+			if folder != "" {
+				search.Filter.Folder = folderRef(folder)
+			}
+
+			client := service.NewClientService()
+
+			if local {
+				key, err := client.GetBlindIndexKey(cmd.Context())
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to get blind index key: %v\n", err)
+					os.Exit(1)
+				}
+				search.Tokens = blindindex.Tokens(key, search.Field, search.Mode, args[0])
+				if len(search.Tokens) == 0 {
+					fmt.Fprintf(os.Stderr, "Failed to search data: query contains no searchable words\n")
+					os.Exit(1)
+				}
+			} else {
+				search.Query = args[0]
+			}
+
+			page := models.PageRequest{
+				Limit: min(limit, constants.MaxPageSize),
+				Sort:  models.DataSort(sort),
+				Order: models.SortOrder(order),
+			}
+
+			items, err := client.BlindSearchData(cmd.Context(), search, page, limit)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to search data: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(items) == 0 {
+				fmt.Println("No data items found")
+				return
+			}
+
+			for _, item := range items {
+				line := fmt.Sprintf("ID: %s, Type: %s, Name: %s", item.ID, item.Type, item.Name)
+				if len(item.Tags) > 0 {
+					line += fmt.Sprintf(", Tags: %s", strings.Join(item.Tags, ", "))
+				}
+				fmt.Println(line)
+			}
+		},
+	}
+	blindSearchCmd.Flags().StringVar(&field, "field", models.BlindIndexNameField, "Field to search: name or the name of an extra field")
+	blindSearchCmd.Flags().StringVar(&mode, "mode", string(models.BlindIndexExact), "Match mode: exact, token or ngram")
+	blindSearchCmd.Flags().BoolVar(&local, "local", false, "Compute search tokens locally without sending the query to the server")
+	blindSearchCmd.Flags().StringVarP(&searchType, "type", "t", "", "Search only items of type")
+	blindSearchCmd.Flags().StringVar(&folder, "folder", "", "Search only items in folder (path, e.g. work/aws)")
+	blindSearchCmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Include items in subfolders")
+	blindSearchCmd.Flags().StringArrayVar(&tags, "tag", nil, "Search only items with tag (prefix with ! to exclude)")
+	blindSearchCmd.Flags().StringVar(&tagMatch, "match", string(models.TagMatchAny), "How tags are combined: any or all")
+	blindSearchCmd.Flags().IntVarP(&limit, "limit", "n", 0, "Show at most N items (all if not set)")
+	blindSearchCmd.Flags().StringVar(&sort, "sort", "", "Sort by: name, created or updated (default updated)")
+	blindSearchCmd.Flags().StringVar(&order, "order", "", "Sort order: asc or desc (default asc for name, desc otherwise)")
+
+	return blindSearchCmd
+}
+
+// newDataReindexCommand создает команду перестроения слепых индексов.
+func newDataReindexCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "reindex",
+		Short: "Rebuild blind indexes of all data items",
+		Long: `Rebuild blind indexes of all data items. Items are indexed when created or changed;
+run this once for items created before blind indexes were enabled on the server or after
+the server secret was changed.`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			indexed, err := client.RebuildBlindIndex(cmd.Context())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to rebuild blind indexes: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Blind indexes rebuilt for %d data items\n", indexed)
+		},
+	}
+}
@@ -0,0 +1,121 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// NewCertificateCommands создает команды для работы с сертификатами.
+func NewCertificateCommands() *cobra.Command {
+	certCmd := &cobra.Command{
+		Use:   "cert",
+		Short: "Certificate management commands",
+		Long: `Certificate management commands. Certificates are stored as certificate data items;
+subject, SANs, issuer and validity period are parsed by the server and shown in listings.`,
+	}
+
+	var keyFile string
+	addCmd := &cobra.Command{
+		Use:   "add [name] [certificate-file]",
+		Short: "Store PEM certificate chain and optional private key",
+		Long: `Store PEM certificate chain and optional private key. The chain must start with the
+leaf certificate followed by its issuers. The private key must match the leaf certificate.
+
+Example:
+  vaultfactory cert add api-tls fullchain.pem --key privkey.pem`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			chain, err := os.ReadFile(args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read certificate: %v\n", err)
+				os.Exit(1)
+			}
+
+			payload := models.CertificatePayload{Certificate: string(chain)}
+			if keyFile != "" {
+				key, err := os.ReadFile(keyFile)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to read private key: %v\n", err)
+					os.Exit(1)
+				}
+				payload.PrivateKey = string(key)
+			}
+
+			data, err := json.Marshal(payload)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to encode certificate: %v\n", err)
+				os.Exit(1)
+			}
+
+			client := service.NewClientService()
+			item, err := client.AddData(cmd.Context(), models.Certificate, args[0], "", string(data))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to add data: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Certificate added successfully: %s\n", item.ID)
+			if item.Certificate != nil {
+				fmt.Printf("Subject: %s\n", item.Certificate.Subject)
+				fmt.Printf("Expires: %s\n", item.Certificate.NotAfter.Format(time.RFC3339))
+			}
+		},
+	}
+	addCmd.Flags().StringVarP(&keyFile, "key", "k", "", "PEM private key file")
+
+	var days int
+	expiringCmd := &cobra.Command{
+		Use:   "expiring",
+		Short: "List certificates expiring within N days",
+		Long:  "List certificates expiring within N days, including already expired ones.",
+		Args:  cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			expiring, err := client.ListExpiringCertificates(cmd.Context(), days)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to list certificates: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(expiring) == 0 {
+				fmt.Printf("No certificates expiring within %d days\n", days)
+				return
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "ID\tNAME\tSUBJECT\tEXPIRES\tDAYS LEFT\n")
+			for _, status := range expiring {
+				left := fmt.Sprintf("%d", status.DaysLeft)
+				if status.Expired {
+					left = "expired"
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", status.DataID, status.Name, status.Certificate.Subject, status.Certificate.NotAfter.Format(time.DateOnly), left)
+			}
+			_ = w.Flush()
+		},
+	}
+	expiringCmd.Flags().IntVarP(&days, "days", "d", constants.DefaultCertificateExpiryDays, "Report certificates expiring within this many days")
+
+	certCmd.AddCommand(addCmd)
+	certCmd.AddCommand(expiringCmd)
+
+	return certCmd
+}
+
+// certificateSummary возвращает краткое описание сертификата для списка элементов.
+func certificateSummary(info *models.CertificateInfo) string {
+	summary := info.Subject
+	if len(info.DNSNames) > 0 {
+		summary += " (" + strings.Join(info.DNSNames, ", ") + ")"
+	}
+	return fmt.Sprintf("%s, expires %s", summary, info.NotAfter.Format(time.DateOnly))
+}
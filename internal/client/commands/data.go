@@ -1,12 +1,14 @@
 package commands
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
 	"github.com/tempizhere/vaultfactory/internal/shared/models"
 )
 
@@ -17,17 +19,75 @@ func NewDataCommands() *cobra.Command {
 		Short: "Data management commands",
 	}
 
+	var customTypeName string
+	var fieldValues []string
+	var addExpiry expiryFlags
 	addCmd := &cobra.Command{
 		Use:   "add [type] [name] [data]",
 		Short: "Add new data item",
-		Args:  cobra.ExactArgs(3),
+		Long: `Add new data item. Data is a JSON object matching the type:
+  login_password  {"login": "...", "password": "...", "url": "...", "notes": "..."}
+  text_data       {"text": "..."}
+  binary_data     {"filename": "...", "mime_type": "...", "content": "<base64>"}
+  bank_card       {"number": "...", "holder": "...", "expiry": "MM/YY", "cvv": "..."}
+  ssh_key         {"private_key": "...", "comment": "..."}
+  totp            {"uri": "otpauth://totp/..."} or {"secret": "...", "issuer": "...", "digits": 6, "period": 30}
+  certificate     {"certificate": "<PEM chain>", "private_key": "<PEM>"}
+  env_bundle      {"variables": {"NAME": "value", ...}}
+
+With --type the type is taken from the flag and the arguments are [name] [data].
+Instead of JSON data, field values can be passed with --field key=value.
+Custom types are defined with "vaultfactory type add".
+Extra fields can be added to an item of any type with "vaultfactory data field set".
+With --expires-in, --expires-at or --max-reads the item deletes itself permanently
+once it expires or has been read the given number of times.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			switch {
+			case customTypeName == "":
+				return cobra.ExactArgs(3)(cmd, args)
+			case len(fieldValues) > 0:
+				return cobra.ExactArgs(1)(cmd, args)
+			default:
+				return cobra.ExactArgs(2)(cmd, args)
+			}
+		},
 		Run: func(cmd *cobra.Command, args []string) {
-			dataType := models.DataType(args[0])
-			name := args[1]
-			data := args[2]
+			typeName := customTypeName
+			if typeName == "" {
+				typeName, args = args[0], args[1:]
+			}
+			dataType := models.DataType(typeName)
+			name := args[0]
+
+			expiry, err := addExpiry.expiry()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid expiry: %v\n", err)
+				os.Exit(1)
+			}
 
 			client := service.NewClientService()
-			item, err := client.AddData(cmd.Context(), dataType, name, "", data)
+
+			var data string
+			if len(args) > 1 {
+				data = args[1]
+			} else {
+				var customType *models.CustomType
+				if !dataType.IsBuiltin() {
+					customType, err = client.GetType(cmd.Context(), typeName)
+					if err != nil {
+						fmt.Fprintf(os.Stderr, "Failed to get type: %v\n", err)
+						os.Exit(1)
+					}
+				}
+
+				data, err = buildFieldData(fieldValues, customType)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Invalid field: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			item, err := client.AddDataWithExpiry(cmd.Context(), dataType, name, "", data, expiry)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to add data: %v\n", err)
 				os.Exit(1)
@@ -36,13 +96,48 @@ func NewDataCommands() *cobra.Command {
 			fmt.Printf("Data added successfully: %s\n", item.ID)
 		},
 	}
+	addCmd.Flags().StringVarP(&customTypeName, "type", "t", "", "Data type (built-in or custom)")
+	addCmd.Flags().StringArrayVarP(&fieldValues, "field", "f", nil, "Field value key=value (used instead of JSON data)")
+	addExpiry.register(addCmd)
 
+	var listFolder string
+	var listRecursive bool
+	var listType string
+	var listTags []string
+	var listMatch string
+	var listLimit int
+	var listSort string
+	var listOrder string
 	listCmd := &cobra.Command{
 		Use:   "list",
 		Short: "List all data items",
+		Long: `List data items. Items can be filtered by type, folder and tags.
+A tag prefixed with ! excludes items with that tag. Items are listed from the most
+recently updated; --sort and --order change the order and --limit shows only the first items.
+
+Example:
+  vaultfactory data list --tag prod --tag aws --match all --tag '!expiring'
+  vaultfactory data list --sort name --limit 20`,
 		Run: func(cmd *cobra.Command, args []string) {
+			filter := models.DataFilter{
+				Type:      models.DataType(listType),
+				Recursive: listRecursive,
+				Tags:      parseTagArgs(listTags),
+			}
+			filter.Tags.Match = models.TagMatch(listMatch)
+			if listFolder != "" {
+				filter.Folder = folderRef(listFolder)
+			}
+
 			client := service.NewClientService()
-			items, err := client.ListData(cmd.Context())
+
+			page := models.PageRequest{
+				Limit: min(listLimit, constants.MaxPageSize),
+				Sort:  models.DataSort(listSort),
+				Order: models.SortOrder(listOrder),
+			}
+
+			items, err := client.ListDataSorted(cmd.Context(), filter, page, listLimit)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to list data: %v\n", err)
 				os.Exit(1)
@@ -53,48 +148,141 @@ func NewDataCommands() *cobra.Command {
 				return
 			}
 
+			overdue := overdueRotations(cmd, client)
+
 			for _, item := range items {
-				fmt.Printf("ID: %s, Type: %s, Name: %s\n", item.ID, item.Type, item.Name)
+				line := fmt.Sprintf("ID: %s, Type: %s, Name: %s", item.ID, item.Type, item.Name)
+				if len(item.Tags) > 0 {
+					line += fmt.Sprintf(", Tags: %s", strings.Join(item.Tags, ", "))
+				}
+				if item.Certificate != nil {
+					line += fmt.Sprintf(", Certificate: %s", certificateSummary(item.Certificate))
+				}
+				if status, ok := overdue[item.ID]; ok {
+					line += fmt.Sprintf(" [WARNING: password rotation overdue by %d days]", status.OverdueDays)
+				}
+				fmt.Println(line)
 			}
 		},
 	}
+	listCmd.Flags().StringVar(&listFolder, "folder", "", "List only items in folder (path, e.g. work/aws)")
+	listCmd.Flags().BoolVarP(&listRecursive, "recursive", "r", false, "Include items in subfolders")
+	listCmd.Flags().StringVarP(&listType, "type", "t", "", "List only items of type")
+	listCmd.Flags().StringArrayVar(&listTags, "tag", nil, "List only items with tag (prefix with ! to exclude)")
+	listCmd.Flags().StringVar(&listMatch, "match", string(models.TagMatchAny), "How tags are combined: any or all")
+	listCmd.Flags().IntVarP(&listLimit, "limit", "n", 0, "Show at most N items (all if not set)")
+	listCmd.Flags().StringVar(&listSort, "sort", "", "Sort by: name, created or updated (default updated)")
+	listCmd.Flags().StringVar(&listOrder, "order", "", "Sort order: asc or desc (default asc for name, desc otherwise)")
 
+	moveCmd := &cobra.Command{
+		Use:   "move [id] [folder-path]",
+		Short: "Move data item into folder (use / for the root)",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			if _, err := client.MoveData(cmd.Context(), args[0], folderRef(args[1])); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to move data: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Data moved successfully: %s\n", args[0])
+		},
+	}
+
+	var outputFile string
+	var reveal bool
+	var getVersion string
+	var passwordHistory bool
 	getCmd := &cobra.Command{
-		Use:   "get [id]",
-		Short: "Get data item by ID",
-		Args:  cobra.ExactArgs(1),
+		Use:     "get [id]",
+		Aliases: []string{"show"},
+		Short:   "Get data item by ID",
+		Args:    cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			id := args[0]
 
 			client := service.NewClientService()
+
+			if passwordHistory {
+				entries, err := client.GetPasswordHistory(cmd.Context(), id)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to get password history: %v\n", err)
+					os.Exit(1)
+				}
+				printPasswordHistory(os.Stdout, entries)
+				return
+			}
+
 			item, err := client.GetData(cmd.Context(), id)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to get data: %v\n", err)
 				os.Exit(1)
 			}
 
-			data, _ := json.MarshalIndent(item, "", "  ")
-			fmt.Println(string(data))
+			if getVersion != "" {
+				item, err = getVersionItem(cmd, client, item, getVersion)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to get version: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			var customType *models.CustomType
+			if !item.Type.IsBuiltin() {
+				customType, err = client.GetType(cmd.Context(), string(item.Type))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to get type: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			if err := printDataItem(os.Stdout, item, customType, reveal); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to display data: %v\n", err)
+				os.Exit(1)
+			}
+
+			if outputFile != "" {
+				if err := saveBinaryContent(item, outputFile); err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to save content: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Printf("Content saved to %s\n", outputFile)
+			}
 		},
 	}
+	getCmd.Flags().StringVarP(&outputFile, "output", "o", "", "Save binary content to file")
+	getCmd.Flags().BoolVar(&reveal, "reveal", false, "Show values of secret fields, private keys, certificates, totp secrets and environment variables")
+	getCmd.Flags().StringVar(&getVersion, "version", "", "Show content of the given version")
+	getCmd.Flags().BoolVar(&passwordHistory, "password-history", false, "Show previous passwords of login_password item")
 
+	var deleteVersion int64
 	deleteCmd := &cobra.Command{
-		Use:   "delete [id]",
-		Short: "Delete data item by ID",
-		Args:  cobra.ExactArgs(1),
+		Use:   "delete [id...]",
+		Short: "Move data items to trash",
+		Args:  cobra.MinimumNArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) > 1 {
+				if deleteVersion != models.AnyVersion {
+					fmt.Fprintf(os.Stderr, "--if-version can only be used with a single data item\n")
+					os.Exit(1)
+				}
+				deleteDataItems(cmd, args)
+				return
+			}
+
 			id := args[0]
 
 			client := service.NewClientService()
-			err := client.DeleteData(cmd.Context(), id)
+			err := client.DeleteData(cmd.Context(), id, deleteVersion)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Failed to delete data: %v\n", err)
 				os.Exit(1)
 			}
 
-			fmt.Printf("Data deleted successfully: %s\n", id)
+			fmt.Printf("Data moved to trash: %s\n", id)
 		},
 	}
+	deleteCmd.Flags().Int64Var(&deleteVersion, "if-version", models.AnyVersion, "Delete only if the item is still at this version")
 
 	syncCmd := &cobra.Command{
 		Use:   "sync",
@@ -113,9 +301,76 @@ func NewDataCommands() *cobra.Command {
 
 	dataCmd.AddCommand(addCmd)
 	dataCmd.AddCommand(listCmd)
+	dataCmd.AddCommand(newDataSearchCommand())
+	dataCmd.AddCommand(newDataBlindSearchCommand())
+	dataCmd.AddCommand(newDataReindexCommand())
 	dataCmd.AddCommand(getCmd)
+	dataCmd.AddCommand(newDataHistoryCommand())
+	dataCmd.AddCommand(newDataDiffCommand())
+	dataCmd.AddCommand(newDataRestoreCommand())
+	dataCmd.AddCommand(moveCmd)
+	dataCmd.AddCommand(newDataExpireCommand())
+	dataCmd.AddCommand(newDataRotationCommand())
+	dataCmd.AddCommand(newDataOverdueCommand())
+	dataCmd.AddCommand(newDataTagCommands())
+	dataCmd.AddCommand(newDataFieldCommands())
 	dataCmd.AddCommand(deleteCmd)
+	dataCmd.AddCommand(newDataBatchCommand())
 	dataCmd.AddCommand(syncCmd)
 
 	return dataCmd
 }
+
+// saveBinaryContent сохраняет содержимое элемента типа binary_data в файл.
+func saveBinaryContent(item *models.DataItem, path string) error {
+	payload, err := models.ParsePayload(item.Type, item.Data)
+	if err != nil {
+		return err
+	}
+
+	binary, ok := payload.(*models.BinaryPayload)
+	if !ok {
+		return fmt.Errorf("data item %s is not %s", item.ID, models.BinaryData)
+	}
+
+	return os.WriteFile(path, binary.Content, 0600)
+}
+
+// findDataItem находит элемент указанного типа по ID или имени и загружает его содержимое.
+// Имя должно быть уникальным среди элементов этого типа.
+func findDataItem(ctx context.Context, client *service.ClientService, dataType models.DataType, ref string) (*models.DataItem, error) {
+	items, err := client.ListDataFiltered(ctx, models.DataFilter{Type: dataType})
+	if err != nil {
+		return nil, err
+	}
+
+	found, err := matchDataItem(items, ref, string(dataType)+" item")
+	if err != nil {
+		return nil, err
+	}
+
+	return client.GetData(ctx, found.ID.String())
+}
+
+// matchDataItem выбирает из списка элемент с указанным ID или единственный элемент
+// с указанным именем. label описывает элементы списка в сообщениях об ошибках.
+func matchDataItem(items []*models.DataItem, ref, label string) (*models.DataItem, error) {
+	var found *models.DataItem
+	for _, item := range items {
+		if item.ID.String() == ref {
+			return item, nil
+		}
+		if item.Name == ref {
+			if found != nil {
+				return nil, fmt.Errorf("several %ss are named %s, use the item ID", label, ref)
+			}
+			found = item
+		}
+	}
+
+	if found == nil {
+		return nil, fmt.Errorf("%s %s not found", label, ref)
+	}
+
+	return found, nil
+}
@@ -0,0 +1,188 @@
+package commands
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"strings"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/envfile"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// NewEnvCommands создает команды для работы с наборами переменных окружения.
+func NewEnvCommands() *cobra.Command {
+	envCmd := &cobra.Command{
+		Use:   "env",
+		Short: "Environment bundle management commands",
+		Long: `Environment bundle management commands. Groups of variables are stored as env_bundle
+data items and injected into processes with "vaultfactory run".`,
+	}
+
+	importCmd := &cobra.Command{
+		Use:   "import [name] [env-file]",
+		Short: "Store variables from .env file as env_bundle item",
+		Long: `Store variables from .env file as env_bundle item. Comments, "export" prefixes and
+single or double quoted values are supported.
+
+Example:
+  vaultfactory env import billing-api .env.production`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			file, err := os.Open(args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read env file: %v\n", err)
+				os.Exit(1)
+			}
+			variables, err := envfile.Parse(file)
+			file.Close()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to parse env file: %v\n", err)
+				os.Exit(1)
+			}
+
+			data, err := json.Marshal(models.EnvBundlePayload{Variables: variables})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to encode variables: %v\n", err)
+				os.Exit(1)
+			}
+
+			client := service.NewClientService()
+			item, err := client.AddData(cmd.Context(), models.EnvBundle, args[0], "", string(data))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to add data: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Environment bundle added successfully: %s (%d variables)\n", item.ID, len(variables))
+		},
+	}
+
+	envCmd.AddCommand(importCmd)
+
+	return envCmd
+}
+
+// NewRunCommand создает команду запуска процесса с переменными из наборов env_bundle.
+func NewRunCommand() *cobra.Command {
+	var bundles []string
+	var prefix string
+	runCmd := &cobra.Command{
+		Use:   "run --bundle [name] -- [command] [args...]",
+		Short: "Run command with variables from environment bundles",
+		Long: `Run command with variables from environment bundles. Bundles are found by ID or name,
+decrypted in memory and passed to the command through its environment; nothing is
+written to disk. Bundles are applied in the given order, so later bundles override
+variables of earlier ones, and all of them override the current environment.
+
+A bundle reference may end with :PREFIX to prefix its variable names; --prefix is
+prepended to the names of all bundles. The command's exit code is returned.
+
+Example:
+  vaultfactory run --bundle billing-api -- ./server
+  vaultfactory run -b common -b postgres:DB_ --prefix APP_ -- env`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(bundles) == 0 {
+				fmt.Fprintf(os.Stderr, "Failed to run command: at least one --bundle is required\n")
+				os.Exit(1)
+			}
+
+			client := service.NewClientService()
+
+			variables := make(map[string]string)
+			for _, bundle := range bundles {
+				ref, bundlePrefix, _ := strings.Cut(bundle, ":")
+
+				item, err := findDataItem(cmd.Context(), client, models.EnvBundle, ref)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to get env bundle: %v\n", err)
+					os.Exit(1)
+				}
+
+				payload, err := models.ParsePayload(item.Type, item.Data)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to parse env bundle %s: %v\n", ref, err)
+					os.Exit(1)
+				}
+				p, ok := payload.(*models.EnvBundlePayload)
+				if !ok {
+					fmt.Fprintf(os.Stderr, "Failed to parse env bundle %s: data item is not %s\n", ref, models.EnvBundle)
+					os.Exit(1)
+				}
+
+				for name, value := range p.Variables {
+					name = prefix + bundlePrefix + name
+					if !envfile.IsValidName(name) {
+						fmt.Fprintf(os.Stderr, "Failed to run command: invalid variable name %q\n", name)
+						os.Exit(1)
+					}
+					variables[name] = value
+				}
+			}
+
+			os.Exit(runWithEnv(args, envfile.Merge(os.Environ(), variables)))
+		},
+	}
+	runCmd.Flags().SetInterspersed(false)
+	runCmd.Flags().StringArrayVarP(&bundles, "bundle", "b", nil, "Environment bundle ID or name, optionally with :PREFIX (repeatable)")
+	runCmd.Flags().StringVarP(&prefix, "prefix", "p", "", "Prefix for names of all injected variables")
+
+	return runCmd
+}
+
+// runWithEnv запускает команду с указанным окружением, подключая стандартные потоки,
+// и возвращает код выхода команды; для команды, завершенной сигналом, — 128 + номер
+// сигнала, как в shell. SIGINT терминал доставляет всей группе процессов, поэтому он
+// только перехватывается, чтобы дождаться завершения команды, а пересылается ей лишь SIGTERM.
+func runWithEnv(args []string, env []string) int {
+	child := exec.Command(args[0], args[1:]...)
+	child.Env = env
+	child.Stdin = os.Stdin
+	child.Stdout = os.Stdout
+	child.Stderr = os.Stderr
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer func() {
+		signal.Stop(signals)
+		close(signals)
+	}()
+
+	if err := child.Start(); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to run command: %v\n", err)
+		return 127
+	}
+
+	go func() {
+		for sig := range signals {
+			if sig == syscall.SIGTERM {
+				_ = child.Process.Signal(sig)
+			}
+		}
+	}()
+
+	err := child.Wait()
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		if exitErr.ExitCode() >= 0 {
+			return exitErr.ExitCode()
+		}
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+			return 128 + int(status.Signal())
+		}
+	}
+
+	fmt.Fprintf(os.Stderr, "Failed to run command: %v\n", err)
+	return 1
+}
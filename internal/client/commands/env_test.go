@@ -0,0 +1,70 @@
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// runUntilReady запускает runWithEnv со скриптом shell и после создания скриптом файла
+// $READY отправляет текущему процессу сигнал sig. Возвращает код выхода runWithEnv.
+func runUntilReady(t *testing.T, script string, sig syscall.Signal) int {
+	t.Helper()
+
+	ready := filepath.Join(t.TempDir(), "ready")
+	result := make(chan int, 1)
+	go func() {
+		result <- runWithEnv([]string{"sh", "-c", script}, append(os.Environ(), "READY="+ready))
+	}()
+
+	require.Eventually(t, func() bool {
+		_, err := os.Stat(ready)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, syscall.Kill(os.Getpid(), sig))
+
+	select {
+	case code := <-result:
+		return code
+	case <-time.After(5 * time.Second):
+		t.Fatal("command did not finish")
+		return 0
+	}
+}
+
+func TestRunWithEnv(t *testing.T) {
+	t.Run("exit code", func(t *testing.T) {
+		assert.Equal(t, 0, runWithEnv([]string{"sh", "-c", "exit 0"}, nil))
+		assert.Equal(t, 3, runWithEnv([]string{"sh", "-c", "exit 3"}, nil))
+	})
+
+	t.Run("environment", func(t *testing.T) {
+		assert.Equal(t, 42, runWithEnv([]string{"sh", "-c", `exit "$CODE"`}, []string{"CODE=42"}))
+	})
+
+	t.Run("command not found", func(t *testing.T) {
+		assert.Equal(t, 127, runWithEnv([]string{filepath.Join(t.TempDir(), "missing")}, nil))
+	})
+
+	t.Run("killed by signal", func(t *testing.T) {
+		assert.Equal(t, 128+int(syscall.SIGKILL), runWithEnv([]string{"sh", "-c", "kill -KILL $$"}, nil))
+	})
+
+	t.Run("interrupt waits for command", func(t *testing.T) {
+		// SIGINT не пересылается: команда получает его от терминала вместе со всей группой.
+		code := runUntilReady(t, `trap 'exit 8' INT; touch "$READY"; sleep 0.3; exit 4`, syscall.SIGINT)
+
+		assert.Equal(t, 4, code)
+	})
+
+	t.Run("terminate is forwarded", func(t *testing.T) {
+		code := runUntilReady(t, `trap 'exit 9' TERM; touch "$READY"; while :; do sleep 0.05; done`, syscall.SIGTERM)
+
+		assert.Equal(t, 9, code)
+	})
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// expiryFlags содержит флаги самоуничтожения элемента данных.
+type expiryFlags struct {
+	in       time.Duration
+	at       string
+	maxReads int
+}
+
+// register добавляет флаги самоуничтожения к команде.
+func (f *expiryFlags) register(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(&f.in, "expires-in", 0, "Delete the item after this duration (e.g. 24h)")
+	cmd.Flags().StringVar(&f.at, "expires-at", "", "Delete the item at this time (RFC 3339)")
+	cmd.Flags().IntVar(&f.maxReads, "max-reads", 0, "Delete the item after it has been read this many times")
+}
+
+// isSet сообщает, что задан хотя бы один флаг самоуничтожения.
+func (f *expiryFlags) isSet() bool {
+	return f.in != 0 || f.at != "" || f.maxReads != 0
+}
+
+// expiry преобразует флаги в условия самоуничтожения.
+func (f *expiryFlags) expiry() (models.DataExpiry, error) {
+	var expiry models.DataExpiry
+
+	if f.in != 0 && f.at != "" {
+		return expiry, fmt.Errorf("--expires-in and --expires-at cannot be used together")
+	}
+
+	if f.in != 0 {
+		expiresAt := time.Now().Add(f.in)
+		expiry.ExpiresAt = &expiresAt
+	}
+
+	if f.at != "" {
+		expiresAt, err := time.Parse(time.RFC3339, f.at)
+		if err != nil {
+			return expiry, fmt.Errorf("--expires-at must be in RFC 3339 format: %w", err)
+		}
+		expiry.ExpiresAt = &expiresAt
+	}
+
+	if f.maxReads != 0 {
+		maxReads := f.maxReads
+		expiry.MaxReads = &maxReads
+	}
+
+	return expiry, nil
+}
+
+// newDataExpireCommand создает команду изменения условий самоуничтожения элемента данных.
+func newDataExpireCommand() *cobra.Command {
+	var flags expiryFlags
+	var clear bool
+	expireCmd := &cobra.Command{
+		Use:   "expire [id]",
+		Short: "Set or clear self-destruct conditions of data item",
+		Long: `Set or clear self-destruct conditions of data item. An expired item, or an item
+read --max-reads times, is deleted permanently without going to the trash.
+Setting new conditions resets the read counter.
+
+Example:
+  vaultfactory data expire <id> --expires-in 24h --max-reads 1
+  vaultfactory data expire <id> --clear`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if clear == flags.isSet() {
+				fmt.Fprintln(os.Stderr, "Specify either self-destruct conditions or --clear")
+				os.Exit(1)
+			}
+
+			expiry, err := flags.expiry()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid expiry: %v\n", err)
+				os.Exit(1)
+			}
+
+			client := service.NewClientService()
+			item, err := client.SetExpiry(cmd.Context(), args[0], expiry)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to set expiry: %v\n", err)
+				os.Exit(1)
+			}
+
+			if clear {
+				fmt.Printf("Self-destruct cleared: %s\n", item.ID)
+				return
+			}
+			fmt.Printf("Self-destruct set: %s\n", item.ID)
+		},
+	}
+	flags.register(expireCmd)
+	expireCmd.Flags().BoolVar(&clear, "clear", false, "Remove self-destruct conditions")
+
+	return expireCmd
+}
@@ -0,0 +1,134 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// newDataFieldCommands создает команды управления дополнительными полями элемента данных.
+func newDataFieldCommands() *cobra.Command {
+	fieldCmd := &cobra.Command{
+		Use:   "field",
+		Short: "Manage custom fields of data item",
+		Long: `Manage custom fields of data item. Custom fields can be added to an item of
+any type, e.g. a security question on a login or a PIN on a card.
+Supported kinds: text, secret, url, boolean, date (YYYY-MM-DD).
+Values of secret fields are masked by "data get" unless --reveal is given.`,
+	}
+
+	var kind string
+	setCmd := &cobra.Command{
+		Use:   "set [id] [name] [value]",
+		Short: "Add custom field or replace its value",
+		Long: `Add custom field or replace its value.
+
+Example:
+  vaultfactory data field set <id> "Security question" "First pet's name"
+  vaultfactory data field set <id> PIN 1234 --kind secret`,
+		Args: cobra.ExactArgs(3),
+		Run: func(cmd *cobra.Command, args []string) {
+			field, err := newItemField(args[1], models.FieldKind(kind), args[2])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid field: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := setItemField(cmd.Context(), service.NewClientService(), args[0], field); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to set field: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Field %s set: %s\n", field.Name, args[0])
+		},
+	}
+	setCmd.Flags().StringVarP(&kind, "kind", "k", string(models.FieldText), "Field kind: text, secret, url, boolean or date")
+
+	rmCmd := &cobra.Command{
+		Use:     "rm [id] [name]",
+		Aliases: []string{"remove"},
+		Short:   "Remove custom field",
+		Args:    cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := removeItemField(cmd.Context(), service.NewClientService(), args[0], args[1]); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to remove field: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Field %s removed: %s\n", args[1], args[0])
+		},
+	}
+
+	fieldCmd.AddCommand(setCmd)
+	fieldCmd.AddCommand(rmCmd)
+
+	return fieldCmd
+}
+
+// newItemField создает дополнительное поле, преобразуя значение из командной строки
+// в JSON значение, соответствующее типу поля.
+func newItemField(name string, kind models.FieldKind, value string) (models.ItemField, error) {
+	field := models.ItemField{Name: name, Kind: kind}
+
+	var encoded interface{} = value
+	if kind == models.FieldBoolean {
+		flag, err := strconv.ParseBool(value)
+		if err != nil {
+			return field, fmt.Errorf("%s must be a boolean", name)
+		}
+		encoded = flag
+	}
+
+	raw, err := json.Marshal(encoded)
+	if err != nil {
+		return field, err
+	}
+	field.Value = raw
+
+	return field, nil
+}
+
+// itemFieldClient описывает методы клиента, необходимые для изменения дополнительных полей.
+type itemFieldClient interface {
+	GetData(ctx context.Context, id string) (*models.DataItem, error)
+	UpdateData(ctx context.Context, id string, version int64, name, metadata string, data json.RawMessage) (*models.DataItem, error)
+}
+
+// setItemField добавляет дополнительное поле элемента или заменяет его значение.
+func setItemField(ctx context.Context, client itemFieldClient, id string, field models.ItemField) error {
+	return updateItemFields(ctx, client, id, func(data []byte) ([]byte, error) {
+		return models.SetItemField(data, field)
+	})
+}
+
+// removeItemField удаляет дополнительное поле элемента.
+func removeItemField(ctx context.Context, client itemFieldClient, id, name string) error {
+	return updateItemFields(ctx, client, id, func(data []byte) ([]byte, error) {
+		return models.RemoveItemField(data, name)
+	})
+}
+
+// updateItemFields получает элемент, изменяет его содержимое функцией update и сохраняет
+// результат с версией полученного элемента. Проверка полей выполняется сервером.
+func updateItemFields(ctx context.Context, client itemFieldClient, id string, update func([]byte) ([]byte, error)) error {
+	item, err := client.GetData(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to get data: %w", err)
+	}
+
+	data, err := update(item.Data)
+	if err != nil {
+		return fmt.Errorf("failed to update fields: %w", err)
+	}
+
+	if _, err := client.UpdateData(ctx, id, item.Version, item.Name, item.Metadata, data); err != nil {
+		return fmt.Errorf("failed to update data: %w", err)
+	}
+	return nil
+}
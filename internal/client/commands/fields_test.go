@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// stubFieldClient хранит один элемент данных и запоминает версии, переданные при изменении.
+type stubFieldClient struct {
+	item     *models.DataItem
+	versions []int64
+}
+
+func (c *stubFieldClient) GetData(ctx context.Context, id string) (*models.DataItem, error) {
+	if id != c.item.ID.String() {
+		return nil, errors.New("not found")
+	}
+	copied := *c.item
+	return &copied, nil
+}
+
+func (c *stubFieldClient) UpdateData(ctx context.Context, id string, version int64, name, metadata string, data json.RawMessage) (*models.DataItem, error) {
+	c.versions = append(c.versions, version)
+	c.item.Name = name
+	c.item.Metadata = metadata
+	c.item.Data = data
+	c.item.Version++
+	return c.item, nil
+}
+
+func TestNewItemField(t *testing.T) {
+	_, err := newItemField("2FA", models.FieldBoolean, "yes")
+	assert.Error(t, err)
+
+	field, err := newItemField("2FA", models.FieldBoolean, "true")
+	require.NoError(t, err)
+	assert.Equal(t, json.RawMessage(`true`), field.Value)
+
+	field, err = newItemField("PIN", models.FieldSecret, "0042")
+	require.NoError(t, err)
+	assert.Equal(t, json.RawMessage(`"0042"`), field.Value)
+}
+
+func TestDataFieldSetRemove(t *testing.T) {
+	ctx := context.Background()
+	item := &models.DataItem{Name: "mail", Metadata: "work", Version: 3, Data: json.RawMessage(`{"login":"user","password":"secret"}`)}
+	client := &stubFieldClient{item: item}
+	id := item.ID.String()
+
+	pin, err := newItemField("PIN", models.FieldSecret, "1234")
+	require.NoError(t, err)
+	require.NoError(t, setItemField(ctx, client, id, pin))
+
+	question, err := newItemField("Security question", models.FieldText, "First pet's name")
+	require.NoError(t, err)
+	require.NoError(t, setItemField(ctx, client, id, question))
+
+	// Повторная установка заменяет значение поля, сохраняя его позицию.
+	pin, err = newItemField("PIN", models.FieldSecret, "4321")
+	require.NoError(t, err)
+	require.NoError(t, setItemField(ctx, client, id, pin))
+
+	fields, err := models.ParseItemFields(item.Data)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ItemField{pin, question}, fields)
+	assert.Equal(t, []int64{3, 4, 5}, client.versions)
+	assert.Equal(t, "mail", item.Name)
+	assert.Equal(t, "work", item.Metadata)
+
+	require.NoError(t, removeItemField(ctx, client, id, "PIN"))
+	require.NoError(t, removeItemField(ctx, client, id, "Security question"))
+	assert.JSONEq(t, `{"login":"user","password":"secret"}`, string(item.Data))
+
+	t.Run("missing field", func(t *testing.T) {
+		err := removeItemField(ctx, client, id, "PIN")
+
+		assert.ErrorContains(t, err, "field PIN not found")
+		assert.Len(t, client.versions, 5)
+	})
+
+	t.Run("missing item", func(t *testing.T) {
+		err := setItemField(ctx, client, "other", pin)
+
+		assert.ErrorContains(t, err, "failed to get data")
+	})
+}
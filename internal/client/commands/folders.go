@@ -0,0 +1,140 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// NewFolderCommands создает команды для управления папками.
+func NewFolderCommands() *cobra.Command {
+	folderCmd := &cobra.Command{
+		Use:   "folder",
+		Short: "Folder management commands",
+		Long:  "Folder management commands. Folders are referenced by path, e.g. work/aws/prod.",
+	}
+
+	createCmd := &cobra.Command{
+		Use:   "create [path]",
+		Short: "Create folder, including missing parent folders",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			folder, err := client.CreateFolder(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to create folder: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Folder created successfully: %s\n", folder.Path)
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List folders",
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			folders, err := client.ListFolders(cmd.Context())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to list folders: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(folders) == 0 {
+				fmt.Println("No folders found")
+				return
+			}
+
+			for _, folder := range folders {
+				depth := strings.Count(folder.Path, models.FolderPathSeparator)
+				fmt.Printf("%s%s/\n", strings.Repeat("  ", depth), folder.Name)
+			}
+		},
+	}
+
+	renameCmd := &cobra.Command{
+		Use:   "rename [path] [new-name]",
+		Short: "Rename folder",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			folder, err := client.FindFolder(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to rename folder: %v\n", err)
+				os.Exit(1)
+			}
+
+			folder, err = client.RenameFolder(cmd.Context(), folder.ID.String(), args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to rename folder: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Folder renamed successfully: %s\n", folder.Path)
+		},
+	}
+
+	moveCmd := &cobra.Command{
+		Use:   "move [path] [new-parent-path]",
+		Short: "Move folder into another folder (use / for the root)",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			folder, err := client.FindFolder(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to move folder: %v\n", err)
+				os.Exit(1)
+			}
+
+			folder, err = client.MoveFolder(cmd.Context(), folder.ID.String(), folderRef(args[1]))
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to move folder: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Folder moved successfully: %s\n", folder.Path)
+		},
+	}
+
+	var recursive bool
+	deleteCmd := &cobra.Command{
+		Use:   "delete [path]",
+		Short: "Delete folder",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			folder, err := client.FindFolder(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to delete folder: %v\n", err)
+				os.Exit(1)
+			}
+
+			if err := client.DeleteFolder(cmd.Context(), folder.ID.String(), recursive); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to delete folder: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Folder deleted successfully: %s\n", folder.Path)
+		},
+	}
+	deleteCmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Delete subfolders and data items as well")
+
+	folderCmd.AddCommand(createCmd)
+	folderCmd.AddCommand(listCmd)
+	folderCmd.AddCommand(renameCmd)
+	folderCmd.AddCommand(moveCmd)
+	folderCmd.AddCommand(deleteCmd)
+	folderCmd.AddCommand(newFolderRotationCommand())
+
+	return folderCmd
+}
+
+// folderRef преобразует путь папки из командной строки в ссылку для API: "/" означает корень.
+func folderRef(path string) string {
+	return strings.Trim(path, models.FolderPathSeparator)
+}
@@ -0,0 +1,269 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// maxDiffValueLength ограничивает длину значения поля при выводе различий.
+const maxDiffValueLength = 60
+
+// newDataHistoryCommand создает команду вывода истории версий элемента данных.
+func newDataHistoryCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "history [id]",
+		Short: "Show version history of data item",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			versions, err := client.ListVersions(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get history: %v\n", err)
+				os.Exit(1)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "VERSION\tCREATED\tNAME\tNOTE\n")
+			for _, version := range versions {
+				note := ""
+				if version.RestoredFrom != nil {
+					note = fmt.Sprintf("restored from v%d", *version.RestoredFrom)
+				}
+				fmt.Fprintf(w, "%d\t%s\t%s\t%s\n", version.Version, version.CreatedAt.Format(time.RFC3339), version.Name, note)
+			}
+			_ = w.Flush()
+		},
+	}
+}
+
+// newDataRestoreCommand создает команду восстановления элемента данных из версии.
+func newDataRestoreCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "restore [id] [version]",
+		Short: "Restore data item from version (creates a new version)",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			version, err := parseVersion(args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid version: %v\n", err)
+				os.Exit(1)
+			}
+
+			client := service.NewClientService()
+			item, err := client.RestoreVersion(cmd.Context(), args[0], version)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to restore data: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Data restored from version %d as version %d: %s\n", version, item.Version, item.ID)
+		},
+	}
+}
+
+// newDataDiffCommand создает команду сравнения версий элемента данных.
+func newDataDiffCommand() *cobra.Command {
+	var reveal bool
+	diffCmd := &cobra.Command{
+		Use:   "diff [id] [from-version] [to-version]",
+		Short: "Show changes between versions (current version by default)",
+		Args:  cobra.RangeArgs(2, 3),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+
+			item, err := client.GetData(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get data: %v\n", err)
+				os.Exit(1)
+			}
+
+			from, err := getVersionItem(cmd, client, item, args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get version: %v\n", err)
+				os.Exit(1)
+			}
+
+			to := item
+			if len(args) == 3 {
+				to, err = getVersionItem(cmd, client, item, args[2])
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to get version: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			var customType *models.CustomType
+			if !item.Type.IsBuiltin() {
+				customType, err = client.GetType(cmd.Context(), string(item.Type))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to get type: %v\n", err)
+					os.Exit(1)
+				}
+			}
+
+			if err := printDataDiff(os.Stdout, from, to, customType, reveal); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to compare versions: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	diffCmd.Flags().BoolVar(&reveal, "reveal", false, "Show values of secret fields")
+
+	return diffCmd
+}
+
+// getVersionItem получает версию элемента данных в виде элемента для вывода.
+func getVersionItem(cmd *cobra.Command, client *service.ClientService, item *models.DataItem, arg string) (*models.DataItem, error) {
+	version, err := parseVersion(arg)
+	if err != nil {
+		return nil, err
+	}
+
+	dataVersion, err := client.GetVersion(cmd.Context(), item.ID.String(), version)
+	if err != nil {
+		return nil, err
+	}
+
+	return versionItem(item, dataVersion), nil
+}
+
+// versionItem формирует элемент данных с содержимым указанной версии.
+func versionItem(item *models.DataItem, version *models.DataVersion) *models.DataItem {
+	return &models.DataItem{
+		ID:        item.ID,
+		FolderID:  item.FolderID,
+		Type:      item.Type,
+		Name:      version.Name,
+		Metadata:  version.Metadata,
+		Data:      version.Data,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: version.CreatedAt,
+		Version:   version.Version,
+		Tags:      item.Tags,
+	}
+}
+
+func parseVersion(arg string) (int64, error) {
+	version, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil || version < 1 {
+		return 0, fmt.Errorf("%s: expected positive version number", arg)
+	}
+	return version, nil
+}
+
+// printDataDiff выводит различия имени, метаданных и полей содержимого двух версий.
+// Значения секретных полей пользовательского типа маскируются, если reveal не установлен.
+func printDataDiff(out io.Writer, from, to *models.DataItem, customType *models.CustomType, reveal bool) error {
+	fmt.Fprintf(out, "--- version %d\n+++ version %d\n", from.Version, to.Version)
+
+	secrets := make(map[string]bool)
+	if customType != nil {
+		for _, field := range customType.Fields {
+			secrets[field.Name] = field.Secret && !reveal
+		}
+	}
+
+	changes := 0
+	printChange := func(name, before, after string, secret bool) {
+		if before == after {
+			return
+		}
+		changes++
+		if secret {
+			before, after = maskDiffValue(before), maskDiffValue(after)
+		}
+		switch {
+		case before == "":
+			fmt.Fprintf(out, "+ %s: %s\n", name, shortenDiffValue(after))
+		case after == "":
+			fmt.Fprintf(out, "- %s: %s\n", name, shortenDiffValue(before))
+		default:
+			fmt.Fprintf(out, "~ %s: %s -> %s\n", name, shortenDiffValue(before), shortenDiffValue(after))
+		}
+	}
+
+	printChange("name", from.Name, to.Name, false)
+	printChange("metadata", from.Metadata, to.Metadata, false)
+
+	fromFields, err := diffFields(from.Data)
+	if err != nil {
+		return err
+	}
+	toFields, err := diffFields(to.Data)
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(fromFields)+len(toFields))
+	for name := range fromFields {
+		names = append(names, name)
+	}
+	for name := range toFields {
+		if _, ok := fromFields[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		printChange(name, fromFields[name], toFields[name], secrets[name])
+	}
+
+	if changes == 0 {
+		fmt.Fprintln(out, "No changes")
+	}
+	return nil
+}
+
+// diffFields разбирает содержимое элемента в значения полей верхнего уровня.
+func diffFields(data []byte) (map[string]string, error) {
+	fields := make(map[string]string)
+	if len(data) == 0 {
+		return fields, nil
+	}
+
+	var values map[string]interface{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, fmt.Errorf("failed to parse data: %w", err)
+	}
+
+	for name, value := range values {
+		if value == nil {
+			continue
+		}
+		if text, ok := value.(string); ok {
+			fields[name] = text
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode field %s: %w", name, err)
+		}
+		fields[name] = string(encoded)
+	}
+	return fields, nil
+}
+
+func maskDiffValue(value string) string {
+	if value == "" {
+		return ""
+	}
+	return "********"
+}
+
+func shortenDiffValue(value string) string {
+	if len(value) <= maxDiffValueLength {
+		return value
+	}
+	return value[:maxDiffValueLength] + fmt.Sprintf("... (%d bytes)", len(value))
+}
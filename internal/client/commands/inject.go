@@ -0,0 +1,164 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/inject"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// NewInjectCommand создает команду подстановки значений из хранилища в шаблон.
+func NewInjectCommand() *cobra.Command {
+	var input string
+	var output string
+	var dryRun bool
+	injectCmd := &cobra.Command{
+		Use:   "inject",
+		Short: "Render template with values from the vault",
+		Long: `Render text/template template with values from the vault. Values are referenced by
+item ID or name and field name:
+  password: {{ vault "prod-db" "password" }}
+
+The field may be a field of the item type, an extra field added with
+"vaultfactory data field set" or a variable of an env_bundle item. A reference to a
+missing item or field fails the command and no output is written. The output file
+is created with permissions 0600.
+
+With --dry-run the referenced items are listed without decrypting them.
+
+Example:
+  vaultfactory inject -i config.yaml.tpl -o config.yaml
+  vaultfactory inject -i config.yaml.tpl --dry-run`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			text, err := readTemplate(input)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read template: %v\n", err)
+				os.Exit(1)
+			}
+
+			name := input
+			if name == "" {
+				name = "stdin"
+			}
+
+			client := service.NewClientService()
+
+			items, err := client.ListDataFiltered(cmd.Context(), models.DataFilter{})
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to list data: %v\n", err)
+				os.Exit(1)
+			}
+
+			if dryRun {
+				refs, err := inject.References(name, text)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to inspect template: %v\n", err)
+					os.Exit(1)
+				}
+				if !printReferences(os.Stdout, refs, items) {
+					os.Exit(1)
+				}
+				return
+			}
+
+			source := &vaultSource{ctx: cmd.Context(), client: client, items: items}
+			rendered, err := inject.Render(name, text, source)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to inject values: %v\n", err)
+				os.Exit(1)
+			}
+
+			if output == "" {
+				_, _ = os.Stdout.Write(rendered)
+				return
+			}
+
+			if err := writePrivateFile(output, rendered); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to write output: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Fprintf(os.Stderr, "Template rendered to %s\n", output)
+		},
+	}
+	injectCmd.Flags().StringVarP(&input, "input", "i", "", "Template file (stdin if not set)")
+	injectCmd.Flags().StringVarP(&output, "output", "o", "", "Output file (stdout if not set)")
+	injectCmd.Flags().BoolVar(&dryRun, "dry-run", false, "List referenced items without decrypting them")
+
+	return injectCmd
+}
+
+// vaultSource загружает элементы, на которые ссылается шаблон, по ID или имени.
+type vaultSource struct {
+	ctx    context.Context
+	client *service.ClientService
+	items  []*models.DataItem
+}
+
+// Item находит элемент по ID или имени и загружает его содержимое.
+func (s *vaultSource) Item(ref string) (*models.DataItem, error) {
+	found, err := matchDataItem(s.items, ref, "item")
+	if err != nil {
+		return nil, err
+	}
+
+	return s.client.GetData(s.ctx, found.ID.String())
+}
+
+// printReferences выводит ссылки шаблона и найденные для них элементы. Возвращает false,
+// если хотя бы одна ссылка не разрешается в элемент.
+func printReferences(out io.Writer, refs []inject.Reference, items []*models.DataItem) bool {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ITEM\tFIELD\tID\tTYPE")
+
+	resolved := true
+	for _, ref := range refs {
+		item, err := matchDataItem(items, ref.Item, "item")
+		if err != nil {
+			resolved = false
+			fmt.Fprintf(w, "%s\t%s\t-\t%v\n", ref.Item, ref.Field, err)
+			continue
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", ref.Item, ref.Field, item.ID, item.Type)
+	}
+
+	_ = w.Flush()
+	return resolved
+}
+
+func readTemplate(path string) (string, error) {
+	if path == "" {
+		data, err := io.ReadAll(os.Stdin)
+		return string(data), err
+	}
+
+	data, err := os.ReadFile(path)
+	return string(data), err
+}
+
+// writePrivateFile записывает данные в файл, доступный только владельцу. Права
+// существующего файла также ограничиваются до 0600.
+func writePrivateFile(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0600)
+	if err != nil {
+		return err
+	}
+
+	if err := file.Chmod(0600); err != nil {
+		file.Close()
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,218 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// printDataItem выводит элемент данных в виде полей, соответствующих его типу.
+// Для пользовательского типа поля выводятся по описанию customType, секретные
+// поля маскируются, если reveal не установлен.
+func printDataItem(out io.Writer, item *models.DataItem, customType *models.CustomType, reveal bool) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+
+	fmt.Fprintf(w, "ID:\t%s\n", item.ID)
+	fmt.Fprintf(w, "Type:\t%s\n", item.Type)
+	fmt.Fprintf(w, "Name:\t%s\n", item.Name)
+	if item.FolderID != nil {
+		fmt.Fprintf(w, "Folder:\t%s\n", item.FolderID)
+	}
+	if len(item.Tags) > 0 {
+		fmt.Fprintf(w, "Tags:\t%s\n", strings.Join(item.Tags, ", "))
+	}
+	if item.Metadata != "" {
+		fmt.Fprintf(w, "Metadata:\t%s\n", item.Metadata)
+	}
+	fmt.Fprintf(w, "Version:\t%d\n", item.Version)
+	fmt.Fprintf(w, "Updated:\t%s\n", item.UpdatedAt.Format(time.RFC3339))
+	if item.ExpiresAt != nil {
+		fmt.Fprintf(w, "Expires:\t%s\n", item.ExpiresAt.Format(time.RFC3339))
+	}
+	if item.MaxReads != nil {
+		fmt.Fprintf(w, "Reads:\t%d of %d\n", item.ReadCount, *item.MaxReads)
+	}
+	if item.PasswordChangedAt != nil {
+		fmt.Fprintf(w, "Password changed:\t%s\n", item.PasswordChangedAt.Format(time.RFC3339))
+	}
+	if item.RotationDays != nil {
+		fmt.Fprintf(w, "Rotation:\tevery %d days\n", *item.RotationDays)
+	}
+
+	if len(item.Data) > 0 && customType != nil {
+		if err := printCustomFields(w, item.Data, customType, reveal); err != nil {
+			return err
+		}
+	} else if len(item.Data) > 0 {
+		payload, err := models.ParsePayload(item.Type, item.Data)
+		if err != nil {
+			// Содержимое, сохраненное до проверки по схеме типа, выводится без разбора.
+			fmt.Fprintf(w, "Data:\t%s\n", item.Data)
+			return w.Flush()
+		}
+
+		switch p := payload.(type) {
+		case *models.LoginPasswordPayload:
+			fmt.Fprintf(w, "Login:\t%s\n", p.Login)
+			fmt.Fprintf(w, "Password:\t%s\n", p.Password)
+			printOptional(w, "URL", p.URL)
+			printOptional(w, "Notes", p.Notes)
+		case *models.TextPayload:
+			fmt.Fprintf(w, "Text:\t%s\n", p.Text)
+		case *models.BinaryPayload:
+			fmt.Fprintf(w, "Filename:\t%s\n", p.Filename)
+			printOptional(w, "MIME type", p.MimeType)
+			fmt.Fprintf(w, "Size:\t%d bytes\n", len(p.Content))
+		case *models.BankCardPayload:
+			fmt.Fprintf(w, "Number:\t%s\n", p.Number)
+			printOptional(w, "Brand", p.Brand)
+			printOptional(w, "Holder", p.Holder)
+			fmt.Fprintf(w, "Expiry:\t%s\n", p.Expiry)
+			printOptional(w, "CVV", p.CVV)
+		case *models.SSHKeyPayload:
+			fmt.Fprintf(w, "Key type:\t%s\n", p.KeyType)
+			fmt.Fprintf(w, "Fingerprint:\t%s\n", p.Fingerprint)
+			fmt.Fprintf(w, "Public key:\t%s\n", p.PublicKey)
+			printOptional(w, "Comment", p.Comment)
+			if reveal {
+				fmt.Fprintf(w, "Private key:\n%s", p.PrivateKey)
+			} else {
+				fmt.Fprintf(w, "Private key:\t%s\n", "********")
+			}
+		case *models.CertificatePayload:
+			if info := item.Certificate; info != nil {
+				fmt.Fprintf(w, "Subject:\t%s\n", info.Subject)
+				fmt.Fprintf(w, "Issuer:\t%s\n", info.Issuer)
+				printOptional(w, "DNS names", strings.Join(info.DNSNames, ", "))
+				printOptional(w, "IP addresses", strings.Join(info.IPAddresses, ", "))
+				fmt.Fprintf(w, "Serial:\t%s\n", info.SerialNumber)
+				fmt.Fprintf(w, "Fingerprint:\t%s\n", info.Fingerprint)
+				fmt.Fprintf(w, "Valid:\t%s - %s\n", info.NotBefore.Format(time.RFC3339), info.NotAfter.Format(time.RFC3339))
+				fmt.Fprintf(w, "Chain:\t%d certificates\n", info.ChainLength)
+			}
+			if reveal {
+				fmt.Fprintf(w, "Certificate:\n%s", p.Certificate)
+				if p.PrivateKey != "" {
+					fmt.Fprintf(w, "Private key:\n%s", p.PrivateKey)
+				}
+			} else if p.PrivateKey != "" {
+				fmt.Fprintf(w, "Private key:\t%s\n", "********")
+			}
+		case *models.EnvBundlePayload:
+			names := make([]string, 0, len(p.Variables))
+			for name := range p.Variables {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				value := "********"
+				if reveal {
+					value = p.Variables[name]
+				}
+				fmt.Fprintf(w, "%s:\t%s\n", name, value)
+			}
+		case *models.TOTPPayload:
+			printOptional(w, "Issuer", p.Issuer)
+			printOptional(w, "Account", p.Account)
+			fmt.Fprintf(w, "Algorithm:\t%s\n", p.Algorithm)
+			fmt.Fprintf(w, "Digits:\t%d\n", p.Digits)
+			fmt.Fprintf(w, "Period:\t%ds\n", p.Period)
+			if reveal {
+				fmt.Fprintf(w, "Secret:\t%s\n", p.Secret)
+				fmt.Fprintf(w, "URI:\t%s\n", p.URI)
+			} else {
+				fmt.Fprintf(w, "Secret:\t%s\n", "********")
+			}
+		}
+	}
+
+	if len(item.Data) > 0 {
+		if err := printItemFields(w, item.Data, reveal); err != nil {
+			return err
+		}
+	}
+
+	return w.Flush()
+}
+
+// printItemFields выводит дополнительные поля элемента; значения секретных полей
+// маскируются, если reveal не установлен.
+func printItemFields(w io.Writer, data []byte, reveal bool) error {
+	fields, err := models.ParseItemFields(data)
+	if err != nil {
+		return fmt.Errorf("failed to parse data: %w", err)
+	}
+
+	for _, field := range fields {
+		if field.IsSecret() && !reveal {
+			fmt.Fprintf(w, "%s:\t%s\n", field.Name, "********")
+			continue
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(field.Value, &value); err != nil {
+			return fmt.Errorf("failed to parse field %s: %w", field.Name, err)
+		}
+		fmt.Fprintf(w, "%s:\t%v\n", field.Name, value)
+	}
+
+	return nil
+}
+
+func printCustomFields(w io.Writer, data []byte, customType *models.CustomType, reveal bool) error {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil {
+		return fmt.Errorf("failed to parse data: %w", err)
+	}
+
+	for _, field := range customType.Fields {
+		raw, ok := values[field.Name]
+		if !ok {
+			continue
+		}
+
+		var value interface{}
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return fmt.Errorf("failed to parse field %s: %w", field.Name, err)
+		}
+
+		if field.Secret && !reveal {
+			fmt.Fprintf(w, "%s:\t%s\n", field.Name, "********")
+			continue
+		}
+		fmt.Fprintf(w, "%s:\t%v\n", field.Name, value)
+	}
+
+	return nil
+}
+
+func printOptional(w io.Writer, label, value string) {
+	if value != "" {
+		fmt.Fprintf(w, "%s:\t%s\n", label, value)
+	}
+}
+
+// printPasswordHistory выводит предыдущие пароли элемента, начиная с последней смены.
+func printPasswordHistory(out io.Writer, entries []*models.PasswordHistoryEntry) {
+	if len(entries) == 0 {
+		fmt.Fprintln(out, "No password history found")
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(w, "PASSWORD\tSET\tCHANGED\n")
+	for _, entry := range entries {
+		setAt := "-"
+		if entry.SetAt != nil {
+			setAt = entry.SetAt.Format(time.RFC3339)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", entry.Password, setAt, entry.ChangedAt.Format(time.RFC3339))
+	}
+	_ = w.Flush()
+}
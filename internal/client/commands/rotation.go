@@ -0,0 +1,156 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// rotationFlags содержит флаги интервала ротации паролей.
+type rotationFlags struct {
+	days  int
+	clear bool
+}
+
+// register добавляет флаги интервала ротации к команде.
+func (f *rotationFlags) register(cmd *cobra.Command) {
+	cmd.Flags().IntVar(&f.days, "days", 0, "Rotate passwords every given number of days")
+	cmd.Flags().BoolVar(&f.clear, "clear", false, "Remove the rotation interval")
+}
+
+// interval возвращает интервал ротации для API; nil означает снятие интервала.
+func (f *rotationFlags) interval() (*int, error) {
+	if f.clear == (f.days != 0) {
+		return nil, fmt.Errorf("specify either --days or --clear")
+	}
+	if f.clear {
+		return nil, nil
+	}
+	days := f.days
+	return &days, nil
+}
+
+// newDataRotationCommand создает команду изменения интервала ротации пароля элемента данных.
+func newDataRotationCommand() *cobra.Command {
+	var flags rotationFlags
+	rotationCmd := &cobra.Command{
+		Use:   "rotation [id]",
+		Short: "Set or clear password rotation interval of login_password item",
+		Long: `Set or clear password rotation interval of login_password item.
+The item interval takes precedence over the interval of its folder.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			days, err := flags.interval()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid flags: %v\n", err)
+				os.Exit(1)
+			}
+
+			client := service.NewClientService()
+			if _, err := client.SetDataRotation(cmd.Context(), args[0], days); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to set rotation: %v\n", err)
+				os.Exit(1)
+			}
+
+			if days == nil {
+				fmt.Printf("Rotation interval cleared: %s\n", args[0])
+				return
+			}
+			fmt.Printf("Rotation interval set to %d days: %s\n", *days, args[0])
+		},
+	}
+	flags.register(rotationCmd)
+
+	return rotationCmd
+}
+
+// newFolderRotationCommand создает команду изменения интервала ротации паролей папки.
+func newFolderRotationCommand() *cobra.Command {
+	var flags rotationFlags
+	rotationCmd := &cobra.Command{
+		Use:   "rotation [path]",
+		Short: "Set or clear password rotation interval of folder",
+		Long: `Set or clear password rotation interval of folder. The interval applies to
+login_password items in the folder and its subfolders unless overridden.`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			days, err := flags.interval()
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid flags: %v\n", err)
+				os.Exit(1)
+			}
+
+			client := service.NewClientService()
+			folder, err := client.FindFolder(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to set rotation: %v\n", err)
+				os.Exit(1)
+			}
+
+			folder, err = client.SetFolderRotation(cmd.Context(), folder.ID.String(), days)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to set rotation: %v\n", err)
+				os.Exit(1)
+			}
+
+			if days == nil {
+				fmt.Printf("Rotation interval cleared: %s\n", folder.Path)
+				return
+			}
+			fmt.Printf("Rotation interval set to %d days: %s\n", *days, folder.Path)
+		},
+	}
+	flags.register(rotationCmd)
+
+	return rotationCmd
+}
+
+// newDataOverdueCommand создает команду отчета о просроченной ротации паролей.
+func newDataOverdueCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "overdue",
+		Short: "List passwords overdue for rotation",
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			overdue, err := client.ListOverdueRotations(cmd.Context())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to list overdue passwords: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(overdue) == 0 {
+				fmt.Println("No passwords overdue for rotation")
+				return
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "ID\tNAME\tCHANGED\tINTERVAL\tOVERDUE\tPOLICY\n")
+			for _, status := range overdue {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%dd\t%dd\t%s\n", status.DataID, status.Name, status.PasswordChangedAt.Format(time.DateOnly), status.RotationDays, status.OverdueDays, status.Policy)
+			}
+			_ = w.Flush()
+		},
+	}
+}
+
+// overdueRotations получает просроченные ротации паролей по ID элементов. Ошибка не прерывает
+// вывод списка, а только выводит предупреждение.
+func overdueRotations(cmd *cobra.Command, client *service.ClientService) map[uuid.UUID]*models.RotationStatus {
+	overdue, err := client.ListOverdueRotations(cmd.Context())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: failed to check password rotation: %v\n", err)
+		return nil
+	}
+
+	result := make(map[uuid.UUID]*models.RotationStatus, len(overdue))
+	for _, status := range overdue {
+		result[status.DataID] = status
+	}
+	return result
+}
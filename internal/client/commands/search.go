@@ -0,0 +1,153 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// newDataSearchCommand создает команду поиска элементов данных.
+func newDataSearchCommand() *cobra.Command {
+	var prefix bool
+	var searchType string
+	var folder string
+	var recursive bool
+	var tags []string
+	var tagMatch string
+	var createdAfter, createdBefore, updatedAfter, updatedBefore string
+	var limit int
+	var sort string
+	var order string
+	searchCmd := &cobra.Command{
+		Use:   "search [query]",
+		Short: "Search data items by name and metadata",
+		Long: `Search data items by name and metadata. The name matches if it contains the query
+(or starts with it with --prefix); the metadata matches if it contains all words of the
+query. Only fields visible to the server are searched: encrypted content never is.
+The query may be omitted if at least one filter is given.
+
+Dates are given as YYYY-MM-DD or RFC3339; --*-after is inclusive, --*-before exclusive.
+
+Example:
+  vaultfactory data search prod --type login_password --tag aws
+  vaultfactory data search --updated-after 2024-01-01 --sort name`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			search := models.DataSearch{
+				Filter: models.DataFilter{
+					Type:      models.DataType(searchType),
+					Recursive: recursive,
+					Tags:      parseTagArgs(tags),
+				},
+			}
+			if len(args) > 0 {
+				search.Query = args[0]
+			}
+			if prefix {
+				search.Match = models.SearchPrefix
+			}
+			search.Filter.Tags.Match = models.TagMatch(tagMatch)
+			if folder != "" {
+				search.Filter.Folder = folderRef(folder)
+			}
+
+			for _, date := range []struct {
+				flag   string
+				value  string
+				target **time.Time
+			}{
+				{"created-after", createdAfter, &search.CreatedAfter},
+				{"created-before", createdBefore, &search.CreatedBefore},
+				{"updated-after", updatedAfter, &search.UpdatedAfter},
+				{"updated-before", updatedBefore, &search.UpdatedBefore},
+			} {
+				if date.value == "" {
+					continue
+				}
+				parsed, err := parseDateFlag(date.value)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Invalid --%s: %v\n", date.flag, err)
+					os.Exit(1)
+				}
+				*date.target = &parsed
+			}
+
+			page := models.PageRequest{
+				Limit: min(limit, constants.MaxPageSize),
+				Sort:  models.DataSort(sort),
+				Order: models.SortOrder(order),
+			}
+
+			client := service.NewClientService()
+			items, err := client.SearchData(cmd.Context(), search, page, limit)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to search data: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(items) == 0 {
+				fmt.Println("No data items found")
+				return
+			}
+
+			start, end := highlightMarkers()
+			for _, item := range items {
+				name := item.Name
+				if item.Highlight != nil && item.Highlight.Name != "" {
+					name = renderHighlight(item.Highlight.Name, start, end)
+				}
+				line := fmt.Sprintf("ID: %s, Type: %s, Name: %s", item.ID, item.Type, name)
+				if len(item.Tags) > 0 {
+					line += fmt.Sprintf(", Tags: %s", strings.Join(item.Tags, ", "))
+				}
+				fmt.Println(line)
+				if item.Highlight != nil && item.Highlight.Metadata != "" {
+					fmt.Printf("  Metadata: %s\n", renderHighlight(item.Highlight.Metadata, start, end))
+				}
+			}
+		},
+	}
+	searchCmd.Flags().BoolVar(&prefix, "prefix", false, "Match names starting with the query instead of containing it")
+	searchCmd.Flags().StringVarP(&searchType, "type", "t", "", "Search only items of type")
+	searchCmd.Flags().StringVar(&folder, "folder", "", "Search only items in folder (path, e.g. work/aws)")
+	searchCmd.Flags().BoolVarP(&recursive, "recursive", "r", false, "Include items in subfolders")
+	searchCmd.Flags().StringArrayVar(&tags, "tag", nil, "Search only items with tag (prefix with ! to exclude)")
+	searchCmd.Flags().StringVar(&tagMatch, "match", string(models.TagMatchAny), "How tags are combined: any or all")
+	searchCmd.Flags().StringVar(&createdAfter, "created-after", "", "Only items created at or after date")
+	searchCmd.Flags().StringVar(&createdBefore, "created-before", "", "Only items created before date")
+	searchCmd.Flags().StringVar(&updatedAfter, "updated-after", "", "Only items updated at or after date")
+	searchCmd.Flags().StringVar(&updatedBefore, "updated-before", "", "Only items updated before date")
+	searchCmd.Flags().IntVarP(&limit, "limit", "n", 0, "Show at most N items (all if not set)")
+	searchCmd.Flags().StringVar(&sort, "sort", "", "Sort by: name, created or updated (default updated)")
+	searchCmd.Flags().StringVar(&order, "order", "", "Sort order: asc or desc (default asc for name, desc otherwise)")
+
+	return searchCmd
+}
+
+// parseDateFlag разбирает дату в формате YYYY-MM-DD (локальное время) или RFC3339.
+func parseDateFlag(value string) (time.Time, error) {
+	if parsed, err := time.ParseInLocation("2006-01-02", value, time.Local); err == nil {
+		return parsed, nil
+	}
+	return time.Parse(time.RFC3339, value)
+}
+
+// highlightMarkers возвращает строки, которыми выделяются совпадения: жирный шрифт
+// в терминале и квадратные скобки при выводе в файл или канал.
+func highlightMarkers() (string, string) {
+	if info, err := os.Stdout.Stat(); err == nil && info.Mode()&os.ModeCharDevice != 0 {
+		return "\033[1m", "\033[0m"
+	}
+	return "[", "]"
+}
+
+// renderHighlight заменяет маркеры подсветки сервера на start и end.
+func renderHighlight(text, start, end string) string {
+	return strings.NewReplacer(models.HighlightStart, start, models.HighlightEnd, end).Replace(text)
+}
@@ -0,0 +1,227 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/client/sshagent"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/sshkey"
+)
+
+// NewSSHKeyCommands создает команды для работы с SSH ключами.
+func NewSSHKeyCommands() *cobra.Command {
+	sshKeyCmd := &cobra.Command{
+		Use:   "ssh-key",
+		Short: "SSH key management commands",
+		Long: `SSH key management commands. Keys are stored as ssh_key data items; the key type,
+public key and fingerprint are derived from the private key by the server.
+Stored keys can be used without writing them to disk via "vaultfactory ssh-agent".`,
+	}
+
+	var algorithm string
+	var bits int
+	var generateComment string
+	generateCmd := &cobra.Command{
+		Use:   "generate [name]",
+		Short: "Generate new SSH key and store it",
+		Long: `Generate new SSH key and store it.
+
+Example:
+  vaultfactory ssh-key generate github --comment me@laptop
+  vaultfactory ssh-key generate legacy --algorithm rsa --bits 4096`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			privateKey, err := sshkey.Generate(sshkey.Algorithm(algorithm), bits, generateComment)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to generate key: %v\n", err)
+				os.Exit(1)
+			}
+
+			addSSHKey(cmd, args[0], privateKey, generateComment)
+		},
+	}
+	generateCmd.Flags().StringVarP(&algorithm, "algorithm", "a", string(sshkey.Ed25519), "Key algorithm: ed25519, rsa or ecdsa")
+	generateCmd.Flags().IntVarP(&bits, "bits", "b", 0, "Key size (rsa: at least 2048, ecdsa: 256, 384 or 521)")
+	generateCmd.Flags().StringVarP(&generateComment, "comment", "C", "", "Key comment")
+
+	var importComment string
+	importCmd := &cobra.Command{
+		Use:   "import [name] [private-key-file]",
+		Short: "Import existing SSH private key",
+		Long: `Import existing SSH private key in OpenSSH or PEM format.
+Keys protected by a passphrase must be decrypted first (ssh-keygen -p -N "").`,
+		Args: cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			privateKey, err := os.ReadFile(args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to read key: %v\n", err)
+				os.Exit(1)
+			}
+
+			addSSHKey(cmd, args[0], string(privateKey), importComment)
+		},
+	}
+	importCmd.Flags().StringVarP(&importComment, "comment", "C", "", "Key comment")
+
+	publicCmd := &cobra.Command{
+		Use:   "public [id]",
+		Short: "Print public key in authorized_keys format",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+
+			item, err := client.GetData(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get data: %v\n", err)
+				os.Exit(1)
+			}
+
+			key, err := parseSSHKey(item)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to parse key: %v\n", err)
+				os.Exit(1)
+			}
+
+			if key.Comment != "" {
+				fmt.Printf("%s %s\n", key.PublicKey, key.Comment)
+				return
+			}
+			fmt.Println(key.PublicKey)
+		},
+	}
+
+	sshKeyCmd.AddCommand(generateCmd)
+	sshKeyCmd.AddCommand(importCmd)
+	sshKeyCmd.AddCommand(publicCmd)
+
+	return sshKeyCmd
+}
+
+// NewSSHAgentCommand создает команду запуска SSH агента с ключами из хранилища.
+func NewSSHAgentCommand() *cobra.Command {
+	var socket string
+	var folder string
+	var tags []string
+	agentCmd := &cobra.Command{
+		Use:   "ssh-agent",
+		Short: "Serve stored SSH keys over the SSH agent protocol",
+		Long: `Serve stored SSH keys over the SSH agent protocol. Keys are loaded into memory
+and served through a Unix socket; they are never written to disk. The agent runs
+until interrupted. Self-destructing keys are skipped, because loading them would
+count as a read.
+
+Example:
+  vaultfactory ssh-agent --socket ~/.ssh/vaultfactory.sock &
+  export SSH_AUTH_SOCK=~/.ssh/vaultfactory.sock`,
+		Args: cobra.NoArgs,
+		Run: func(cmd *cobra.Command, args []string) {
+			filter := models.DataFilter{Type: models.SSHKey, Tags: parseTagArgs(tags)}
+			if folder != "" {
+				filter.Folder = folderRef(folder)
+				filter.Recursive = true
+			}
+
+			client := service.NewClientService()
+
+			listed, err := client.ListDataFiltered(cmd.Context(), filter)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to list keys: %v\n", err)
+				os.Exit(1)
+			}
+
+			var items []*models.DataItem
+			for _, item := range listed {
+				if item.MaxReads != nil {
+					fmt.Fprintf(os.Stderr, "Skipping self-destructing key %s (%s)\n", item.Name, item.ID)
+					continue
+				}
+
+				item, err := client.GetData(cmd.Context(), item.ID.String())
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to get key: %v\n", err)
+					os.Exit(1)
+				}
+				items = append(items, item)
+			}
+
+			keyring, err := sshagent.NewKeyring(items)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to load keys: %v\n", err)
+				os.Exit(1)
+			}
+
+			if socket == "" {
+				dir, err := os.MkdirTemp("", "vaultfactory-agent-")
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to create socket directory: %v\n", err)
+					os.Exit(1)
+				}
+				defer os.RemoveAll(dir)
+				socket = filepath.Join(dir, "agent.sock")
+			}
+
+			listener, err := sshagent.Listen(socket)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to start agent: %v\n", err)
+				os.Exit(1)
+			}
+			defer os.Remove(socket)
+
+			fmt.Fprintf(os.Stderr, "Serving %d keys\n", len(items))
+			fmt.Printf("SSH_AUTH_SOCK=%s; export SSH_AUTH_SOCK;\n", socket)
+
+			if err := sshagent.Serve(cmd.Context(), listener, keyring); err != nil {
+				fmt.Fprintf(os.Stderr, "Agent stopped: %v\n", err)
+				os.Exit(1)
+			}
+		},
+	}
+	agentCmd.Flags().StringVarP(&socket, "socket", "a", "", "Unix socket path (default: temporary directory)")
+	agentCmd.Flags().StringVar(&folder, "folder", "", "Serve only keys in folder and its subfolders")
+	agentCmd.Flags().StringArrayVar(&tags, "tag", nil, "Serve only keys with tag (prefix with ! to exclude)")
+
+	return agentCmd
+}
+
+// addSSHKey сохраняет закрытый ключ как элемент типа ssh_key.
+func addSSHKey(cmd *cobra.Command, name, privateKey, comment string) {
+	data, err := json.Marshal(models.SSHKeyPayload{PrivateKey: privateKey, Comment: comment})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to encode key: %v\n", err)
+		os.Exit(1)
+	}
+
+	client := service.NewClientService()
+
+	item, err := client.AddData(cmd.Context(), models.SSHKey, name, "", string(data))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to add data: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("SSH key added successfully: %s\n", item.ID)
+	if key, err := parseSSHKey(item); err == nil {
+		fmt.Printf("Fingerprint: %s\n", key.Fingerprint)
+		fmt.Printf("Public key: %s\n", key.PublicKey)
+	}
+}
+
+// parseSSHKey извлекает содержимое элемента типа ssh_key.
+func parseSSHKey(item *models.DataItem) (*models.SSHKeyPayload, error) {
+	payload, err := models.ParsePayload(item.Type, item.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	key, ok := payload.(*models.SSHKeyPayload)
+	if !ok {
+		return nil, fmt.Errorf("data item %s is not %s", item.ID, models.SSHKey)
+	}
+
+	return key, nil
+}
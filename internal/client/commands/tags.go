@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// NewTagCommands создает команды для управления тегами.
+func NewTagCommands() *cobra.Command {
+	tagCmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Tag management commands",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List tags with item counts",
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			tags, err := client.ListTags(cmd.Context())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to list tags: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(tags) == 0 {
+				fmt.Println("No tags found")
+				return
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "TAG\tITEMS\n")
+			for _, tag := range tags {
+				fmt.Fprintf(w, "%s\t%d\n", tag.Name, tag.ItemCount)
+			}
+			_ = w.Flush()
+		},
+	}
+
+	renameCmd := &cobra.Command{
+		Use:   "rename [tag] [new-name]",
+		Short: "Rename tag",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			tag, err := client.RenameTag(cmd.Context(), args[0], args[1])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to rename tag: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Tag renamed successfully: %s\n", tag.Name)
+		},
+	}
+
+	mergeCmd := &cobra.Command{
+		Use:   "merge [target] [source]...",
+		Short: "Merge source tags into target tag",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			if err := client.MergeTags(cmd.Context(), args[1:], args[0]); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to merge tags: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Tags merged successfully into %s\n", args[0])
+		},
+	}
+
+	tagCmd.AddCommand(listCmd)
+	tagCmd.AddCommand(renameCmd)
+	tagCmd.AddCommand(mergeCmd)
+
+	return tagCmd
+}
+
+// newDataTagCommands создает команды для пометки элементов данных тегами.
+func newDataTagCommands() *cobra.Command {
+	tagCmd := &cobra.Command{
+		Use:   "tag",
+		Short: "Add or remove data item tags",
+	}
+
+	addCmd := &cobra.Command{
+		Use:   "add [id] [tag]...",
+		Short: "Add tags to data item",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			item, err := client.AddTags(cmd.Context(), args[0], args[1:])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to add tags: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Tags of %s: %s\n", item.ID, strings.Join(item.Tags, ", "))
+		},
+	}
+
+	removeCmd := &cobra.Command{
+		Use:   "remove [id] [tag]...",
+		Short: "Remove tags from data item",
+		Args:  cobra.MinimumNArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+
+			var item *models.DataItem
+			for _, tag := range args[1:] {
+				var err error
+				item, err = client.RemoveTag(cmd.Context(), args[0], tag)
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to remove tag %s: %v\n", tag, err)
+					os.Exit(1)
+				}
+			}
+
+			fmt.Printf("Tags of %s: %s\n", item.ID, strings.Join(item.Tags, ", "))
+		},
+	}
+
+	tagCmd.AddCommand(addCmd)
+	tagCmd.AddCommand(removeCmd)
+
+	return tagCmd
+}
+
+// parseTagArgs разбирает теги из командной строки: тег с префиксом ! исключается.
+func parseTagArgs(args []string) models.TagFilter {
+	var filter models.TagFilter
+	for _, arg := range args {
+		for _, tag := range strings.Split(arg, ",") {
+			tag = strings.TrimSpace(tag)
+			switch {
+			case strings.HasPrefix(tag, models.TagExcludePrefix):
+				if tag = strings.TrimPrefix(tag, models.TagExcludePrefix); tag != "" {
+					filter.Exclude = append(filter.Exclude, tag)
+				}
+			case tag != "":
+				filter.Include = append(filter.Include, tag)
+			}
+		}
+	}
+	return filter
+}
@@ -0,0 +1,95 @@
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/totp"
+)
+
+// NewTOTPCommand создает команду вывода текущего одноразового кода.
+func NewTOTPCommand() *cobra.Command {
+	var watch bool
+	totpCmd := &cobra.Command{
+		Use:   "totp [name]",
+		Short: "Print current one-time code of totp item",
+		Long: `Print current one-time code of totp item and the seconds until it changes.
+The item is found by ID or name. With --watch the code is refreshed until interrupted.
+
+2FA seeds are stored as totp data items:
+  vaultfactory data add totp github '{"uri": "otpauth://totp/GitHub:me?secret=..."}'`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+
+			params, err := findTOTPParams(cmd.Context(), client, args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get totp item: %v\n", err)
+				os.Exit(1)
+			}
+
+			if !watch {
+				code, remaining, err := params.Code(time.Now())
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Failed to generate code: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Printf("%s (%ds remaining)\n", code, int(remaining.Seconds()))
+				return
+			}
+
+			ticker := time.NewTicker(time.Second)
+			defer ticker.Stop()
+			for {
+				code, remaining, err := params.Code(time.Now())
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "\nFailed to generate code: %v\n", err)
+					os.Exit(1)
+				}
+				fmt.Printf("\r%s (%2ds remaining)", code, int(remaining.Seconds()))
+
+				select {
+				case <-cmd.Context().Done():
+					fmt.Println()
+					return
+				case <-ticker.C:
+				}
+			}
+		},
+	}
+	totpCmd.Flags().BoolVarP(&watch, "watch", "w", false, "Refresh the code every second until interrupted")
+
+	return totpCmd
+}
+
+// findTOTPParams находит элемент типа totp по ID или имени и возвращает параметры генерации кодов.
+func findTOTPParams(ctx context.Context, client *service.ClientService, ref string) (*totp.Params, error) {
+	item, err := findDataItem(ctx, client, models.TOTP, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := models.ParsePayload(item.Type, item.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := payload.(*models.TOTPPayload)
+	if !ok {
+		return nil, fmt.Errorf("data item %s is not %s", item.ID, models.TOTP)
+	}
+
+	return &totp.Params{
+		Secret:    p.Secret,
+		Issuer:    p.Issuer,
+		Account:   p.Account,
+		Algorithm: totp.Algorithm(p.Algorithm),
+		Digits:    p.Digits,
+		Period:    p.Period,
+	}, nil
+}
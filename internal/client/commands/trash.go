@@ -0,0 +1,101 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+)
+
+// NewTrashCommands создает команды для управления корзиной.
+func NewTrashCommands() *cobra.Command {
+	trashCmd := &cobra.Command{
+		Use:   "trash",
+		Short: "Trash management commands",
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List deleted data items",
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			items, err := client.ListTrash(cmd.Context())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to list trash: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(items) == 0 {
+				fmt.Println("Trash is empty")
+				return
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "ID\tTYPE\tNAME\tDELETED\n")
+			for _, item := range items {
+				deleted := ""
+				if item.DeletedAt != nil {
+					deleted = item.DeletedAt.Format(time.RFC3339)
+				}
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", item.ID, item.Type, item.Name, deleted)
+			}
+			_ = w.Flush()
+		},
+	}
+
+	restoreCmd := &cobra.Command{
+		Use:   "restore [id]",
+		Short: "Restore data item from trash",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			item, err := client.RestoreFromTrash(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to restore data: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Data restored successfully: %s\n", item.ID)
+		},
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete [id]",
+		Short: "Permanently delete data item from trash",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			if err := client.PurgeFromTrash(cmd.Context(), args[0]); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to delete data: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Data permanently deleted: %s\n", args[0])
+		},
+	}
+
+	emptyCmd := &cobra.Command{
+		Use:   "empty",
+		Short: "Permanently delete all data items in trash",
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			deleted, err := client.EmptyTrash(cmd.Context())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to empty trash: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Trash emptied: %d item(s) permanently deleted\n", deleted)
+		},
+	}
+
+	trashCmd.AddCommand(listCmd)
+	trashCmd.AddCommand(restoreCmd)
+	trashCmd.AddCommand(deleteCmd)
+	trashCmd.AddCommand(emptyCmd)
+
+	return trashCmd
+}
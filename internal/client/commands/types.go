@@ -0,0 +1,216 @@
+package commands
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"github.com/tempizhere/vaultfactory/internal/client/service"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// NewTypeCommands создает команды для управления пользовательскими типами данных.
+func NewTypeCommands() *cobra.Command {
+	typeCmd := &cobra.Command{
+		Use:   "type",
+		Short: "Custom data type management commands",
+	}
+
+	var description string
+	var fieldSpecs []string
+	addCmd := &cobra.Command{
+		Use:   "add [name]",
+		Short: "Define a new custom data type",
+		Long: `Define a new custom data type. Each field is described as name:kind[:secret][:required],
+where kind is one of: text, multiline, number, boolean, date, url, email.
+
+Example:
+  vaultfactory type add database --field dsn:text:secret:required --field port:number`,
+		Args: cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			fields, err := parseFieldSpecs(fieldSpecs)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Invalid field: %v\n", err)
+				os.Exit(1)
+			}
+
+			client := service.NewClientService()
+			customType, err := client.CreateType(cmd.Context(), args[0], description, fields)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to add type: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Type added successfully: %s\n", customType.Name)
+		},
+	}
+	addCmd.Flags().StringVarP(&description, "description", "d", "", "Type description")
+	addCmd.Flags().StringArrayVarP(&fieldSpecs, "field", "f", nil, "Field definition name:kind[:secret][:required]")
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "List custom data types",
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			customTypes, err := client.ListTypes(cmd.Context())
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to list types: %v\n", err)
+				os.Exit(1)
+			}
+
+			if len(customTypes) == 0 {
+				fmt.Println("No custom types found")
+				return
+			}
+
+			for _, customType := range customTypes {
+				fmt.Printf("Name: %s, Fields: %d, Description: %s\n", customType.Name, len(customType.Fields), customType.Description)
+			}
+		},
+	}
+
+	showCmd := &cobra.Command{
+		Use:   "show [name]",
+		Short: "Show custom data type fields",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			customType, err := client.GetType(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get type: %v\n", err)
+				os.Exit(1)
+			}
+
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintf(w, "FIELD\tKIND\tSECRET\tREQUIRED\n")
+			for _, field := range customType.Fields {
+				fmt.Fprintf(w, "%s\t%s\t%t\t%t\n", field.Name, field.Kind, field.Secret, field.Required)
+			}
+			_ = w.Flush()
+		},
+	}
+
+	templateCmd := &cobra.Command{
+		Use:   "template [name]",
+		Short: "Print a JSON template for a custom data type",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			customType, err := client.GetType(cmd.Context(), args[0])
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to get type: %v\n", err)
+				os.Exit(1)
+			}
+
+			template := make(map[string]interface{}, len(customType.Fields))
+			for _, field := range customType.Fields {
+				switch field.Kind {
+				case models.FieldNumber:
+					template[field.Name] = 0
+				case models.FieldBoolean:
+					template[field.Name] = false
+				default:
+					template[field.Name] = ""
+				}
+			}
+
+			output, _ := json.MarshalIndent(template, "", "  ")
+			fmt.Println(string(output))
+		},
+	}
+
+	deleteCmd := &cobra.Command{
+		Use:   "delete [name]",
+		Short: "Delete custom data type",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			client := service.NewClientService()
+			if err := client.DeleteType(cmd.Context(), args[0]); err != nil {
+				fmt.Fprintf(os.Stderr, "Failed to delete type: %v\n", err)
+				os.Exit(1)
+			}
+
+			fmt.Printf("Type deleted successfully: %s\n", args[0])
+		},
+	}
+
+	typeCmd.AddCommand(addCmd)
+	typeCmd.AddCommand(listCmd)
+	typeCmd.AddCommand(showCmd)
+	typeCmd.AddCommand(templateCmd)
+	typeCmd.AddCommand(deleteCmd)
+
+	return typeCmd
+}
+
+// parseFieldSpecs разбирает описания полей в формате name:kind[:secret][:required].
+func parseFieldSpecs(specs []string) ([]models.CustomField, error) {
+	fields := make([]models.CustomField, 0, len(specs))
+	for _, spec := range specs {
+		parts := strings.Split(spec, ":")
+		if len(parts) < 2 {
+			return nil, fmt.Errorf("%s: expected name:kind[:secret][:required]", spec)
+		}
+
+		field := models.CustomField{Name: parts[0], Kind: models.FieldKind(parts[1])}
+		for _, flag := range parts[2:] {
+			switch flag {
+			case "secret":
+				field.Secret = true
+			case "required":
+				field.Required = true
+			default:
+				return nil, fmt.Errorf("%s: unknown flag %s", spec, flag)
+			}
+		}
+
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+// buildFieldData собирает JSON содержимое элемента из значений key=value.
+// Для пользовательского типа значения приводятся к типу поля.
+func buildFieldData(values []string, customType *models.CustomType) (string, error) {
+	kinds := make(map[string]models.FieldKind)
+	if customType != nil {
+		for _, field := range customType.Fields {
+			kinds[field.Name] = field.Kind
+		}
+	}
+
+	data := make(map[string]interface{}, len(values))
+	for _, value := range values {
+		key, raw, found := strings.Cut(value, "=")
+		if !found || key == "" {
+			return "", fmt.Errorf("%s: expected key=value", value)
+		}
+
+		switch kinds[key] {
+		case models.FieldNumber:
+			number, err := strconv.ParseFloat(raw, 64)
+			if err != nil {
+				return "", fmt.Errorf("%s must be a number", key)
+			}
+			data[key] = number
+		case models.FieldBoolean:
+			flag, err := strconv.ParseBool(raw)
+			if err != nil {
+				return "", fmt.Errorf("%s must be a boolean", key)
+			}
+			data[key] = flag
+		default:
+			data[key] = raw
+		}
+	}
+
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode data: %w", err)
+	}
+	return string(encoded), nil
+}
@@ -0,0 +1,128 @@
+// Package envfile разбирает файлы переменных окружения в формате .env.
+package envfile
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var nameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// IsValidName сообщает, является ли строка допустимым именем переменной окружения.
+func IsValidName(name string) bool {
+	return nameRegex.MatchString(name)
+}
+
+// Merge возвращает окружение base в формате KEY=value, дополненное переменными variables.
+// Переменные из variables заменяют одноименные переменные base.
+func Merge(base []string, variables map[string]string) []string {
+	env := make([]string, 0, len(base)+len(variables))
+	for _, entry := range base {
+		name, _, _ := strings.Cut(entry, "=")
+		if _, ok := variables[name]; ok {
+			continue
+		}
+		env = append(env, entry)
+	}
+
+	names := make([]string, 0, len(variables))
+	for name := range variables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		env = append(env, name+"="+variables[name])
+	}
+
+	return env
+}
+
+// Parse разбирает содержимое .env файла. Поддерживаются комментарии (#), префикс export,
+// значения в одинарных кавычках (без обработки) и в двойных кавычках (с экранированием \n, \t, \" и \\).
+// Повторное объявление переменной заменяет предыдущее значение.
+func Parse(r io.Reader) (map[string]string, error) {
+	variables := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		line = strings.TrimPrefix(line, "export ")
+		name, value, found := strings.Cut(line, "=")
+		if !found {
+			return nil, fmt.Errorf("line %d: expected NAME=value", lineNumber)
+		}
+
+		name = strings.TrimSpace(name)
+		if !IsValidName(name) {
+			return nil, fmt.Errorf("line %d: invalid variable name %q", lineNumber, name)
+		}
+
+		parsed, err := parseValue(strings.TrimSpace(value))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		variables[name] = parsed
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read env file: %w", err)
+	}
+
+	return variables, nil
+}
+
+func parseValue(value string) (string, error) {
+	if value == "" {
+		return "", nil
+	}
+
+	switch value[0] {
+	case '\'':
+		end := strings.IndexByte(value[1:], '\'')
+		if end < 0 {
+			return "", fmt.Errorf("unterminated single-quoted value")
+		}
+		return value[1 : end+1], nil
+	case '"':
+		var b strings.Builder
+		for i := 1; i < len(value); i++ {
+			switch c := value[i]; c {
+			case '"':
+				return b.String(), nil
+			case '\\':
+				if i+1 == len(value) {
+					return "", fmt.Errorf("unterminated double-quoted value")
+				}
+				i++
+				switch value[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				default:
+					b.WriteByte(value[i])
+				}
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", fmt.Errorf("unterminated double-quoted value")
+	}
+
+	// Комментарий после значения без кавычек отделяется пробелом.
+	if idx := strings.Index(value, " #"); idx >= 0 {
+		value = value[:idx]
+	}
+	return strings.TrimSpace(value), nil
+}
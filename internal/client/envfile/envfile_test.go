@@ -0,0 +1,54 @@
+package envfile
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	content := `
+# database settings
+DB_HOST=localhost
+export DB_PORT=5432 # default port
+DB_PASSWORD='p@ss#word $HOME'
+GREETING="hello\nworld \"quoted\""
+EMPTY=
+DB_HOST=db.internal
+`
+
+	variables, err := Parse(strings.NewReader(content))
+
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{
+		"DB_HOST":     "db.internal",
+		"DB_PORT":     "5432",
+		"DB_PASSWORD": "p@ss#word $HOME",
+		"GREETING":    "hello\nworld \"quoted\"",
+		"EMPTY":       "",
+	}, variables)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	invalid := []string{
+		"NO_EQUALS",
+		"1NAME=value",
+		"NAME='unterminated",
+		`NAME="unterminated`,
+	}
+
+	for _, content := range invalid {
+		_, err := Parse(strings.NewReader(content))
+		assert.Error(t, err, content)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	base := []string{"PATH=/usr/bin", "DB_HOST=localhost", "HOME=/root"}
+
+	env := Merge(base, map[string]string{"DB_HOST": "db.internal", "DB_PORT": "5432"})
+
+	assert.Equal(t, []string{"PATH=/usr/bin", "HOME=/root", "DB_HOST=db.internal", "DB_PORT=5432"}, env)
+}
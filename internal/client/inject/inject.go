@@ -0,0 +1,144 @@
+// Package inject подставляет значения из хранилища в текстовые шаблоны конфигурации.
+//
+// Шаблоны используют синтаксис text/template и функцию vault:
+//
+//	password: {{ vault "prod-db" "password" }}
+//
+// Первый аргумент — ID или имя элемента, второй — поле его содержимого: поле встроенного
+// или пользовательского типа, дополнительное поле или переменная набора env_bundle.
+package inject
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// Source загружает расшифрованные элементы данных по ID или имени.
+type Source interface {
+	Item(ref string) (*models.DataItem, error)
+}
+
+// Reference описывает ссылку шаблона на поле элемента хранилища.
+type Reference struct {
+	Item  string
+	Field string
+}
+
+// Render выполняет шаблон, подставляя значения полей элементов из source. Ссылка на
+// отсутствующий элемент или поле, как и обращение к отсутствующему ключу, является ошибкой.
+// Каждый элемент загружается из source один раз.
+func Render(name, text string, source Source) ([]byte, error) {
+	items := make(map[string]*models.DataItem)
+
+	return execute(name, text, func(ref, field string) (string, error) {
+		item, ok := items[ref]
+		if !ok {
+			var err error
+			item, err = source.Item(ref)
+			if err != nil {
+				return "", err
+			}
+			items[ref] = item
+		}
+
+		return FieldValue(item, field)
+	})
+}
+
+// References возвращает ссылки шаблона на элементы хранилища без обращения к нему.
+// Ссылки собираются при выполнении шаблона с пустыми значениями, поэтому ссылки в ветках,
+// зависящих от значений полей, могут быть не найдены.
+func References(name, text string) ([]Reference, error) {
+	var refs []Reference
+	seen := make(map[Reference]bool)
+
+	_, err := execute(name, text, func(ref, field string) (string, error) {
+		reference := Reference{Item: ref, Field: field}
+		if !seen[reference] {
+			seen[reference] = true
+			refs = append(refs, reference)
+		}
+		return "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return refs, nil
+}
+
+func execute(name, text string, lookup func(ref, field string) (string, error)) ([]byte, error) {
+	tpl, err := template.New(name).
+		Option("missingkey=error").
+		Funcs(template.FuncMap{"vault": lookup}).
+		Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse template: %w", err)
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, nil); err != nil {
+		return nil, fmt.Errorf("failed to render template: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// FieldValue возвращает значение поля содержимого элемента в виде строки. Поле ищется
+// среди полей содержимого, затем среди дополнительных полей и переменных env_bundle.
+func FieldValue(item *models.DataItem, field string) (string, error) {
+	var content map[string]json.RawMessage
+	if err := json.Unmarshal(item.Data, &content); err != nil {
+		return "", fmt.Errorf("item %s: invalid content: %w", item.Name, err)
+	}
+
+	if field != models.ItemFieldsKey {
+		if raw, ok := content[field]; ok {
+			return rawValue(item, field, raw)
+		}
+	}
+
+	fields, err := models.ParseItemFields(item.Data)
+	if err != nil {
+		return "", fmt.Errorf("item %s: %w", item.Name, err)
+	}
+	for _, f := range fields {
+		if f.Name == field {
+			return rawValue(item, field, f.Value)
+		}
+	}
+
+	if item.Type == models.EnvBundle {
+		var variables map[string]string
+		if raw, ok := content["variables"]; ok && json.Unmarshal(raw, &variables) == nil {
+			if value, ok := variables[field]; ok {
+				return value, nil
+			}
+		}
+	}
+
+	return "", fmt.Errorf("item %s has no field %s", item.Name, field)
+}
+
+func rawValue(item *models.DataItem, field string, raw json.RawMessage) (string, error) {
+	var value any
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return "", fmt.Errorf("item %s: invalid field %s: %w", item.Name, field, err)
+	}
+
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case float64, bool:
+		return strings.TrimSpace(string(raw)), nil
+	case nil:
+		return "", fmt.Errorf("item %s: field %s is empty", item.Name, field)
+	default:
+		return "", fmt.Errorf("item %s: field %s is not a scalar value", item.Name, field)
+	}
+}
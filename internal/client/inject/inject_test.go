@@ -0,0 +1,87 @@
+package inject
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+type mapSource struct {
+	items map[string]*models.DataItem
+	loads int
+}
+
+func (s *mapSource) Item(ref string) (*models.DataItem, error) {
+	s.loads++
+	item, ok := s.items[ref]
+	if !ok {
+		return nil, fmt.Errorf("item %s not found", ref)
+	}
+	return item, nil
+}
+
+func newSource() *mapSource {
+	return &mapSource{items: map[string]*models.DataItem{
+		"prod-db": {
+			Name: "prod-db",
+			Type: models.LoginPassword,
+			Data: []byte(`{"login":"app","password":"s3cr3t","fields":[{"name":"port","kind":"text","value":"5432"}]}`),
+		},
+		"billing": {
+			Name: "billing",
+			Type: models.EnvBundle,
+			Data: []byte(`{"variables":{"API_KEY":"key-123"}}`),
+		},
+	}}
+}
+
+func TestRender(t *testing.T) {
+	source := newSource()
+	text := `db: {{ vault "prod-db" "login" }}:{{ vault "prod-db" "password" }}@db:{{ vault "prod-db" "port" }}
+key: {{ vault "billing" "API_KEY" | printf "%q" }}
+`
+
+	out, err := Render("config.tpl", text, source)
+
+	require.NoError(t, err)
+	assert.Equal(t, "db: app:s3cr3t@db:5432\nkey: \"key-123\"\n", string(out))
+	assert.Equal(t, 2, source.loads)
+}
+
+func TestRender_Missing(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		err  string
+	}{
+		{"missing item", `{{ vault "unknown" "password" }}`, "item unknown not found"},
+		{"missing field", `{{ vault "prod-db" "token" }}`, "item prod-db has no field token"},
+		{"missing key", `{{ .Password }}`, "failed to render template"},
+		{"parse error", `{{ vault "prod-db" `, "failed to parse template"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Render("config.tpl", tt.text, newSource())
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestReferences(t *testing.T) {
+	text := `{{ vault "prod-db" "login" }} {{ vault "prod-db" "password" }} {{ vault "prod-db" "login" }} {{ vault "billing" "API_KEY" }}`
+
+	refs, err := References("config.tpl", text)
+
+	require.NoError(t, err)
+	assert.Equal(t, []Reference{
+		{Item: "prod-db", Field: "login"},
+		{Item: "prod-db", Field: "password"},
+		{Item: "billing", Field: "API_KEY"},
+	}, refs)
+}
@@ -2,19 +2,30 @@
 package service
 
 import (
-	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/tempizhere/vaultfactory/internal/client/api"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
 	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
 )
 
+// attachmentChecksumHeader содержит SHA-256 содержимого скачиваемого вложения.
+const attachmentChecksumHeader = "X-Checksum-SHA256"
+
 // ClientService предоставляет методы для взаимодействия с сервером.
 type ClientService struct {
 	baseURL     string
@@ -23,6 +34,184 @@ type ClientService struct {
 	configDir   string
 }
 
+// Ошибки, с которыми сравниваются ошибки сервера через errors.Is по коду ошибки.
+var (
+	ErrBadRequest      = errors.New("bad request")
+	ErrValidation      = errors.New("validation failed")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrAccessDenied    = errors.New("access denied")
+	ErrNotFound        = errors.New("not found")
+	ErrConflict        = errors.New("conflict")
+	ErrVersionConflict = errors.New("version conflict")
+	ErrInternal        = errors.New("internal server error")
+)
+
+// APIError представляет ошибочный ответ сервера. Code, Message, Field, Details и RequestID
+// заполняются из JSON описания ошибки; Body содержит исходное тело ответа.
+//
+// Ошибка разворачивается в *validator.ValidationError, *validator.PasswordPolicyError или
+// *VersionConflictError, если ответ их описывает, и сравнивается через errors.Is с
+// ErrNotFound, ErrAccessDenied и другими ошибками по коду.
+type APIError struct {
+	StatusCode int
+	Code       apperrors.ErrorCode
+	Message    string
+	Field      string
+	Details    map[string]interface{}
+	RequestID  string
+	Body       []byte
+
+	cause error
+}
+
+func (e *APIError) Error() string {
+	if e.Code == "" {
+		return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, string(e.Body))
+	}
+
+	message := e.Message
+	if e.Field != "" {
+		message = fmt.Sprintf("%s: %s", e.Field, message)
+	}
+	if e.RequestID != "" {
+		return fmt.Sprintf("%s (%s, request %s)", message, e.Code, e.RequestID)
+	}
+	return fmt.Sprintf("%s (%s)", message, e.Code)
+}
+
+// Unwrap возвращает типизированную ошибку, описанную ответом сервера.
+func (e *APIError) Unwrap() error {
+	return e.cause
+}
+
+// Is сопоставляет код ошибки сервера с ошибками пакета (ErrNotFound, ErrConflict и т.д.).
+func (e *APIError) Is(target error) bool {
+	switch e.Code {
+	case apperrors.CodeBadRequest:
+		return target == ErrBadRequest
+	case apperrors.CodeValidationFailed:
+		return target == ErrValidation || target == ErrBadRequest
+	case apperrors.CodeUnauthorized:
+		return target == ErrUnauthorized
+	case apperrors.CodeAccessDenied:
+		return target == ErrAccessDenied
+	case apperrors.CodeNotFound:
+		return target == ErrNotFound
+	case apperrors.CodeConflict:
+		return target == ErrConflict
+	case apperrors.CodeVersionConflict:
+		return target == ErrVersionConflict || target == ErrConflict
+	case apperrors.CodeInternal:
+		return target == ErrInternal
+	}
+	return false
+}
+
+// newAPIError создает ошибку из ответа сервера. Тело, не являющееся описанием ошибки
+// в формате apperrors.ErrorResponse, сохраняется только в Body.
+func newAPIError(statusCode int, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: statusCode, Body: body}
+
+	var response apperrors.ErrorResponse
+	if json.Unmarshal(body, &response) == nil && response.Error.Code != "" {
+		apiErr.setBody(response.Error)
+	}
+
+	return apiErr
+}
+
+// setBody заполняет ошибку из описания ошибки API.
+func (e *APIError) setBody(body apperrors.ErrorBody) {
+	e.Code = body.Code
+	e.Message = body.Message
+	e.Field = body.Field
+	e.Details = body.Details
+	e.RequestID = body.RequestID
+
+	switch body.Code {
+	case apperrors.CodeValidationFailed:
+		validationErr := validator.ValidationError{Field: body.Field, Message: body.Message}
+		var feedback validator.PasswordFeedback
+		if decodeDetail(body.Details, "feedback", &feedback) {
+			e.cause = &validator.PasswordPolicyError{ValidationError: validationErr, Feedback: feedback}
+		} else {
+			e.cause = &validationErr
+		}
+	case apperrors.CodeVersionConflict:
+		var current int64
+		if decodeDetail(body.Details, "current_version", &current) {
+			e.cause = &VersionConflictError{CurrentVersion: current, Message: body.Message}
+		}
+	}
+}
+
+// decodeDetail декодирует поле key из дополнительных сведений об ошибке в target.
+func decodeDetail(details map[string]interface{}, key string, target interface{}) bool {
+	value, ok := details[key]
+	if !ok {
+		return false
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, target) == nil
+}
+
+// VersionConflictError сообщает, что элемент данных изменен на сервере после того, как
+// клиент получил ожидаемую версию. CurrentVersion содержит актуальную версию: чтобы
+// разрешить конфликт, элемент нужно получить заново и повторить изменение.
+type VersionConflictError struct {
+	CurrentVersion int64
+	Message        string
+}
+
+func (e *VersionConflictError) Error() string {
+	return e.Message
+}
+
+// ifMatch возвращает значение заголовка If-Match с ожидаемой версией элемента;
+// models.AnyVersion отключает проверку версии.
+func ifMatch(version int64) string {
+	if version == models.AnyVersion {
+		return "*"
+	}
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// BatchResult содержит результаты пакетного запроса.
+type BatchResult struct {
+	Committed bool                   `json:"committed"`
+	Results   []BatchOperationStatus `json:"results"`
+}
+
+// BatchOperationStatus содержит результат операции пакета: HTTP статус, элемент без
+// содержимого для create и update или описание ошибки.
+type BatchOperationStatus struct {
+	Index  int                  `json:"index"`
+	Status int                  `json:"status"`
+	Item   *models.DataItem     `json:"item,omitempty"`
+	Error  *apperrors.ErrorBody `json:"error,omitempty"`
+}
+
+// Failed сообщает, что операция не выполнена.
+func (s BatchOperationStatus) Failed() bool {
+	return s.Status >= http.StatusBadRequest
+}
+
+// Err возвращает ошибку операции в виде *APIError или nil, если операция выполнена.
+func (s BatchOperationStatus) Err() error {
+	if !s.Failed() {
+		return nil
+	}
+
+	apiErr := &APIError{StatusCode: s.Status}
+	if s.Error != nil {
+		apiErr.setBody(*s.Error)
+	}
+	return apiErr
+}
+
 // NewClientService создает новый экземпляр ClientService.
 func NewClientService() *ClientService {
 	homeDir, _ := os.UserHomeDir()
@@ -45,193 +234,535 @@ func NewClientService() *ClientService {
 	return client
 }
 
+// client возвращает клиент API, отправляющий токен доступа с каждым запросом.
+func (c *ClientService) client() *api.Client {
+	return api.NewClient(c.baseURL,
+		api.WithHTTPClient(c.httpClient),
+		api.WithErrorDecoder(decodeAPIError),
+		api.WithRequestEditorFn(func(ctx context.Context, req *http.Request) error {
+			if c.accessToken == "" {
+				return fmt.Errorf("not authenticated")
+			}
+			req.Header.Set("Authorization", "Bearer "+c.accessToken)
+			return nil
+		}),
+	)
+}
+
+// publicClient возвращает клиент API для операций, не требующих аутентификации.
+func (c *ClientService) publicClient() *api.Client {
+	return api.NewClient(c.baseURL, api.WithHTTPClient(c.httpClient), api.WithErrorDecoder(decodeAPIError))
+}
+
+func decodeAPIError(statusCode int, body []byte) error {
+	return newAPIError(statusCode, body)
+}
+
 // Register регистрирует нового пользователя на сервере.
 func (c *ClientService) Register(ctx context.Context, email, password string) (*models.User, error) {
-	req := map[string]string{
-		"email":    email,
-		"password": password,
-	}
-
-	resp, err := c.makeRequest(ctx, "POST", "/auth/register", req)
+	resp, err := c.publicClient().Register(ctx, api.Credentials{Email: email, Password: password})
 	if err != nil {
 		return nil, err
 	}
 
-	var authResp struct {
-		User *models.User `json:"user"`
-	}
-
-	if err := json.Unmarshal(resp, &authResp); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	return authResp.User, nil
+	return resp.User, nil
 }
 
 // Login выполняет аутентификацию пользователя на сервере.
 func (c *ClientService) Login(ctx context.Context, email, password string) (*models.User, string, string, error) {
-	req := map[string]string{
-		"email":    email,
-		"password": password,
-	}
-
-	resp, err := c.makeRequest(ctx, "POST", "/auth/login", req)
+	resp, err := c.publicClient().Login(ctx, api.Credentials{Email: email, Password: password})
 	if err != nil {
 		return nil, "", "", err
 	}
 
-	var authResp struct {
-		User         *models.User `json:"user"`
-		AccessToken  string       `json:"access_token"`
-		RefreshToken string       `json:"refresh_token"`
-	}
-
-	if err := json.Unmarshal(resp, &authResp); err != nil {
-		return nil, "", "", fmt.Errorf("failed to parse response: %w", err)
-	}
-
-	c.accessToken = authResp.AccessToken
+	c.accessToken = resp.AccessToken
 	_ = c.saveToken()
-	return authResp.User, authResp.AccessToken, authResp.RefreshToken, nil
+	return resp.User, resp.AccessToken, resp.RefreshToken, nil
+}
+
+// ChangePassword меняет пароль пользователя. После смены пароля требуется повторный вход.
+func (c *ClientService) ChangePassword(ctx context.Context, currentPassword, newPassword string) error {
+	return c.client().ChangePassword(ctx, api.ChangePasswordRequest{
+		CurrentPassword: currentPassword,
+		NewPassword:     newPassword,
+	})
 }
 
 func (c *ClientService) AddData(ctx context.Context, dataType models.DataType, name, metadata, data string) (*models.DataItem, error) {
+	return c.AddDataWithExpiry(ctx, dataType, name, metadata, data, models.DataExpiry{})
+}
+
+// AddDataWithExpiry создает самоуничтожающийся элемент данных.
+func (c *ClientService) AddDataWithExpiry(ctx context.Context, dataType models.DataType, name, metadata, data string, expiry models.DataExpiry) (*models.DataItem, error) {
 	var jsonData json.RawMessage
 	if err := json.Unmarshal([]byte(data), &jsonData); err != nil {
 		return nil, fmt.Errorf("invalid JSON data: %w", err)
 	}
 
-	req := map[string]interface{}{
-		"type":     dataType,
-		"name":     name,
-		"metadata": metadata,
-		"data":     jsonData,
+	return c.client().CreateData(ctx, api.CreateDataRequest{
+		Type:      string(dataType),
+		Name:      name,
+		Metadata:  metadata,
+		Data:      jsonData,
+		ExpiresAt: expiry.ExpiresAt,
+		MaxReads:  expiry.MaxReads,
+	})
+}
+
+func (c *ClientService) ListData(ctx context.Context) ([]*models.DataItem, error) {
+	return c.ListDataFiltered(ctx, models.DataFilter{})
+}
+
+func (c *ClientService) GetData(ctx context.Context, id string) (*models.DataItem, error) {
+	return c.client().GetData(ctx, id)
+}
+
+// DeleteData перемещает элемент данных в корзину, если его версия на сервере равна
+// version (models.AnyVersion — без проверки). При конфликте возвращает *VersionConflictError.
+func (c *ClientService) DeleteData(ctx context.Context, id string, version int64) error {
+	return c.client().DeleteData(ctx, id, &api.DeleteDataParams{IfMatch: ifMatch(version)})
+}
+
+// ExecuteBatch выполняет операции create, update и delete одним запросом. Атомарный пакет
+// отправляется целиком и не может превышать constants.MaxBatchOperations операций;
+// независимый разбивается на запросы допустимого размера, а индексы результатов
+// соответствуют позициям операций в исходном пакете.
+func (c *ClientService) ExecuteBatch(ctx context.Context, batch models.DataBatch) (*BatchResult, error) {
+	if batch.Mode != models.BatchIndependent {
+		return c.executeBatch(ctx, batch)
 	}
 
-	resp, err := c.makeAuthenticatedRequest(ctx, "POST", "/data", req)
+	result := &BatchResult{Committed: true}
+	for start := 0; start < len(batch.Operations); start += constants.MaxBatchOperations {
+		end := min(start+constants.MaxBatchOperations, len(batch.Operations))
+		chunk, err := c.executeBatch(ctx, models.DataBatch{Mode: batch.Mode, Operations: batch.Operations[start:end]})
+		if err != nil {
+			return nil, err
+		}
+		for _, status := range chunk.Results {
+			status.Index += start
+			result.Results = append(result.Results, status)
+		}
+	}
+
+	return result, nil
+}
+
+func (c *ClientService) executeBatch(ctx context.Context, batch models.DataBatch) (*BatchResult, error) {
+	resp, err := c.client().ExecuteBatch(ctx, batch)
 	if err != nil {
 		return nil, err
 	}
 
-	var item models.DataItem
-	if err := json.Unmarshal(resp, &item); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	result := &BatchResult{Committed: resp.Committed}
+	for _, status := range resp.Results {
+		result.Results = append(result.Results, BatchOperationStatus{
+			Index:  status.Index,
+			Status: status.Status,
+			Item:   status.Item,
+			Error:  status.Error,
+		})
 	}
 
-	return &item, nil
+	return result, nil
 }
 
-func (c *ClientService) ListData(ctx context.Context) ([]*models.DataItem, error) {
-	resp, err := c.makeAuthenticatedRequest(ctx, "GET", "/data", nil)
-	if err != nil {
-		return nil, err
+func (c *ClientService) Sync(ctx context.Context) error {
+	_, err := c.SyncData(ctx, time.Unix(0, 0))
+	return err
+}
+
+// SyncData получает все элементы, измененные после since, включая удаленные, запрашивая
+// страницы последовательно.
+func (c *ClientService) SyncData(ctx context.Context, since time.Time) ([]*models.DataItem, error) {
+	params := &api.SyncDataParams{LastSync: since.UTC()}
+
+	return collectPages(0, func(cursor string) (*api.DataList, error) {
+		params.Cursor = cursor
+		return c.client().SyncData(ctx, params)
+	})
+}
+
+// ListDataFiltered получает все элементы данных, отобранные по типу, папке (ID или путь) и тегам,
+// запрашивая страницы последовательно.
+func (c *ClientService) ListDataFiltered(ctx context.Context, filter models.DataFilter) ([]*models.DataItem, error) {
+	return c.ListDataSorted(ctx, filter, models.PageRequest{}, 0)
+}
+
+// ListDataSorted получает элементы данных, отобранные фильтром, в порядке page.Sort и page.Order.
+// page.Limit задает размер запрашиваемых страниц, max — общее количество элементов (0 — все).
+func (c *ClientService) ListDataSorted(ctx context.Context, filter models.DataFilter, page models.PageRequest, max int) ([]*models.DataItem, error) {
+	params := listParams(filter, page)
+
+	return collectPages(max, func(cursor string) (*api.DataList, error) {
+		params.Cursor = cursor
+		return c.client().ListData(ctx, params)
+	})
+}
+
+// SearchData ищет элементы данных по имени и метаданным с учетом фильтров и диапазонов дат.
+// Совпадения подсвечиваются сервером в поле Highlight. Параметры page и max — как в ListDataSorted.
+func (c *ClientService) SearchData(ctx context.Context, search models.DataSearch, page models.PageRequest, max int) ([]*models.DataItem, error) {
+	list := listParams(search.Filter, page)
+	params := &api.SearchDataParams{
+		Q:             search.Query,
+		Match:         string(search.Match),
+		CreatedAfter:  formatTime(search.CreatedAfter),
+		CreatedBefore: formatTime(search.CreatedBefore),
+		UpdatedAfter:  formatTime(search.UpdatedAfter),
+		UpdatedBefore: formatTime(search.UpdatedBefore),
+		Type:          list.Type,
+		Folder:        list.Folder,
+		Recursive:     list.Recursive,
+		Tag:           list.Tag,
+		TagMatch:      list.TagMatch,
+		Limit:         list.Limit,
+		Sort:          list.Sort,
+		Order:         list.Order,
 	}
 
-	var items []*models.DataItem
-	if err := json.Unmarshal(resp, &items); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	return collectPages(max, func(cursor string) (*api.DataList, error) {
+		params.Cursor = cursor
+		return c.client().SearchData(ctx, params)
+	})
+}
+
+// BlindSearchData ищет элементы данных по слепым индексам имени и дополнительных полей.
+// Если в search заданы токены, текст запроса на сервер не передается. Параметры page и
+// max — как в ListDataSorted.
+func (c *ClientService) BlindSearchData(ctx context.Context, search models.BlindSearch, page models.PageRequest, max int) ([]*models.DataItem, error) {
+	list := listParams(search.Filter, page)
+	params := &api.BlindSearchDataParams{
+		Type:      list.Type,
+		Folder:    list.Folder,
+		Recursive: list.Recursive,
+		Tag:       list.Tag,
+		TagMatch:  list.TagMatch,
+		Limit:     list.Limit,
+		Sort:      list.Sort,
+		Order:     list.Order,
+	}
+	if len(search.Tokens) > 0 {
+		for _, token := range search.Tokens {
+			params.Token = append(params.Token, hex.EncodeToString(token))
+		}
+	} else {
+		params.Field = search.Field
+		params.Q = search.Query
+		params.Mode = string(search.Mode)
 	}
 
-	return items, nil
+	return collectPages(max, func(cursor string) (*api.DataList, error) {
+		params.Cursor = cursor
+		return c.client().BlindSearchData(ctx, params)
+	})
 }
 
-func (c *ClientService) GetData(ctx context.Context, id string) (*models.DataItem, error) {
-	resp, err := c.makeAuthenticatedRequest(ctx, "GET", fmt.Sprintf("/data/%s", id), nil)
+// GetBlindIndexKey получает ключ слепого индекса пользователя для вычисления токенов
+// запросов на клиенте.
+func (c *ClientService) GetBlindIndexKey(ctx context.Context) ([]byte, error) {
+	resp, err := c.client().GetBlindIndexKey(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	var item models.DataItem
-	if err := json.Unmarshal(resp, &item); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+	return resp.Key, nil
+}
+
+// RebuildBlindIndex перестраивает слепые индексы элементов пользователя и возвращает
+// количество проиндексированных элементов.
+func (c *ClientService) RebuildBlindIndex(ctx context.Context) (int, error) {
+	resp, err := c.client().RebuildBlindIndex(ctx)
+	if err != nil {
+		return 0, err
 	}
 
-	return &item, nil
+	return resp.Indexed, nil
 }
 
-func (c *ClientService) DeleteData(ctx context.Context, id string) error {
-	_, err := c.makeAuthenticatedRequest(ctx, "DELETE", fmt.Sprintf("/data/%s", id), nil)
-	return err
+// listParams формирует параметры фильтра по типу, папке и тегам, сортировки и размера страницы.
+func listParams(filter models.DataFilter, page models.PageRequest) *api.ListDataParams {
+	params := &api.ListDataParams{
+		Type:  string(filter.Type),
+		Sort:  string(page.Sort),
+		Order: string(page.Order),
+	}
+	if filter.Folder != "" {
+		params.Folder = filter.Folder
+		if filter.Recursive {
+			params.Recursive = &filter.Recursive
+		}
+	}
+	params.Tag = append(params.Tag, filter.Tags.Include...)
+	for _, tag := range filter.Tags.Exclude {
+		params.Tag = append(params.Tag, models.TagExcludePrefix+tag)
+	}
+	if len(filter.Tags.Include) > 0 {
+		params.TagMatch = string(filter.Tags.Match)
+	}
+	if page.Limit > 0 {
+		params.Limit = &page.Limit
+	}
+	return params
 }
 
-func (c *ClientService) Sync(ctx context.Context) error {
-	_, err := c.makeAuthenticatedRequest(ctx, "GET", "/data/sync?last_sync=1970-01-01T00:00:00Z", nil)
-	return err
+// formatTime возвращает время в формате RFC 3339 или пустую строку для nil.
+func formatTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
 }
 
-func (c *ClientService) makeRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
-	var reqBody io.Reader
-	if body != nil {
-		jsonData, err := json.Marshal(body)
+// collectPages запрашивает страницы списка элементов функцией fetch, передавая курсор
+// следующей страницы, пока они не закончатся или не будет получено max элементов
+// (0 — без ограничения).
+func collectPages(max int, fetch func(cursor string) (*api.DataList, error)) ([]*models.DataItem, error) {
+	var items []*models.DataItem
+	cursor := ""
+	for {
+		page, err := fetch(cursor)
 		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+			return nil, err
+		}
+
+		items = append(items, page.Items...)
+		if max > 0 && len(items) >= max {
+			return items[:max], nil
 		}
-		reqBody = bytes.NewBuffer(jsonData)
+		if page.NextCursor == "" {
+			return items, nil
+		}
+		cursor = page.NextCursor
 	}
+}
+
+// UpdateData обновляет имя, метаданные и содержимое элемента данных, если его версия на
+// сервере равна version (обычно версия полученного элемента). При конфликте возвращает
+// *VersionConflictError.
+func (c *ClientService) UpdateData(ctx context.Context, id string, version int64, name, metadata string, data json.RawMessage) (*models.DataItem, error) {
+	return c.client().UpdateData(ctx, id, &api.UpdateDataParams{IfMatch: ifMatch(version)}, api.UpdateDataRequest{
+		Name:     name,
+		Metadata: metadata,
+		Data:     data,
+	})
+}
+
+// MoveData перемещает элемент данных в папку (ID или путь). Пустая папка означает корень.
+func (c *ClientService) MoveData(ctx context.Context, id, folder string) (*models.DataItem, error) {
+	return c.client().MoveData(ctx, id, api.MoveDataRequest{Folder: folder})
+}
+
+// SetExpiry задает или снимает условия самоуничтожения элемента данных.
+func (c *ClientService) SetExpiry(ctx context.Context, id string, expiry models.DataExpiry) (*models.DataItem, error) {
+	return c.client().SetExpiry(ctx, id, api.SetExpiryRequest{ExpiresAt: expiry.ExpiresAt, MaxReads: expiry.MaxReads})
+}
+
+// SetDataRotation задает интервал ротации пароля элемента данных в днях; nil снимает интервал.
+func (c *ClientService) SetDataRotation(ctx context.Context, id string, days *int) (*models.DataItem, error) {
+	return c.client().SetDataRotation(ctx, id, api.SetRotationRequest{RotationDays: days})
+}
+
+// ListOverdueRotations получает элементы, пароль которых не менялся дольше интервала ротации.
+func (c *ClientService) ListOverdueRotations(ctx context.Context) ([]*models.RotationStatus, error) {
+	return c.client().ListOverdueRotations(ctx)
+}
+
+// ListExpiringCertificates получает сертификаты, срок действия которых истекает в ближайшие days дней или уже истек.
+func (c *ClientService) ListExpiringCertificates(ctx context.Context, days int) ([]*models.CertificateStatus, error) {
+	return c.client().ListExpiringCertificates(ctx, &api.ListExpiringCertificatesParams{Days: &days})
+}
+
+// GetPasswordHistory получает предыдущие пароли элемента login_password, начиная с последней смены.
+func (c *ClientService) GetPasswordHistory(ctx context.Context, id string) ([]*models.PasswordHistoryEntry, error) {
+	return c.client().GetPasswordHistory(ctx, id)
+}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+// ListVersions получает историю версий элемента данных, начиная с последней.
+func (c *ClientService) ListVersions(ctx context.Context, id string) ([]*models.DataVersion, error) {
+	return c.client().ListVersions(ctx, id)
+}
+
+// GetVersion получает версию элемента данных с содержимым.
+func (c *ClientService) GetVersion(ctx context.Context, id string, version int64) (*models.DataVersion, error) {
+	return c.client().GetVersion(ctx, id, version)
+}
+
+// RestoreVersion восстанавливает элемент данных из версии; восстановление создает новую версию.
+func (c *ClientService) RestoreVersion(ctx context.Context, id string, version int64) (*models.DataItem, error) {
+	return c.client().RestoreVersion(ctx, id, version)
+}
+
+// ListTrash получает элементы данных, находящиеся в корзине.
+func (c *ClientService) ListTrash(ctx context.Context) ([]*models.DataItem, error) {
+	return c.client().ListTrash(ctx)
+}
+
+// RestoreFromTrash возвращает элемент данных из корзины.
+func (c *ClientService) RestoreFromTrash(ctx context.Context, id string) (*models.DataItem, error) {
+	return c.client().RestoreData(ctx, id)
+}
+
+// PurgeFromTrash окончательно удаляет элемент данных из корзины.
+func (c *ClientService) PurgeFromTrash(ctx context.Context, id string) error {
+	return c.client().PurgeData(ctx, id)
+}
+
+// EmptyTrash окончательно удаляет все элементы из корзины и возвращает их количество.
+func (c *ClientService) EmptyTrash(ctx context.Context) (int, error) {
+	resp, err := c.client().EmptyTrash(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return 0, err
 	}
 
-	req.Header.Set("Content-Type", "application/json")
+	return resp.Deleted, nil
+}
 
-	resp, err := c.httpClient.Do(req)
+// ListAttachments получает вложения элемента данных.
+func (c *ClientService) ListAttachments(ctx context.Context, id string) ([]*models.Attachment, error) {
+	return c.client().ListAttachments(ctx, id)
+}
+
+// UploadAttachment загружает содержимое content как вложение элемента данных.
+// Пустой mimeType означает определение типа на сервере.
+func (c *ClientService) UploadAttachment(ctx context.Context, id, filename, mimeType string, content io.Reader, size int64) (*models.Attachment, error) {
+	if mimeType == "" {
+		mimeType = "application/octet-stream"
+	}
+
+	setContentLength := func(ctx context.Context, req *http.Request) error {
+		req.ContentLength = size
+		return nil
+	}
+
+	return c.client().UploadAttachment(ctx, id, &api.UploadAttachmentParams{Filename: filename}, mimeType, content, setContentLength)
+}
+
+// DownloadAttachment записывает содержимое вложения в out и проверяет его контрольную сумму.
+func (c *ClientService) DownloadAttachment(ctx context.Context, id, attachmentID string, out io.Writer) error {
+	resp, err := c.client().DownloadAttachment(ctx, id, attachmentID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
+		return err
 	}
 	defer resp.Body.Close()
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+	hash := sha256.New()
+	if _, err := io.Copy(io.MultiWriter(out, hash), resp.Body); err != nil {
+		return fmt.Errorf("failed to download attachment: %w", err)
 	}
 
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+	if expected := resp.Header.Get(attachmentChecksumHeader); expected != "" && expected != hex.EncodeToString(hash.Sum(nil)) {
+		return fmt.Errorf("attachment checksum mismatch")
 	}
 
-	return respBody, nil
+	return nil
 }
 
-func (c *ClientService) makeAuthenticatedRequest(ctx context.Context, method, path string, body interface{}) ([]byte, error) {
-	if c.accessToken == "" {
-		return nil, fmt.Errorf("not authenticated")
+// DeleteAttachment удаляет вложение элемента данных.
+func (c *ClientService) DeleteAttachment(ctx context.Context, id, attachmentID string) error {
+	return c.client().DeleteAttachment(ctx, id, attachmentID)
+}
+
+// ListFolders получает все папки пользователя, упорядоченные по пути.
+func (c *ClientService) ListFolders(ctx context.Context) ([]*models.Folder, error) {
+	return c.client().ListFolders(ctx)
+}
+
+// FindFolder находит папку по ID или пути.
+func (c *ClientService) FindFolder(ctx context.Context, ref string) (*models.Folder, error) {
+	folders, err := c.ListFolders(ctx)
+	if err != nil {
+		return nil, err
 	}
 
-	var reqBody io.Reader
-	if body != nil {
-		jsonData, err := json.Marshal(body)
-		if err != nil {
-			return nil, fmt.Errorf("failed to marshal request body: %w", err)
+	path := strings.Trim(ref, models.FolderPathSeparator)
+	for _, folder := range folders {
+		if folder.ID.String() == ref || folder.Path == path {
+			return folder, nil
 		}
-		reqBody = bytes.NewBuffer(jsonData)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
+	return nil, fmt.Errorf("folder %s not found", ref)
+}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", "Bearer "+c.accessToken)
+// CreateFolder создает папку по пути, создавая недостающие родительские папки.
+func (c *ClientService) CreateFolder(ctx context.Context, path string) (*models.Folder, error) {
+	return c.client().CreateFolder(ctx, api.CreateFolderRequest{Path: path})
+}
 
-	resp, err := c.httpClient.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to make request: %w", err)
-	}
-	defer resp.Body.Close()
+// RenameFolder переименовывает папку.
+func (c *ClientService) RenameFolder(ctx context.Context, id, name string) (*models.Folder, error) {
+	return c.client().UpdateFolder(ctx, id, api.UpdateFolderRequest{Name: name})
+}
 
-	respBody, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response: %w", err)
+// MoveFolder перемещает папку в родительскую папку (ID или путь). Пустой parent означает корень.
+func (c *ClientService) MoveFolder(ctx context.Context, id, parent string) (*models.Folder, error) {
+	return c.client().UpdateFolder(ctx, id, api.UpdateFolderRequest{Parent: &parent})
+}
+
+// SetFolderRotation задает интервал ротации паролей папки в днях; nil снимает интервал.
+func (c *ClientService) SetFolderRotation(ctx context.Context, id string, days *int) (*models.Folder, error) {
+	return c.client().SetFolderRotation(ctx, id, api.SetRotationRequest{RotationDays: days})
+}
+
+// DeleteFolder удаляет папку; непустая папка удаляется только при recursive.
+func (c *ClientService) DeleteFolder(ctx context.Context, id string, recursive bool) error {
+	params := &api.DeleteFolderParams{}
+	if recursive {
+		params.Recursive = &recursive
 	}
 
-	if resp.StatusCode >= 400 {
-		return nil, fmt.Errorf("request failed with status %d: %s", resp.StatusCode, string(respBody))
+	return c.client().DeleteFolder(ctx, id, params)
+}
+
+// AddTags помечает элемент данных тегами.
+func (c *ClientService) AddTags(ctx context.Context, id string, tags []string) (*models.DataItem, error) {
+	return c.client().AddTags(ctx, id, api.TagsRequest{Tags: tags})
+}
+
+// RemoveTag снимает тег с элемента данных.
+func (c *ClientService) RemoveTag(ctx context.Context, id, tag string) (*models.DataItem, error) {
+	return c.client().RemoveTag(ctx, id, tag)
+}
+
+// ListTags получает теги пользователя с количеством помеченных элементов.
+func (c *ClientService) ListTags(ctx context.Context) ([]*models.Tag, error) {
+	return c.client().ListTags(ctx)
+}
+
+// RenameTag переименовывает тег.
+func (c *ClientService) RenameTag(ctx context.Context, name, newName string) (*models.Tag, error) {
+	return c.client().RenameTag(ctx, name, api.RenameTagRequest{Name: newName})
+}
+
+// MergeTags переносит элементы исходных тегов на целевой тег и удаляет исходные теги.
+func (c *ClientService) MergeTags(ctx context.Context, sources []string, target string) error {
+	return c.client().MergeTags(ctx, api.MergeTagsRequest{Sources: sources, Target: target})
+}
+
+// ListTypes получает пользовательские типы данных.
+func (c *ClientService) ListTypes(ctx context.Context) ([]*models.CustomType, error) {
+	return c.client().ListTypes(ctx)
+}
+
+// GetType получает пользовательский тип данных по имени.
+func (c *ClientService) GetType(ctx context.Context, name string) (*models.CustomType, error) {
+	return c.client().GetType(ctx, name)
+}
+
+// CreateType создает пользовательский тип данных.
+func (c *ClientService) CreateType(ctx context.Context, name, description string, fields []models.CustomField) (*models.CustomType, error) {
+	req := api.CustomTypeRequest{Name: name, Description: description}
+	for idx := range fields {
+		req.Fields = append(req.Fields, &fields[idx])
 	}
 
-	return respBody, nil
+	return c.client().CreateType(ctx, req)
+}
+
+// DeleteType удаляет пользовательский тип данных.
+func (c *ClientService) DeleteType(ctx context.Context, name string) error {
+	return c.client().DeleteType(ctx, name)
 }
 
 func (c *ClientService) saveToken() error {
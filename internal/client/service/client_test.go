@@ -2,7 +2,9 @@ package service
 
 import (
 	"context"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"os"
@@ -11,7 +13,10 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
 	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
 )
 
 func TestClientService_Register(t *testing.T) {
@@ -129,6 +134,57 @@ func TestClientService_Login(t *testing.T) {
 		assert.Empty(t, refreshToken)
 		assert.Contains(t, err.Error(), "request failed with status 401")
 	})
+
+	t.Run("error envelope", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"code":"unauthorized","message":"invalid credentials","status":401,"request_id":"req-1"}}`))
+		}))
+		defer server.Close()
+
+		client := &ClientService{
+			baseURL:    server.URL + "/api/v1",
+			httpClient: &http.Client{},
+		}
+
+		_, _, _, err := client.Login(context.Background(), "test@example.com", "wrongpassword")
+
+		assert.True(t, errors.Is(err, ErrUnauthorized))
+		assert.False(t, errors.Is(err, ErrNotFound))
+		var apiErr *APIError
+		if assert.ErrorAs(t, err, &apiErr) {
+			assert.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+			assert.Equal(t, apperrors.CodeUnauthorized, apiErr.Code)
+			assert.Equal(t, "invalid credentials", apiErr.Message)
+			assert.Equal(t, "req-1", apiErr.RequestID)
+		}
+		assert.Equal(t, "invalid credentials (unauthorized, request req-1)", err.Error())
+	})
+}
+
+func TestClientService_Register_PasswordPolicy(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = w.Write([]byte(`{"error":{"code":"validation_failed","message":"password is too weak","status":400,"field":"password","details":{"feedback":{"score":1,"min_score":3,"warning":"This is a top-10 common password"}}}}`))
+	}))
+	defer server.Close()
+
+	client := &ClientService{
+		baseURL:    server.URL + "/api/v1",
+		httpClient: &http.Client{},
+	}
+
+	_, err := client.Register(context.Background(), "test@example.com", "password")
+
+	assert.True(t, errors.Is(err, ErrValidation))
+	var policyErr *validator.PasswordPolicyError
+	if assert.ErrorAs(t, err, &policyErr) {
+		assert.Equal(t, "password", policyErr.Field)
+		assert.Equal(t, 3, policyErr.Feedback.MinScore)
+		assert.Equal(t, "This is a top-10 common password", policyErr.Feedback.Warning)
+	}
 }
 
 func TestClientService_AddData(t *testing.T) {
@@ -210,13 +266,15 @@ func TestClientService_ListData(t *testing.T) {
 			assert.Equal(t, "/api/v1/data", r.URL.Path)
 			assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
 
-			response := []models.DataItem{
-				{
-					ID:       uuid.New(),
-					UserID:   uuid.New(),
-					Type:     models.LoginPassword,
-					Name:     "test-password",
-					Metadata: "test-metadata",
+			response := map[string]interface{}{
+				"items": []models.DataItem{
+					{
+						ID:       uuid.New(),
+						UserID:   uuid.New(),
+						Type:     models.LoginPassword,
+						Name:     "test-password",
+						Metadata: "test-metadata",
+					},
 				},
 			}
 
@@ -239,6 +297,44 @@ func TestClientService_ListData(t *testing.T) {
 		assert.Equal(t, "test-password", items[0].Name)
 	})
 
+	t.Run("pages are followed", func(t *testing.T) {
+		var cursors []string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			cursor := r.URL.Query().Get("cursor")
+			cursors = append(cursors, cursor)
+			assert.Equal(t, "name", r.URL.Query().Get("sort"))
+			assert.Equal(t, "2", r.URL.Query().Get("limit"))
+
+			response := map[string]interface{}{
+				"items": []models.DataItem{{ID: uuid.New(), Name: "a"}, {ID: uuid.New(), Name: "b"}},
+			}
+			if cursor == "" {
+				response["next_cursor"] = "page-2"
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(response)
+		}))
+		defer server.Close()
+
+		client := &ClientService{
+			baseURL:     server.URL + "/api/v1",
+			accessToken: "test-token",
+			httpClient:  &http.Client{},
+		}
+
+		items, err := client.ListDataSorted(context.Background(), models.DataFilter{}, models.PageRequest{Sort: models.SortByName, Limit: 2}, 0)
+		assert.NoError(t, err)
+		assert.Len(t, items, 4)
+		assert.Equal(t, []string{"", "page-2"}, cursors)
+
+		cursors = nil
+		items, err = client.ListDataSorted(context.Background(), models.DataFilter{}, models.PageRequest{Sort: models.SortByName, Limit: 2}, 3)
+		assert.NoError(t, err)
+		assert.Len(t, items, 3)
+		assert.Equal(t, []string{"", "page-2"}, cursors)
+	})
+
 	t.Run("not authenticated", func(t *testing.T) {
 		client := &ClientService{
 			baseURL:     "http://localhost:8080/api/v1",
@@ -254,6 +350,173 @@ func TestClientService_ListData(t *testing.T) {
 	})
 }
 
+func TestClientService_BlindSearchData(t *testing.T) {
+	token := []byte("0123456789abcdef")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "GET", r.Method)
+		assert.Equal(t, "/api/v1/data/blind-search", r.URL.Path)
+		assert.Equal(t, []string{hex.EncodeToString(token)}, r.URL.Query()["token"])
+		assert.Empty(t, r.URL.Query().Get("q"))
+		assert.Equal(t, "login_password", r.URL.Query().Get("type"))
+
+		response := map[string]interface{}{
+			"items": []models.DataItem{{ID: uuid.New(), Type: models.LoginPassword, Name: "prod-db"}},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := &ClientService{
+		baseURL:     server.URL + "/api/v1",
+		accessToken: "test-token",
+		httpClient:  &http.Client{},
+	}
+
+	items, err := client.BlindSearchData(context.Background(), models.BlindSearch{
+		Tokens: [][]byte{token},
+		Filter: models.DataFilter{Type: models.LoginPassword},
+	}, models.PageRequest{}, 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, items, 1)
+	assert.Equal(t, "prod-db", items[0].Name)
+}
+
+func TestClientService_ExecuteBatch(t *testing.T) {
+	t.Run("independent batch is split into chunks", func(t *testing.T) {
+		var sizes []int
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, "/api/v1/data/batch", r.URL.Path)
+
+			var batch models.DataBatch
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			assert.Equal(t, models.BatchIndependent, batch.Mode)
+			sizes = append(sizes, len(batch.Operations))
+
+			results := make([]BatchOperationStatus, len(batch.Operations))
+			for i := range results {
+				results[i] = BatchOperationStatus{Index: i, Status: http.StatusNoContent}
+			}
+			results[0] = BatchOperationStatus{Index: 0, Status: http.StatusNotFound, Error: &apperrors.ErrorBody{Code: apperrors.CodeNotFound, Message: "data not found", Status: http.StatusNotFound}}
+
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(BatchResult{Committed: true, Results: results})
+		}))
+		defer server.Close()
+
+		client := &ClientService{
+			baseURL:     server.URL + "/api/v1",
+			accessToken: "test-token",
+			httpClient:  &http.Client{},
+		}
+
+		operations := make([]models.BatchOperation, constants.MaxBatchOperations+1)
+		for i := range operations {
+			operations[i] = models.BatchOperation{Op: models.BatchDelete, ID: uuid.New()}
+		}
+
+		result, err := client.ExecuteBatch(context.Background(), models.DataBatch{
+			Mode:       models.BatchIndependent,
+			Operations: operations,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []int{constants.MaxBatchOperations, 1}, sizes)
+		assert.True(t, result.Committed)
+		assert.Len(t, result.Results, len(operations))
+		assert.True(t, result.Results[0].Failed())
+		assert.True(t, errors.Is(result.Results[0].Err(), ErrNotFound))
+		assert.Equal(t, constants.MaxBatchOperations, result.Results[constants.MaxBatchOperations].Index)
+		assert.True(t, result.Results[constants.MaxBatchOperations].Failed())
+		assert.False(t, result.Results[1].Failed())
+	})
+
+	t.Run("atomic batch is sent whole", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(BatchResult{
+				Committed: false,
+				Results: []BatchOperationStatus{
+					{Index: 0, Status: http.StatusFailedDependency, Error: &apperrors.ErrorBody{Code: apperrors.CodeFailedDependency, Message: "rolled back: operation 1 failed"}},
+					{Index: 1, Status: http.StatusBadRequest, Error: &apperrors.ErrorBody{Code: apperrors.CodeValidationFailed, Message: "name is required", Field: "operations[1]"}},
+				},
+			})
+		}))
+		defer server.Close()
+
+		client := &ClientService{
+			baseURL:     server.URL + "/api/v1",
+			accessToken: "test-token",
+			httpClient:  &http.Client{},
+		}
+
+		result, err := client.ExecuteBatch(context.Background(), models.DataBatch{
+			Operations: []models.BatchOperation{
+				{Op: models.BatchDelete, ID: uuid.New()},
+				{Op: models.BatchCreate, Type: models.TextData},
+			},
+		})
+
+		assert.NoError(t, err)
+		assert.False(t, result.Committed)
+		var validationErr *validator.ValidationError
+		if assert.ErrorAs(t, result.Results[1].Err(), &validationErr) {
+			assert.Equal(t, "operations[1]", validationErr.Field)
+		}
+		assert.NoError(t, BatchOperationStatus{Status: http.StatusOK}.Err())
+	})
+}
+
+func TestClientService_UpdateData_VersionConflict(t *testing.T) {
+	dataID := uuid.New()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "PUT", r.Method)
+		assert.Equal(t, "/api/v1/data/"+dataID.String(), r.URL.Path)
+		assert.Equal(t, `"3"`, r.Header.Get("If-Match"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusConflict)
+		_, _ = w.Write([]byte(`{"error":{"code":"version_conflict","message":"version conflict: expected version 3, current version is 5","status":409,"details":{"current_version":5,"expected_version":3}}}`))
+	}))
+	defer server.Close()
+
+	client := &ClientService{
+		baseURL:     server.URL + "/api/v1",
+		accessToken: "test-token",
+		httpClient:  &http.Client{},
+	}
+
+	item, err := client.UpdateData(context.Background(), dataID.String(), 3, "note", "", json.RawMessage(`{"text":"hi"}`))
+
+	assert.Nil(t, item)
+	var conflictErr *VersionConflictError
+	if assert.ErrorAs(t, err, &conflictErr) {
+		assert.Equal(t, int64(5), conflictErr.CurrentVersion)
+	}
+	assert.True(t, errors.Is(err, ErrVersionConflict))
+	assert.True(t, errors.Is(err, ErrConflict))
+}
+
+func TestClientService_DeleteData_AnyVersion(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "DELETE", r.Method)
+		assert.Equal(t, "*", r.Header.Get("If-Match"))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := &ClientService{
+		baseURL:     server.URL + "/api/v1",
+		accessToken: "test-token",
+		httpClient:  &http.Client{},
+	}
+
+	assert.NoError(t, client.DeleteData(context.Background(), uuid.New().String(), models.AnyVersion))
+}
+
 func TestClientService_TokenManagement(t *testing.T) {
 	t.Run("save and load token", func(t *testing.T) {
 		// Создаем временную директорию для тестов
@@ -0,0 +1,118 @@
+// Package sshagent реализует SSH агент, который обслуживает ключи из хранилища
+// через Unix сокет, не записывая их на диск.
+package sshagent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/sshkey"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// NewKeyring создает агент, хранящий в памяти ключи из элементов типа ssh_key.
+// Комментарием ключа служит комментарий элемента, а при его отсутствии — имя элемента.
+func NewKeyring(items []*models.DataItem) (agent.Agent, error) {
+	keyring := agent.NewKeyring()
+
+	for _, item := range items {
+		payload, err := models.ParsePayload(item.Type, item.Data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse data item %s: %w", item.ID, err)
+		}
+
+		key, ok := payload.(*models.SSHKeyPayload)
+		if !ok {
+			return nil, fmt.Errorf("data item %s is not %s", item.ID, models.SSHKey)
+		}
+
+		privateKey, err := sshkey.ParsePrivateKey(key.PrivateKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse key %s: %w", item.ID, err)
+		}
+
+		comment := key.Comment
+		if comment == "" {
+			comment = item.Name
+		}
+
+		if err := keyring.Add(agent.AddedKey{PrivateKey: privateKey, Comment: comment}); err != nil {
+			return nil, fmt.Errorf("failed to add key %s: %w", item.ID, err)
+		}
+	}
+
+	return keyring, nil
+}
+
+// Listen создает Unix сокет агента, доступный только текущему пользователю.
+func Listen(path string) (net.Listener, error) {
+	listener, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on %s: %w", path, err)
+	}
+
+	if err := os.Chmod(path, 0600); err != nil {
+		listener.Close()
+		return nil, fmt.Errorf("failed to set socket permissions: %w", err)
+	}
+
+	return listener, nil
+}
+
+// Serve принимает соединения и обслуживает их по протоколу SSH агента до отмены ctx.
+// При отмене слушатель и открытые соединения закрываются.
+func Serve(ctx context.Context, listener net.Listener, keyring agent.Agent) error {
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		conns = make(map[net.Conn]struct{})
+	)
+
+	// Соединения закрываются до ожидания их обработчиков.
+	defer wg.Wait()
+	stopped := make(chan struct{})
+	defer close(stopped)
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stopped:
+		}
+		listener.Close()
+
+		mu.Lock()
+		for conn := range conns {
+			conn.Close()
+		}
+		mu.Unlock()
+	}()
+
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return fmt.Errorf("failed to accept connection: %w", err)
+		}
+
+		mu.Lock()
+		conns[conn] = struct{}{}
+		mu.Unlock()
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_ = agent.ServeAgent(keyring, conn)
+
+			mu.Lock()
+			delete(conns, conn)
+			mu.Unlock()
+			conn.Close()
+		}()
+	}
+}
@@ -0,0 +1,80 @@
+package sshagent
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/sshkey"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func newKeyItem(t *testing.T, name, comment string) *models.DataItem {
+	t.Helper()
+
+	privateKey, err := sshkey.Generate(sshkey.Ed25519, 0, "")
+	require.NoError(t, err)
+
+	data, err := json.Marshal(models.SSHKeyPayload{PrivateKey: privateKey, Comment: comment})
+	require.NoError(t, err)
+
+	return &models.DataItem{ID: uuid.New(), Type: models.SSHKey, Name: name, Data: data}
+}
+
+func TestServe(t *testing.T) {
+	items := []*models.DataItem{
+		newKeyItem(t, "github", "me@github"),
+		newKeyItem(t, "prod", ""),
+	}
+
+	keyring, err := NewKeyring(items)
+	require.NoError(t, err)
+
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+	listener, err := Listen(socket)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- Serve(ctx, listener, keyring)
+	}()
+
+	conn, err := net.Dial("unix", socket)
+	require.NoError(t, err)
+	defer conn.Close()
+
+	client := agent.NewClient(conn)
+
+	keys, err := client.List()
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+
+	comments := []string{keys[0].Comment, keys[1].Comment}
+	assert.ElementsMatch(t, []string{"me@github", "prod"}, comments)
+
+	message := []byte("challenge")
+	signature, err := client.Sign(keys[0], message)
+	require.NoError(t, err)
+
+	publicKey, err := ssh.ParsePublicKey(keys[0].Marshal())
+	require.NoError(t, err)
+	assert.NoError(t, publicKey.Verify(message, signature))
+
+	cancel()
+	assert.NoError(t, <-done)
+}
+
+func TestNewKeyring_InvalidItem(t *testing.T) {
+	item := &models.DataItem{ID: uuid.New(), Type: models.TextData, Data: []byte(`{"text":"key"}`)}
+
+	_, err := NewKeyring([]*models.DataItem{item})
+	assert.Error(t, err)
+}
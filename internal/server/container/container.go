@@ -2,20 +2,26 @@ package container
 
 import (
 	"database/sql"
+	"fmt"
 	"net/http"
 
 	"github.com/gorilla/mux"
 	"github.com/uptrace/bun"
+	"google.golang.org/grpc"
 
 	"github.com/tempizhere/vaultfactory/internal/server/auth"
+	"github.com/tempizhere/vaultfactory/internal/server/grpcserver"
 	"github.com/tempizhere/vaultfactory/internal/server/handlers"
 	"github.com/tempizhere/vaultfactory/internal/server/middleware"
 	"github.com/tempizhere/vaultfactory/internal/server/repository"
 	"github.com/tempizhere/vaultfactory/internal/server/service"
 	"github.com/tempizhere/vaultfactory/internal/shared/config"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
 	"github.com/tempizhere/vaultfactory/internal/shared/crypto"
 	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
 	"github.com/tempizhere/vaultfactory/internal/shared/logger"
+	"github.com/tempizhere/vaultfactory/internal/shared/openapi"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
 )
 
 type Container struct {
@@ -25,96 +31,267 @@ type Container struct {
 	SQLDB  *sql.DB
 
 	// Repositories
-	UserRepo    interfaces.UserRepository
-	SessionRepo interfaces.SessionRepository
-	DataRepo    interfaces.DataRepository
-	VersionRepo interfaces.VersionRepository
+	UserRepo       interfaces.UserRepository
+	SessionRepo    interfaces.SessionRepository
+	DataRepo       interfaces.DataRepository
+	VersionRepo    interfaces.VersionRepository
+	TypeRepo       interfaces.CustomTypeRepository
+	FolderRepo     interfaces.FolderRepository
+	TagRepo        interfaces.TagRepository
+	AttachmentRepo interfaces.AttachmentRepository
+	HistoryRepo    interfaces.PasswordHistoryRepository
 
 	// Services
-	CryptoService *crypto.CryptoService
-	JWTService    *auth.JWTService
-	AuthService   interfaces.AuthService
-	DataService   interfaces.DataService
+	CryptoService      *crypto.CryptoService
+	JWTService         *auth.JWTService
+	PasswordPolicy     *validator.PasswordPolicy
+	AuthService        interfaces.AuthService
+	DataService        interfaces.DataService
+	TypeService        interfaces.CustomTypeService
+	FolderService      interfaces.FolderService
+	TagService         interfaces.TagService
+	TrashService       interfaces.TrashService
+	AttachmentService  interfaces.AttachmentService
+	RotationService    interfaces.RotationService
+	CertificateService interfaces.CertificateService
+	BatchService       interfaces.BatchService
 
 	// Handlers
-	AuthHandler *handlers.AuthHandler
-	DataHandler *handlers.DataHandler
+	AuthHandler        *handlers.AuthHandler
+	DataHandler        *handlers.DataHandler
+	TypeHandler        *handlers.CustomTypeHandler
+	FolderHandler      *handlers.FolderHandler
+	TagHandler         *handlers.TagHandler
+	TrashHandler       *handlers.TrashHandler
+	AttachmentHandler  *handlers.AttachmentHandler
+	RotationHandler    *handlers.RotationHandler
+	CertificateHandler *handlers.CertificateHandler
+	BatchHandler       *handlers.BatchHandler
+	OpenAPIHandler     *handlers.OpenAPIHandler
 
 	// Middleware
 	AuthMiddleware    *middleware.AuthMiddleware
 	LoggingMiddleware *middleware.LoggingMiddleware
+	ErrorHandler      *middleware.ErrorHandler
+	RequestValidator  *middleware.RequestValidator
 
 	// Router
 	Router *mux.Router
+
+	// GRPCServer обслуживает gRPC API с теми же сервисами аутентификации и данных.
+	GRPCServer *grpc.Server
 }
 
-func NewContainer(cfg config.ConfigReader, appLogger logger.Logger, db *bun.DB, sqldb *sql.DB) *Container {
+func NewContainer(cfg config.ConfigReader, appLogger logger.Logger, db *bun.DB, sqldb *sql.DB) (*Container, error) {
+	passwordPolicy, err := newPasswordPolicy(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	spec, err := openapi.Load()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load OpenAPI specification: %w", err)
+	}
+
 	userRepo := repository.NewUserRepository(db)
 	sessionRepo := repository.NewSessionRepository(db)
 	dataRepo := repository.NewDataRepository(db)
 	versionRepo := repository.NewVersionRepository(db)
+	customTypeRepo := repository.NewCustomTypeRepository(db)
+	folderRepo := repository.NewFolderRepository(db)
+	tagRepo := repository.NewTagRepository(db)
+	attachmentRepo := repository.NewAttachmentRepository(db)
+	historyRepo := repository.NewPasswordHistoryRepository(db)
+	blindIndexRepo := repository.NewBlindIndexRepository(db)
+	transactor := repository.NewTransactor(db)
 
 	cryptoService := crypto.NewCryptoService()
 	jwtService := auth.NewJWTService(cfg.GetJWTSecret(), cfg.GetJWTExpireDuration())
-	authService := service.NewAuthService(userRepo, sessionRepo, cryptoService, jwtService, appLogger)
-	dataService := service.NewDataService(dataRepo, versionRepo, cryptoService)
+	authService := service.NewAuthService(userRepo, sessionRepo, cryptoService, jwtService, passwordPolicy, appLogger)
+	dataService := service.NewDataService(dataRepo, versionRepo, customTypeRepo, folderRepo, tagRepo, historyRepo, blindIndexRepo, cryptoService, []byte(cfg.GetEncryptionKey()), cfg.GetVersionRetention(), constants.PasswordHistorySize)
+	typeService := service.NewCustomTypeService(customTypeRepo, dataRepo)
+	folderService := service.NewFolderService(folderRepo, dataRepo, transactor)
+	tagService := service.NewTagService(tagRepo, dataRepo, versionRepo, transactor, cfg.GetVersionRetention())
+	trashService := service.NewTrashService(dataRepo, cfg.GetTrashRetention())
+	attachmentService := service.NewAttachmentService(attachmentRepo, dataRepo, versionRepo, cryptoService, transactor, constants.MaxAttachmentSize, cfg.GetVersionRetention())
+	rotationService := service.NewRotationService(dataRepo, versionRepo, folderRepo, cfg.GetVersionRetention())
+	certificateService := service.NewCertificateService(dataRepo)
+	batchService := service.NewBatchService(dataService, transactor)
 
 	authHandler := handlers.NewAuthHandler(authService)
 	dataHandler := handlers.NewDataHandler(dataService)
+	typeHandler := handlers.NewCustomTypeHandler(typeService)
+	folderHandler := handlers.NewFolderHandler(folderService)
+	tagHandler := handlers.NewTagHandler(tagService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	attachmentHandler := handlers.NewAttachmentHandler(attachmentService)
+	rotationHandler := handlers.NewRotationHandler(rotationService)
+	certificateHandler := handlers.NewCertificateHandler(certificateService)
+	batchHandler := handlers.NewBatchHandler(batchService)
+	openAPIHandler := handlers.NewOpenAPIHandler(openapi.JSON())
 
 	authMiddleware := middleware.NewAuthMiddleware(authService)
 	loggingMiddleware := middleware.NewLoggingMiddleware(appLogger)
+	errorHandler := middleware.NewErrorHandler(appLogger)
+	// Наибольшее допустимое JSON тело запроса - пакет операций.
+	requestValidator := middleware.NewRequestValidator(spec, constants.MaxBatchRequestSize)
 
-	router := setupRoutes(authHandler, dataHandler, authMiddleware, loggingMiddleware)
+	router := setupRoutes(authHandler, dataHandler, typeHandler, folderHandler, tagHandler, trashHandler, attachmentHandler, rotationHandler, certificateHandler, batchHandler, openAPIHandler, authMiddleware, loggingMiddleware, errorHandler, requestValidator)
 
 	router.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 		_, _ = w.Write([]byte("OK"))
 	}).Methods("GET")
 
+	grpcServer := grpcserver.NewServer(authService, dataService, appLogger)
+
 	return &Container{
-		Config:            cfg,
-		Logger:            appLogger,
-		DB:                db,
-		SQLDB:             sqldb,
-		UserRepo:          userRepo,
-		SessionRepo:       sessionRepo,
-		DataRepo:          dataRepo,
-		VersionRepo:       versionRepo,
-		CryptoService:     cryptoService,
-		JWTService:        jwtService,
-		AuthService:       authService,
-		DataService:       dataService,
-		AuthHandler:       authHandler,
-		DataHandler:       dataHandler,
-		AuthMiddleware:    authMiddleware,
-		LoggingMiddleware: loggingMiddleware,
-		Router:            router,
+		Config:             cfg,
+		Logger:             appLogger,
+		DB:                 db,
+		SQLDB:              sqldb,
+		UserRepo:           userRepo,
+		SessionRepo:        sessionRepo,
+		DataRepo:           dataRepo,
+		VersionRepo:        versionRepo,
+		TypeRepo:           customTypeRepo,
+		FolderRepo:         folderRepo,
+		TagRepo:            tagRepo,
+		AttachmentRepo:     attachmentRepo,
+		HistoryRepo:        historyRepo,
+		CryptoService:      cryptoService,
+		JWTService:         jwtService,
+		PasswordPolicy:     passwordPolicy,
+		AuthService:        authService,
+		DataService:        dataService,
+		TypeService:        typeService,
+		FolderService:      folderService,
+		TagService:         tagService,
+		TrashService:       trashService,
+		AttachmentService:  attachmentService,
+		RotationService:    rotationService,
+		CertificateService: certificateService,
+		BatchService:       batchService,
+		AuthHandler:        authHandler,
+		DataHandler:        dataHandler,
+		TypeHandler:        typeHandler,
+		FolderHandler:      folderHandler,
+		TagHandler:         tagHandler,
+		TrashHandler:       trashHandler,
+		AttachmentHandler:  attachmentHandler,
+		RotationHandler:    rotationHandler,
+		CertificateHandler: certificateHandler,
+		BatchHandler:       batchHandler,
+		OpenAPIHandler:     openAPIHandler,
+		AuthMiddleware:     authMiddleware,
+		LoggingMiddleware:  loggingMiddleware,
+		ErrorHandler:       errorHandler,
+		RequestValidator:   requestValidator,
+		Router:             router,
+		GRPCServer:         grpcServer,
+	}, nil
+}
+
+// newPasswordPolicy создает политику паролей на основе конфигурации.
+func newPasswordPolicy(cfg config.ConfigReader) (*validator.PasswordPolicy, error) {
+	policyConfig := validator.DefaultPasswordPolicyConfig()
+	policyConfig.MinLength = cfg.GetPasswordMinLength()
+	policyConfig.MinScore = cfg.GetPasswordMinScore()
+
+	if path := cfg.GetBannedPasswordsFile(); path != "" {
+		banned, err := validator.LoadBannedPasswords(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load password policy: %w", err)
+		}
+		policyConfig.BannedPasswords = banned
+	}
+
+	var breachChecker validator.BreachChecker
+	if path := cfg.GetBreachedPasswordsPath(); path != "" {
+		checker, err := validator.NewFileBreachChecker(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load password policy: %w", err)
+		}
+		breachChecker = checker
 	}
+
+	return validator.NewPasswordPolicy(policyConfig, breachChecker), nil
 }
 
-// setupRoutes устанавливает маршруты для API.
-func setupRoutes(authHandler *handlers.AuthHandler, dataHandler *handlers.DataHandler, authMiddleware *middleware.AuthMiddleware, loggingMiddleware *middleware.LoggingMiddleware) *mux.Router {
+// setupRoutes устанавливает маршруты для API. Маршруты должны соответствовать спецификации
+// OpenAPI: запросы проверяются по ней после аутентификации.
+func setupRoutes(authHandler *handlers.AuthHandler, dataHandler *handlers.DataHandler, typeHandler *handlers.CustomTypeHandler, folderHandler *handlers.FolderHandler, tagHandler *handlers.TagHandler, trashHandler *handlers.TrashHandler, attachmentHandler *handlers.AttachmentHandler, rotationHandler *handlers.RotationHandler, certificateHandler *handlers.CertificateHandler, batchHandler *handlers.BatchHandler, openAPIHandler *handlers.OpenAPIHandler, authMiddleware *middleware.AuthMiddleware, loggingMiddleware *middleware.LoggingMiddleware, errorHandler *middleware.ErrorHandler, requestValidator *middleware.RequestValidator) *mux.Router {
 	router := mux.NewRouter()
 
+	router.Use(errorHandler.Middleware)
 	router.Use(loggingMiddleware.Logging)
+	router.NotFoundHandler = errorHandler.Middleware(http.HandlerFunc(errorHandler.NotFound))
+	router.MethodNotAllowedHandler = errorHandler.Middleware(http.HandlerFunc(errorHandler.MethodNotAllowed))
 
 	api := router.PathPrefix("/api/v1").Subrouter()
+	api.HandleFunc("/openapi.json", openAPIHandler.GetSpec).Methods("GET")
 
 	auth := api.PathPrefix("/auth").Subrouter()
-	auth.HandleFunc("/register", authHandler.Register).Methods("POST")
-	auth.HandleFunc("/login", authHandler.Login).Methods("POST")
-	auth.HandleFunc("/refresh", authHandler.Refresh).Methods("POST")
-	auth.HandleFunc("/logout", authHandler.Logout).Methods("POST")
+	auth.Handle("/register", requestValidator.Middleware(http.HandlerFunc(authHandler.Register))).Methods("POST")
+	auth.Handle("/login", requestValidator.Middleware(http.HandlerFunc(authHandler.Login))).Methods("POST")
+	auth.Handle("/refresh", requestValidator.Middleware(http.HandlerFunc(authHandler.Refresh))).Methods("POST")
+	auth.Handle("/logout", requestValidator.Middleware(http.HandlerFunc(authHandler.Logout))).Methods("POST")
+	auth.Handle("/password", authMiddleware.RequireAuth(requestValidator.Middleware(http.HandlerFunc(authHandler.ChangePassword)))).Methods("POST")
 
 	data := api.PathPrefix("/data").Subrouter()
-	data.Use(authMiddleware.RequireAuth)
+	data.Use(authMiddleware.RequireAuth, requestValidator.Middleware)
 	data.HandleFunc("", dataHandler.CreateData).Methods("POST")
 	data.HandleFunc("", dataHandler.GetUserData).Methods("GET")
 	data.HandleFunc("/sync", dataHandler.SyncData).Methods("GET")
+	data.HandleFunc("/batch", batchHandler.ExecuteBatch).Methods("POST")
+	data.HandleFunc("/search", dataHandler.SearchData).Methods("GET")
+	data.HandleFunc("/blind-search", dataHandler.BlindSearchData).Methods("GET")
+	data.HandleFunc("/blind-index/key", dataHandler.GetBlindIndexKey).Methods("GET")
+	data.HandleFunc("/blind-index/rebuild", dataHandler.RebuildBlindIndex).Methods("POST")
+	data.HandleFunc("/rotation/overdue", rotationHandler.GetOverdue).Methods("GET")
+	data.HandleFunc("/certificates/expiring", certificateHandler.GetExpiring).Methods("GET")
+	data.HandleFunc("/trash", trashHandler.GetTrash).Methods("GET")
+	data.HandleFunc("/trash", trashHandler.EmptyTrash).Methods("DELETE")
+	data.HandleFunc("/trash/{id}", trashHandler.PurgeData).Methods("DELETE")
+	data.HandleFunc("/trash/{id}/restore", trashHandler.RestoreData).Methods("POST")
 	data.HandleFunc("/{id}", dataHandler.GetData).Methods("GET")
 	data.HandleFunc("/{id}", dataHandler.UpdateData).Methods("PUT")
 	data.HandleFunc("/{id}", dataHandler.DeleteData).Methods("DELETE")
+	data.HandleFunc("/{id}/folder", dataHandler.MoveData).Methods("PUT")
+	data.HandleFunc("/{id}/expiry", dataHandler.SetExpiry).Methods("PUT")
+	data.HandleFunc("/{id}/rotation", rotationHandler.SetDataRotation).Methods("PUT")
+	data.HandleFunc("/{id}/versions", dataHandler.GetVersions).Methods("GET")
+	data.HandleFunc("/{id}/versions/{version}", dataHandler.GetVersion).Methods("GET")
+	data.HandleFunc("/{id}/versions/{version}/restore", dataHandler.RestoreVersion).Methods("POST")
+	data.HandleFunc("/{id}/password-history", dataHandler.GetPasswordHistory).Methods("GET")
+	data.HandleFunc("/{id}/tags", tagHandler.AddTags).Methods("POST")
+	data.HandleFunc("/{id}/tags/{tag}", tagHandler.RemoveTag).Methods("DELETE")
+	data.HandleFunc("/{id}/attachments", attachmentHandler.GetAttachments).Methods("GET")
+	data.HandleFunc("/{id}/attachments", attachmentHandler.UploadAttachment).Methods("POST")
+	data.HandleFunc("/{id}/attachments/{attachment}", attachmentHandler.DownloadAttachment).Methods("GET")
+	data.HandleFunc("/{id}/attachments/{attachment}", attachmentHandler.DeleteAttachment).Methods("DELETE")
+
+	types := api.PathPrefix("/types").Subrouter()
+	types.Use(authMiddleware.RequireAuth, requestValidator.Middleware)
+	types.HandleFunc("", typeHandler.CreateType).Methods("POST")
+	types.HandleFunc("", typeHandler.GetTypes).Methods("GET")
+	types.HandleFunc("/{name}", typeHandler.GetType).Methods("GET")
+	types.HandleFunc("/{name}", typeHandler.UpdateType).Methods("PUT")
+	types.HandleFunc("/{name}", typeHandler.DeleteType).Methods("DELETE")
+
+	folders := api.PathPrefix("/folders").Subrouter()
+	folders.Use(authMiddleware.RequireAuth, requestValidator.Middleware)
+	folders.HandleFunc("", folderHandler.CreateFolder).Methods("POST")
+	folders.HandleFunc("", folderHandler.GetFolders).Methods("GET")
+	folders.HandleFunc("/{id}", folderHandler.UpdateFolder).Methods("PUT")
+	folders.HandleFunc("/{id}", folderHandler.DeleteFolder).Methods("DELETE")
+	folders.HandleFunc("/{id}/rotation", rotationHandler.SetFolderRotation).Methods("PUT")
+
+	tags := api.PathPrefix("/tags").Subrouter()
+	tags.Use(authMiddleware.RequireAuth, requestValidator.Middleware)
+	tags.HandleFunc("", tagHandler.GetTags).Methods("GET")
+	tags.HandleFunc("/merge", tagHandler.MergeTags).Methods("POST")
+	tags.HandleFunc("/{name}", tagHandler.RenameTag).Methods("PUT")
 
 	return router
 }
@@ -0,0 +1,231 @@
+package container
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tempizhere/vaultfactory/internal/server/handlers"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/logger"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/openapi"
+)
+
+const testToken = "valid-token"
+
+// stubAuthService принимает только testToken; остальные методы не используются.
+type stubAuthService struct {
+	interfaces.AuthService
+}
+
+func (stubAuthService) ValidateToken(ctx context.Context, token string) (*models.User, error) {
+	if token != testToken {
+		return nil, errors.New("invalid token")
+	}
+	return &models.User{ID: uuid.New()}, nil
+}
+
+// stubDataService запоминает имя создаваемого элемента и не находит существующие элементы.
+type stubDataService struct {
+	interfaces.DataService
+	created string
+}
+
+func (s *stubDataService) CreateData(ctx context.Context, userID uuid.UUID, dataType models.DataType, name, metadata string, data []byte, expiry models.DataExpiry) (*models.DataItem, error) {
+	s.created = name
+	return &models.DataItem{ID: uuid.New(), Type: dataType, Name: name, Version: 1}, nil
+}
+
+func (s *stubDataService) GetData(ctx context.Context, userID, dataID uuid.UUID) (*models.DataItem, error) {
+	return nil, apperrors.NewNotFound("data item not found", nil)
+}
+
+func newTestRouter(t *testing.T, dataService interfaces.DataService) (*mux.Router, *openapi.Document) {
+	t.Helper()
+
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	log := logger.NewMockLogger()
+	router := setupRoutes(
+		handlers.NewAuthHandler(nil),
+		handlers.NewDataHandler(dataService),
+		handlers.NewCustomTypeHandler(nil),
+		handlers.NewFolderHandler(nil),
+		handlers.NewTagHandler(nil),
+		handlers.NewTrashHandler(nil),
+		handlers.NewAttachmentHandler(nil),
+		handlers.NewRotationHandler(nil),
+		handlers.NewCertificateHandler(nil),
+		handlers.NewBatchHandler(nil),
+		handlers.NewOpenAPIHandler(openapi.JSON()),
+		middleware.NewAuthMiddleware(stubAuthService{}),
+		middleware.NewLoggingMiddleware(log),
+		middleware.NewErrorHandler(log),
+		middleware.NewRequestValidator(doc, constants.MaxBatchRequestSize),
+	)
+
+	return router, doc
+}
+
+func TestRoutesMatchSpecification(t *testing.T) {
+	router, doc := newTestRouter(t, nil)
+
+	var routes []string
+	err := router.Walk(func(route *mux.Route, router *mux.Router, ancestors []*mux.Route) error {
+		template, err := route.GetPathTemplate()
+		if err != nil || !strings.HasPrefix(template, doc.BasePath()) {
+			return nil
+		}
+		methods, err := route.GetMethods()
+		if err != nil {
+			// Префиксы подмаршрутизаторов не задают методов.
+			return nil
+		}
+		for _, method := range methods {
+			routes = append(routes, method+" "+strings.TrimPrefix(template, doc.BasePath()))
+		}
+		return nil
+	})
+	require.NoError(t, err)
+
+	var operations []string
+	for _, op := range doc.Operations() {
+		operations = append(operations, op.Method+" "+op.Path)
+	}
+
+	sort.Strings(routes)
+	sort.Strings(operations)
+	assert.Equal(t, operations, routes, "routes and specification operations differ")
+}
+
+// samplePath подставляет в шаблон пути допустимые значения параметров.
+func samplePath(doc *openapi.Document, op openapi.OperationRef) string {
+	path := op.Path
+	for _, param := range op.Parameters {
+		if param.In != "path" {
+			continue
+		}
+		value := "sample"
+		switch schema := doc.Resolve(param.Schema); {
+		case schema.Format == "uuid":
+			value = uuid.NewString()
+		case schema.Type == "integer":
+			value = "1"
+		}
+		path = strings.ReplaceAll(path, "{"+param.Name+"}", value)
+	}
+	return doc.BasePath() + path
+}
+
+func TestRoutesAuthentication(t *testing.T) {
+	router, doc := newTestRouter(t, nil)
+
+	for _, op := range doc.Operations() {
+		t.Run(op.OperationID, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest(op.Method, samplePath(doc, op), nil))
+
+			if op.Public() {
+				assert.NotEqual(t, http.StatusUnauthorized, w.Code)
+			} else {
+				assert.Equal(t, http.StatusUnauthorized, w.Code)
+			}
+		})
+	}
+}
+
+func TestRoutesServeSpecification(t *testing.T) {
+	router, _ := newTestRouter(t, nil)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, openapi.JSON(), w.Body.Bytes())
+}
+
+func TestRoutesValidateRequests(t *testing.T) {
+	dataID := uuid.NewString()
+	tests := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		status int
+		field  string
+	}{
+		{name: "missing required property", method: "POST", path: "/api/v1/data", body: `{"name":"mail"}`, status: http.StatusBadRequest, field: "type"},
+		{name: "wrong property type", method: "POST", path: "/api/v1/data", body: `{"type":"text_data","name":42}`, status: http.StatusBadRequest, field: "name"},
+		{name: "property below minimum", method: "POST", path: "/api/v1/data", body: `{"type":"text_data","name":"note","max_reads":0}`, status: http.StatusBadRequest, field: "max_reads"},
+		{name: "invalid JSON", method: "POST", path: "/api/v1/data", body: `{"type":`, status: http.StatusBadRequest},
+		{name: "missing body", method: "POST", path: "/api/v1/data", status: http.StatusBadRequest},
+		{name: "invalid path parameter", method: "GET", path: "/api/v1/data/not-a-uuid", status: http.StatusBadRequest, field: "id"},
+		{name: "invalid query parameter", method: "GET", path: "/api/v1/data?limit=0", status: http.StatusBadRequest, field: "limit"},
+		{name: "invalid enum query parameter", method: "GET", path: "/api/v1/data?sort=size", status: http.StatusBadRequest, field: "sort"},
+		{name: "invalid repeated query parameter", method: "GET", path: "/api/v1/data/blind-search?token=ab&token=xyz", status: http.StatusBadRequest, field: "token[1]"},
+		{name: "missing required query parameter", method: "GET", path: "/api/v1/data/sync", status: http.StatusBadRequest, field: "last_sync"},
+		{name: "invalid nested enum", method: "POST", path: "/api/v1/data/batch", body: `{"operations":[{"op":"move"}]}`, status: http.StatusBadRequest, field: "operations[0].op"},
+		{name: "invalid array item", method: "POST", path: "/api/v1/types", body: `{"name":"api-key","fields":[{"name":"key","kind":"secret"}]}`, status: http.StatusBadRequest, field: "fields[0].kind"},
+		{name: "valid request reaches handler", method: "GET", path: "/api/v1/data/" + dataID, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router, _ := newTestRouter(t, &stubDataService{})
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", "Bearer "+testToken)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			require.Equal(t, tt.status, w.Code, w.Body.String())
+
+			var response apperrors.ErrorResponse
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.field, response.Error.Field)
+			if tt.field != "" {
+				assert.Equal(t, apperrors.CodeValidationFailed, response.Error.Code)
+				assert.True(t, strings.HasPrefix(response.Error.Message, tt.field+" "), response.Error.Message)
+			}
+		})
+	}
+
+	t.Run("validated body is passed to handler", func(t *testing.T) {
+		dataService := &stubDataService{}
+		router, _ := newTestRouter(t, dataService)
+
+		req := httptest.NewRequest("POST", "/api/v1/data", strings.NewReader(`{"type":"text_data","name":"note","data":{"content":"x"}}`))
+		req.Header.Set("Authorization", "Bearer "+testToken)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "note", dataService.created)
+	})
+
+	t.Run("authentication precedes validation", func(t *testing.T) {
+		router, _ := newTestRouter(t, nil)
+
+		for _, path := range []string{"/api/v1/data", "/api/v1/auth/password"} {
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, httptest.NewRequest("POST", path, strings.NewReader(`{}`)))
+			assert.Equal(t, http.StatusUnauthorized, w.Code, path)
+		}
+	})
+}
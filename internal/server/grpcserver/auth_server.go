@@ -0,0 +1,104 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/pb"
+)
+
+// AuthServer реализует gRPC сервис аутентификации.
+type AuthServer struct {
+	pb.UnimplementedAuthServiceServer
+	authService interfaces.AuthService
+}
+
+// NewAuthServer создает новый экземпляр AuthServer.
+func NewAuthServer(authService interfaces.AuthService) *AuthServer {
+	return &AuthServer{
+		authService: authService,
+	}
+}
+
+// Register регистрирует пользователя и выполняет вход.
+func (s *AuthServer) Register(ctx context.Context, req *pb.RegisterRequest) (*pb.AuthResponse, error) {
+	if req.GetEmail() == "" || req.GetPassword() == "" {
+		return nil, apperrors.NewBadRequest("Email and password are required", nil)
+	}
+
+	user, err := s.authService.Register(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+
+	_, accessToken, refreshToken, err := s.authService.Login(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, apperrors.NewInternalServerError("Failed to generate token", err)
+	}
+
+	return &pb.AuthResponse{
+		User:         toUser(user),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Login выполняет вход по email и паролю.
+func (s *AuthServer) Login(ctx context.Context, req *pb.LoginRequest) (*pb.AuthResponse, error) {
+	if req.GetEmail() == "" || req.GetPassword() == "" {
+		return nil, apperrors.NewBadRequest("Email and password are required", nil)
+	}
+
+	user, accessToken, refreshToken, err := s.authService.Login(ctx, req.GetEmail(), req.GetPassword())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AuthResponse{
+		User:         toUser(user),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Refresh выдает новую пару токенов по токену обновления.
+func (s *AuthServer) Refresh(ctx context.Context, req *pb.RefreshRequest) (*pb.AuthResponse, error) {
+	if req.GetRefreshToken() == "" {
+		return nil, apperrors.NewBadRequest("Refresh token is required", nil)
+	}
+
+	accessToken, refreshToken, err := s.authService.RefreshToken(ctx, req.GetRefreshToken())
+	if err != nil {
+		return nil, err
+	}
+
+	return &pb.AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// Logout отзывает токен обновления.
+func (s *AuthServer) Logout(ctx context.Context, req *pb.LogoutRequest) (*pb.LogoutResponse, error) {
+	if err := s.authService.Logout(ctx, req.GetRefreshToken()); err != nil {
+		return nil, err
+	}
+	return &pb.LogoutResponse{}, nil
+}
+
+// ChangePassword меняет пароль аутентифицированного пользователя.
+func (s *AuthServer) ChangePassword(ctx context.Context, req *pb.ChangePasswordRequest) (*pb.ChangePasswordResponse, error) {
+	user := ctx.Value(middleware.UserKey).(*models.User)
+
+	if req.GetCurrentPassword() == "" || req.GetNewPassword() == "" {
+		return nil, apperrors.NewBadRequest("Current and new passwords are required", nil)
+	}
+
+	if err := s.authService.ChangePassword(ctx, user.ID, req.GetCurrentPassword(), req.GetNewPassword()); err != nil {
+		return nil, err
+	}
+	return &pb.ChangePasswordResponse{}, nil
+}
@@ -0,0 +1,59 @@
+package grpcserver
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/pb"
+)
+
+// toUser преобразует пользователя в сообщение gRPC.
+func toUser(user *models.User) *pb.User {
+	if user == nil {
+		return nil
+	}
+	return &pb.User{
+		Id:        user.ID.String(),
+		Email:     user.Email,
+		CreatedAt: timestamppb.New(user.CreatedAt),
+		UpdatedAt: timestamppb.New(user.UpdatedAt),
+	}
+}
+
+// toDataItem преобразует элемент данных в сообщение gRPC вместе с содержимым.
+func toDataItem(item *models.DataItem) *pb.DataItem {
+	message := &pb.DataItem{
+		Id:        item.ID.String(),
+		Type:      string(item.Type),
+		Name:      item.Name,
+		Metadata:  item.Metadata,
+		Data:      item.Data,
+		Version:   item.Version,
+		Tags:      item.Tags,
+		CreatedAt: timestamppb.New(item.CreatedAt),
+		UpdatedAt: timestamppb.New(item.UpdatedAt),
+		ReadCount: int32(item.ReadCount),
+	}
+	if item.FolderID != nil {
+		message.FolderId = item.FolderID.String()
+	}
+	if item.DeletedAt != nil {
+		message.DeletedAt = timestamppb.New(*item.DeletedAt)
+	}
+	if item.ExpiresAt != nil {
+		message.ExpiresAt = timestamppb.New(*item.ExpiresAt)
+	}
+	if item.MaxReads != nil {
+		maxReads := int32(*item.MaxReads)
+		message.MaxReads = &maxReads
+	}
+	return message
+}
+
+// toDataItemSummary преобразует элемент данных в сообщение gRPC без содержимого,
+// как в списках HTTP API.
+func toDataItemSummary(item *models.DataItem) *pb.DataItem {
+	message := toDataItem(item)
+	message.Data = nil
+	return message
+}
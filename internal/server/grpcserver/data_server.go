@@ -0,0 +1,167 @@
+package grpcserver
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/pb"
+)
+
+// DataServer реализует gRPC сервис работы с данными пользователей.
+type DataServer struct {
+	pb.UnimplementedDataServiceServer
+	dataService interfaces.DataService
+}
+
+// NewDataServer создает новый экземпляр DataServer.
+func NewDataServer(dataService interfaces.DataService) *DataServer {
+	return &DataServer{
+		dataService: dataService,
+	}
+}
+
+// CreateData создает элемент данных.
+func (s *DataServer) CreateData(ctx context.Context, req *pb.CreateDataRequest) (*pb.DataItem, error) {
+	user := ctx.Value(middleware.UserKey).(*models.User)
+
+	if req.GetType() == "" || req.GetName() == "" {
+		return nil, apperrors.NewBadRequest("Type and name are required", nil)
+	}
+
+	expiry := models.DataExpiry{}
+	if req.ExpiresAt != nil {
+		if err := req.ExpiresAt.CheckValid(); err != nil {
+			return nil, apperrors.NewBadRequest("Invalid expires_at", err)
+		}
+		expiresAt := req.ExpiresAt.AsTime()
+		expiry.ExpiresAt = &expiresAt
+	}
+	if req.MaxReads != nil {
+		maxReads := int(req.GetMaxReads())
+		expiry.MaxReads = &maxReads
+	}
+
+	dataItem, err := s.dataService.CreateData(ctx, user.ID, models.DataType(req.GetType()), req.GetName(), req.GetMetadata(), req.GetData(), expiry)
+	if err != nil {
+		return nil, err
+	}
+	return toDataItem(dataItem), nil
+}
+
+// GetData возвращает элемент данных с содержимым.
+func (s *DataServer) GetData(ctx context.Context, req *pb.GetDataRequest) (*pb.DataItem, error) {
+	user := ctx.Value(middleware.UserKey).(*models.User)
+
+	dataID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, apperrors.NewBadRequest("Invalid data ID", nil)
+	}
+
+	dataItem, err := s.dataService.GetData(ctx, user.ID, dataID)
+	if err != nil {
+		return nil, err
+	}
+	return toDataItem(dataItem), nil
+}
+
+// ListData возвращает страницу элементов данных без содержимого.
+func (s *DataServer) ListData(ctx context.Context, req *pb.ListDataRequest) (*pb.ListDataResponse, error) {
+	user := ctx.Value(middleware.UserKey).(*models.User)
+
+	tagFilter, err := models.ParseTagFilter(req.GetTags(), models.TagMatch(req.GetTagMatch()))
+	if err != nil {
+		return nil, apperrors.NewBadRequest(err.Error(), err)
+	}
+
+	if req.GetLimit() < 0 {
+		return nil, apperrors.NewBadRequest("invalid limit parameter", nil)
+	}
+
+	result, err := s.dataService.GetUserData(ctx, user.ID, models.DataFilter{
+		Type:      models.DataType(req.GetType()),
+		Folder:    req.GetFolder(),
+		Recursive: req.GetRecursive(),
+		Tags:      tagFilter,
+	}, models.PageRequest{
+		Limit:  int(req.GetLimit()),
+		Cursor: req.GetCursor(),
+		Sort:   models.DataSort(req.GetSort()),
+		Order:  models.SortOrder(req.GetOrder()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := &pb.ListDataResponse{
+		Items:      make([]*pb.DataItem, 0, len(result.Items)),
+		NextCursor: result.NextCursor,
+	}
+	for _, item := range result.Items {
+		response.Items = append(response.Items, toDataItemSummary(item))
+	}
+	return response, nil
+}
+
+// UpdateData обновляет элемент данных. Ожидаемая версия элемента обязательна.
+func (s *DataServer) UpdateData(ctx context.Context, req *pb.UpdateDataRequest) (*pb.DataItem, error) {
+	user := ctx.Value(middleware.UserKey).(*models.User)
+
+	dataID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, apperrors.NewBadRequest("Invalid data ID", nil)
+	}
+
+	version, err := expectedVersion(req.Version, req.GetForce())
+	if err != nil {
+		return nil, err
+	}
+
+	dataItem, err := s.dataService.UpdateData(ctx, user.ID, dataID, version, req.GetName(), req.GetMetadata(), req.GetData())
+	if err != nil {
+		return nil, err
+	}
+	return toDataItem(dataItem), nil
+}
+
+// DeleteData перемещает элемент данных в корзину. Ожидаемая версия элемента обязательна.
+func (s *DataServer) DeleteData(ctx context.Context, req *pb.DeleteDataRequest) (*pb.DeleteDataResponse, error) {
+	user := ctx.Value(middleware.UserKey).(*models.User)
+
+	dataID, err := uuid.Parse(req.GetId())
+	if err != nil {
+		return nil, apperrors.NewBadRequest("Invalid data ID", nil)
+	}
+
+	version, err := expectedVersion(req.Version, req.GetForce())
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dataService.DeleteData(ctx, user.ID, dataID, version); err != nil {
+		return nil, err
+	}
+	return &pb.DeleteDataResponse{}, nil
+}
+
+// expectedVersion возвращает ожидаемую версию элемента из поля version запроса.
+// Проверка версии отключается только явным флагом force (models.AnyVersion).
+func expectedVersion(version *int64, force bool) (int64, error) {
+	if force {
+		if version != nil {
+			return 0, apperrors.NewBadRequest("version and force are mutually exclusive", nil)
+		}
+		return models.AnyVersion, nil
+	}
+	if version == nil {
+		return 0, apperrors.NewPreconditionRequired("expected version is required: send version field or set force", nil)
+	}
+	if *version <= 0 {
+		return 0, apperrors.NewBadRequest("version must be positive", nil)
+	}
+	return *version, nil
+}
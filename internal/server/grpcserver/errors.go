@@ -0,0 +1,85 @@
+package grpcserver
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+)
+
+// ErrorDomain - домен ErrorInfo в деталях статусов gRPC.
+const ErrorDomain = "vaultfactory"
+
+// toStatus преобразует ошибку в статус gRPC. Код ошибки HTTP API передается в поле
+// Reason деталей ErrorInfo, поле ошибки и подробности - в ее метаданных.
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return status.FromContextError(err).Err()
+	}
+
+	body := middleware.NewErrorBody(err)
+	st := status.New(grpcCode(body.Code), body.Message)
+
+	info := &errdetails.ErrorInfo{
+		Reason: string(body.Code),
+		Domain: ErrorDomain,
+	}
+	if body.Field != "" || len(body.Details) > 0 {
+		info.Metadata = make(map[string]string, len(body.Details)+1)
+	}
+	if body.Field != "" {
+		info.Metadata["field"] = body.Field
+	}
+	for key, value := range body.Details {
+		if text, ok := value.(string); ok {
+			info.Metadata[key] = text
+			continue
+		}
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			continue
+		}
+		info.Metadata[key] = string(encoded)
+	}
+
+	detailed, detailsErr := st.WithDetails(info)
+	if detailsErr != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
+
+// grpcCode подбирает код gRPC для кода ошибки HTTP API.
+func grpcCode(code apperrors.ErrorCode) codes.Code {
+	switch code {
+	case apperrors.CodeBadRequest, apperrors.CodeValidationFailed:
+		return codes.InvalidArgument
+	case apperrors.CodeUnauthorized:
+		return codes.Unauthenticated
+	case apperrors.CodeAccessDenied:
+		return codes.PermissionDenied
+	case apperrors.CodeNotFound:
+		return codes.NotFound
+	case apperrors.CodeMethodNotAllowed, apperrors.CodeNotImplemented:
+		return codes.Unimplemented
+	case apperrors.CodeConflict:
+		return codes.AlreadyExists
+	case apperrors.CodeVersionConflict:
+		return codes.Aborted
+	case apperrors.CodePreconditionRequired, apperrors.CodeFailedDependency:
+		return codes.FailedPrecondition
+	case apperrors.CodeRequestTooLarge:
+		return codes.ResourceExhausted
+	default:
+		return codes.Internal
+	}
+}
@@ -0,0 +1,160 @@
+package grpcserver
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/logger"
+)
+
+// authorizationKey - ключ метаданных с токеном доступа.
+const authorizationKey = "authorization"
+
+// AuthInterceptor проверяет токен доступа из метаданных authorization и передает
+// пользователя в контексте под ключом middleware.UserKey, как AuthMiddleware.
+type AuthInterceptor struct {
+	authService   interfaces.AuthService
+	publicMethods map[string]bool
+}
+
+// NewAuthInterceptor создает новый экземпляр AuthInterceptor. Методы publicMethods
+// (полные имена вида /package.Service/Method) вызываются без проверки токена.
+func NewAuthInterceptor(authService interfaces.AuthService, publicMethods ...string) *AuthInterceptor {
+	interceptor := &AuthInterceptor{
+		authService:   authService,
+		publicMethods: make(map[string]bool, len(publicMethods)),
+	}
+	for _, method := range publicMethods {
+		interceptor.publicMethods[method] = true
+	}
+	return interceptor
+}
+
+// Unary возвращает перехватчик унарных вызовов.
+func (i *AuthInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if i.publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		ctx, err := i.authenticate(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// Stream возвращает перехватчик потоковых вызовов.
+func (i *AuthInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if i.publicMethods[info.FullMethod] {
+			return handler(srv, stream)
+		}
+
+		ctx, err := i.authenticate(stream.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	}
+}
+
+// authenticate проверяет токен вида "Bearer <token>" и возвращает контекст с пользователем.
+func (i *AuthInterceptor) authenticate(ctx context.Context) (context.Context, error) {
+	values := metadata.ValueFromIncomingContext(ctx, authorizationKey)
+	if len(values) == 0 || values[0] == "" {
+		return nil, apperrors.NewUnauthorized("Authorization metadata required", nil)
+	}
+
+	tokenParts := strings.Split(values[0], " ")
+	if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
+		return nil, apperrors.NewUnauthorized("Invalid authorization metadata format", nil)
+	}
+
+	user, err := i.authService.ValidateToken(ctx, tokenParts[1])
+	if err != nil {
+		return nil, apperrors.NewUnauthorized("Invalid token", err)
+	}
+
+	return context.WithValue(ctx, middleware.UserKey, user), nil
+}
+
+// contextStream подменяет контекст потока.
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+// ErrorInterceptor преобразует ошибки методов в статусы gRPC, перехватывает паники
+// и логирует причины внутренних ошибок.
+type ErrorInterceptor struct {
+	logger logger.Logger
+}
+
+// NewErrorInterceptor создает новый экземпляр ErrorInterceptor.
+func NewErrorInterceptor(logger logger.Logger) *ErrorInterceptor {
+	return &ErrorInterceptor{
+		logger: logger,
+	}
+}
+
+// Unary возвращает перехватчик унарных вызовов.
+func (i *ErrorInterceptor) Unary() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				resp = nil
+				err = apperrors.NewInternalServerError("Internal server error", fmt.Errorf("panic: %v", recovered))
+			}
+			err = i.handleError(info.FullMethod, err)
+		}()
+
+		return handler(ctx, req)
+	}
+}
+
+// Stream возвращает перехватчик потоковых вызовов.
+func (i *ErrorInterceptor) Stream() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				err = apperrors.NewInternalServerError("Internal server error", fmt.Errorf("panic: %v", recovered))
+			}
+			err = i.handleError(info.FullMethod, err)
+		}()
+
+		return handler(srv, stream)
+	}
+}
+
+// handleError преобразует ошибку в статус и логирует внутренние ошибки с исходной причиной.
+func (i *ErrorInterceptor) handleError(method string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	statusErr := toStatus(err)
+	if code := status.Code(statusErr); code == codes.Internal || code == codes.Unknown {
+		i.logger.Error("RPC failed",
+			zap.String("method", method),
+			zap.String("code", code.String()),
+			zap.Error(err),
+		)
+	}
+	return statusErr
+}
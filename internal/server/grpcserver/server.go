@@ -0,0 +1,39 @@
+// Package grpcserver содержит gRPC API сервера. Сервисы используют те же AuthService
+// и DataService, что и HTTP хендлеры, и возвращают ошибки с кодами, согласованными
+// с кодами ошибок HTTP API.
+package grpcserver
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/logger"
+	"github.com/tempizhere/vaultfactory/internal/shared/pb"
+)
+
+// publicMethods содержит методы, доступные без токена доступа.
+var publicMethods = []string{
+	pb.AuthService_Register_FullMethodName,
+	pb.AuthService_Login_FullMethodName,
+	pb.AuthService_Refresh_FullMethodName,
+	pb.AuthService_Logout_FullMethodName,
+}
+
+// NewServer создает gRPC сервер с сервисами аутентификации, данных и синхронизации.
+// Ошибки всех методов преобразуются в статусы gRPC, методы кроме publicMethods
+// требуют токен доступа в метаданных authorization.
+func NewServer(authService interfaces.AuthService, dataService interfaces.DataService, appLogger logger.Logger, opts ...grpc.ServerOption) *grpc.Server {
+	errorInterceptor := NewErrorInterceptor(appLogger)
+	authInterceptor := NewAuthInterceptor(authService, publicMethods...)
+
+	opts = append(opts,
+		grpc.ChainUnaryInterceptor(errorInterceptor.Unary(), authInterceptor.Unary()),
+		grpc.ChainStreamInterceptor(errorInterceptor.Stream(), authInterceptor.Stream()),
+	)
+
+	server := grpc.NewServer(opts...)
+	pb.RegisterAuthServiceServer(server, NewAuthServer(authService))
+	pb.RegisterDataServiceServer(server, NewDataServer(dataService))
+	pb.RegisterSyncServiceServer(server, NewSyncServer(dataService))
+	return server
+}
@@ -0,0 +1,320 @@
+package grpcserver
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/logger"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/pb"
+)
+
+const testToken = "valid-token"
+
+var testUser = &models.User{ID: uuid.New(), Email: "user@example.com"}
+
+// stubAuthService принимает только testToken и пароль "password".
+type stubAuthService struct {
+	interfaces.AuthService
+}
+
+func (stubAuthService) ValidateToken(ctx context.Context, token string) (*models.User, error) {
+	if token != testToken {
+		return nil, errors.New("invalid token")
+	}
+	return testUser, nil
+}
+
+func (stubAuthService) Login(ctx context.Context, email, password string) (*models.User, string, string, error) {
+	if password != "password" {
+		return nil, "", "", apperrors.NewUnauthorized("Invalid credentials", nil)
+	}
+	return testUser, testToken, "refresh-token", nil
+}
+
+// stubDataService возвращает сохраненные элементы и запоминает параметры вызовов.
+type stubDataService struct {
+	interfaces.DataService
+
+	item      *models.DataItem
+	err       error
+	userID    uuid.UUID
+	version   int64
+	filter    models.DataFilter
+	syncPages []*models.DataPage
+	cursors   []string
+}
+
+func (s *stubDataService) GetData(ctx context.Context, userID, dataID uuid.UUID) (*models.DataItem, error) {
+	s.userID = userID
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.item, nil
+}
+
+func (s *stubDataService) GetUserData(ctx context.Context, userID uuid.UUID, filter models.DataFilter, page models.PageRequest) (*models.DataPage, error) {
+	s.filter = filter
+	return &models.DataPage{Items: []*models.DataItem{s.item}, NextCursor: "next"}, nil
+}
+
+func (s *stubDataService) UpdateData(ctx context.Context, userID, dataID uuid.UUID, expectedVersion int64, name, metadata string, data []byte) (*models.DataItem, error) {
+	s.version = expectedVersion
+	if s.err != nil {
+		return nil, s.err
+	}
+	return s.item, nil
+}
+
+func (s *stubDataService) SyncData(ctx context.Context, userID uuid.UUID, lastSync time.Time, page models.PageRequest) (*models.DataPage, error) {
+	s.cursors = append(s.cursors, page.Cursor)
+	result := s.syncPages[0]
+	s.syncPages = s.syncPages[1:]
+	return result, nil
+}
+
+// newTestClient запускает сервер на bufconn и возвращает соединение с ним.
+func newTestClient(t *testing.T, dataService interfaces.DataService) *grpc.ClientConn {
+	t.Helper()
+
+	listener := bufconn.Listen(1024 * 1024)
+	server := NewServer(stubAuthService{}, dataService, logger.NewMockLogger())
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return conn
+}
+
+func authorized(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+// errorInfo возвращает детали ErrorInfo статуса ошибки.
+func errorInfo(t *testing.T, err error) *errdetails.ErrorInfo {
+	t.Helper()
+
+	st, ok := status.FromError(err)
+	require.True(t, ok, "expected status error, got %v", err)
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.ErrorInfo); ok {
+			return info
+		}
+	}
+	t.Fatalf("status %v has no ErrorInfo", st)
+	return nil
+}
+
+func TestAuthInterceptor(t *testing.T) {
+	dataService := &stubDataService{item: &models.DataItem{ID: uuid.New(), Name: "mail", Data: []byte(`{}`)}}
+	conn := newTestClient(t, dataService)
+	client := pb.NewDataServiceClient(conn)
+	request := &pb.GetDataRequest{Id: dataService.item.ID.String()}
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		message string
+	}{
+		{"no metadata", context.Background(), "Authorization metadata required"},
+		{"invalid format", metadata.AppendToOutgoingContext(context.Background(), "authorization", testToken), "Invalid authorization metadata format"},
+		{"invalid token", authorized("other"), "Invalid token"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetData(tt.ctx, request)
+
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+			assert.Equal(t, tt.message, status.Convert(err).Message())
+			assert.Equal(t, string(apperrors.CodeUnauthorized), errorInfo(t, err).Reason)
+		})
+	}
+
+	t.Run("valid token", func(t *testing.T) {
+		item, err := client.GetData(authorized(testToken), request)
+		require.NoError(t, err)
+
+		assert.Equal(t, "mail", item.GetName())
+		assert.Equal(t, []byte(`{}`), item.GetData())
+		assert.Equal(t, testUser.ID, dataService.userID)
+	})
+
+	t.Run("public method", func(t *testing.T) {
+		response, err := pb.NewAuthServiceClient(conn).Login(context.Background(), &pb.LoginRequest{Email: testUser.Email, Password: "password"})
+		require.NoError(t, err)
+
+		assert.Equal(t, testToken, response.GetAccessToken())
+		assert.Equal(t, testUser.Email, response.GetUser().GetEmail())
+	})
+}
+
+func TestErrorStatus(t *testing.T) {
+	dataService := &stubDataService{item: &models.DataItem{ID: uuid.New(), Version: 4}}
+	client := pb.NewDataServiceClient(newTestClient(t, dataService))
+	ctx := authorized(testToken)
+	id := dataService.item.ID.String()
+
+	t.Run("invalid argument", func(t *testing.T) {
+		_, err := client.GetData(ctx, &pb.GetDataRequest{Id: "not-a-uuid"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, string(apperrors.CodeBadRequest), errorInfo(t, err).Reason)
+	})
+
+	t.Run("version required", func(t *testing.T) {
+		_, err := client.UpdateData(ctx, &pb.UpdateDataRequest{Id: id, Name: "mail"})
+
+		assert.Equal(t, codes.FailedPrecondition, status.Code(err))
+		assert.Equal(t, string(apperrors.CodePreconditionRequired), errorInfo(t, err).Reason)
+	})
+
+	t.Run("zero version", func(t *testing.T) {
+		_, err := client.UpdateData(ctx, &pb.UpdateDataRequest{Id: id, Version: new(int64), Name: "mail"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+		assert.Equal(t, string(apperrors.CodeBadRequest), errorInfo(t, err).Reason)
+	})
+
+	t.Run("version with force", func(t *testing.T) {
+		expected := int64(4)
+		_, err := client.UpdateData(ctx, &pb.UpdateDataRequest{Id: id, Version: &expected, Force: true, Name: "mail"})
+
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("force", func(t *testing.T) {
+		dataService.version = -1
+		_, err := client.UpdateData(ctx, &pb.UpdateDataRequest{Id: id, Force: true, Name: "mail"})
+		require.NoError(t, err)
+
+		assert.Equal(t, models.AnyVersion, dataService.version)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		dataService.err = &apperrors.VersionConflictError{Expected: 3, Current: 4}
+		defer func() { dataService.err = nil }()
+
+		expected := int64(3)
+		_, err := client.UpdateData(ctx, &pb.UpdateDataRequest{Id: id, Version: &expected, Name: "mail"})
+
+		assert.Equal(t, codes.Aborted, status.Code(err))
+		info := errorInfo(t, err)
+		assert.Equal(t, string(apperrors.CodeVersionConflict), info.Reason)
+		assert.Equal(t, ErrorDomain, info.Domain)
+		assert.Equal(t, map[string]string{"current_version": "4", "expected_version": "3"}, info.Metadata)
+	})
+
+	t.Run("internal error", func(t *testing.T) {
+		dataService.err = errors.New("database is down")
+		defer func() { dataService.err = nil }()
+
+		_, err := client.GetData(ctx, &pb.GetDataRequest{Id: id})
+
+		assert.Equal(t, codes.Internal, status.Code(err))
+		assert.Equal(t, "Internal server error", status.Convert(err).Message())
+	})
+}
+
+func TestDataServer_ListData(t *testing.T) {
+	dataService := &stubDataService{item: &models.DataItem{ID: uuid.New(), Name: "mail", Data: []byte(`{"password":"secret"}`)}}
+	client := pb.NewDataServiceClient(newTestClient(t, dataService))
+
+	response, err := client.ListData(authorized(testToken), &pb.ListDataRequest{
+		Folder:   "work",
+		Tags:     []string{"Prod,!archive"},
+		TagMatch: "all",
+	})
+	require.NoError(t, err)
+
+	require.Len(t, response.GetItems(), 1)
+	assert.Empty(t, response.GetItems()[0].GetData())
+	assert.Equal(t, "next", response.GetNextCursor())
+	assert.Equal(t, models.DataFilter{
+		Folder: "work",
+		Tags:   models.TagFilter{Include: []string{"prod"}, Exclude: []string{"archive"}, Match: models.TagMatchAll},
+	}, dataService.filter)
+
+	_, err = client.ListData(authorized(testToken), &pb.ListDataRequest{TagMatch: "some"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSyncServer_Sync(t *testing.T) {
+	deletedAt := time.Date(2024, 1, 3, 0, 0, 0, 0, time.UTC)
+	first := &models.DataItem{ID: uuid.New(), Name: "first", Data: []byte(`{}`)}
+	second := &models.DataItem{ID: uuid.New(), Name: "second"}
+	deleted := &models.DataItem{ID: uuid.New(), Name: "deleted", DeletedAt: &deletedAt}
+
+	dataService := &stubDataService{syncPages: []*models.DataPage{
+		{Items: []*models.DataItem{first, second}, NextCursor: "page-2"},
+		{Items: []*models.DataItem{deleted}},
+	}}
+	client := pb.NewSyncServiceClient(newTestClient(t, dataService))
+
+	stream, err := client.Sync(authorized(testToken), &pb.SyncRequest{
+		LastSync: timestamppb.New(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)),
+		PageSize: 2,
+	})
+	require.NoError(t, err)
+
+	var names []string
+	for {
+		response, err := stream.Recv()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		names = append(names, response.GetItem().GetName())
+		assert.Empty(t, response.GetItem().GetData())
+		if response.GetItem().GetName() == "deleted" {
+			assert.Equal(t, deletedAt, response.GetItem().GetDeletedAt().AsTime())
+		}
+	}
+
+	assert.Equal(t, []string{"first", "second", "deleted"}, names)
+	assert.Equal(t, []string{"", "page-2"}, dataService.cursors)
+
+	t.Run("last_sync required", func(t *testing.T) {
+		stream, err := client.Sync(authorized(testToken), &pb.SyncRequest{})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	})
+
+	t.Run("unauthenticated", func(t *testing.T) {
+		stream, err := client.Sync(context.Background(), &pb.SyncRequest{LastSync: timestamppb.Now()})
+		require.NoError(t, err)
+
+		_, err = stream.Recv()
+		assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	})
+}
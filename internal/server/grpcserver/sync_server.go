@@ -0,0 +1,60 @@
+package grpcserver
+
+import (
+	"google.golang.org/grpc"
+
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/pb"
+)
+
+// SyncServer реализует gRPC сервис синхронизации данных.
+type SyncServer struct {
+	pb.UnimplementedSyncServiceServer
+	dataService interfaces.DataService
+}
+
+// NewSyncServer создает новый экземпляр SyncServer.
+func NewSyncServer(dataService interfaces.DataService) *SyncServer {
+	return &SyncServer{
+		dataService: dataService,
+	}
+}
+
+// Sync передает потоком элементы, измененные после last_sync. Элементы читаются из
+// хранилища страницами по page_size, пока не будет получена последняя страница.
+func (s *SyncServer) Sync(req *pb.SyncRequest, stream grpc.ServerStreamingServer[pb.SyncResponse]) error {
+	ctx := stream.Context()
+	user := ctx.Value(middleware.UserKey).(*models.User)
+
+	if req.LastSync == nil {
+		return apperrors.NewBadRequest("last_sync is required", nil)
+	}
+	if err := req.LastSync.CheckValid(); err != nil {
+		return apperrors.NewBadRequest("Invalid last_sync format", err)
+	}
+	if req.GetPageSize() < 0 {
+		return apperrors.NewBadRequest("page_size must not be negative", nil)
+	}
+
+	page := models.PageRequest{Limit: int(req.GetPageSize())}
+	for {
+		result, err := s.dataService.SyncData(ctx, user.ID, req.LastSync.AsTime(), page)
+		if err != nil {
+			return err
+		}
+
+		for _, item := range result.Items {
+			if err := stream.Send(&pb.SyncResponse{Item: toDataItemSummary(item)}); err != nil {
+				return err
+			}
+		}
+
+		if result.NextCursor == "" {
+			return nil
+		}
+		page.Cursor = result.NextCursor
+	}
+}
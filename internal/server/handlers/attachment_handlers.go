@@ -0,0 +1,150 @@
+package handlers
+
+import (
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// ChecksumHeader содержит SHA-256 содержимого скачиваемого вложения в hex.
+const ChecksumHeader = "X-Checksum-SHA256"
+
+// AttachmentHandler обрабатывает HTTP запросы для работы с вложениями.
+type AttachmentHandler struct {
+	attachmentService interfaces.AttachmentService
+}
+
+// NewAttachmentHandler создает новый экземпляр AttachmentHandler.
+func NewAttachmentHandler(attachmentService interfaces.AttachmentService) *AttachmentHandler {
+	return &AttachmentHandler{
+		attachmentService: attachmentService,
+	}
+}
+
+// GetAttachments обрабатывает запрос на получение списка вложений элемента данных.
+func (h *AttachmentHandler) GetAttachments(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	attachments, err := h.attachmentService.GetAttachments(r.Context(), user.ID, dataID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if attachments == nil {
+		attachments = []*models.Attachment{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(attachments)
+}
+
+// UploadAttachment обрабатывает загрузку вложения. Тело запроса содержит файл целиком,
+// имя файла передается параметром filename, тип содержимого — заголовком Content-Type.
+func (h *AttachmentHandler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	var mimeType string
+	if contentType := r.Header.Get("Content-Type"); contentType != "" {
+		mediaType, _, err := mime.ParseMediaType(contentType)
+		if err != nil {
+			writeError(w, r, apperrors.NewBadRequest("Invalid Content-Type", nil))
+			return
+		}
+		if mediaType != "application/octet-stream" {
+			mimeType = mediaType
+		}
+	}
+
+	attachment, err := h.attachmentService.AddAttachment(r.Context(), user.ID, dataID, r.URL.Query().Get("filename"), mimeType, r.Body)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(attachment)
+}
+
+// DownloadAttachment обрабатывает скачивание вложения. Содержимое передается потоком в теле
+// ответа как есть, контрольная сумма — заголовком X-Checksum-SHA256.
+func (h *AttachmentHandler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, attachmentID, ok := parseAttachmentVars(w, r)
+	if !ok {
+		return
+	}
+
+	attachment, content, err := h.attachmentService.GetAttachment(r.Context(), user.ID, dataID, attachmentID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", attachment.MimeType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set(ChecksumHeader, attachment.Checksum)
+	// При ошибке чтения ответ обрывается раньше объявленного Content-Length, и клиент
+	// получает ошибку вместо усеченного файла.
+	_, _ = io.Copy(w, content)
+}
+
+// DeleteAttachment обрабатывает запрос на удаление вложения.
+func (h *AttachmentHandler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, attachmentID, ok := parseAttachmentVars(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.attachmentService.DeleteAttachment(r.Context(), user.ID, dataID, attachmentID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// parseAttachmentVars разбирает ID элемента данных и ID вложения из пути запроса.
+func parseAttachmentVars(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	vars := mux.Vars(r)
+
+	dataID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	attachmentID, err := uuid.Parse(vars["attachment"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid attachment ID", nil))
+		return uuid.Nil, uuid.Nil, false
+	}
+
+	return dataID, attachmentID, true
+}
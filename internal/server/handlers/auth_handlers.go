@@ -6,7 +6,10 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
 	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
 )
 
 // AuthHandler обрабатывает HTTP запросы для аутентификации.
@@ -46,32 +49,34 @@ type AuthResponse struct {
 	ExpiresAt    time.Time   `json:"expires_at"`
 }
 
-type ErrorResponse struct {
-	Error string `json:"error"`
+// ChangePasswordRequest содержит данные для смены пароля.
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
 }
 
 // Register обрабатывает запрос на регистрацию пользователя.
 func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 	var req RegisterRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
 		return
 	}
 
 	if req.Email == "" || req.Password == "" {
-		http.Error(w, "Email and password are required", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Email and password are required", nil))
 		return
 	}
 
 	user, err := h.authService.Register(r.Context(), req.Email, req.Password)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusConflict)
+		writeError(w, r, err)
 		return
 	}
 
 	_, accessToken, refreshToken, err := h.authService.Login(r.Context(), req.Email, req.Password)
 	if err != nil {
-		http.Error(w, "Failed to generate token", http.StatusInternalServerError)
+		writeError(w, r, apperrors.NewInternalServerError("Failed to generate token", err))
 		return
 	}
 
@@ -90,18 +95,18 @@ func (h *AuthHandler) Register(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 	var req LoginRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
 		return
 	}
 
 	if req.Email == "" || req.Password == "" {
-		http.Error(w, "Email and password are required", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Email and password are required", nil))
 		return
 	}
 
 	user, accessToken, refreshToken, err := h.authService.Login(r.Context(), req.Email, req.Password)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
+		writeError(w, r, err)
 		return
 	}
 
@@ -120,18 +125,18 @@ func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 	var req RefreshRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
 		return
 	}
 
 	if req.RefreshToken == "" {
-		http.Error(w, "Refresh token is required", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Refresh token is required", nil))
 		return
 	}
 
 	accessToken, refreshToken, err := h.authService.RefreshToken(r.Context(), req.RefreshToken)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusUnauthorized)
+		writeError(w, r, err)
 		return
 	}
 
@@ -149,14 +154,43 @@ func (h *AuthHandler) Refresh(w http.ResponseWriter, r *http.Request) {
 func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
 	var req RefreshRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
 		return
 	}
 
 	if err := h.authService.Logout(r.Context(), req.RefreshToken); err != nil {
-		http.Error(w, err.Error(), http.StatusBadRequest)
+		writeError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
 }
+
+// ChangePassword обрабатывает запрос на смену пароля аутентифицированного пользователя.
+func (h *AuthHandler) ChangePassword(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	if req.CurrentPassword == "" || req.NewPassword == "" {
+		writeError(w, r, apperrors.NewBadRequest("Current and new passwords are required", nil))
+		return
+	}
+
+	if err := h.authService.ChangePassword(r.Context(), user.ID, req.CurrentPassword, req.NewPassword); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// writeError отправляет ошибку в едином JSON формате с машиночитаемым кодом и
+// идентификатором запроса. Статус определяется типом ошибки.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	middleware.WriteError(w, r, err)
+}
@@ -12,7 +12,9 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
 	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
 )
 
 // MockAuthService для тестирования handlers
@@ -89,6 +91,18 @@ func (mr *MockAuthServiceMockRecorder) Logout(ctx, refreshToken interface{}) *go
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logout", reflect.TypeOf((*MockAuthService)(nil).Logout), ctx, refreshToken)
 }
 
+func (m *MockAuthService) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangePassword", ctx, userID, currentPassword, newPassword)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+func (mr *MockAuthServiceMockRecorder) ChangePassword(ctx, userID, currentPassword, newPassword interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangePassword", reflect.TypeOf((*MockAuthService)(nil).ChangePassword), ctx, userID, currentPassword, newPassword)
+}
+
 func (m *MockAuthService) ValidateToken(ctx context.Context, token string) (*models.User, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ValidateToken", ctx, token)
@@ -170,7 +184,7 @@ func TestAuthHandler_Register(t *testing.T) {
 
 		mockAuthService.EXPECT().
 			Register(gomock.Any(), "test@example.com", "password123").
-			Return(nil, assert.AnError)
+			Return(nil, apperrors.NewConflict("user with email test@example.com already exists", nil))
 
 		reqBody := RegisterRequest{
 			Email:    "test@example.com",
@@ -185,7 +199,48 @@ func TestAuthHandler_Register(t *testing.T) {
 		handler.Register(w, req)
 
 		assert.Equal(t, http.StatusConflict, w.Code)
-		assert.Contains(t, w.Body.String(), "assert.AnError")
+		body := decodeErrorBody(t, w)
+		assert.Equal(t, apperrors.CodeConflict, body.Code)
+		assert.Equal(t, "user with email test@example.com already exists", body.Message)
+	})
+
+	t.Run("password policy violation", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockAuthService := NewMockAuthService(ctrl)
+		handler := NewAuthHandler(mockAuthService)
+
+		policyErr := &validator.PasswordPolicyError{
+			ValidationError: validator.ValidationError{Field: "password", Message: "password is too weak"},
+			Feedback:        validator.PasswordFeedback{Score: 0, MinScore: 2, Warning: "This is a top-10 common password"},
+		}
+
+		mockAuthService.EXPECT().
+			Register(gomock.Any(), "test@example.com", "password").
+			Return(nil, policyErr)
+
+		reqBody := RegisterRequest{
+			Email:    "test@example.com",
+			Password: "password",
+		}
+
+		jsonBody, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/auth/register", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		handler.Register(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		body := decodeErrorBody(t, w)
+		assert.Equal(t, apperrors.CodeValidationFailed, body.Code)
+		assert.Equal(t, "password is too weak", body.Message)
+		assert.Equal(t, "password", body.Field)
+		feedback, ok := body.Details["feedback"].(map[string]interface{})
+		assert.True(t, ok)
+		assert.Equal(t, "This is a top-10 common password", feedback["warning"])
 	})
 }
 
@@ -237,7 +292,7 @@ func TestAuthHandler_Login(t *testing.T) {
 
 		mockAuthService.EXPECT().
 			Login(gomock.Any(), "test@example.com", "wrongpassword").
-			Return(nil, "", "", assert.AnError)
+			Return(nil, "", "", apperrors.NewUnauthorized("invalid credentials", nil))
 
 		reqBody := LoginRequest{
 			Email:    "test@example.com",
@@ -252,7 +307,7 @@ func TestAuthHandler_Login(t *testing.T) {
 		handler.Login(w, req)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
-		assert.Contains(t, w.Body.String(), "assert.AnError")
+		assert.Equal(t, apperrors.CodeUnauthorized, decodeErrorBody(t, w).Code)
 	})
 }
 
@@ -297,7 +352,7 @@ func TestAuthHandler_Refresh(t *testing.T) {
 
 		mockAuthService.EXPECT().
 			RefreshToken(gomock.Any(), "invalid-token").
-			Return("", "", assert.AnError)
+			Return("", "", apperrors.NewUnauthorized("invalid refresh token", nil))
 
 		reqBody := RefreshRequest{
 			RefreshToken: "invalid-token",
@@ -311,7 +366,7 @@ func TestAuthHandler_Refresh(t *testing.T) {
 		handler.Refresh(w, req)
 
 		assert.Equal(t, http.StatusUnauthorized, w.Code)
-		assert.Contains(t, w.Body.String(), "assert.AnError")
+		assert.Equal(t, "invalid refresh token", decodeErrorBody(t, w).Message)
 	})
 }
 
@@ -350,7 +405,7 @@ func TestAuthHandler_Logout(t *testing.T) {
 
 		mockAuthService.EXPECT().
 			Logout(gomock.Any(), "invalid-token").
-			Return(assert.AnError)
+			Return(apperrors.NewBadRequest("invalid refresh token", nil))
 
 		reqBody := RefreshRequest{
 			RefreshToken: "invalid-token",
@@ -364,6 +419,17 @@ func TestAuthHandler_Logout(t *testing.T) {
 		handler.Logout(w, req)
 
 		assert.Equal(t, http.StatusBadRequest, w.Code)
-		assert.Contains(t, w.Body.String(), "assert.AnError")
+		assert.Equal(t, apperrors.CodeBadRequest, decodeErrorBody(t, w).Code)
 	})
 }
+
+// decodeErrorBody разбирает ответ с ошибкой в едином формате API.
+func decodeErrorBody(t *testing.T, w *httptest.ResponseRecorder) apperrors.ErrorBody {
+	t.Helper()
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var response apperrors.ErrorResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, w.Code, response.Error.Status)
+	return response.Error
+}
@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// BatchHandler обрабатывает HTTP запросы для пакетного выполнения операций с данными.
+type BatchHandler struct {
+	batchService interfaces.BatchService
+}
+
+// NewBatchHandler создает новый экземпляр BatchHandler.
+func NewBatchHandler(batchService interfaces.BatchService) *BatchHandler {
+	return &BatchHandler{
+		batchService: batchService,
+	}
+}
+
+// BatchOperationResponse содержит результат одной операции пакета: HTTP статус, который
+// вернул бы одиночный запрос, и элемент (без содержимого) или описание ошибки в том же
+// виде, что и в ответе на одиночный запрос.
+type BatchOperationResponse struct {
+	Index  int                  `json:"index"`
+	Status int                  `json:"status"`
+	Item   *DataResponse        `json:"item,omitempty"`
+	Error  *apperrors.ErrorBody `json:"error,omitempty"`
+}
+
+// BatchResponse содержит результаты операций пакета в порядке операций.
+type BatchResponse struct {
+	Committed bool                     `json:"committed"`
+	Results   []BatchOperationResponse `json:"results"`
+}
+
+// ExecuteBatch обрабатывает запрос на пакетное выполнение операций create, update и
+// delete. Выполненный пакет возвращается со статусом 200 и результатами операций, даже
+// если атомарный пакет был отменен: это видно по committed и статусам операций.
+func (h *BatchHandler) ExecuteBatch(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	var batch models.DataBatch
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, constants.MaxBatchRequestSize)).Decode(&batch); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			writeError(w, r, apperrors.NewRequestEntityTooLarge(fmt.Sprintf("Batch must not exceed %d bytes", constants.MaxBatchRequestSize), err))
+			return
+		}
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	result, err := h.batchService.ExecuteBatch(r.Context(), user.ID, batch)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := BatchResponse{
+		Committed: result.Committed,
+		Results:   make([]BatchOperationResponse, 0, len(result.Results)),
+	}
+	for idx, op := range result.Results {
+		opResponse := BatchOperationResponse{Index: idx, Status: http.StatusOK}
+		switch {
+		case op.Err != nil:
+			body := middleware.NewErrorBody(op.Err)
+			opResponse.Status = body.Status
+			opResponse.Error = &body
+		case op.Item != nil:
+			op.Item.Data = nil
+			item := newDataResponse(op.Item)
+			opResponse.Item = &item
+		default:
+			opResponse.Status = http.StatusNoContent
+		}
+		response.Results = append(response.Results, opResponse)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -0,0 +1,163 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+// MockBatchService для тестирования handlers
+type MockBatchService struct {
+	ctrl     *gomock.Controller
+	recorder *MockBatchServiceMockRecorder
+}
+
+type MockBatchServiceMockRecorder struct {
+	mock *MockBatchService
+}
+
+func NewMockBatchService(ctrl *gomock.Controller) *MockBatchService {
+	mock := &MockBatchService{ctrl: ctrl}
+	mock.recorder = &MockBatchServiceMockRecorder{mock}
+	return mock
+}
+
+func (m *MockBatchService) EXPECT() *MockBatchServiceMockRecorder {
+	return m.recorder
+}
+
+func (m *MockBatchService) ExecuteBatch(ctx context.Context, userID uuid.UUID, batch models.DataBatch) (*models.BatchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteBatch", ctx, userID, batch)
+	ret0, _ := ret[0].(*models.BatchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockBatchServiceMockRecorder) ExecuteBatch(ctx, userID, batch interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteBatch", reflect.TypeOf((*MockBatchService)(nil).ExecuteBatch), ctx, userID, batch)
+}
+
+func TestBatchHandler_ExecuteBatch(t *testing.T) {
+	t.Run("per-operation statuses", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBatchService := NewMockBatchService(ctrl)
+		handler := NewBatchHandler(mockBatchService)
+
+		userID := uuid.New()
+		deletedID := uuid.New()
+		batch := models.DataBatch{
+			Mode: models.BatchIndependent,
+			Operations: []models.BatchOperation{
+				{Op: models.BatchCreate, Type: models.TextData, Name: "note", Data: json.RawMessage(`{"text":"hi"}`)},
+				{Op: models.BatchCreate, Type: models.TextData, Name: "broken", Data: json.RawMessage(`{}`)},
+				{Op: models.BatchDelete, ID: deletedID},
+				{Op: models.BatchDelete, ID: uuid.New()},
+				{Op: models.BatchUpdate, ID: uuid.New(), Name: "stale", Data: json.RawMessage(`{"text":"hi"}`)},
+			},
+		}
+		created := &models.DataItem{ID: uuid.New(), UserID: userID, Type: models.TextData, Name: "note", Data: []byte(`{"text":"hi"}`)}
+
+		mockBatchService.EXPECT().ExecuteBatch(gomock.Any(), userID, batch).Return(&models.BatchResult{
+			Committed: true,
+			Results: []models.BatchOperationResult{
+				{Item: created},
+				{Err: &validator.ValidationError{Field: "data.text", Message: "text is required"}},
+				{},
+				{Err: apperrors.NewNotFound("data item not found", nil)},
+				{Err: &apperrors.VersionConflictError{Expected: 1, Current: 2}},
+			},
+		}, nil)
+
+		body, err := json.Marshal(batch)
+		require.NoError(t, err)
+		req := httptest.NewRequest("POST", "/data/batch", bytes.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: userID}))
+		w := httptest.NewRecorder()
+
+		handler.ExecuteBatch(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response BatchResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.True(t, response.Committed)
+		require.Len(t, response.Results, 5)
+
+		assert.Equal(t, http.StatusOK, response.Results[0].Status)
+		require.NotNil(t, response.Results[0].Item)
+		assert.Equal(t, created.ID.String(), response.Results[0].Item.ID)
+		assert.JSONEq(t, "{}", string(response.Results[0].Item.Data))
+
+		assert.Equal(t, http.StatusBadRequest, response.Results[1].Status)
+		require.NotNil(t, response.Results[1].Error)
+		assert.Equal(t, apperrors.CodeValidationFailed, response.Results[1].Error.Code)
+		assert.Equal(t, "data.text", response.Results[1].Error.Field)
+
+		assert.Equal(t, http.StatusNoContent, response.Results[2].Status)
+		assert.Equal(t, 2, response.Results[2].Index)
+
+		assert.Equal(t, http.StatusNotFound, response.Results[3].Status)
+		require.NotNil(t, response.Results[3].Error)
+		assert.Equal(t, apperrors.CodeNotFound, response.Results[3].Error.Code)
+		assert.Equal(t, "data item not found", response.Results[3].Error.Message)
+
+		assert.Equal(t, http.StatusConflict, response.Results[4].Status)
+		require.NotNil(t, response.Results[4].Error)
+		assert.Equal(t, apperrors.CodeVersionConflict, response.Results[4].Error.Code)
+		assert.Equal(t, float64(2), response.Results[4].Error.Details["current_version"])
+	})
+
+	t.Run("invalid batch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockBatchService := NewMockBatchService(ctrl)
+		handler := NewBatchHandler(mockBatchService)
+
+		mockBatchService.EXPECT().ExecuteBatch(gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, &validator.ValidationError{Field: "operations", Message: "at least one operation is required"})
+
+		req := httptest.NewRequest("POST", "/data/batch", strings.NewReader(`{"operations":[]}`))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: uuid.New()}))
+		w := httptest.NewRecorder()
+
+		handler.ExecuteBatch(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("request too large", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := NewBatchHandler(NewMockBatchService(ctrl))
+
+		body := `{"operations":[{"op":"create","name":"` + strings.Repeat("a", constants.MaxBatchRequestSize) + `"}]}`
+		req := httptest.NewRequest("POST", "/data/batch", strings.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: uuid.New()}))
+		w := httptest.NewRecorder()
+
+		handler.ExecuteBatch(w, req)
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+}
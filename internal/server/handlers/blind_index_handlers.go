@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// BlindIndexKeyResponse содержит ключ слепого индекса пользователя в base64.
+type BlindIndexKeyResponse struct {
+	Key string `json:"key"`
+}
+
+// RebuildBlindIndexResponse содержит количество проиндексированных элементов.
+type RebuildBlindIndexResponse struct {
+	Indexed int `json:"indexed"`
+}
+
+// BlindSearchData обрабатывает запрос на поиск элементов данных по слепым индексам:
+// field (name или field:<имя>), mode (exact, token или ngram) и q либо вычисленные
+// клиентом токены token в hex, фильтры type, folder, recursive и tag и параметры страницы.
+func (h *DataHandler) BlindSearchData(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	query := r.URL.Query()
+
+	tagFilter, err := parseTagFilter(query)
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest(err.Error(), err))
+		return
+	}
+
+	page, err := parsePageRequest(query)
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest(err.Error(), err))
+		return
+	}
+
+	search := models.BlindSearch{
+		Field: query.Get("field"),
+		Mode:  models.BlindIndexMode(query.Get("mode")),
+		Query: query.Get("q"),
+		Filter: models.DataFilter{
+			Type:      models.DataType(query.Get("type")),
+			Folder:    query.Get("folder"),
+			Recursive: query.Get("recursive") == "true",
+			Tags:      tagFilter,
+		},
+	}
+	for _, value := range query["token"] {
+		token, err := hex.DecodeString(value)
+		if err != nil {
+			writeError(w, r, apperrors.NewBadRequest(fmt.Sprintf("invalid token %q: expected hex", value), nil))
+			return
+		}
+		search.Tokens = append(search.Tokens, token)
+	}
+
+	result, err := h.dataService.BlindSearchData(r.Context(), user.ID, search, page)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newDataListResponse(result))
+}
+
+// GetBlindIndexKey обрабатывает запрос на получение ключа слепого индекса пользователя.
+func (h *DataHandler) GetBlindIndexKey(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	key, err := h.dataService.GetBlindIndexKey(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	_ = json.NewEncoder(w).Encode(BlindIndexKeyResponse{Key: base64.StdEncoding.EncodeToString(key)})
+}
+
+// RebuildBlindIndex обрабатывает запрос на перестроение слепых индексов элементов пользователя.
+func (h *DataHandler) RebuildBlindIndex(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	indexed, err := h.dataService.RebuildBlindIndex(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(RebuildBlindIndexResponse{Indexed: indexed})
+}
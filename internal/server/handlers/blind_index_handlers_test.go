@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+func TestDataHandler_BlindSearchData(t *testing.T) {
+	t.Run("query", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		userID := uuid.New()
+		expectedSearch := models.BlindSearch{
+			Field:  "field:owner",
+			Mode:   models.BlindIndexToken,
+			Query:  "alice",
+			Filter: models.DataFilter{Type: models.LoginPassword},
+		}
+		item := &models.DataItem{ID: uuid.New(), UserID: userID, Type: models.LoginPassword, Name: "prod-db"}
+
+		mockDataService.EXPECT().
+			BlindSearchData(gomock.Any(), userID, expectedSearch, models.PageRequest{}).
+			Return(&models.DataPage{Items: []*models.DataItem{item}}, nil)
+
+		req := httptest.NewRequest("GET", "/data/blind-search?field=field:owner&mode=token&q=alice&type=login_password", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: userID}))
+		w := httptest.NewRecorder()
+
+		handler.BlindSearchData(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response DataListResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Items, 1)
+		assert.Equal(t, "prod-db", response.Items[0].Name)
+	})
+
+	t.Run("client tokens", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		userID := uuid.New()
+		first, second := bytes.Repeat([]byte{1}, 16), bytes.Repeat([]byte{2}, 16)
+
+		mockDataService.EXPECT().
+			BlindSearchData(gomock.Any(), userID, models.BlindSearch{
+				Tokens: [][]byte{first, second},
+			}, models.PageRequest{}).
+			Return(&models.DataPage{}, nil)
+
+		req := httptest.NewRequest("GET", "/data/blind-search?token="+hex.EncodeToString(first)+"&token="+hex.EncodeToString(second), nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: userID}))
+		w := httptest.NewRecorder()
+
+		handler.BlindSearchData(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("invalid token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := NewDataHandler(NewMockDataService(ctrl))
+
+		req := httptest.NewRequest("GET", "/data/blind-search?token=zz", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: uuid.New()}))
+		w := httptest.NewRecorder()
+
+		handler.BlindSearchData(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("not configured", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		mockDataService.EXPECT().
+			BlindSearchData(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+			Return(nil, apperrors.NewNotImplemented("blind index is not configured on the server", nil))
+
+		req := httptest.NewRequest("GET", "/data/blind-search?field=name&q=prod", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: uuid.New()}))
+		w := httptest.NewRecorder()
+
+		handler.BlindSearchData(w, req)
+
+		assert.Equal(t, http.StatusNotImplemented, w.Code)
+	})
+}
+
+func TestDataHandler_GetBlindIndexKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataService := NewMockDataService(ctrl)
+	handler := NewDataHandler(mockDataService)
+
+	userID := uuid.New()
+	key := bytes.Repeat([]byte{7}, 32)
+	mockDataService.EXPECT().GetBlindIndexKey(gomock.Any(), userID).Return(key, nil)
+
+	req := httptest.NewRequest("GET", "/data/blind-index/key", nil)
+	req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: userID}))
+	w := httptest.NewRecorder()
+
+	handler.GetBlindIndexKey(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+
+	var response BlindIndexKeyResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, base64.StdEncoding.EncodeToString(key), response.Key)
+}
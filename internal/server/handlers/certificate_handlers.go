@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// CertificateHandler обрабатывает HTTP запросы для контроля сроков действия сертификатов.
+type CertificateHandler struct {
+	certificateService interfaces.CertificateService
+}
+
+// NewCertificateHandler создает новый экземпляр CertificateHandler.
+func NewCertificateHandler(certificateService interfaces.CertificateService) *CertificateHandler {
+	return &CertificateHandler{
+		certificateService: certificateService,
+	}
+}
+
+// GetExpiring обрабатывает запрос на получение сертификатов, истекающих в ближайшие days дней
+// (по умолчанию constants.DefaultCertificateExpiryDays).
+func (h *CertificateHandler) GetExpiring(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	days := constants.DefaultCertificateExpiryDays
+	if value := r.URL.Query().Get("days"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			writeError(w, r, apperrors.NewBadRequest("Invalid days parameter", nil))
+			return
+		}
+		days = parsed
+	}
+
+	expiring, err := h.certificateService.GetExpiring(r.Context(), user.ID, days)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if expiring == nil {
+		expiring = []*models.CertificateStatus{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(expiring)
+}
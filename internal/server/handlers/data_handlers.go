@@ -2,12 +2,17 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
 	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
 	"github.com/tempizhere/vaultfactory/internal/shared/models"
 )
@@ -24,29 +29,67 @@ func NewDataHandler(dataService interfaces.DataService) *DataHandler {
 	}
 }
 
-// CreateDataRequest содержит данные для создания элемента данных.
+// CreateDataRequest содержит данные для создания элемента данных. ExpiresAt и MaxReads
+// необязательны и делают элемент самоуничтожающимся.
 type CreateDataRequest struct {
-	Type     models.DataType `json:"type"`
-	Name     string          `json:"name"`
-	Metadata string          `json:"metadata"`
-	Data     json.RawMessage `json:"data"`
+	Type      models.DataType `json:"type"`
+	Name      string          `json:"name"`
+	Metadata  string          `json:"metadata"`
+	Data      json.RawMessage `json:"data"`
+	ExpiresAt *time.Time      `json:"expires_at,omitempty"`
+	MaxReads  *int            `json:"max_reads,omitempty"`
 }
 
+// UpdateDataRequest содержит новые имя, метаданные и содержимое элемента данных.
+// Version содержит ожидаемую версию элемента и может передаваться вместо заголовка If-Match.
 type UpdateDataRequest struct {
 	Name     string          `json:"name"`
 	Metadata string          `json:"metadata"`
 	Data     json.RawMessage `json:"data"`
+	Version  *int64          `json:"version,omitempty"`
+}
+
+// MoveDataRequest содержит папку (ID или путь), в которую перемещается элемент данных.
+// Пустое значение перемещает элемент в корень.
+type MoveDataRequest struct {
+	Folder string `json:"folder"`
+}
+
+// SetExpiryRequest содержит условия самоуничтожения элемента данных.
+// Отсутствующие поля снимают соответствующее ограничение.
+type SetExpiryRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	MaxReads  *int       `json:"max_reads,omitempty"`
 }
 
 type DataResponse struct {
 	ID        string          `json:"id"`
+	FolderID  string          `json:"folder_id,omitempty"`
 	Type      models.DataType `json:"type"`
 	Name      string          `json:"name"`
 	Metadata  string          `json:"metadata"`
-	Data      json.RawMessage `json:"data"`
+	Data      json.RawMessage `json:"data,omitempty"`
 	CreatedAt string          `json:"created_at"`
 	UpdatedAt string          `json:"updated_at"`
 	Version   int64           `json:"version"`
+	Tags      []string        `json:"tags,omitempty"`
+	DeletedAt string          `json:"deleted_at,omitempty"`
+	ExpiresAt string          `json:"expires_at,omitempty"`
+	MaxReads  *int            `json:"max_reads,omitempty"`
+	ReadCount int             `json:"read_count,omitempty"`
+
+	PasswordChangedAt string `json:"password_changed_at,omitempty"`
+	RotationDays      *int   `json:"rotation_days,omitempty"`
+
+	Certificate *models.CertificateInfo `json:"certificate,omitempty"`
+	Highlight   *models.SearchHighlight `json:"highlight,omitempty"`
+}
+
+// DataListResponse представляет страницу элементов данных. NextCursor передается
+// в параметре cursor для получения следующей страницы; на последней странице он пуст.
+type DataListResponse struct {
+	Items      []DataResponse `json:"items"`
+	NextCursor string         `json:"next_cursor,omitempty"`
 }
 
 // CreateData обрабатывает запрос на создание элемента данных.
@@ -55,32 +98,27 @@ func (h *DataHandler) CreateData(w http.ResponseWriter, r *http.Request) {
 
 	var req CreateDataRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
 		return
 	}
 
 	if req.Type == "" || req.Name == "" {
-		http.Error(w, "Type and name are required", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Type and name are required", nil))
 		return
 	}
 
-	dataItem, err := h.dataService.CreateData(r.Context(), user.ID, req.Type, req.Name, req.Metadata, []byte(req.Data))
+	dataItem, err := h.dataService.CreateData(r.Context(), user.ID, req.Type, req.Name, req.Metadata, []byte(req.Data), models.DataExpiry{
+		ExpiresAt: req.ExpiresAt,
+		MaxReads:  req.MaxReads,
+	})
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
-	response := DataResponse{
-		ID:        dataItem.ID.String(),
-		Type:      dataItem.Type,
-		Name:      dataItem.Name,
-		Metadata:  dataItem.Metadata,
-		Data:      req.Data,
-		CreatedAt: dataItem.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: dataItem.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-		Version:   dataItem.Version,
-	}
+	response := newDataResponse(dataItem)
 
+	setETag(w, dataItem.Version)
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -93,27 +131,19 @@ func (h *DataHandler) GetData(w http.ResponseWriter, r *http.Request) {
 
 	dataIDUUID, err := uuid.Parse(dataID)
 	if err != nil {
-		http.Error(w, "Invalid data ID", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
 		return
 	}
 
 	dataItem, err := h.dataService.GetData(r.Context(), user.ID, dataIDUUID)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusNotFound)
+		writeError(w, r, err)
 		return
 	}
 
-	response := DataResponse{
-		ID:        dataItem.ID.String(),
-		Type:      dataItem.Type,
-		Name:      dataItem.Name,
-		Metadata:  dataItem.Metadata,
-		Data:      json.RawMessage("{}"),
-		CreatedAt: dataItem.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: dataItem.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-		Version:   dataItem.Version,
-	}
+	response := newDataResponse(dataItem)
 
+	setETag(w, dataItem.Version)
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
 }
@@ -122,41 +152,37 @@ func (h *DataHandler) GetData(w http.ResponseWriter, r *http.Request) {
 func (h *DataHandler) GetUserData(w http.ResponseWriter, r *http.Request) {
 	user := r.Context().Value(middleware.UserKey).(*models.User)
 
-	dataType := r.URL.Query().Get("type")
-
-	var items []*models.DataItem
-	var err error
+	query := r.URL.Query()
 
-	if dataType != "" {
-		items, err = h.dataService.GetUserDataByType(r.Context(), user.ID, models.DataType(dataType))
-	} else {
-		items, err = h.dataService.GetUserData(r.Context(), user.ID)
+	tagFilter, err := parseTagFilter(query)
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest(err.Error(), err))
+		return
 	}
 
+	page, err := parsePageRequest(query)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, apperrors.NewBadRequest(err.Error(), err))
 		return
 	}
 
-	var responses []DataResponse
-	for _, item := range items {
-		response := DataResponse{
-			ID:        item.ID.String(),
-			Type:      item.Type,
-			Name:      item.Name,
-			Metadata:  item.Metadata,
-			CreatedAt: item.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			UpdatedAt: item.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-			Version:   item.Version,
-		}
-		responses = append(responses, response)
+	result, err := h.dataService.GetUserData(r.Context(), user.ID, models.DataFilter{
+		Type:      models.DataType(query.Get("type")),
+		Folder:    query.Get("folder"),
+		Recursive: query.Get("recursive") == "true",
+		Tags:      tagFilter,
+	}, page)
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(responses)
+	_ = json.NewEncoder(w).Encode(newDataListResponse(result))
 }
 
-// UpdateData обрабатывает запрос на обновление элемента данных.
+// UpdateData обрабатывает запрос на обновление элемента данных. Ожидаемая версия
+// элемента обязательна и передается заголовком If-Match или полем version.
 func (h *DataHandler) UpdateData(w http.ResponseWriter, r *http.Request) {
 	user := r.Context().Value(middleware.UserKey).(*models.User)
 	vars := mux.Vars(r)
@@ -164,38 +190,94 @@ func (h *DataHandler) UpdateData(w http.ResponseWriter, r *http.Request) {
 
 	var req UpdateDataRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
-		http.Error(w, "Invalid request body", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
 		return
 	}
 
 	dataIDUUID, err := uuid.Parse(dataID)
 	if err != nil {
-		http.Error(w, "Invalid data ID", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
 		return
 	}
 
-	dataItem, err := h.dataService.UpdateData(r.Context(), user.ID, dataIDUUID, req.Name, req.Metadata, []byte(req.Data))
+	version, err := expectedVersion(r, req.Version)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
-	response := DataResponse{
-		ID:        dataItem.ID.String(),
-		Type:      dataItem.Type,
-		Name:      dataItem.Name,
-		Metadata:  dataItem.Metadata,
-		Data:      req.Data,
-		CreatedAt: dataItem.CreatedAt.Format("2006-01-02T15:04:05Z"),
-		UpdatedAt: dataItem.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-		Version:   dataItem.Version,
+	dataItem, err := h.dataService.UpdateData(r.Context(), user.ID, dataIDUUID, version, req.Name, req.Metadata, []byte(req.Data))
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
 
+	response := newDataResponse(dataItem)
+
+	setETag(w, dataItem.Version)
 	w.Header().Set("Content-Type", "application/json")
 	_ = json.NewEncoder(w).Encode(response)
 }
 
-// DeleteData обрабатывает запрос на удаление элемента данных.
+// MoveData обрабатывает запрос на перемещение элемента данных в папку.
+func (h *DataHandler) MoveData(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+	vars := mux.Vars(r)
+
+	dataIDUUID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	var req MoveDataRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	dataItem, err := h.dataService.MoveData(r.Context(), user.ID, dataIDUUID, req.Folder)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newDataResponse(dataItem))
+}
+
+// SetExpiry обрабатывает запрос на изменение условий самоуничтожения элемента данных.
+func (h *DataHandler) SetExpiry(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+	vars := mux.Vars(r)
+
+	dataIDUUID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	var req SetExpiryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	dataItem, err := h.dataService.SetExpiry(r.Context(), user.ID, dataIDUUID, models.DataExpiry{
+		ExpiresAt: req.ExpiresAt,
+		MaxReads:  req.MaxReads,
+	})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newDataResponse(dataItem))
+}
+
+// DeleteData обрабатывает запрос на удаление элемента данных. Ожидаемая версия элемента
+// обязательна и передается заголовком If-Match.
 func (h *DataHandler) DeleteData(w http.ResponseWriter, r *http.Request) {
 	user := r.Context().Value(middleware.UserKey).(*models.User)
 	vars := mux.Vars(r)
@@ -203,54 +285,243 @@ func (h *DataHandler) DeleteData(w http.ResponseWriter, r *http.Request) {
 
 	dataIDUUID, err := uuid.Parse(dataID)
 	if err != nil {
-		http.Error(w, "Invalid data ID", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	version, err := expectedVersion(r, nil)
+	if err != nil {
+		writeError(w, r, err)
 		return
 	}
 
-	if err := h.dataService.DeleteData(r.Context(), user.ID, dataIDUUID); err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+	if err := h.dataService.DeleteData(r.Context(), user.ID, dataIDUUID, version); err != nil {
+		writeError(w, r, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// expectedVersion возвращает ожидаемую версию элемента из заголовка If-Match (ETag вида
+// "3" или * для изменения без сравнения) или из поля version тела запроса. Поле version
+// не может отключить проверку: для этого используется только If-Match: *.
+func expectedVersion(r *http.Request, bodyVersion *int64) (int64, error) {
+	if bodyVersion != nil && *bodyVersion <= 0 {
+		return 0, apperrors.NewBadRequest("version must be positive", nil)
+	}
+
+	ifMatch := strings.TrimSpace(r.Header.Get("If-Match"))
+	if ifMatch == "" {
+		if bodyVersion == nil {
+			return 0, apperrors.NewPreconditionRequired("expected version is required: send If-Match header or version field", nil)
+		}
+		return *bodyVersion, nil
+	}
+
+	version := models.AnyVersion
+	if ifMatch != "*" {
+		unquoted, err := strconv.Unquote(ifMatch)
+		if err != nil {
+			return 0, apperrors.NewBadRequest("If-Match must be a version ETag or *", err)
+		}
+		version, err = strconv.ParseInt(unquoted, 10, 64)
+		if err != nil || version <= 0 {
+			return 0, apperrors.NewBadRequest("If-Match must be a version ETag or *", err)
+		}
+	}
+
+	if bodyVersion != nil && *bodyVersion != version {
+		return 0, apperrors.NewBadRequest("If-Match header and version field do not match", nil)
+	}
+	return version, nil
+}
+
+// setETag передает версию элемента данных в заголовке ETag.
+func setETag(w http.ResponseWriter, version int64) {
+	w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(version, 10)))
+}
+
 // SyncData обрабатывает запрос на синхронизацию данных.
 func (h *DataHandler) SyncData(w http.ResponseWriter, r *http.Request) {
 	user := r.Context().Value(middleware.UserKey).(*models.User)
 
 	lastSyncStr := r.URL.Query().Get("last_sync")
 	if lastSyncStr == "" {
-		http.Error(w, "last_sync parameter is required", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("last_sync parameter is required", nil))
 		return
 	}
 
 	lastSync, err := time.Parse(time.RFC3339, lastSyncStr)
 	if err != nil {
-		http.Error(w, "Invalid last_sync format", http.StatusBadRequest)
+		writeError(w, r, apperrors.NewBadRequest("Invalid last_sync format", nil))
+		return
+	}
+
+	page, err := parsePageRequest(r.URL.Query())
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest(err.Error(), err))
 		return
 	}
 
-	items, err := h.dataService.SyncData(r.Context(), user.ID, lastSync)
+	result, err := h.dataService.SyncData(r.Context(), user.ID, lastSync, page)
 	if err != nil {
-		http.Error(w, err.Error(), http.StatusInternalServerError)
+		writeError(w, r, err)
 		return
 	}
 
-	var responses []DataResponse
-	for _, item := range items {
-		response := DataResponse{
-			ID:        item.ID.String(),
-			Type:      item.Type,
-			Name:      item.Name,
-			Metadata:  item.Metadata,
-			CreatedAt: item.CreatedAt.Format("2006-01-02T15:04:05Z"),
-			UpdatedAt: item.UpdatedAt.Format("2006-01-02T15:04:05Z"),
-			Version:   item.Version,
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newDataListResponse(result))
+}
+
+// SearchData обрабатывает запрос на поиск элементов данных по имени и метаданным:
+// q, match (substring или prefix), фильтры type, folder, recursive и tag, диапазоны
+// created_after/created_before и updated_after/updated_before, а также параметры страницы.
+func (h *DataHandler) SearchData(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	query := r.URL.Query()
+
+	tagFilter, err := parseTagFilter(query)
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest(err.Error(), err))
+		return
+	}
+
+	page, err := parsePageRequest(query)
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest(err.Error(), err))
+		return
+	}
+
+	search := models.DataSearch{
+		Query: query.Get("q"),
+		Match: models.SearchMatch(query.Get("match")),
+		Filter: models.DataFilter{
+			Type:      models.DataType(query.Get("type")),
+			Folder:    query.Get("folder"),
+			Recursive: query.Get("recursive") == "true",
+			Tags:      tagFilter,
+		},
+	}
+
+	for name, target := range map[string]**time.Time{
+		"created_after":  &search.CreatedAfter,
+		"created_before": &search.CreatedBefore,
+		"updated_after":  &search.UpdatedAfter,
+		"updated_before": &search.UpdatedBefore,
+	} {
+		*target, err = parseTimeParam(query, name)
+		if err != nil {
+			writeError(w, r, apperrors.NewBadRequest(err.Error(), err))
+			return
 		}
-		responses = append(responses, response)
+	}
+
+	result, err := h.dataService.SearchData(r.Context(), user.ID, search, page)
+	if err != nil {
+		writeError(w, r, err)
+		return
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	_ = json.NewEncoder(w).Encode(responses)
+	_ = json.NewEncoder(w).Encode(newDataListResponse(result))
+}
+
+// parseTimeParam разбирает необязательный параметр времени в формате RFC3339 или YYYY-MM-DD (UTC).
+func parseTimeParam(query url.Values, name string) (*time.Time, error) {
+	value := query.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+
+	for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+		if parsed, err := time.Parse(layout, value); err == nil {
+			return &parsed, nil
+		}
+	}
+
+	return nil, fmt.Errorf("invalid %s parameter", name)
+}
+
+// parsePageRequest разбирает параметры постраничной выборки limit, cursor, sort и order.
+func parsePageRequest(query url.Values) (models.PageRequest, error) {
+	page := models.PageRequest{
+		Cursor: query.Get("cursor"),
+		Sort:   models.DataSort(query.Get("sort")),
+		Order:  models.SortOrder(query.Get("order")),
+	}
+
+	if value := query.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 {
+			return page, fmt.Errorf("invalid limit parameter")
+		}
+		page.Limit = limit
+	}
+
+	return page, nil
+}
+
+// newDataListResponse формирует ответ со страницей элементов данных без содержимого.
+func newDataListResponse(page *models.DataPage) DataListResponse {
+	response := DataListResponse{
+		Items:      make([]DataResponse, 0, len(page.Items)),
+		NextCursor: page.NextCursor,
+	}
+	for _, item := range page.Items {
+		itemResponse := newDataResponse(item)
+		itemResponse.Data = nil
+		response.Items = append(response.Items, itemResponse)
+	}
+	return response
+}
+
+// newDataResponse формирует ответ с элементом данных.
+func newDataResponse(item *models.DataItem) DataResponse {
+	response := DataResponse{
+		ID:        item.ID.String(),
+		Type:      item.Type,
+		Name:      item.Name,
+		Metadata:  item.Metadata,
+		Data:      responseData(item),
+		CreatedAt: item.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: item.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+		Version:   item.Version,
+		Tags:      item.Tags,
+		MaxReads:  item.MaxReads,
+		ReadCount: item.ReadCount,
+
+		RotationDays: item.RotationDays,
+
+		Certificate: item.Certificate,
+		Highlight:   item.Highlight,
+	}
+	if item.FolderID != nil {
+		response.FolderID = item.FolderID.String()
+	}
+	if item.DeletedAt != nil {
+		response.DeletedAt = item.DeletedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if item.ExpiresAt != nil {
+		response.ExpiresAt = item.ExpiresAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	if item.PasswordChangedAt != nil {
+		response.PasswordChangedAt = item.PasswordChangedAt.UTC().Format("2006-01-02T15:04:05Z")
+	}
+	return response
+}
+
+// parseTagFilter разбирает фильтр по тегам из параметров запроса: tag=prod&tag=!expiring&tag_match=all.
+// Несколько тегов можно также перечислить через запятую.
+func parseTagFilter(query url.Values) (models.TagFilter, error) {
+	return models.ParseTagFilter(query["tag"], models.TagMatch(query.Get("tag_match")))
+}
+
+// responseData возвращает содержимое элемента для ответа или пустой объект, если оно не загружено.
+func responseData(item *models.DataItem) json.RawMessage {
+	if len(item.Data) == 0 {
+		return json.RawMessage("{}")
+	}
+	return item.Data
 }
@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,6 +17,7 @@ import (
 	"github.com/gorilla/mux"
 	"github.com/stretchr/testify/assert"
 	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
 	"github.com/tempizhere/vaultfactory/internal/shared/models"
 )
 
@@ -38,17 +41,17 @@ func (m *MockDataService) EXPECT() *MockDataServiceMockRecorder {
 	return m.recorder
 }
 
-func (m *MockDataService) CreateData(ctx context.Context, userID uuid.UUID, dataType models.DataType, name, metadata string, data []byte) (*models.DataItem, error) {
+func (m *MockDataService) CreateData(ctx context.Context, userID uuid.UUID, dataType models.DataType, name, metadata string, data []byte, expiry models.DataExpiry) (*models.DataItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "CreateData", ctx, userID, dataType, name, metadata, data)
+	ret := m.ctrl.Call(m, "CreateData", ctx, userID, dataType, name, metadata, data, expiry)
 	ret0, _ := ret[0].(*models.DataItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (mr *MockDataServiceMockRecorder) CreateData(ctx, userID, dataType, name, metadata, data interface{}) *gomock.Call {
+func (mr *MockDataServiceMockRecorder) CreateData(ctx, userID, dataType, name, metadata, data, expiry interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateData", reflect.TypeOf((*MockDataService)(nil).CreateData), ctx, userID, dataType, name, metadata, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateData", reflect.TypeOf((*MockDataService)(nil).CreateData), ctx, userID, dataType, name, metadata, data, expiry)
 }
 
 func (m *MockDataService) GetData(ctx context.Context, userID, dataID uuid.UUID) (*models.DataItem, error) {
@@ -64,68 +67,198 @@ func (mr *MockDataServiceMockRecorder) GetData(ctx, userID, dataID interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetData", reflect.TypeOf((*MockDataService)(nil).GetData), ctx, userID, dataID)
 }
 
-func (m *MockDataService) GetUserData(ctx context.Context, userID uuid.UUID) ([]*models.DataItem, error) {
+func (m *MockDataService) GetUserData(ctx context.Context, userID uuid.UUID, filter models.DataFilter, page models.PageRequest) (*models.DataPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserData", ctx, userID)
-	ret0, _ := ret[0].([]*models.DataItem)
+	ret := m.ctrl.Call(m, "GetUserData", ctx, userID, filter, page)
+	ret0, _ := ret[0].(*models.DataPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (mr *MockDataServiceMockRecorder) GetUserData(ctx, userID interface{}) *gomock.Call {
+func (mr *MockDataServiceMockRecorder) GetUserData(ctx, userID, filter, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserData", reflect.TypeOf((*MockDataService)(nil).GetUserData), ctx, userID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserData", reflect.TypeOf((*MockDataService)(nil).GetUserData), ctx, userID, filter, page)
 }
 
-func (m *MockDataService) GetUserDataByType(ctx context.Context, userID uuid.UUID, dataType models.DataType) ([]*models.DataItem, error) {
+func (m *MockDataService) SearchData(ctx context.Context, userID uuid.UUID, search models.DataSearch, page models.PageRequest) (*models.DataPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUserDataByType", ctx, userID, dataType)
-	ret0, _ := ret[0].([]*models.DataItem)
+	ret := m.ctrl.Call(m, "SearchData", ctx, userID, search, page)
+	ret0, _ := ret[0].(*models.DataPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (mr *MockDataServiceMockRecorder) GetUserDataByType(ctx, userID, dataType interface{}) *gomock.Call {
+func (mr *MockDataServiceMockRecorder) SearchData(ctx, userID, search, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserDataByType", reflect.TypeOf((*MockDataService)(nil).GetUserDataByType), ctx, userID, dataType)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchData", reflect.TypeOf((*MockDataService)(nil).SearchData), ctx, userID, search, page)
 }
 
-func (m *MockDataService) UpdateData(ctx context.Context, userID, dataID uuid.UUID, name, metadata string, data []byte) (*models.DataItem, error) {
+func (m *MockDataService) UpdateData(ctx context.Context, userID, dataID uuid.UUID, expectedVersion int64, name, metadata string, data []byte) (*models.DataItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateData", ctx, userID, dataID, name, metadata, data)
+	ret := m.ctrl.Call(m, "UpdateData", ctx, userID, dataID, expectedVersion, name, metadata, data)
 	ret0, _ := ret[0].(*models.DataItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (mr *MockDataServiceMockRecorder) UpdateData(ctx, userID, dataID, name, metadata, data interface{}) *gomock.Call {
+func (mr *MockDataServiceMockRecorder) UpdateData(ctx, userID, dataID, expectedVersion, name, metadata, data interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateData", reflect.TypeOf((*MockDataService)(nil).UpdateData), ctx, userID, dataID, name, metadata, data)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateData", reflect.TypeOf((*MockDataService)(nil).UpdateData), ctx, userID, dataID, expectedVersion, name, metadata, data)
 }
 
-func (m *MockDataService) DeleteData(ctx context.Context, userID, dataID uuid.UUID) error {
+func (m *MockDataService) MoveData(ctx context.Context, userID, dataID uuid.UUID, folderRef string) (*models.DataItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "DeleteData", ctx, userID, dataID)
+	ret := m.ctrl.Call(m, "MoveData", ctx, userID, dataID, folderRef)
+	ret0, _ := ret[0].(*models.DataItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockDataServiceMockRecorder) MoveData(ctx, userID, dataID, folderRef interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveData", reflect.TypeOf((*MockDataService)(nil).MoveData), ctx, userID, dataID, folderRef)
+}
+
+func (m *MockDataService) GetVersions(ctx context.Context, userID, dataID uuid.UUID) ([]*models.DataVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersions", ctx, userID, dataID)
+	ret0, _ := ret[0].([]*models.DataVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockDataServiceMockRecorder) GetVersions(ctx, userID, dataID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersions", reflect.TypeOf((*MockDataService)(nil).GetVersions), ctx, userID, dataID)
+}
+
+func (m *MockDataService) GetVersion(ctx context.Context, userID, dataID uuid.UUID, version int64) (*models.DataVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetVersion", ctx, userID, dataID, version)
+	ret0, _ := ret[0].(*models.DataVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockDataServiceMockRecorder) GetVersion(ctx, userID, dataID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetVersion", reflect.TypeOf((*MockDataService)(nil).GetVersion), ctx, userID, dataID, version)
+}
+
+func (m *MockDataService) RestoreVersion(ctx context.Context, userID, dataID uuid.UUID, version int64) (*models.DataItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreVersion", ctx, userID, dataID, version)
+	ret0, _ := ret[0].(*models.DataItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockDataServiceMockRecorder) RestoreVersion(ctx, userID, dataID, version interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreVersion", reflect.TypeOf((*MockDataService)(nil).RestoreVersion), ctx, userID, dataID, version)
+}
+
+func (m *MockDataService) SetExpiry(ctx context.Context, userID, dataID uuid.UUID, expiry models.DataExpiry) (*models.DataItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExpiry", ctx, userID, dataID, expiry)
+	ret0, _ := ret[0].(*models.DataItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockDataServiceMockRecorder) SetExpiry(ctx, userID, dataID, expiry interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExpiry", reflect.TypeOf((*MockDataService)(nil).SetExpiry), ctx, userID, dataID, expiry)
+}
+
+func (m *MockDataService) PurgeExpired(ctx context.Context) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", ctx)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockDataServiceMockRecorder) PurgeExpired(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockDataService)(nil).PurgeExpired), ctx)
+}
+
+func (m *MockDataService) GetPasswordHistory(ctx context.Context, userID, dataID uuid.UUID) ([]*models.PasswordHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPasswordHistory", ctx, userID, dataID)
+	ret0, _ := ret[0].([]*models.PasswordHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockDataServiceMockRecorder) GetPasswordHistory(ctx, userID, dataID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPasswordHistory", reflect.TypeOf((*MockDataService)(nil).GetPasswordHistory), ctx, userID, dataID)
+}
+
+func (m *MockDataService) BlindSearchData(ctx context.Context, userID uuid.UUID, search models.BlindSearch, page models.PageRequest) (*models.DataPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BlindSearchData", ctx, userID, search, page)
+	ret0, _ := ret[0].(*models.DataPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockDataServiceMockRecorder) BlindSearchData(ctx, userID, search, page interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BlindSearchData", reflect.TypeOf((*MockDataService)(nil).BlindSearchData), ctx, userID, search, page)
+}
+
+func (m *MockDataService) GetBlindIndexKey(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBlindIndexKey", ctx, userID)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockDataServiceMockRecorder) GetBlindIndexKey(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBlindIndexKey", reflect.TypeOf((*MockDataService)(nil).GetBlindIndexKey), ctx, userID)
+}
+
+func (m *MockDataService) RebuildBlindIndex(ctx context.Context, userID uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RebuildBlindIndex", ctx, userID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+func (mr *MockDataServiceMockRecorder) RebuildBlindIndex(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RebuildBlindIndex", reflect.TypeOf((*MockDataService)(nil).RebuildBlindIndex), ctx, userID)
+}
+
+func (m *MockDataService) DeleteData(ctx context.Context, userID, dataID uuid.UUID, expectedVersion int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteData", ctx, userID, dataID, expectedVersion)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-func (mr *MockDataServiceMockRecorder) DeleteData(ctx, userID, dataID interface{}) *gomock.Call {
+func (mr *MockDataServiceMockRecorder) DeleteData(ctx, userID, dataID, expectedVersion interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteData", reflect.TypeOf((*MockDataService)(nil).DeleteData), ctx, userID, dataID)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteData", reflect.TypeOf((*MockDataService)(nil).DeleteData), ctx, userID, dataID, expectedVersion)
 }
 
-func (m *MockDataService) SyncData(ctx context.Context, userID uuid.UUID, since time.Time) ([]*models.DataItem, error) {
+func (m *MockDataService) SyncData(ctx context.Context, userID uuid.UUID, since time.Time, page models.PageRequest) (*models.DataPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "SyncData", ctx, userID, since)
-	ret0, _ := ret[0].([]*models.DataItem)
+	ret := m.ctrl.Call(m, "SyncData", ctx, userID, since, page)
+	ret0, _ := ret[0].(*models.DataPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-func (mr *MockDataServiceMockRecorder) SyncData(ctx, userID, since interface{}) *gomock.Call {
+func (mr *MockDataServiceMockRecorder) SyncData(ctx, userID, since, page interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncData", reflect.TypeOf((*MockDataService)(nil).SyncData), ctx, userID, since)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncData", reflect.TypeOf((*MockDataService)(nil).SyncData), ctx, userID, since, page)
 }
 
 func TestDataHandler_CreateData(t *testing.T) {
@@ -152,7 +285,7 @@ func TestDataHandler_CreateData(t *testing.T) {
 		}
 
 		mockDataService.EXPECT().
-			CreateData(gomock.Any(), userID, models.LoginPassword, "test-password", "test-metadata", gomock.Any()).
+			CreateData(gomock.Any(), userID, models.LoginPassword, "test-password", "test-metadata", gomock.Any(), models.DataExpiry{}).
 			Return(createdItem, nil)
 
 		reqBody := CreateDataRequest{
@@ -284,7 +417,7 @@ func TestDataHandler_GetData(t *testing.T) {
 
 		mockDataService.EXPECT().
 			GetData(gomock.Any(), userID, dataID).
-			Return(nil, assert.AnError)
+			Return(nil, apperrors.NewNotFound("data item not found", nil))
 
 		req := httptest.NewRequest("GET", "/data/"+dataID.String(), nil)
 		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
@@ -294,7 +427,9 @@ func TestDataHandler_GetData(t *testing.T) {
 		handler.GetData(w, req)
 
 		assert.Equal(t, http.StatusNotFound, w.Code)
-		assert.Contains(t, w.Body.String(), "assert.AnError")
+		body := decodeErrorBody(t, w)
+		assert.Equal(t, apperrors.CodeNotFound, body.Code)
+		assert.Equal(t, "data item not found", body.Message)
 	})
 }
 
@@ -323,8 +458,8 @@ func TestDataHandler_GetUserData(t *testing.T) {
 		}
 
 		mockDataService.EXPECT().
-			GetUserData(gomock.Any(), userID).
-			Return(dataItems, nil)
+			GetUserData(gomock.Any(), userID, models.DataFilter{}, models.PageRequest{}).
+			Return(&models.DataPage{Items: dataItems}, nil)
 
 		req := httptest.NewRequest("GET", "/data", nil)
 		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
@@ -334,12 +469,153 @@ func TestDataHandler_GetUserData(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var response []DataResponse
+		var response DataListResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Items, 1)
+		assert.Equal(t, models.LoginPassword, response.Items[0].Type)
+		assert.Equal(t, "test-password", response.Items[0].Name)
+
+		var raw struct {
+			Items []map[string]json.RawMessage `json:"items"`
+		}
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &raw))
+		if assert.Len(t, raw.Items, 1) {
+			assert.NotContains(t, raw.Items[0], "data")
+		}
+	})
+
+	t.Run("folder filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		userID := uuid.New()
+		user := &models.User{ID: userID}
+		folderID := uuid.New()
+
+		dataItems := []*models.DataItem{
+			{ID: uuid.New(), UserID: userID, FolderID: &folderID, Type: models.TextData, Name: "note"},
+		}
+
+		mockDataService.EXPECT().
+			GetUserData(gomock.Any(), userID, models.DataFilter{Folder: "work/aws", Recursive: true}, models.PageRequest{}).
+			Return(&models.DataPage{Items: dataItems}, nil)
+
+		req := httptest.NewRequest("GET", "/data?folder=work/aws&recursive=true", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
+		w := httptest.NewRecorder()
+
+		handler.GetUserData(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response DataListResponse
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Len(t, response, 1)
-		assert.Equal(t, models.LoginPassword, response[0].Type)
-		assert.Equal(t, "test-password", response[0].Name)
+		assert.Len(t, response.Items, 1)
+		assert.Equal(t, folderID.String(), response.Items[0].FolderID)
+	})
+
+	t.Run("type and tag filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		userID := uuid.New()
+		user := &models.User{ID: userID}
+
+		expectedFilter := models.DataFilter{
+			Type: models.LoginPassword,
+			Tags: models.TagFilter{
+				Include: []string{"prod", "shared-with-ops"},
+				Exclude: []string{"expiring"},
+				Match:   models.TagMatchAll,
+			},
+		}
+
+		mockDataService.EXPECT().
+			GetUserData(gomock.Any(), userID, expectedFilter, models.PageRequest{}).
+			Return(&models.DataPage{Items: []*models.DataItem{{ID: uuid.New(), UserID: userID, Tags: []string{"prod", "shared-with-ops"}}}}, nil)
+
+		req := httptest.NewRequest("GET", "/data?type=login_password&tag=prod,shared-with-ops&tag=!expiring&tag_match=all", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
+		w := httptest.NewRecorder()
+
+		handler.GetUserData(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response DataListResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"prod", "shared-with-ops"}, response.Items[0].Tags)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		userID := uuid.New()
+		user := &models.User{ID: userID}
+
+		expectedPage := models.PageRequest{Limit: 10, Cursor: "abc", Sort: models.SortByName, Order: models.SortDesc}
+		mockDataService.EXPECT().
+			GetUserData(gomock.Any(), userID, models.DataFilter{}, expectedPage).
+			Return(&models.DataPage{Items: []*models.DataItem{{ID: uuid.New(), UserID: userID, Name: "b"}}, NextCursor: "next"}, nil)
+
+		req := httptest.NewRequest("GET", "/data?limit=10&cursor=abc&sort=name&order=desc", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
+		w := httptest.NewRecorder()
+
+		handler.GetUserData(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response DataListResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Items, 1)
+		assert.Equal(t, "next", response.NextCursor)
+	})
+
+	t.Run("invalid limit", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		req := httptest.NewRequest("GET", "/data?limit=many", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: uuid.New()}))
+		w := httptest.NewRecorder()
+
+		handler.GetUserData(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+
+	t.Run("invalid tag match", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		req := httptest.NewRequest("GET", "/data?tag=prod&tag_match=some", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: uuid.New()}))
+		w := httptest.NewRecorder()
+
+		handler.GetUserData(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 
 	t.Run("no data found", func(t *testing.T) {
@@ -353,8 +629,8 @@ func TestDataHandler_GetUserData(t *testing.T) {
 		user := &models.User{ID: userID}
 
 		mockDataService.EXPECT().
-			GetUserData(gomock.Any(), userID).
-			Return([]*models.DataItem{}, nil)
+			GetUserData(gomock.Any(), userID, models.DataFilter{}, models.PageRequest{}).
+			Return(&models.DataPage{}, nil)
 
 		req := httptest.NewRequest("GET", "/data", nil)
 		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
@@ -364,10 +640,75 @@ func TestDataHandler_GetUserData(t *testing.T) {
 
 		assert.Equal(t, http.StatusOK, w.Code)
 
-		var response []DataResponse
+		var response DataListResponse
 		err := json.Unmarshal(w.Body.Bytes(), &response)
 		assert.NoError(t, err)
-		assert.Len(t, response, 0)
+		assert.Len(t, response.Items, 0)
+	})
+}
+
+func TestDataHandler_SearchData(t *testing.T) {
+	t.Run("successful search", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		userID := uuid.New()
+		user := &models.User{ID: userID}
+		updatedAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		expectedSearch := models.DataSearch{
+			Query: "prod",
+			Match: models.SearchPrefix,
+			Filter: models.DataFilter{
+				Type: models.LoginPassword,
+				Tags: models.TagFilter{Include: []string{"aws"}, Match: models.TagMatchAny},
+			},
+			UpdatedAfter: &updatedAfter,
+		}
+		item := &models.DataItem{
+			ID:        uuid.New(),
+			UserID:    userID,
+			Type:      models.LoginPassword,
+			Name:      "prod-db",
+			Highlight: &models.SearchHighlight{Name: "<mark>prod</mark>-db"},
+		}
+
+		mockDataService.EXPECT().
+			SearchData(gomock.Any(), userID, expectedSearch, models.PageRequest{Limit: 5}).
+			Return(&models.DataPage{Items: []*models.DataItem{item}}, nil)
+
+		req := httptest.NewRequest("GET", "/data/search?q=prod&match=prefix&type=login_password&tag=aws&updated_after=2024-01-01&limit=5", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
+		w := httptest.NewRecorder()
+
+		handler.SearchData(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response DataListResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Len(t, response.Items, 1)
+		assert.Equal(t, "<mark>prod</mark>-db", response.Items[0].Highlight.Name)
+	})
+
+	t.Run("invalid date", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		req := httptest.NewRequest("GET", "/data/search?q=prod&created_before=yesterday", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: uuid.New()}))
+		w := httptest.NewRecorder()
+
+		handler.SearchData(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
 	})
 }
 
@@ -384,10 +725,11 @@ func TestDataHandler_DeleteData(t *testing.T) {
 		user := &models.User{ID: userID}
 
 		mockDataService.EXPECT().
-			DeleteData(gomock.Any(), userID, dataID).
+			DeleteData(gomock.Any(), userID, dataID, int64(3)).
 			Return(nil)
 
 		req := httptest.NewRequest("DELETE", "/data/"+dataID.String(), nil)
+		req.Header.Set("If-Match", `"3"`)
 		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
 		req = mux.SetURLVars(req, map[string]string{"id": dataID.String()})
 		w := httptest.NewRecorder()
@@ -409,10 +751,11 @@ func TestDataHandler_DeleteData(t *testing.T) {
 		user := &models.User{ID: userID}
 
 		mockDataService.EXPECT().
-			DeleteData(gomock.Any(), userID, dataID).
+			DeleteData(gomock.Any(), userID, dataID, models.AnyVersion).
 			Return(assert.AnError)
 
 		req := httptest.NewRequest("DELETE", "/data/"+dataID.String(), nil)
+		req.Header.Set("If-Match", "*")
 		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
 		req = mux.SetURLVars(req, map[string]string{"id": dataID.String()})
 		w := httptest.NewRecorder()
@@ -420,6 +763,141 @@ func TestDataHandler_DeleteData(t *testing.T) {
 		handler.DeleteData(w, req)
 
 		assert.Equal(t, http.StatusInternalServerError, w.Code)
-		assert.Contains(t, w.Body.String(), "assert.AnError")
+		assert.Equal(t, apperrors.CodeInternal, decodeErrorBody(t, w).Code)
+		assert.NotContains(t, w.Body.String(), "assert.AnError")
+	})
+
+	t.Run("expected version is required", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		dataID := uuid.New()
+		req := httptest.NewRequest("DELETE", "/data/"+dataID.String(), nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, &models.User{ID: uuid.New()}))
+		req = mux.SetURLVars(req, map[string]string{"id": dataID.String()})
+		w := httptest.NewRecorder()
+
+		handler.DeleteData(w, req)
+
+		assert.Equal(t, http.StatusPreconditionRequired, w.Code)
+	})
+}
+
+func TestDataHandler_UpdateData(t *testing.T) {
+	newRequest := func(user *models.User, dataID uuid.UUID, body string) *http.Request {
+		req := httptest.NewRequest("PUT", "/data/"+dataID.String(), strings.NewReader(body))
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
+		return mux.SetURLVars(req, map[string]string{"id": dataID.String()})
+	}
+
+	t.Run("version from If-Match", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		user := &models.User{ID: uuid.New()}
+		dataID := uuid.New()
+		updated := &models.DataItem{ID: dataID, UserID: user.ID, Type: models.TextData, Name: "note", Version: 4}
+
+		mockDataService.EXPECT().
+			UpdateData(gomock.Any(), user.ID, dataID, int64(3), "note", "", []byte(`{"text":"hi"}`)).
+			Return(updated, nil)
+
+		req := newRequest(user, dataID, `{"name":"note","data":{"text":"hi"}}`)
+		req.Header.Set("If-Match", `"3"`)
+		w := httptest.NewRecorder()
+
+		handler.UpdateData(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, `"4"`, w.Header().Get("ETag"))
+	})
+
+	t.Run("version from body", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		user := &models.User{ID: uuid.New()}
+		dataID := uuid.New()
+
+		mockDataService.EXPECT().
+			UpdateData(gomock.Any(), user.ID, dataID, int64(2), "note", "", gomock.Any()).
+			Return(&models.DataItem{ID: dataID, Version: 3}, nil)
+
+		w := httptest.NewRecorder()
+		handler.UpdateData(w, newRequest(user, dataID, `{"name":"note","data":{"text":"hi"},"version":2}`))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("version conflict", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		user := &models.User{ID: uuid.New()}
+		dataID := uuid.New()
+
+		mockDataService.EXPECT().
+			UpdateData(gomock.Any(), user.ID, dataID, int64(3), "note", "", gomock.Any()).
+			Return(nil, fmt.Errorf("failed to update data item: %w", &apperrors.VersionConflictError{Expected: 3, Current: 5}))
+
+		req := newRequest(user, dataID, `{"name":"note","data":{"text":"hi"}}`)
+		req.Header.Set("If-Match", `"3"`)
+		w := httptest.NewRecorder()
+
+		handler.UpdateData(w, req)
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+
+		body := decodeErrorBody(t, w)
+		assert.Equal(t, apperrors.CodeVersionConflict, body.Code)
+		assert.Equal(t, float64(5), body.Details["current_version"])
+		assert.Equal(t, float64(3), body.Details["expected_version"])
+	})
+
+	t.Run("invalid or missing precondition", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		handler := NewDataHandler(NewMockDataService(ctrl))
+		user := &models.User{ID: uuid.New()}
+		dataID := uuid.New()
+
+		cases := []struct {
+			ifMatch string
+			body    string
+			status  int
+		}{
+			{"", `{"name":"note"}`, http.StatusPreconditionRequired},
+			{"3", `{"name":"note"}`, http.StatusBadRequest},
+			{`W/"3"`, `{"name":"note"}`, http.StatusBadRequest},
+			{`"3"`, `{"name":"note","version":2}`, http.StatusBadRequest},
+			{"", `{"name":"note","version":0}`, http.StatusBadRequest},
+			{"", `{"name":"note","version":-1}`, http.StatusBadRequest},
+			{"*", `{"name":"note","version":0}`, http.StatusBadRequest},
+		}
+		for _, tc := range cases {
+			req := newRequest(user, dataID, tc.body)
+			if tc.ifMatch != "" {
+				req.Header.Set("If-Match", tc.ifMatch)
+			}
+			w := httptest.NewRecorder()
+
+			handler.UpdateData(w, req)
+
+			assert.Equal(t, tc.status, w.Code, tc.ifMatch+" "+tc.body)
+		}
 	})
 }
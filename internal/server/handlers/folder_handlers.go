@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// FolderHandler обрабатывает HTTP запросы для работы с папками.
+type FolderHandler struct {
+	folderService interfaces.FolderService
+}
+
+// NewFolderHandler создает новый экземпляр FolderHandler.
+func NewFolderHandler(folderService interfaces.FolderService) *FolderHandler {
+	return &FolderHandler{
+		folderService: folderService,
+	}
+}
+
+// CreateFolderRequest содержит путь создаваемой папки, например work/aws/prod.
+type CreateFolderRequest struct {
+	Path string `json:"path"`
+}
+
+// UpdateFolderRequest содержит новое имя и (или) новую родительскую папку.
+// Parent задается ID или путем; пустая строка означает корень.
+type UpdateFolderRequest struct {
+	Name   string  `json:"name,omitempty"`
+	Parent *string `json:"parent,omitempty"`
+}
+
+// CreateFolder обрабатывает запрос на создание папки.
+func (h *FolderHandler) CreateFolder(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	var req CreateFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	folder, err := h.folderService.CreateFolder(r.Context(), user.ID, req.Path)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(folder)
+}
+
+// GetFolders обрабатывает запрос на получение всех папок пользователя.
+func (h *FolderHandler) GetFolders(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	folders, err := h.folderService.GetFolders(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(folders)
+}
+
+// UpdateFolder обрабатывает запрос на переименование и (или) перемещение папки.
+func (h *FolderHandler) UpdateFolder(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+	folderID := mux.Vars(r)["id"]
+
+	var req UpdateFolderRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	if req.Name == "" && req.Parent == nil {
+		writeError(w, r, apperrors.NewBadRequest("Name or parent is required", nil))
+		return
+	}
+
+	var folder *models.Folder
+	var err error
+
+	if req.Parent != nil {
+		folder, err = h.folderService.MoveFolder(r.Context(), user.ID, folderID, *req.Parent)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+	}
+
+	if req.Name != "" {
+		folder, err = h.folderService.RenameFolder(r.Context(), user.ID, folderID, req.Name)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(folder)
+}
+
+// DeleteFolder обрабатывает запрос на удаление папки. Непустая папка удаляется
+// только с параметром recursive=true.
+func (h *FolderHandler) DeleteFolder(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+	folderID := mux.Vars(r)["id"]
+	recursive := r.URL.Query().Get("recursive") == "true"
+
+	if err := h.folderService.DeleteFolder(r.Context(), user.ID, folderID, recursive); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
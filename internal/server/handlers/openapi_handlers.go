@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+)
+
+// OpenAPIHandler отдает спецификацию OpenAPI сервера.
+type OpenAPIHandler struct {
+	spec []byte
+}
+
+// NewOpenAPIHandler создает новый экземпляр OpenAPIHandler для спецификации в формате JSON.
+func NewOpenAPIHandler(spec []byte) *OpenAPIHandler {
+	return &OpenAPIHandler{
+		spec: spec,
+	}
+}
+
+// GetSpec обрабатывает запрос на получение спецификации API.
+func (h *OpenAPIHandler) GetSpec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(h.spec)
+}
@@ -0,0 +1,138 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/openapi"
+)
+
+// specTypes связывает схемы спецификации с типами, которыми сервер разбирает запросы
+// и формирует ответы. Свойства схемы должны совпадать с JSON полями типов.
+var specTypes = map[string][]interface{}{
+	"ErrorResponse":           {apperrors.ErrorResponse{}},
+	"ErrorBody":               {apperrors.ErrorBody{}},
+	"User":                    {models.User{}},
+	"Credentials":             {RegisterRequest{}, LoginRequest{}},
+	"AuthResponse":            {AuthResponse{}},
+	"RefreshRequest":          {RefreshRequest{}},
+	"LogoutRequest":           {RefreshRequest{}},
+	"ChangePasswordRequest":   {ChangePasswordRequest{}},
+	"CertificateInfo":         {models.CertificateInfo{}},
+	"SearchHighlight":         {models.SearchHighlight{}},
+	"DataItem":                {DataResponse{}},
+	"DataList":                {DataListResponse{}},
+	"CreateDataRequest":       {CreateDataRequest{}},
+	"UpdateDataRequest":       {UpdateDataRequest{}},
+	"MoveDataRequest":         {MoveDataRequest{}},
+	"SetExpiryRequest":        {SetExpiryRequest{}},
+	"SetRotationRequest":      {SetRotationRequest{}},
+	"BatchOperation":          {models.BatchOperation{}},
+	"DataBatch":               {models.DataBatch{}},
+	"BatchOperationResult":    {BatchOperationResponse{}},
+	"BatchResponse":           {BatchResponse{}},
+	"BlindIndexKey":           {BlindIndexKeyResponse{}},
+	"RebuildBlindIndexResult": {RebuildBlindIndexResponse{}},
+	"RotationStatus":          {models.RotationStatus{}},
+	"CertificateStatus":       {models.CertificateStatus{}},
+	"EmptyTrashResult":        {EmptyTrashResponse{}},
+	"DataVersion":             {VersionResponse{}},
+	"PasswordHistoryEntry":    {PasswordHistoryResponse{}},
+	"TagsRequest":             {TagsRequest{}},
+	"RenameTagRequest":        {RenameTagRequest{}},
+	"MergeTagsRequest":        {MergeTagsRequest{}},
+	"Tag":                     {models.Tag{}},
+	"Attachment":              {models.Attachment{}},
+	"Folder":                  {models.Folder{}},
+	"CreateFolderRequest":     {CreateFolderRequest{}},
+	"UpdateFolderRequest":     {UpdateFolderRequest{}},
+	"CustomField":             {models.CustomField{}},
+	"CustomTypeRequest":       {CustomTypeRequest{}},
+	"CustomType":              {models.CustomType{}},
+}
+
+// clientTypes содержит типы, указанные в x-go-type, которыми клиент разбирает ответы.
+var clientTypes = map[string]interface{}{
+	"apperrors.ErrorResponse":     apperrors.ErrorResponse{},
+	"apperrors.ErrorBody":         apperrors.ErrorBody{},
+	"models.User":                 models.User{},
+	"models.CertificateInfo":      models.CertificateInfo{},
+	"models.SearchHighlight":      models.SearchHighlight{},
+	"models.DataItem":             models.DataItem{},
+	"models.BatchOperation":       models.BatchOperation{},
+	"models.DataBatch":            models.DataBatch{},
+	"models.RotationStatus":       models.RotationStatus{},
+	"models.CertificateStatus":    models.CertificateStatus{},
+	"models.DataVersion":          models.DataVersion{},
+	"models.PasswordHistoryEntry": models.PasswordHistoryEntry{},
+	"models.Tag":                  models.Tag{},
+	"models.Attachment":           models.Attachment{},
+	"models.Folder":               models.Folder{},
+	"models.CustomField":          models.CustomField{},
+	"models.CustomType":           models.CustomType{},
+}
+
+// jsonFields возвращает имена JSON полей структуры.
+func jsonFields(t reflect.Type) []string {
+	var fields []string
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if field.Anonymous || !field.IsExported() {
+			continue
+		}
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		fields = append(fields, name)
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+func TestOpenAPISchemasMatchTypes(t *testing.T) {
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	for name, schema := range doc.Components.Schemas {
+		t.Run(name, func(t *testing.T) {
+			types, ok := specTypes[name]
+			require.True(t, ok, "schema %s is not mapped to a Go type", name)
+
+			properties := append([]string(nil), schema.Properties.Names...)
+			sort.Strings(properties)
+
+			for _, value := range types {
+				assert.Equal(t, properties, jsonFields(reflect.TypeOf(value)), "%T", value)
+			}
+
+			if schema.GoType != "" {
+				clientType, ok := clientTypes[schema.GoType]
+				require.True(t, ok, "x-go-type %s is not mapped to a Go type", schema.GoType)
+				assert.Subset(t, jsonFields(reflect.TypeOf(clientType)), properties, schema.GoType)
+			}
+		})
+	}
+}
+
+func TestOpenAPIHandler_GetSpec(t *testing.T) {
+	handler := NewOpenAPIHandler(openapi.JSON())
+
+	w := httptest.NewRecorder()
+	handler.GetSpec(w, httptest.NewRequest("GET", "/api/v1/openapi.json", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.JSONEq(t, string(openapi.JSON()), w.Body.String())
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// PasswordHistoryResponse содержит один из предыдущих паролей элемента данных.
+type PasswordHistoryResponse struct {
+	Password  string `json:"password"`
+	SetAt     string `json:"set_at,omitempty"`
+	ChangedAt string `json:"changed_at"`
+}
+
+// GetPasswordHistory обрабатывает запрос на получение истории паролей элемента login_password.
+func (h *DataHandler) GetPasswordHistory(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	entries, err := h.dataService.GetPasswordHistory(r.Context(), user.ID, dataID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	responses := make([]PasswordHistoryResponse, 0, len(entries))
+	for _, entry := range entries {
+		response := PasswordHistoryResponse{
+			Password:  entry.Password,
+			ChangedAt: entry.ChangedAt.UTC().Format("2006-01-02T15:04:05Z"),
+		}
+		if entry.SetAt != nil {
+			response.SetAt = entry.SetAt.UTC().Format("2006-01-02T15:04:05Z")
+		}
+		responses = append(responses, response)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(responses)
+}
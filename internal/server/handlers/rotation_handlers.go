@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// RotationHandler обрабатывает HTTP запросы для контроля ротации паролей.
+type RotationHandler struct {
+	rotationService interfaces.RotationService
+}
+
+// NewRotationHandler создает новый экземпляр RotationHandler.
+func NewRotationHandler(rotationService interfaces.RotationService) *RotationHandler {
+	return &RotationHandler{
+		rotationService: rotationService,
+	}
+}
+
+// SetRotationRequest содержит интервал ротации пароля в днях; null снимает интервал.
+type SetRotationRequest struct {
+	RotationDays *int `json:"rotation_days"`
+}
+
+// SetDataRotation обрабатывает запрос на изменение интервала ротации пароля элемента данных.
+func (h *RotationHandler) SetDataRotation(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	var req SetRotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	dataItem, err := h.rotationService.SetDataRotation(r.Context(), user.ID, dataID, req.RotationDays)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newDataResponse(dataItem))
+}
+
+// SetFolderRotation обрабатывает запрос на изменение интервала ротации паролей папки.
+func (h *RotationHandler) SetFolderRotation(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	var req SetRotationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	folder, err := h.rotationService.SetFolderRotation(r.Context(), user.ID, mux.Vars(r)["id"], req.RotationDays)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(folder)
+}
+
+// GetOverdue обрабатывает запрос на получение элементов с просроченной ротацией пароля.
+func (h *RotationHandler) GetOverdue(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	overdue, err := h.rotationService.GetOverdue(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if overdue == nil {
+		overdue = []*models.RotationStatus{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(overdue)
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// TagHandler обрабатывает HTTP запросы для работы с тегами.
+type TagHandler struct {
+	tagService interfaces.TagService
+}
+
+// NewTagHandler создает новый экземпляр TagHandler.
+func NewTagHandler(tagService interfaces.TagService) *TagHandler {
+	return &TagHandler{
+		tagService: tagService,
+	}
+}
+
+// TagsRequest содержит список тегов элемента данных.
+type TagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+// RenameTagRequest содержит новое имя тега.
+type RenameTagRequest struct {
+	Name string `json:"name"`
+}
+
+// MergeTagsRequest содержит исходные теги и тег, в который они объединяются.
+type MergeTagsRequest struct {
+	Sources []string `json:"sources"`
+	Target  string   `json:"target"`
+}
+
+// GetTags обрабатывает запрос на получение всех тегов пользователя.
+func (h *TagHandler) GetTags(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	tags, err := h.tagService.GetTags(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if tags == nil {
+		tags = []*models.Tag{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(tags)
+}
+
+// RenameTag обрабатывает запрос на переименование тега.
+func (h *TagHandler) RenameTag(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+	name := mux.Vars(r)["name"]
+
+	var req RenameTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	tag, err := h.tagService.RenameTag(r.Context(), user.ID, name, req.Name)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(tag)
+}
+
+// MergeTags обрабатывает запрос на объединение тегов.
+func (h *TagHandler) MergeTags(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	var req MergeTagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	if err := h.tagService.MergeTags(r.Context(), user.ID, req.Sources, req.Target); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// AddTags обрабатывает запрос на добавление тегов к элементу данных.
+func (h *TagHandler) AddTags(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	var req TagsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	dataItem, err := h.tagService.AddTags(r.Context(), user.ID, dataID, req.Tags)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newDataResponse(dataItem))
+}
+
+// RemoveTag обрабатывает запрос на снятие тега с элемента данных.
+func (h *TagHandler) RemoveTag(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+	vars := mux.Vars(r)
+
+	dataID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	dataItem, err := h.tagService.RemoveTags(r.Context(), user.ID, dataID, []string{vars["tag"]})
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newDataResponse(dataItem))
+}
@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// TrashHandler обрабатывает HTTP запросы для работы с корзиной.
+type TrashHandler struct {
+	trashService interfaces.TrashService
+}
+
+// NewTrashHandler создает новый экземпляр TrashHandler.
+func NewTrashHandler(trashService interfaces.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// EmptyTrashResponse содержит количество окончательно удаленных элементов.
+type EmptyTrashResponse struct {
+	Deleted int `json:"deleted"`
+}
+
+// GetTrash обрабатывает запрос на получение элементов данных в корзине.
+func (h *TrashHandler) GetTrash(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	items, err := h.trashService.GetTrash(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	responses := make([]DataResponse, 0, len(items))
+	for _, item := range items {
+		response := newDataResponse(item)
+		response.Data = nil
+		responses = append(responses, response)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(responses)
+}
+
+// RestoreData обрабатывает запрос на восстановление элемента данных из корзины.
+func (h *TrashHandler) RestoreData(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	dataItem, err := h.trashService.RestoreData(r.Context(), user.ID, dataID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	response := newDataResponse(dataItem)
+	response.Data = nil
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// PurgeData обрабатывает запрос на окончательное удаление элемента данных из корзины.
+func (h *TrashHandler) PurgeData(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	if err := h.trashService.PurgeData(r.Context(), user.ID, dataID); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// EmptyTrash обрабатывает запрос на очистку корзины.
+func (h *TrashHandler) EmptyTrash(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	deleted, err := h.trashService.EmptyTrash(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(EmptyTrashResponse{Deleted: deleted})
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// CustomTypeHandler обрабатывает HTTP запросы для работы с пользовательскими типами данных.
+type CustomTypeHandler struct {
+	typeService interfaces.CustomTypeService
+}
+
+// NewCustomTypeHandler создает новый экземпляр CustomTypeHandler.
+func NewCustomTypeHandler(typeService interfaces.CustomTypeService) *CustomTypeHandler {
+	return &CustomTypeHandler{
+		typeService: typeService,
+	}
+}
+
+// CustomTypeRequest содержит данные для создания или обновления пользовательского типа.
+type CustomTypeRequest struct {
+	Name        string               `json:"name"`
+	Description string               `json:"description"`
+	Fields      []models.CustomField `json:"fields"`
+}
+
+// CreateType обрабатывает запрос на создание пользовательского типа.
+func (h *CustomTypeHandler) CreateType(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	var req CustomTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	customType, err := h.typeService.CreateType(r.Context(), user.ID, req.Name, req.Description, req.Fields)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	_ = json.NewEncoder(w).Encode(customType)
+}
+
+// GetTypes обрабатывает запрос на получение всех пользовательских типов.
+func (h *CustomTypeHandler) GetTypes(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	customTypes, err := h.typeService.GetTypes(r.Context(), user.ID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	if customTypes == nil {
+		customTypes = []*models.CustomType{}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(customTypes)
+}
+
+// GetType обрабатывает запрос на получение пользовательского типа по имени.
+func (h *CustomTypeHandler) GetType(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+	name := mux.Vars(r)["name"]
+
+	customType, err := h.typeService.GetType(r.Context(), user.ID, name)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(customType)
+}
+
+// UpdateType обрабатывает запрос на обновление пользовательского типа.
+func (h *CustomTypeHandler) UpdateType(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+	name := mux.Vars(r)["name"]
+
+	var req CustomTypeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		return
+	}
+
+	if req.Name != "" && req.Name != name {
+		writeError(w, r, apperrors.NewBadRequest("Type name cannot be changed", nil))
+		return
+	}
+
+	customType, err := h.typeService.UpdateType(r.Context(), user.ID, name, req.Description, req.Fields)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(customType)
+}
+
+// DeleteType обрабатывает запрос на удаление пользовательского типа.
+func (h *CustomTypeHandler) DeleteType(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+	name := mux.Vars(r)["name"]
+
+	if err := h.typeService.DeleteType(r.Context(), user.ID, name); err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,118 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// VersionResponse содержит версию элемента данных.
+type VersionResponse struct {
+	Version      int64           `json:"version"`
+	Name         string          `json:"name"`
+	Metadata     string          `json:"metadata"`
+	Data         json.RawMessage `json:"data,omitempty"`
+	RestoredFrom *int64          `json:"restored_from,omitempty"`
+	CreatedAt    string          `json:"created_at"`
+}
+
+// GetVersions обрабатывает запрос на получение истории версий элемента данных.
+func (h *DataHandler) GetVersions(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return
+	}
+
+	versions, err := h.dataService.GetVersions(r.Context(), user.ID, dataID)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	responses := make([]VersionResponse, 0, len(versions))
+	for _, version := range versions {
+		responses = append(responses, newVersionResponse(version))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(responses)
+}
+
+// GetVersion обрабатывает запрос на получение версии элемента данных с содержимым.
+func (h *DataHandler) GetVersion(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, version, ok := parseVersionVars(w, r)
+	if !ok {
+		return
+	}
+
+	dataVersion, err := h.dataService.GetVersion(r.Context(), user.ID, dataID, version)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newVersionResponse(dataVersion))
+}
+
+// RestoreVersion обрабатывает запрос на восстановление элемента данных из версии.
+func (h *DataHandler) RestoreVersion(w http.ResponseWriter, r *http.Request) {
+	user := r.Context().Value(middleware.UserKey).(*models.User)
+
+	dataID, version, ok := parseVersionVars(w, r)
+	if !ok {
+		return
+	}
+
+	dataItem, err := h.dataService.RestoreVersion(r.Context(), user.ID, dataID, version)
+	if err != nil {
+		writeError(w, r, err)
+		return
+	}
+
+	setETag(w, dataItem.Version)
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(newDataResponse(dataItem))
+}
+
+// parseVersionVars разбирает ID элемента данных и номер версии из пути запроса.
+func parseVersionVars(w http.ResponseWriter, r *http.Request) (uuid.UUID, int64, bool) {
+	vars := mux.Vars(r)
+
+	dataID, err := uuid.Parse(vars["id"])
+	if err != nil {
+		writeError(w, r, apperrors.NewBadRequest("Invalid data ID", nil))
+		return uuid.Nil, 0, false
+	}
+
+	version, err := strconv.ParseInt(vars["version"], 10, 64)
+	if err != nil || version < 1 {
+		writeError(w, r, apperrors.NewBadRequest("Invalid version", nil))
+		return uuid.Nil, 0, false
+	}
+
+	return dataID, version, true
+}
+
+// newVersionResponse формирует ответ с версией элемента данных.
+func newVersionResponse(version *models.DataVersion) VersionResponse {
+	return VersionResponse{
+		Version:      version.Version,
+		Name:         version.Name,
+		Metadata:     version.Metadata,
+		Data:         version.Data,
+		RestoredFrom: version.RestoredFrom,
+		CreatedAt:    version.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/tempizhere/vaultfactory/internal/server/middleware"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+func TestDataHandler_GetVersion(t *testing.T) {
+	t.Run("successful version retrieval", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		userID := uuid.New()
+		dataID := uuid.New()
+		user := &models.User{ID: userID}
+		restoredFrom := int64(1)
+
+		mockDataService.EXPECT().
+			GetVersion(gomock.Any(), userID, dataID, int64(3)).
+			Return(&models.DataVersion{
+				DataID:       dataID,
+				Version:      3,
+				Name:         "note",
+				Data:         json.RawMessage(`{"text":"v1"}`),
+				RestoredFrom: &restoredFrom,
+				CreatedAt:    time.Now(),
+			}, nil)
+
+		req := httptest.NewRequest("GET", "/data/"+dataID.String()+"/versions/3", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
+		req = mux.SetURLVars(req, map[string]string{"id": dataID.String(), "version": "3"})
+		w := httptest.NewRecorder()
+
+		handler.GetVersion(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response VersionResponse
+		err := json.Unmarshal(w.Body.Bytes(), &response)
+		assert.NoError(t, err)
+		assert.Equal(t, int64(3), response.Version)
+		assert.Equal(t, "note", response.Name)
+		assert.JSONEq(t, `{"text":"v1"}`, string(response.Data))
+		assert.Equal(t, &restoredFrom, response.RestoredFrom)
+	})
+
+	t.Run("invalid version", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockDataService := NewMockDataService(ctrl)
+		handler := NewDataHandler(mockDataService)
+
+		dataID := uuid.New()
+		user := &models.User{ID: uuid.New()}
+
+		req := httptest.NewRequest("GET", "/data/"+dataID.String()+"/versions/latest", nil)
+		req = req.WithContext(context.WithValue(req.Context(), middleware.UserKey, user))
+		req = mux.SetURLVars(req, map[string]string{"id": dataID.String(), "version": "latest"})
+		w := httptest.NewRecorder()
+
+		handler.GetVersion(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
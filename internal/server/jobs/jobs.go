@@ -0,0 +1,33 @@
+// Package jobs содержит фоновые задачи сервера.
+package jobs
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/logger"
+)
+
+// Task представляет периодически выполняемую фоновую задачу.
+type Task func(ctx context.Context) error
+
+// RunPeriodic выполняет задачу сразу и затем с интервалом interval до отмены ctx.
+// Ошибки задачи записываются в лог и не прерывают последующие запуски.
+func RunPeriodic(ctx context.Context, log logger.Logger, name string, interval time.Duration, task Task) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := task(ctx); err != nil && ctx.Err() == nil {
+			log.Error("Background job failed", zap.String("job", name), zap.Error(err))
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,37 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/logger"
+)
+
+func TestRunPeriodic(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var runs int32
+	done := make(chan struct{})
+	go func() {
+		RunPeriodic(ctx, logger.NewMockLogger(), "test", 10*time.Millisecond, func(ctx context.Context) error {
+			if atomic.AddInt32(&runs, 1) == 3 {
+				cancel()
+			}
+			return errors.New("task failed")
+		})
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("RunPeriodic did not stop after context cancellation")
+	}
+
+	assert.Equal(t, int32(3), atomic.LoadInt32(&runs))
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"strings"
 
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
 	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
 )
 
@@ -35,20 +36,20 @@ func (m *AuthMiddleware) RequireAuth(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
-			http.Error(w, "Authorization header required", http.StatusUnauthorized)
+			WriteError(w, r, apperrors.NewUnauthorized("Authorization header required", nil))
 			return
 		}
 
 		tokenParts := strings.Split(authHeader, " ")
 		if len(tokenParts) != 2 || tokenParts[0] != "Bearer" {
-			http.Error(w, "Invalid authorization header format", http.StatusUnauthorized)
+			WriteError(w, r, apperrors.NewUnauthorized("Invalid authorization header format", nil))
 			return
 		}
 
 		token := tokenParts[1]
 		user, err := m.authService.ValidateToken(r.Context(), token)
 		if err != nil {
-			http.Error(w, "Invalid token", http.StatusUnauthorized)
+			WriteError(w, r, apperrors.NewUnauthorized("Invalid token", err))
 			return
 		}
 
@@ -1,38 +1,227 @@
 package middleware
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
 
-	"github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/google/uuid"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/logger"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+	"go.uber.org/zap"
 )
 
+// RequestIDHeader - заголовок с идентификатором запроса.
+const RequestIDHeader = "X-Request-ID"
+
+// maxRequestIDLength ограничивает длину идентификатора запроса, переданного клиентом.
+const maxRequestIDLength = 128
+
+type requestIDKey struct{}
+
+type errorSlotKey struct{}
+
+// errorSlot хранит ошибку, отправленную клиенту, для логирования после обработки запроса.
+type errorSlot struct {
+	err    error
+	status int
+}
+
+// RequestIDFromContext возвращает идентификатор запроса из контекста.
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
+
 // ErrorHandler обрабатывает ошибки HTTP запросов.
-type ErrorHandler struct{}
+type ErrorHandler struct {
+	logger logger.Logger
+}
 
 // NewErrorHandler создает новый экземпляр ErrorHandler.
-func NewErrorHandler() *ErrorHandler {
-	return &ErrorHandler{}
+func NewErrorHandler(logger logger.Logger) *ErrorHandler {
+	return &ErrorHandler{
+		logger: logger,
+	}
+}
+
+// Middleware присваивает запросу идентификатор, перехватывает паники обработчиков
+// и логирует причины серверных ошибок. Идентификатор берется из заголовка X-Request-ID,
+// если клиент передал допустимое значение, и возвращается в ответе.
+func (h *ErrorHandler) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(RequestIDHeader)
+		if !validRequestID(requestID) {
+			requestID = uuid.NewString()
+		}
+		w.Header().Set(RequestIDHeader, requestID)
+
+		slot := &errorSlot{}
+		ctx := context.WithValue(r.Context(), requestIDKey{}, requestID)
+		ctx = context.WithValue(ctx, errorSlotKey{}, slot)
+		r = r.WithContext(ctx)
+
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				if recovered == http.ErrAbortHandler {
+					panic(recovered)
+				}
+				WriteError(w, r, apperrors.NewInternalServerError("Internal server error", fmt.Errorf("panic: %v", recovered)))
+			}
+
+			if slot.err != nil && slot.status >= http.StatusInternalServerError {
+				h.logger.Error("Request failed",
+					zap.String("request_id", requestID),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+					zap.Int("status_code", slot.status),
+					zap.Error(slot.err),
+				)
+			}
+		}()
+
+		next.ServeHTTP(w, r)
+	})
 }
 
 // HandleError обрабатывает ошибку и отправляет JSON ответ.
 func (h *ErrorHandler) HandleError(w http.ResponseWriter, r *http.Request, err error) {
-	var appErr *errors.AppError
-	var ok bool
+	WriteError(w, r, err)
+}
 
-	if appErr, ok = err.(*errors.AppError); !ok {
-		appErr = errors.NewInternalServerError("Internal server error", err)
+// NotFound отвечает ошибкой 404 для неизвестных маршрутов.
+func (h *ErrorHandler) NotFound(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, r, apperrors.NewNotFound("Route not found", nil))
+}
+
+// MethodNotAllowed отвечает ошибкой 405 для неподдерживаемых методов.
+func (h *ErrorHandler) MethodNotAllowed(w http.ResponseWriter, r *http.Request) {
+	WriteError(w, r, &apperrors.AppError{
+		Code:      http.StatusMethodNotAllowed,
+		ErrorCode: apperrors.CodeMethodNotAllowed,
+		Message:   "Method not allowed",
+	})
+}
+
+// WriteError отправляет ошибку в формате ErrorResponse с идентификатором запроса.
+// Ошибки, не описанные типами приложения, отправляются как внутренние без исходного текста.
+func WriteError(w http.ResponseWriter, r *http.Request, err error) {
+	body := NewErrorBody(err)
+	body.RequestID = RequestIDFromContext(r.Context())
+
+	if slot, ok := r.Context().Value(errorSlotKey{}).(*errorSlot); ok {
+		slot.err = err
+		slot.status = body.Status
+	}
+
+	var conflictErr *apperrors.VersionConflictError
+	if errors.As(err, &conflictErr) {
+		w.Header().Set("ETag", strconv.Quote(strconv.FormatInt(conflictErr.Current, 10)))
 	}
 
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(appErr.Code)
+	w.WriteHeader(body.Status)
+	_ = json.NewEncoder(w).Encode(apperrors.ErrorResponse{Error: body})
+}
 
-	response := map[string]interface{}{
-		"error": map[string]interface{}{
-			"code":    appErr.Code,
-			"message": appErr.Message,
-		},
+// NewErrorBody преобразует ошибку в описание ошибки API.
+func NewErrorBody(err error) apperrors.ErrorBody {
+	var policyErr *validator.PasswordPolicyError
+	var validationErr *validator.ValidationError
+	var conflictErr *apperrors.VersionConflictError
+	var appErr *apperrors.AppError
+
+	switch {
+	case errors.As(err, &policyErr):
+		return apperrors.ErrorBody{
+			Code:    apperrors.CodeValidationFailed,
+			Message: policyErr.Message,
+			Status:  http.StatusBadRequest,
+			Field:   policyErr.Field,
+			Details: map[string]interface{}{"feedback": policyErr.Feedback},
+		}
+	case errors.As(err, &validationErr):
+		return apperrors.ErrorBody{
+			Code:    apperrors.CodeValidationFailed,
+			Message: validationErr.Message,
+			Status:  http.StatusBadRequest,
+			Field:   validationErr.Field,
+		}
+	case errors.As(err, &conflictErr):
+		return apperrors.ErrorBody{
+			Code:    apperrors.CodeVersionConflict,
+			Message: conflictErr.Error(),
+			Status:  http.StatusConflict,
+			Details: map[string]interface{}{
+				"current_version":  conflictErr.Current,
+				"expected_version": conflictErr.Expected,
+			},
+		}
+	case errors.As(err, &appErr):
+		code := appErr.ErrorCode
+		if code == "" {
+			code = codeForStatus(appErr.Code)
+		}
+		return apperrors.ErrorBody{
+			Code:    code,
+			Message: appErr.Message,
+			Status:  appErr.Code,
+			Field:   appErr.Field,
+			Details: appErr.Details,
+		}
+	default:
+		return apperrors.ErrorBody{
+			Code:    apperrors.CodeInternal,
+			Message: "Internal server error",
+			Status:  http.StatusInternalServerError,
+		}
+	}
+}
+
+// codeForStatus подбирает код ошибки для AppError, созданной без ErrorCode.
+func codeForStatus(status int) apperrors.ErrorCode {
+	switch status {
+	case http.StatusBadRequest:
+		return apperrors.CodeBadRequest
+	case http.StatusUnauthorized:
+		return apperrors.CodeUnauthorized
+	case http.StatusForbidden:
+		return apperrors.CodeAccessDenied
+	case http.StatusNotFound:
+		return apperrors.CodeNotFound
+	case http.StatusMethodNotAllowed:
+		return apperrors.CodeMethodNotAllowed
+	case http.StatusConflict:
+		return apperrors.CodeConflict
+	case http.StatusRequestEntityTooLarge:
+		return apperrors.CodeRequestTooLarge
+	case http.StatusFailedDependency:
+		return apperrors.CodeFailedDependency
+	case http.StatusPreconditionRequired:
+		return apperrors.CodePreconditionRequired
+	case http.StatusNotImplemented:
+		return apperrors.CodeNotImplemented
 	}
+	if status >= http.StatusInternalServerError {
+		return apperrors.CodeInternal
+	}
+	return apperrors.CodeBadRequest
+}
 
-	_ = json.NewEncoder(w).Encode(response)
+// validRequestID проверяет, что идентификатор запроса от клиента непустой,
+// ограничен по длине и состоит из печатных ASCII символов без пробелов.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for i := 0; i < len(id); i++ {
+		if id[i] <= ' ' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
 }
@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/logger"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+	"go.uber.org/zap"
+)
+
+// recordingLogger запоминает сообщения уровня Error.
+type recordingLogger struct {
+	logger.MockLogger
+	errors []string
+}
+
+func (l *recordingLogger) Error(msg string, fields ...zap.Field) {
+	l.errors = append(l.errors, msg)
+}
+
+func decodeErrorResponse(t *testing.T, w *httptest.ResponseRecorder) apperrors.ErrorBody {
+	t.Helper()
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+
+	var response apperrors.ErrorResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return response.Error
+}
+
+func TestErrorHandler_Middleware(t *testing.T) {
+	t.Run("generates request ID", func(t *testing.T) {
+		handler := NewErrorHandler(logger.NewMockLogger())
+
+		var requestID string
+		next := handler.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requestID = RequestIDFromContext(r.Context())
+			WriteError(w, r, apperrors.NewNotFound("data item not found", nil))
+		}))
+
+		w := httptest.NewRecorder()
+		next.ServeHTTP(w, httptest.NewRequest("GET", "/data/1", nil))
+
+		_, err := uuid.Parse(requestID)
+		assert.NoError(t, err)
+		assert.Equal(t, requestID, w.Header().Get(RequestIDHeader))
+
+		body := decodeErrorResponse(t, w)
+		assert.Equal(t, http.StatusNotFound, w.Code)
+		assert.Equal(t, apperrors.CodeNotFound, body.Code)
+		assert.Equal(t, "data item not found", body.Message)
+		assert.Equal(t, http.StatusNotFound, body.Status)
+		assert.Equal(t, requestID, body.RequestID)
+	})
+
+	t.Run("keeps client request ID", func(t *testing.T) {
+		handler := NewErrorHandler(logger.NewMockLogger())
+		next := handler.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "client-request-1", RequestIDFromContext(r.Context()))
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set(RequestIDHeader, "client-request-1")
+		w := httptest.NewRecorder()
+		next.ServeHTTP(w, req)
+
+		assert.Equal(t, "client-request-1", w.Header().Get(RequestIDHeader))
+	})
+
+	t.Run("replaces invalid request ID", func(t *testing.T) {
+		handler := NewErrorHandler(logger.NewMockLogger())
+		next := handler.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusNoContent)
+		}))
+
+		for _, id := range []string{"with space", "line\nbreak", strings.Repeat("a", maxRequestIDLength+1)} {
+			req := httptest.NewRequest("GET", "/", nil)
+			req.Header.Set(RequestIDHeader, id)
+			w := httptest.NewRecorder()
+			next.ServeHTTP(w, req)
+
+			_, err := uuid.Parse(w.Header().Get(RequestIDHeader))
+			assert.NoError(t, err, id)
+		}
+	})
+
+	t.Run("recovers panic and logs cause", func(t *testing.T) {
+		log := &recordingLogger{}
+		handler := NewErrorHandler(log)
+		next := handler.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		}))
+
+		w := httptest.NewRecorder()
+		next.ServeHTTP(w, httptest.NewRequest("GET", "/", nil))
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+		body := decodeErrorResponse(t, w)
+		assert.Equal(t, apperrors.CodeInternal, body.Code)
+		assert.NotContains(t, body.Message, "boom")
+		assert.NotEmpty(t, body.RequestID)
+		assert.Len(t, log.errors, 1)
+	})
+
+	t.Run("client errors are not logged", func(t *testing.T) {
+		log := &recordingLogger{}
+		handler := NewErrorHandler(log)
+		next := handler.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			WriteError(w, r, apperrors.NewBadRequest("Invalid request body", nil))
+		}))
+
+		next.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("POST", "/", nil))
+
+		assert.Empty(t, log.errors)
+	})
+}
+
+func TestWriteError(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		status  int
+		code    apperrors.ErrorCode
+		message string
+		field   string
+	}{
+		{
+			name:    "validation error",
+			err:     fmt.Errorf("invalid data: %w", &validator.ValidationError{Field: "name", Message: "name is required"}),
+			status:  http.StatusBadRequest,
+			code:    apperrors.CodeValidationFailed,
+			message: "name is required",
+			field:   "name",
+		},
+		{
+			name:    "access denied",
+			err:     apperrors.NewForbidden("access denied", nil),
+			status:  http.StatusForbidden,
+			code:    apperrors.CodeAccessDenied,
+			message: "access denied",
+		},
+		{
+			name:    "conflict",
+			err:     fmt.Errorf("failed to create type: %w", apperrors.NewConflict("type api-key already exists", nil)),
+			status:  http.StatusConflict,
+			code:    apperrors.CodeConflict,
+			message: "type api-key already exists",
+		},
+		{
+			name:    "app error without code",
+			err:     &apperrors.AppError{Code: http.StatusNotFound, Message: "gone"},
+			status:  http.StatusNotFound,
+			code:    apperrors.CodeNotFound,
+			message: "gone",
+		},
+		{
+			name:    "untyped error is masked",
+			err:     fmt.Errorf("failed to query: connection refused"),
+			status:  http.StatusInternalServerError,
+			code:    apperrors.CodeInternal,
+			message: "Internal server error",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			WriteError(w, httptest.NewRequest("GET", "/", nil), tt.err)
+
+			assert.Equal(t, tt.status, w.Code)
+			body := decodeErrorResponse(t, w)
+			assert.Equal(t, tt.code, body.Code)
+			assert.Equal(t, tt.message, body.Message)
+			assert.Equal(t, tt.status, body.Status)
+			assert.Equal(t, tt.field, body.Field)
+		})
+	}
+
+	t.Run("version conflict", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		WriteError(w, httptest.NewRequest("PUT", "/", nil), &apperrors.VersionConflictError{Expected: 3, Current: 5})
+
+		assert.Equal(t, http.StatusConflict, w.Code)
+		assert.Equal(t, `"5"`, w.Header().Get("ETag"))
+		body := decodeErrorResponse(t, w)
+		assert.Equal(t, apperrors.CodeVersionConflict, body.Code)
+		assert.Equal(t, float64(5), body.Details["current_version"])
+		assert.Equal(t, float64(3), body.Details["expected_version"])
+	})
+
+	t.Run("password policy feedback", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		WriteError(w, httptest.NewRequest("POST", "/", nil), &validator.PasswordPolicyError{
+			ValidationError: validator.ValidationError{Field: "password", Message: "password is too weak"},
+			Feedback:        validator.PasswordFeedback{Score: 1, MinScore: 3},
+		})
+
+		body := decodeErrorResponse(t, w)
+		assert.Equal(t, apperrors.CodeValidationFailed, body.Code)
+		assert.Equal(t, "password", body.Field)
+		feedback, ok := body.Details["feedback"].(map[string]interface{})
+		require.True(t, ok)
+		assert.Equal(t, float64(3), feedback["min_score"])
+	})
+}
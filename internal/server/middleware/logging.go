@@ -36,6 +36,7 @@ func (m *LoggingMiddleware) Logging(next http.Handler) http.Handler {
 		duration := time.Since(start)
 
 		m.logger.Info("HTTP request",
+			zap.String("request_id", RequestIDFromContext(r.Context())),
 			zap.String("method", r.Method),
 			zap.String("path", r.URL.Path),
 			zap.String("query", r.URL.RawQuery),
@@ -48,6 +49,7 @@ func (m *LoggingMiddleware) Logging(next http.Handler) http.Handler {
 
 		if wrapped.statusCode >= 500 {
 			m.logger.Error("HTTP server error",
+				zap.String("request_id", RequestIDFromContext(r.Context())),
 				zap.String("method", r.Method),
 				zap.String("path", r.URL.Path),
 				zap.Int("status_code", wrapped.statusCode),
@@ -0,0 +1,142 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/openapi"
+)
+
+// RequestValidator проверяет параметры и JSON тела запросов по спецификации OpenAPI.
+type RequestValidator struct {
+	doc         *openapi.Document
+	maxBodySize int64
+}
+
+// NewRequestValidator создает новый экземпляр RequestValidator. maxBodySize ограничивает
+// размер проверяемого JSON тела запроса.
+func NewRequestValidator(doc *openapi.Document, maxBodySize int64) *RequestValidator {
+	return &RequestValidator{
+		doc:         doc,
+		maxBodySize: maxBodySize,
+	}
+}
+
+// Middleware находит операцию спецификации по шаблону маршрута и методу запроса и
+// проверяет параметры пути, запроса, заголовки и JSON тело. Запросы, не прошедшие
+// проверку, отклоняются ошибкой validation_failed; маршруты, не описанные в спецификации,
+// пропускаются без проверки. Тела других типов, например содержимое вложений, не читаются.
+func (v *RequestValidator) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op := v.operation(r)
+		if op == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		if err := v.validateParameters(op, r); err != nil {
+			WriteError(w, r, err)
+			return
+		}
+
+		if op.RequestBody != nil {
+			if media, ok := op.RequestBody.Content["application/json"]; ok {
+				body, err := v.validateBody(w, r, op.RequestBody.Required, media.Schema)
+				if err != nil {
+					WriteError(w, r, err)
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// operation возвращает операцию спецификации для маршрута запроса.
+func (v *RequestValidator) operation(r *http.Request) *openapi.Operation {
+	route := mux.CurrentRoute(r)
+	if route == nil {
+		return nil
+	}
+
+	template, err := route.GetPathTemplate()
+	if err != nil {
+		return nil
+	}
+
+	basePath := v.doc.BasePath()
+	if !strings.HasPrefix(template, basePath) {
+		return nil
+	}
+
+	return v.doc.Operation(r.Method, strings.TrimPrefix(template, basePath))
+}
+
+func (v *RequestValidator) validateParameters(op *openapi.Operation, r *http.Request) error {
+	var query map[string][]string
+	for _, param := range op.Parameters {
+		var values []string
+		switch param.In {
+		case "path":
+			if value, ok := mux.Vars(r)[param.Name]; ok {
+				values = []string{value}
+			}
+		case "query":
+			if query == nil {
+				query = r.URL.Query()
+			}
+			values = query[param.Name]
+		case "header":
+			values = r.Header.Values(param.Name)
+		}
+
+		if err := v.doc.ValidateParameter(param, values); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateBody читает JSON тело запроса и проверяет его по схеме. Возвращает прочитанное
+// тело для передачи обработчику.
+func (v *RequestValidator) validateBody(w http.ResponseWriter, r *http.Request, required bool, schema *openapi.Schema) ([]byte, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, v.maxBodySize))
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			return nil, apperrors.NewRequestEntityTooLarge(fmt.Sprintf("Request body must not exceed %d bytes", v.maxBodySize), err)
+		}
+		return nil, apperrors.NewBadRequest("Invalid request body", err)
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		if required {
+			return nil, apperrors.NewValidationError("", "request body is required", nil)
+		}
+		return body, nil
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+
+	var value interface{}
+	if err := decoder.Decode(&value); err != nil {
+		return nil, apperrors.NewBadRequest("Invalid request body", nil)
+	}
+
+	if err := v.doc.ValidateValue(schema, value, ""); err != nil {
+		return nil, err
+	}
+
+	return body, nil
+}
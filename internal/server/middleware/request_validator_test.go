@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/openapi"
+)
+
+// newValidatedRouter регистрирует обработчик на маршруте API под проверкой RequestValidator.
+func newValidatedRouter(t *testing.T, path, method string, maxBodySize int64, handler http.HandlerFunc) *mux.Router {
+	t.Helper()
+
+	doc, err := openapi.Load()
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	router.Use(NewRequestValidator(doc, maxBodySize).Middleware)
+	router.HandleFunc(path, handler).Methods(method)
+	return router
+}
+
+func TestRequestValidator_Middleware(t *testing.T) {
+	t.Run("body is available to handler", func(t *testing.T) {
+		var received string
+		router := newValidatedRouter(t, "/api/v1/tags/merge", "POST", 1024, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received = string(body)
+			w.WriteHeader(http.StatusNoContent)
+		})
+
+		body := `{"sources":["work"],"target":"job"}`
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/tags/merge", strings.NewReader(body)))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		assert.Equal(t, body, received)
+	})
+
+	t.Run("body too large", func(t *testing.T) {
+		router := newValidatedRouter(t, "/api/v1/tags/merge", "POST", 16, func(w http.ResponseWriter, r *http.Request) {
+			t.Fatal("handler must not be called")
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("POST", "/api/v1/tags/merge", strings.NewReader(`{"sources":["work"],"target":"job"}`)))
+
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+		assert.Equal(t, apperrors.CodeRequestTooLarge, decodeErrorResponse(t, w).Code)
+	})
+
+	t.Run("non-JSON body is not read", func(t *testing.T) {
+		var received string
+		router := newValidatedRouter(t, "/api/v1/data/{id}/attachments", "POST", 4, func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			received = string(body)
+			w.WriteHeader(http.StatusCreated)
+		})
+
+		req := httptest.NewRequest("POST", "/api/v1/data/7f0c4e1e-4b7a-4f4c-9a57-0b1f3b2d9c10/attachments?filename=a.json", strings.NewReader(`{"not":"validated"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, `{"not":"validated"}`, received)
+	})
+
+	t.Run("route outside specification", func(t *testing.T) {
+		router := newValidatedRouter(t, "/health", "GET", 16, func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		})
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest("GET", "/health?limit=0", nil))
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/uptrace/bun"
+)
+
+// attachmentRepository реализует интерфейс AttachmentRepository для работы с вложениями.
+type attachmentRepository struct {
+	db *bun.DB
+}
+
+// NewAttachmentRepository создает новый экземпляр AttachmentRepository.
+func NewAttachmentRepository(db *bun.DB) interfaces.AttachmentRepository {
+	return &attachmentRepository{db: db}
+}
+
+// Create создает новое вложение в базе данных.
+func (r *attachmentRepository) Create(ctx context.Context, attachment *models.Attachment) error {
+	_, err := idb(ctx, r.db).NewInsert().Model(attachment).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment: %w", err)
+	}
+	return nil
+}
+
+// Update сохраняет сведения о содержимом вложения после загрузки всех его частей.
+func (r *attachmentRepository) Update(ctx context.Context, attachment *models.Attachment) error {
+	_, err := idb(ctx, r.db).NewUpdate().
+		Model(attachment).
+		Column("mime_type", "size", "checksum", "chunks").
+		WherePK().
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update attachment: %w", err)
+	}
+	return nil
+}
+
+// GetByID получает вложение по ID. Содержимое вложений, хранящихся по частям,
+// читается отдельно через GetChunk.
+func (r *attachmentRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error) {
+	attachment := new(models.Attachment)
+	err := idb(ctx, r.db).NewSelect().
+		Model(attachment).
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment by id: %w", err)
+	}
+	return attachment, nil
+}
+
+// GetByDataID получает вложения элемента данных без содержимого.
+func (r *attachmentRepository) GetByDataID(ctx context.Context, dataID uuid.UUID) ([]*models.Attachment, error) {
+	var attachments []*models.Attachment
+	err := idb(ctx, r.db).NewSelect().
+		Model(&attachments).
+		ExcludeColumn("encrypted_content", "encryption_key", "chunks").
+		Where("data_id = ?", dataID).
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments by data id: %w", err)
+	}
+	return attachments, nil
+}
+
+// CreateChunk сохраняет часть содержимого вложения.
+func (r *attachmentRepository) CreateChunk(ctx context.Context, chunk *models.AttachmentChunk) error {
+	_, err := idb(ctx, r.db).NewInsert().Model(chunk).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create attachment chunk: %w", err)
+	}
+	return nil
+}
+
+// GetChunk получает часть содержимого вложения по номеру.
+func (r *attachmentRepository) GetChunk(ctx context.Context, attachmentID uuid.UUID, seq int) (*models.AttachmentChunk, error) {
+	chunk := new(models.AttachmentChunk)
+	err := idb(ctx, r.db).NewSelect().
+		Model(chunk).
+		Where("attachment_id = ? AND seq = ?", attachmentID, seq).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachment chunk: %w", err)
+	}
+	return chunk, nil
+}
+
+// Delete удаляет вложение из базы данных вместе с частями содержимого.
+func (r *attachmentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := idb(ctx, r.db).NewDelete().Model((*models.Attachment)(nil)).Where("id = ?", id).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/uptrace/bun"
+)
+
+// blindIndexRepository реализует интерфейс BlindIndexRepository для работы со слепыми индексами.
+type blindIndexRepository struct {
+	db *bun.DB
+}
+
+// NewBlindIndexRepository создает новый экземпляр BlindIndexRepository.
+func NewBlindIndexRepository(db *bun.DB) interfaces.BlindIndexRepository {
+	return &blindIndexRepository{db: db}
+}
+
+// Replace заменяет токены слепого индекса элемента данных.
+func (r *blindIndexRepository) Replace(ctx context.Context, userID, dataID uuid.UUID, tokens [][]byte) error {
+	return idb(ctx, r.db).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewDelete().
+			Model((*models.BlindIndexEntry)(nil)).
+			Where("data_id = ?", dataID).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete blind index: %w", err)
+		}
+
+		if len(tokens) == 0 {
+			return nil
+		}
+
+		entries := make([]*models.BlindIndexEntry, 0, len(tokens))
+		for _, token := range tokens {
+			entries = append(entries, &models.BlindIndexEntry{DataID: dataID, UserID: userID, Token: token})
+		}
+
+		if _, err := tx.NewInsert().Model(&entries).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to create blind index: %w", err)
+		}
+		return nil
+	})
+}
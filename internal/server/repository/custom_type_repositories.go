@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/uptrace/bun"
+)
+
+// customTypeRepository реализует интерфейс CustomTypeRepository для работы с пользовательскими типами данных.
+type customTypeRepository struct {
+	db *bun.DB
+}
+
+// NewCustomTypeRepository создает новый экземпляр CustomTypeRepository.
+func NewCustomTypeRepository(db *bun.DB) interfaces.CustomTypeRepository {
+	return &customTypeRepository{db: db}
+}
+
+// Create создает новый пользовательский тип данных в базе данных.
+func (r *customTypeRepository) Create(ctx context.Context, customType *models.CustomType) error {
+	_, err := idb(ctx, r.db).NewInsert().Model(customType).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create custom type: %w", err)
+	}
+	return nil
+}
+
+// GetByUserID получает все пользовательские типы данных пользователя.
+func (r *customTypeRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.CustomType, error) {
+	var customTypes []*models.CustomType
+	err := idb(ctx, r.db).NewSelect().
+		Model(&customTypes).
+		Where("user_id = ?", userID).
+		Order("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom types by user id: %w", err)
+	}
+	return customTypes, nil
+}
+
+// GetByUserIDAndName получает пользовательский тип данных по имени.
+func (r *customTypeRepository) GetByUserIDAndName(ctx context.Context, userID uuid.UUID, name string) (*models.CustomType, error) {
+	customType := new(models.CustomType)
+	err := idb(ctx, r.db).NewSelect().
+		Model(customType).
+		Where("user_id = ? AND name = ?", userID, name).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom type by name: %w", err)
+	}
+	return customType, nil
+}
+
+// Update обновляет пользовательский тип данных в базе данных.
+func (r *customTypeRepository) Update(ctx context.Context, customType *models.CustomType) error {
+	_, err := idb(ctx, r.db).NewUpdate().Model(customType).Where("id = ?", customType.ID).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update custom type: %w", err)
+	}
+	return nil
+}
+
+// Delete удаляет пользовательский тип данных из базы данных.
+func (r *customTypeRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := idb(ctx, r.db).NewDelete().Model((*models.CustomType)(nil)).Where("id = ?", id).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete custom type: %w", err)
+	}
+	return nil
+}
@@ -2,8 +2,11 @@
 package repository
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,7 +27,7 @@ func NewDataRepository(db *bun.DB) interfaces.DataRepository {
 
 // Create создает новый элемент данных в базе данных.
 func (r *dataRepository) Create(ctx context.Context, data *models.DataItem) error {
-	_, err := r.db.NewInsert().Model(data).Exec(ctx)
+	_, err := idb(ctx, r.db).NewInsert().Model(data).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create data item: %w", err)
 	}
@@ -34,7 +37,7 @@ func (r *dataRepository) Create(ctx context.Context, data *models.DataItem) erro
 // GetByID получает элемент данных по ID.
 func (r *dataRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.DataItem, error) {
 	data := new(models.DataItem)
-	err := r.db.NewSelect().
+	err := idb(ctx, r.db).NewSelect().
 		Model(data).
 		Relation("User").
 		Where("id = ?", id).
@@ -45,62 +48,426 @@ func (r *dataRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Dat
 	return data, nil
 }
 
-// GetByUserID получает все данные пользователя.
-func (r *dataRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.DataItem, error) {
+// GetByUserIDAndType получает данные пользователя определенного типа.
+func (r *dataRepository) GetByUserIDAndType(ctx context.Context, userID uuid.UUID, dataType models.DataType) ([]*models.DataItem, error) {
 	var items []*models.DataItem
-	err := r.db.NewSelect().
+	err := idb(ctx, r.db).NewSelect().
 		Model(&items).
-		Where("user_id = ?", userID).
+		Where("user_id = ? AND type = ?", userID, dataType).
+		Apply(whereActive).
 		Order("updated_at DESC").
 		Scan(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get data items by user id: %w", err)
+		return nil, fmt.Errorf("failed to get data items by user id and type: %w", err)
 	}
 	return items, nil
 }
 
-// GetByUserIDAndType получает данные пользователя определенного типа.
-func (r *dataRepository) GetByUserIDAndType(ctx context.Context, userID uuid.UUID, dataType models.DataType) ([]*models.DataItem, error) {
+// GetByUserIDAndFolderIDs получает данные пользователя, находящиеся в указанных папках.
+// Элементы с истекшим сроком действия не возвращаются, как и в списках данных.
+func (r *dataRepository) GetByUserIDAndFolderIDs(ctx context.Context, userID uuid.UUID, folderIDs []uuid.UUID) ([]*models.DataItem, error) {
 	var items []*models.DataItem
-	err := r.db.NewSelect().
+	err := idb(ctx, r.db).NewSelect().
 		Model(&items).
-		Where("user_id = ? AND type = ?", userID, dataType).
+		Where("user_id = ? AND folder_id IN (?)", userID, bun.In(folderIDs)).
+		Apply(whereActive).
 		Order("updated_at DESC").
 		Scan(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get data items by user id and type: %w", err)
+		return nil, fmt.Errorf("failed to get data items by folder ids: %w", err)
 	}
 	return items, nil
 }
 
-// Update обновляет элемент данных в базе данных.
-func (r *dataRepository) Update(ctx context.Context, data *models.DataItem) error {
-	_, err := r.db.NewUpdate().Model(data).Where("id = ?", data.ID).Exec(ctx)
-	if err != nil {
+// Find получает данные пользователя, удовлетворяющие условиям выборки, в порядке
+// query.Sort и query.Order. Элементы с одинаковым ключом сортировки упорядочиваются по ID,
+// поэтому позиция курсора query.After однозначна.
+func (r *dataRepository) Find(ctx context.Context, userID uuid.UUID, query models.DataQuery) ([]*models.DataItem, error) {
+	var items []*models.DataItem
+	q := idb(ctx, r.db).NewSelect().
+		Model(&items).
+		Where("data_item.user_id = ?", userID).
+		Apply(whereActive)
+
+	if query.Type != "" {
+		q = q.Where("data_item.type = ?", query.Type)
+	}
+
+	if len(query.FolderIDs) > 0 {
+		q = q.Where("data_item.folder_id IN (?)", bun.In(query.FolderIDs))
+	}
+
+	if len(query.Tags.Include) > 0 {
+		tagged := r.taggedItemsQuery(query.Tags.Include)
+		if query.Tags.Match == models.TagMatchAll {
+			q = q.Where("(?) = ?", tagged.ColumnExpr("COUNT(DISTINCT t.name)"), len(query.Tags.Include))
+		} else {
+			q = q.Where("EXISTS (?)", tagged.ColumnExpr("1"))
+		}
+	}
+
+	if len(query.Tags.Exclude) > 0 {
+		q = q.Where("NOT EXISTS (?)", r.taggedItemsQuery(query.Tags.Exclude).ColumnExpr("1"))
+	}
+
+	if query.Text != "" {
+		pattern := escapeLike(query.Text) + "%"
+		if query.TextMatch != models.SearchPrefix {
+			pattern = "%" + pattern
+		}
+		q = q.WhereGroup(" AND ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("data_item.name ILIKE ?", pattern).
+				WhereOr("data_item.metadata_tsv @@ plainto_tsquery('simple', ?)", query.Text)
+		})
+	}
+
+	if len(query.BlindTokens) > 0 {
+		indexed := r.db.NewSelect().
+			TableExpr("data_blind_indexes AS bi").
+			ColumnExpr("COUNT(DISTINCT bi.token)").
+			Where("bi.data_id = data_item.id").
+			Where("bi.user_id = ?", userID).
+			Where("bi.token IN (?)", bun.In(query.BlindTokens))
+		q = q.Where("(?) = ?", indexed, len(query.BlindTokens))
+	}
+
+	if query.CreatedAfter != nil {
+		q = q.Where("data_item.created_at >= ?", *query.CreatedAfter)
+	}
+	if query.CreatedBefore != nil {
+		q = q.Where("data_item.created_at < ?", *query.CreatedBefore)
+	}
+	if query.UpdatedAfter != nil {
+		q = q.Where("data_item.updated_at >= ?", *query.UpdatedAfter)
+	}
+	if query.UpdatedBefore != nil {
+		q = q.Where("data_item.updated_at < ?", *query.UpdatedBefore)
+	}
+
+	column, direction, comparison := sortColumn(query.Sort), "DESC", "<"
+	if query.Order == models.SortAsc {
+		direction, comparison = "ASC", ">"
+	}
+
+	if query.After != nil {
+		var value interface{} = query.After.Time
+		if query.Sort == models.SortByName {
+			value = query.After.Name
+		}
+		q = q.Where("(?, data_item.id) "+comparison+" (?, ?)", bun.Ident(column), value, query.After.ID)
+	}
+
+	q = q.OrderExpr("? "+direction+", data_item.id "+direction, bun.Ident(column))
+	if query.Limit > 0 {
+		q = q.Limit(query.Limit)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to find data items: %w", err)
+	}
+	return items, nil
+}
+
+// escapeLike экранирует специальные символы шаблона LIKE.
+func escapeLike(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(value)
+}
+
+// sortColumn возвращает столбец, соответствующий полю сортировки.
+func sortColumn(sort models.DataSort) string {
+	switch sort {
+	case models.SortByName:
+		return "data_item.name"
+	case models.SortByCreated:
+		return "data_item.created_at"
+	default:
+		return "data_item.updated_at"
+	}
+}
+
+// taggedItemsQuery возвращает подзапрос связей текущего элемента с тегами из списка names.
+func (r *dataRepository) taggedItemsQuery(names []string) *bun.SelectQuery {
+	return r.db.NewSelect().
+		TableExpr("data_item_tags AS dit").
+		Join("JOIN tags AS t ON t.id = dit.tag_id").
+		Where("dit.data_id = data_item.id").
+		Where("t.name IN (?)", bun.In(names))
+}
+
+// Update обновляет элемент данных в базе данных, если его версия равна expectedVersion,
+// иначе возвращает models.ErrVersionConflict. Проверка выполняется тем же запросом, что
+// и обновление, поэтому из двух конкурентных изменений одной версии применяется только
+// одно. Счетчик чтений изменяется только атомарно через IncrementReadCount и SetExpiry,
+// условия самоуничтожения — только через SetExpiry, поэтому изменение, прочитавшее элемент
+// до SetExpiry, не возвращает прежние expires_at и max_reads.
+func (r *dataRepository) Update(ctx context.Context, data *models.DataItem, expectedVersion int64) error {
+	query := idb(ctx, r.db).NewUpdate().
+		Model(data).
+		ExcludeColumn("read_count", "expires_at", "max_reads").
+		Where("id = ?", data.ID)
+	if err := r.execVersioned(ctx, query, expectedVersion); err != nil {
 		return fmt.Errorf("failed to update data item: %w", err)
 	}
 	return nil
 }
 
-// Delete удаляет элемент данных из базы данных.
-func (r *dataRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.NewDelete().Model((*models.DataItem)(nil)).Where("id = ?", id).Exec(ctx)
+// SetExpiry задает условия самоуничтожения элемента данных и сбрасывает счетчик чтений.
+func (r *dataRepository) SetExpiry(ctx context.Context, data *models.DataItem) error {
+	_, err := idb(ctx, r.db).NewUpdate().
+		Model(data).
+		Column("expires_at", "max_reads", "read_count", "updated_at").
+		Where("id = ?", data.ID).
+		Exec(ctx)
 	if err != nil {
+		return fmt.Errorf("failed to set data item expiry: %w", err)
+	}
+	return nil
+}
+
+// Delete перемещает элемент данных в корзину, если его версия равна expectedVersion,
+// иначе возвращает models.ErrVersionConflict. Изменение времени обновления позволяет
+// клиентам узнать об удалении при синхронизации.
+func (r *dataRepository) Delete(ctx context.Context, id uuid.UUID, expectedVersion int64) error {
+	now := time.Now()
+	query := idb(ctx, r.db).NewUpdate().
+		Model((*models.DataItem)(nil)).
+		Set("deleted_at = ?", now).
+		Set("updated_at = ?", now).
+		Where("id = ?", id)
+	if err := r.execVersioned(ctx, query, expectedVersion); err != nil {
 		return fmt.Errorf("failed to delete data item: %w", err)
 	}
 	return nil
 }
 
-// GetUpdatedSince получает данные, измененные после указанного времени.
-func (r *dataRepository) GetUpdatedSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]*models.DataItem, error) {
+// execVersioned выполняет изменение элемента данных с условием на его версию.
+func (r *dataRepository) execVersioned(ctx context.Context, query *bun.UpdateQuery, expectedVersion int64) error {
+	result, err := query.Where("version = ?", expectedVersion).Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return models.ErrVersionConflict
+	}
+	return nil
+}
+
+// GetDeletedByID получает элемент данных из корзины по ID.
+func (r *dataRepository) GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.DataItem, error) {
+	data := new(models.DataItem)
+	err := idb(ctx, r.db).NewSelect().
+		Model(data).
+		WhereDeleted().
+		Where("id = ?", id).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted data item by id: %w", err)
+	}
+	return data, nil
+}
+
+// GetDeletedByUserID получает элементы данных пользователя, находящиеся в корзине.
+func (r *dataRepository) GetDeletedByUserID(ctx context.Context, userID uuid.UUID) ([]*models.DataItem, error) {
 	var items []*models.DataItem
-	err := r.db.NewSelect().
+	err := idb(ctx, r.db).NewSelect().
 		Model(&items).
-		Where("user_id = ? AND updated_at > ?", userID, since).
-		Order("updated_at ASC").
+		WhereDeleted().
+		Where("user_id = ?", userID).
+		Order("deleted_at DESC").
 		Scan(ctx)
 	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted data items by user id: %w", err)
+	}
+	return items, nil
+}
+
+// Restore возвращает элемент данных из корзины.
+func (r *dataRepository) Restore(ctx context.Context, id uuid.UUID) error {
+	_, err := idb(ctx, r.db).NewUpdate().
+		Model((*models.DataItem)(nil)).
+		WhereDeleted().
+		Set("deleted_at = NULL").
+		Set("updated_at = ?", time.Now()).
+		Where("id = ?", id).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to restore data item: %w", err)
+	}
+	return nil
+}
+
+// ForceDelete окончательно удаляет элемент данных из базы данных вместе с версиями.
+func (r *dataRepository) ForceDelete(ctx context.Context, id uuid.UUID) error {
+	_, err := r.purge(ctx, func(q *bun.DeleteQuery) *bun.DeleteQuery {
+		return q.WhereAllWithDeleted().Where("id = ?", id)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to force delete data item: %w", err)
+	}
+	return nil
+}
+
+// PurgeDeleted окончательно удаляет все элементы данных пользователя из корзины.
+func (r *dataRepository) PurgeDeleted(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := r.purge(ctx, func(q *bun.DeleteQuery) *bun.DeleteQuery {
+		return q.WhereDeleted().Where("user_id = ?", userID)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted data items: %w", err)
+	}
+	return count, nil
+}
+
+// PurgeDeletedBefore окончательно удаляет элементы данных, находящиеся в корзине с момента до before.
+func (r *dataRepository) PurgeDeletedBefore(ctx context.Context, before time.Time) (int, error) {
+	count, err := r.purge(ctx, func(q *bun.DeleteQuery) *bun.DeleteQuery {
+		return q.WhereDeleted().Where("deleted_at < ?", before)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired data items: %w", err)
+	}
+	return count, nil
+}
+
+// PurgeExpired окончательно удаляет элементы данных, срок действия которых истек к моменту now
+// или лимит чтений которых исчерпан, включая находящиеся в корзине.
+func (r *dataRepository) PurgeExpired(ctx context.Context, now time.Time) (int, error) {
+	count, err := r.purge(ctx, func(q *bun.DeleteQuery) *bun.DeleteQuery {
+		return q.WhereAllWithDeleted().
+			WhereGroup(" AND ", func(q *bun.DeleteQuery) *bun.DeleteQuery {
+				return q.Where("expires_at <= ?", now).
+					WhereOr("max_reads IS NOT NULL AND read_count >= max_reads")
+			})
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge self-destructed data items: %w", err)
+	}
+	return count, nil
+}
+
+// IncrementReadCount атомарно увеличивает счетчик чтений элемента данных и возвращает
+// новое значение. Если срок действия элемента истек или лимит чтений исчерпан,
+// возвращается sql.ErrNoRows.
+func (r *dataRepository) IncrementReadCount(ctx context.Context, id uuid.UUID) (int, error) {
+	var readCount int
+	_, err := idb(ctx, r.db).NewUpdate().
+		Model((*models.DataItem)(nil)).
+		Set("read_count = read_count + 1").
+		Where("id = ?", id).
+		Where("expires_at IS NULL OR expires_at > now()").
+		Where("max_reads IS NULL OR read_count < max_reads").
+		Returning("read_count").
+		Exec(ctx, &readCount)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment read count: %w", err)
+	}
+	return readCount, nil
+}
+
+// purge окончательно удаляет выбранные apply элементы данных и в той же транзакции
+// оставляет на их месте записи об удалении для синхронизации клиентов.
+func (r *dataRepository) purge(ctx context.Context, apply func(*bun.DeleteQuery) *bun.DeleteQuery) (int, error) {
+	var purged []*models.DataTombstone
+	err := idb(ctx, r.db).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		q := tx.NewDelete().
+			Model((*models.DataItem)(nil)).
+			ForceDelete().
+			Returning("id AS data_id, user_id, now() AS deleted_at")
+		if _, err := apply(q).Exec(ctx, &purged); err != nil {
+			return err
+		}
+
+		if len(purged) == 0 {
+			return nil
+		}
+
+		_, err := tx.NewInsert().
+			Model(&purged).
+			On("CONFLICT (data_id) DO UPDATE").
+			Set("deleted_at = EXCLUDED.deleted_at").
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to create tombstones: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return len(purged), nil
+}
+
+// GetUpdatedSince получает данные, измененные после указанного времени, включая
+// перемещенные в корзину и окончательно удаленные, чтобы клиенты могли удалить их локально.
+// Окончательно удаленные элементы содержат только ID, время удаления и время изменения.
+// Результат упорядочен по времени изменения и ID; after и limit задают позицию и размер страницы.
+func (r *dataRepository) GetUpdatedSince(ctx context.Context, userID uuid.UUID, since time.Time, after *models.DataCursor, limit int) ([]*models.DataItem, error) {
+	var items []*models.DataItem
+	q := idb(ctx, r.db).NewSelect().
+		Model(&items).
+		WhereAllWithDeleted().
+		Where("user_id = ? AND updated_at > ?", userID, since)
+	if after != nil {
+		q = q.Where("(updated_at, id) > (?, ?)", after.Time, after.ID)
+	}
+	q = q.Order("updated_at ASC", "id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Scan(ctx); err != nil {
 		return nil, fmt.Errorf("failed to get updated data items: %w", err)
 	}
+
+	var tombstones []*models.DataTombstone
+	q = idb(ctx, r.db).NewSelect().
+		Model(&tombstones).
+		Where("user_id = ? AND deleted_at > ?", userID, since)
+	if after != nil {
+		q = q.Where("(deleted_at, data_id) > (?, ?)", after.Time, after.ID)
+	}
+	q = q.Order("deleted_at ASC", "data_id ASC")
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Scan(ctx); err != nil {
+		return nil, fmt.Errorf("failed to get tombstones: %w", err)
+	}
+
+	if len(tombstones) == 0 {
+		return items, nil
+	}
+
+	for _, tombstone := range tombstones {
+		deletedAt := tombstone.DeletedAt
+		items = append(items, &models.DataItem{
+			ID:        tombstone.DataID,
+			UserID:    tombstone.UserID,
+			UpdatedAt: deletedAt,
+			DeletedAt: &deletedAt,
+		})
+	}
+	sort.SliceStable(items, func(i, j int) bool {
+		if !items[i].UpdatedAt.Equal(items[j].UpdatedAt) {
+			return items[i].UpdatedAt.Before(items[j].UpdatedAt)
+		}
+		return bytes.Compare(items[i].ID[:], items[j].ID[:]) < 0
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
 	return items, nil
 }
+
+// whereActive исключает из выборки элементы данных с истекшим сроком действия
+// или исчерпанным лимитом чтений, еще не удаленные фоновой очисткой.
+func whereActive(q *bun.SelectQuery) *bun.SelectQuery {
+	return q.
+		Where("data_item.expires_at IS NULL OR data_item.expires_at > now()").
+		Where("data_item.max_reads IS NULL OR data_item.read_count < data_item.max_reads")
+}
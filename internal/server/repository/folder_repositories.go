@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/uptrace/bun"
+)
+
+// folderRepository реализует интерфейс FolderRepository для работы с папками.
+type folderRepository struct {
+	db *bun.DB
+}
+
+// NewFolderRepository создает новый экземпляр FolderRepository.
+func NewFolderRepository(db *bun.DB) interfaces.FolderRepository {
+	return &folderRepository{db: db}
+}
+
+// Create создает новую папку в базе данных.
+func (r *folderRepository) Create(ctx context.Context, folder *models.Folder) error {
+	_, err := idb(ctx, r.db).NewInsert().Model(folder).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create folder: %w", err)
+	}
+	return nil
+}
+
+// GetByUserID получает все папки пользователя.
+func (r *folderRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Folder, error) {
+	var folders []*models.Folder
+	err := idb(ctx, r.db).NewSelect().
+		Model(&folders).
+		Where("user_id = ?", userID).
+		Order("name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folders by user id: %w", err)
+	}
+	return folders, nil
+}
+
+// Update обновляет папку в базе данных.
+func (r *folderRepository) Update(ctx context.Context, folder *models.Folder) error {
+	_, err := idb(ctx, r.db).NewUpdate().Model(folder).Where("id = ?", folder.ID).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to update folder: %w", err)
+	}
+	return nil
+}
+
+// Delete удаляет папку из базы данных.
+func (r *folderRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := idb(ctx, r.db).NewDelete().Model((*models.Folder)(nil)).Where("id = ?", id).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete folder: %w", err)
+	}
+	return nil
+}
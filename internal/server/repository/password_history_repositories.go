@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/uptrace/bun"
+)
+
+// passwordHistoryRepository реализует интерфейс PasswordHistoryRepository для работы с историей паролей.
+type passwordHistoryRepository struct {
+	db *bun.DB
+}
+
+// NewPasswordHistoryRepository создает новый экземпляр PasswordHistoryRepository.
+func NewPasswordHistoryRepository(db *bun.DB) interfaces.PasswordHistoryRepository {
+	return &passwordHistoryRepository{db: db}
+}
+
+// Create сохраняет предыдущий пароль элемента данных.
+func (r *passwordHistoryRepository) Create(ctx context.Context, entry *models.PasswordHistoryEntry) error {
+	_, err := idb(ctx, r.db).NewInsert().Model(entry).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to create password history entry: %w", err)
+	}
+	return nil
+}
+
+// GetByDataID получает историю паролей элемента данных, начиная с последней смены.
+func (r *passwordHistoryRepository) GetByDataID(ctx context.Context, dataID uuid.UUID) ([]*models.PasswordHistoryEntry, error) {
+	var entries []*models.PasswordHistoryEntry
+	err := idb(ctx, r.db).NewSelect().
+		Model(&entries).
+		Where("data_id = ?", dataID).
+		Order("changed_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password history: %w", err)
+	}
+	return entries, nil
+}
+
+// DeleteOlderEntries удаляет записи истории паролей элемента данных, кроме keep последних.
+func (r *passwordHistoryRepository) DeleteOlderEntries(ctx context.Context, dataID uuid.UUID, keep int) error {
+	latest := idb(ctx, r.db).NewSelect().
+		Model((*models.PasswordHistoryEntry)(nil)).
+		Column("id").
+		Where("data_id = ?", dataID).
+		Order("changed_at DESC").
+		Limit(keep)
+
+	_, err := idb(ctx, r.db).NewDelete().
+		Model((*models.PasswordHistoryEntry)(nil)).
+		Where("data_id = ?", dataID).
+		Where("id NOT IN (?)", latest).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete older password history entries: %w", err)
+	}
+	return nil
+}
@@ -23,7 +23,7 @@ func NewSessionRepository(db *bun.DB) interfaces.SessionRepository {
 
 // Create создает новую сессию пользователя в базе данных.
 func (r *sessionRepository) Create(ctx context.Context, session *models.UserSession) error {
-	_, err := r.db.NewInsert().Model(session).Exec(ctx)
+	_, err := idb(ctx, r.db).NewInsert().Model(session).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create session: %w", err)
 	}
@@ -33,7 +33,7 @@ func (r *sessionRepository) Create(ctx context.Context, session *models.UserSess
 // GetByRefreshToken получает сессию по refresh токену.
 func (r *sessionRepository) GetByRefreshToken(ctx context.Context, refreshToken string) (*models.UserSession, error) {
 	session := new(models.UserSession)
-	err := r.db.NewSelect().
+	err := idb(ctx, r.db).NewSelect().
 		Model(session).
 		Relation("User").
 		Where("refresh_token = ?", refreshToken).
@@ -47,7 +47,7 @@ func (r *sessionRepository) GetByRefreshToken(ctx context.Context, refreshToken
 // GetByUserID получает все сессии пользователя.
 func (r *sessionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.UserSession, error) {
 	var sessions []*models.UserSession
-	err := r.db.NewSelect().
+	err := idb(ctx, r.db).NewSelect().
 		Model(&sessions).
 		Where("user_id = ?", userID).
 		Scan(ctx)
@@ -59,7 +59,7 @@ func (r *sessionRepository) GetByUserID(ctx context.Context, userID uuid.UUID) (
 
 // Update обновляет данные сессии в базе данных.
 func (r *sessionRepository) Update(ctx context.Context, session *models.UserSession) error {
-	_, err := r.db.NewUpdate().Model(session).Where("id = ?", session.ID).Exec(ctx)
+	_, err := idb(ctx, r.db).NewUpdate().Model(session).Where("id = ?", session.ID).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to update session: %w", err)
 	}
@@ -68,7 +68,7 @@ func (r *sessionRepository) Update(ctx context.Context, session *models.UserSess
 
 // Delete удаляет сессию из базы данных.
 func (r *sessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.NewDelete().Model((*models.UserSession)(nil)).Where("id = ?", id).Exec(ctx)
+	_, err := idb(ctx, r.db).NewDelete().Model((*models.UserSession)(nil)).Where("id = ?", id).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to delete session: %w", err)
 	}
@@ -77,7 +77,7 @@ func (r *sessionRepository) Delete(ctx context.Context, id uuid.UUID) error {
 
 // DeleteByUserID удаляет все сессии пользователя.
 func (r *sessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID) error {
-	_, err := r.db.NewDelete().Model((*models.UserSession)(nil)).Where("user_id = ?", userID).Exec(ctx)
+	_, err := idb(ctx, r.db).NewDelete().Model((*models.UserSession)(nil)).Where("user_id = ?", userID).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to delete sessions by user id: %w", err)
 	}
@@ -86,7 +86,7 @@ func (r *sessionRepository) DeleteByUserID(ctx context.Context, userID uuid.UUID
 
 // DeleteExpired удаляет истекшие сессии.
 func (r *sessionRepository) DeleteExpired(ctx context.Context) error {
-	_, err := r.db.NewDelete().
+	_, err := idb(ctx, r.db).NewDelete().
 		Model((*models.UserSession)(nil)).
 		Where("expires_at < ?", time.Now()).
 		Exec(ctx)
@@ -0,0 +1,231 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/uptrace/bun"
+)
+
+// tagRepository реализует интерфейс TagRepository для работы с тегами.
+type tagRepository struct {
+	db *bun.DB
+}
+
+// NewTagRepository создает новый экземпляр TagRepository.
+func NewTagRepository(db *bun.DB) interfaces.TagRepository {
+	return &tagRepository{db: db}
+}
+
+// GetByUserID получает все теги пользователя с количеством помеченных элементов.
+func (r *tagRepository) GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Tag, error) {
+	var tags []*models.Tag
+	err := idb(ctx, r.db).NewSelect().
+		Model(&tags).
+		ColumnExpr("tag.*").
+		ColumnExpr("(SELECT COUNT(*) FROM data_item_tags AS dit JOIN data_items AS di ON di.id = dit.data_id WHERE dit.tag_id = tag.id AND di.deleted_at IS NULL) AS item_count").
+		Where("tag.user_id = ?", userID).
+		Order("tag.name ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags by user id: %w", err)
+	}
+	return tags, nil
+}
+
+// GetByUserIDAndName получает тег пользователя по имени.
+func (r *tagRepository) GetByUserIDAndName(ctx context.Context, userID uuid.UUID, name string) (*models.Tag, error) {
+	tag := new(models.Tag)
+	err := idb(ctx, r.db).NewSelect().
+		Model(tag).
+		Where("user_id = ? AND name = ?", userID, name).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tag by name: %w", err)
+	}
+	return tag, nil
+}
+
+// GetNamesByDataIDs получает имена тегов для каждого из элементов данных.
+func (r *tagRepository) GetNamesByDataIDs(ctx context.Context, dataIDs []uuid.UUID) (map[uuid.UUID][]string, error) {
+	result := make(map[uuid.UUID][]string, len(dataIDs))
+	if len(dataIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		DataID uuid.UUID `bun:"data_id"`
+		Name   string    `bun:"name"`
+	}
+	err := idb(ctx, r.db).NewSelect().
+		TableExpr("data_item_tags AS dit").
+		Join("JOIN tags AS t ON t.id = dit.tag_id").
+		ColumnExpr("dit.data_id, t.name").
+		Where("dit.data_id IN (?)", bun.In(dataIDs)).
+		OrderExpr("t.name ASC").
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags by data ids: %w", err)
+	}
+
+	for _, row := range rows {
+		result[row.DataID] = append(result[row.DataID], row.Name)
+	}
+	return result, nil
+}
+
+// AddToData помечает элемент данных тегами, создавая отсутствующие теги.
+func (r *tagRepository) AddToData(ctx context.Context, userID, dataID uuid.UUID, names []string) error {
+	return idb(ctx, r.db).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		tagIDs, err := ensureTags(ctx, tx, userID, names)
+		if err != nil {
+			return err
+		}
+
+		links := make([]*models.DataItemTag, 0, len(tagIDs))
+		for _, tagID := range tagIDs {
+			links = append(links, &models.DataItemTag{DataID: dataID, TagID: tagID})
+		}
+
+		if _, err := tx.NewInsert().Model(&links).On("CONFLICT DO NOTHING").Exec(ctx); err != nil {
+			return fmt.Errorf("failed to add tags to data item: %w", err)
+		}
+		return nil
+	})
+}
+
+// RemoveFromData снимает теги с элемента данных.
+func (r *tagRepository) RemoveFromData(ctx context.Context, userID, dataID uuid.UUID, names []string) error {
+	_, err := idb(ctx, r.db).NewDelete().
+		Model((*models.DataItemTag)(nil)).
+		Where("data_id = ?", dataID).
+		Where("tag_id IN (?)", idb(ctx, r.db).NewSelect().
+			Model((*models.Tag)(nil)).
+			Column("id").
+			Where("user_id = ? AND name IN (?)", userID, bun.In(names))).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to remove tags from data item: %w", err)
+	}
+	return nil
+}
+
+// Rename переименовывает тег и обновляет время изменения помеченных им элементов данных.
+func (r *tagRepository) Rename(ctx context.Context, tag *models.Tag, name string) error {
+	err := idb(ctx, r.db).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		_, err := tx.NewUpdate().
+			Model(tag).
+			Set("name = ?", name).
+			WherePK().
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to rename tag: %w", err)
+		}
+
+		return touchTaggedData(ctx, tx, bun.In([]uuid.UUID{tag.ID}))
+	})
+	if err != nil {
+		return err
+	}
+	tag.Name = name
+	return nil
+}
+
+// Merge переносит элементы исходных тегов на целевой тег, удаляет исходные теги и
+// обновляет время изменения затронутых элементов данных.
+func (r *tagRepository) Merge(ctx context.Context, userID uuid.UUID, sources []string, target string) error {
+	return idb(ctx, r.db).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		targetIDs, err := ensureTags(ctx, tx, userID, []string{target})
+		if err != nil {
+			return err
+		}
+
+		sourceIDs := tx.NewSelect().
+			Model((*models.Tag)(nil)).
+			Column("id").
+			Where("user_id = ? AND name IN (?)", userID, bun.In(sources))
+
+		_, err = tx.NewRaw(
+			"INSERT INTO data_item_tags (data_id, tag_id) SELECT DISTINCT data_id, ? FROM data_item_tags WHERE tag_id IN (?) ON CONFLICT DO NOTHING",
+			targetIDs[0], sourceIDs,
+		).Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to merge tags: %w", err)
+		}
+
+		if err := touchTaggedData(ctx, tx, sourceIDs); err != nil {
+			return err
+		}
+
+		if _, err := tx.NewDelete().Model((*models.DataItemTag)(nil)).Where("tag_id IN (?)", sourceIDs).Exec(ctx); err != nil {
+			return fmt.Errorf("failed to merge tags: %w", err)
+		}
+
+		_, err = tx.NewDelete().
+			Model((*models.Tag)(nil)).
+			Where("user_id = ? AND name IN (?)", userID, bun.In(sources)).
+			Exec(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to delete merged tags: %w", err)
+		}
+		return nil
+	})
+}
+
+// touchTaggedData обновляет время изменения и версию элементов данных, помеченных тегами
+// tagIDs (список или подзапрос ID), чтобы изменение тегов попало в синхронизацию, а
+// конкурентное изменение элемента с прежней версией получило конфликт. Для каждой новой
+// версии тем же запросом сохраняется запись в истории версий; ограничение числа хранимых
+// версий применяется при следующем изменении элемента.
+func touchTaggedData(ctx context.Context, tx bun.Tx, tagIDs interface{}) error {
+	touched := tx.NewUpdate().
+		Table("data_items").
+		Set("updated_at = ?", time.Now()).
+		Set("version = version + 1").
+		Where("id IN (?)", tx.NewSelect().
+			Model((*models.DataItemTag)(nil)).
+			Column("data_id").
+			Where("tag_id IN (?)", tagIDs)).
+		Returning("id, version, name, metadata, encrypted_data")
+
+	_, err := tx.NewRaw(
+		"WITH touched AS (?) INSERT INTO data_versions (data_id, version, name, metadata, encrypted_data) SELECT id, version, name, metadata, encrypted_data FROM touched",
+		touched,
+	).Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to touch tagged data items: %w", err)
+	}
+	return nil
+}
+
+// ensureTags создает отсутствующие теги пользователя и возвращает ID всех указанных тегов.
+func ensureTags(ctx context.Context, tx bun.Tx, userID uuid.UUID, names []string) ([]uuid.UUID, error) {
+	tags := make([]*models.Tag, 0, len(names))
+	for _, name := range names {
+		tags = append(tags, &models.Tag{UserID: userID, Name: name})
+	}
+
+	_, err := tx.NewInsert().
+		Model(&tags).
+		On("CONFLICT (user_id, name) DO NOTHING").
+		Returning("NULL").
+		Exec(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tags: %w", err)
+	}
+
+	var tagIDs []uuid.UUID
+	err = tx.NewSelect().
+		Model((*models.Tag)(nil)).
+		Column("id").
+		Where("user_id = ? AND name IN (?)", userID, bun.In(names)).
+		Scan(ctx, &tagIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	return tagIDs, nil
+}
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/uptrace/bun"
+)
+
+// txKey — ключ контекста, под которым хранится текущая транзакция.
+type txKey struct{}
+
+// idb возвращает транзакцию, начатую Transactor.RunInTx, если контекст выполняется внутри
+// нее, иначе db. Через него выполняются все запросы репозиториев, поэтому операции
+// нескольких репозиториев попадают в одну транзакцию, а их собственные транзакции
+// становятся точками сохранения внутри нее.
+func idb(ctx context.Context, db *bun.DB) bun.IDB {
+	if tx, ok := ctx.Value(txKey{}).(bun.Tx); ok {
+		return tx
+	}
+	return db
+}
+
+// transactor реализует интерфейс Transactor на основе транзакций bun.
+type transactor struct {
+	db *bun.DB
+}
+
+// NewTransactor создает новый экземпляр Transactor.
+func NewTransactor(db *bun.DB) interfaces.Transactor {
+	return &transactor{db: db}
+}
+
+// RunInTx выполняет fn в транзакции и фиксирует ее, если fn не вернула ошибку. Вложенный
+// вызов выполняется в точке сохранения внешней транзакции.
+func (t *transactor) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	return idb(ctx, t.db).RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+		return fn(context.WithValue(ctx, txKey{}, tx))
+	})
+}
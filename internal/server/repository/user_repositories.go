@@ -22,7 +22,7 @@ func NewUserRepository(db *bun.DB) interfaces.UserRepository {
 
 // Create создает нового пользователя в базе данных.
 func (r *userRepository) Create(ctx context.Context, user *models.User) error {
-	_, err := r.db.NewInsert().Model(user).Exec(ctx)
+	_, err := idb(ctx, r.db).NewInsert().Model(user).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create user: %w", err)
 	}
@@ -32,7 +32,7 @@ func (r *userRepository) Create(ctx context.Context, user *models.User) error {
 // GetByEmail получает пользователя по email.
 func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	user := new(models.User)
-	err := r.db.NewSelect().Model(user).Where("email = ?", email).Scan(ctx)
+	err := idb(ctx, r.db).NewSelect().Model(user).Where("email = ?", email).Scan(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by email: %w", err)
 	}
@@ -42,7 +42,7 @@ func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.
 // GetByID получает пользователя по ID.
 func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.User, error) {
 	user := new(models.User)
-	err := r.db.NewSelect().Model(user).Where("id = ?", id).Scan(ctx)
+	err := idb(ctx, r.db).NewSelect().Model(user).Where("id = ?", id).Scan(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get user by id: %w", err)
 	}
@@ -51,7 +51,7 @@ func (r *userRepository) GetByID(ctx context.Context, id uuid.UUID) (*models.Use
 
 // Update обновляет данные пользователя в базе данных.
 func (r *userRepository) Update(ctx context.Context, user *models.User) error {
-	_, err := r.db.NewUpdate().Model(user).Where("id = ?", user.ID).Exec(ctx)
+	_, err := idb(ctx, r.db).NewUpdate().Model(user).Where("id = ?", user.ID).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to update user: %w", err)
 	}
@@ -60,7 +60,7 @@ func (r *userRepository) Update(ctx context.Context, user *models.User) error {
 
 // Delete удаляет пользователя из базы данных.
 func (r *userRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	_, err := r.db.NewDelete().Model((*models.User)(nil)).Where("id = ?", id).Exec(ctx)
+	_, err := idb(ctx, r.db).NewDelete().Model((*models.User)(nil)).Where("id = ?", id).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -22,18 +22,19 @@ func NewVersionRepository(db *bun.DB) interfaces.VersionRepository {
 
 // Create создает новую версию данных в базе данных.
 func (r *versionRepository) Create(ctx context.Context, version *models.DataVersion) error {
-	_, err := r.db.NewInsert().Model(version).Exec(ctx)
+	_, err := idb(ctx, r.db).NewInsert().Model(version).Exec(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to create data version: %w", err)
 	}
 	return nil
 }
 
-// GetByDataID получает все версии данных по ID элемента данных.
+// GetByDataID получает все версии данных по ID элемента данных без зашифрованного содержимого.
 func (r *versionRepository) GetByDataID(ctx context.Context, dataID uuid.UUID) ([]*models.DataVersion, error) {
 	var versions []*models.DataVersion
-	err := r.db.NewSelect().
+	err := idb(ctx, r.db).NewSelect().
 		Model(&versions).
+		ExcludeColumn("encrypted_data").
 		Where("data_id = ?", dataID).
 		Order("version DESC").
 		Scan(ctx)
@@ -46,7 +47,7 @@ func (r *versionRepository) GetByDataID(ctx context.Context, dataID uuid.UUID) (
 // GetLatestVersion получает последнюю версию данных по ID элемента данных.
 func (r *versionRepository) GetLatestVersion(ctx context.Context, dataID uuid.UUID) (*models.DataVersion, error) {
 	version := new(models.DataVersion)
-	err := r.db.NewSelect().
+	err := idb(ctx, r.db).NewSelect().
 		Model(version).
 		Where("data_id = ?", dataID).
 		Order("version DESC").
@@ -57,3 +58,36 @@ func (r *versionRepository) GetLatestVersion(ctx context.Context, dataID uuid.UU
 	}
 	return version, nil
 }
+
+// GetByDataIDAndVersion получает версию данных по ID элемента данных и номеру версии.
+func (r *versionRepository) GetByDataIDAndVersion(ctx context.Context, dataID uuid.UUID, version int64) (*models.DataVersion, error) {
+	dataVersion := new(models.DataVersion)
+	err := idb(ctx, r.db).NewSelect().
+		Model(dataVersion).
+		Where("data_id = ? AND version = ?", dataID, version).
+		Scan(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data version: %w", err)
+	}
+	return dataVersion, nil
+}
+
+// DeleteOlderVersions удаляет версии элемента данных, кроме keep последних.
+func (r *versionRepository) DeleteOlderVersions(ctx context.Context, dataID uuid.UUID, keep int) error {
+	latest := idb(ctx, r.db).NewSelect().
+		Model((*models.DataVersion)(nil)).
+		Column("id").
+		Where("data_id = ?", dataID).
+		Order("version DESC").
+		Limit(keep)
+
+	_, err := idb(ctx, r.db).NewDelete().
+		Model((*models.DataVersion)(nil)).
+		Where("data_id = ?", dataID).
+		Where("id NOT IN (?)", latest).
+		Exec(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to delete older data versions: %w", err)
+	}
+	return nil
+}
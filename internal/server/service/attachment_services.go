@@ -0,0 +1,332 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	"github.com/tempizhere/vaultfactory/internal/shared/crypto"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+// attachmentService реализует интерфейс AttachmentService для работы с вложениями.
+type attachmentService struct {
+	attachmentRepo   interfaces.AttachmentRepository
+	dataRepo         interfaces.DataRepository
+	versionRepo      interfaces.VersionRepository
+	crypto           *crypto.CryptoService
+	transactor       interfaces.Transactor
+	validator        *validator.Validator
+	maxSize          int64
+	chunkSize        int
+	versionRetention int
+}
+
+// NewAttachmentService создает новый экземпляр AttachmentService. maxSize ограничивает
+// размер содержимого одного вложения в байтах, versionRetention задает, сколько последних
+// версий элемента хранить (0 — без ограничений).
+func NewAttachmentService(
+	attachmentRepo interfaces.AttachmentRepository,
+	dataRepo interfaces.DataRepository,
+	versionRepo interfaces.VersionRepository,
+	crypto *crypto.CryptoService,
+	transactor interfaces.Transactor,
+	maxSize int64,
+	versionRetention int,
+) interfaces.AttachmentService {
+	return &attachmentService{
+		attachmentRepo:   attachmentRepo,
+		dataRepo:         dataRepo,
+		versionRepo:      versionRepo,
+		crypto:           crypto,
+		transactor:       transactor,
+		validator:        validator.NewValidator(),
+		maxSize:          maxSize,
+		chunkSize:        constants.AttachmentChunkSize,
+		versionRetention: versionRetention,
+	}
+}
+
+// AddAttachment шифрует содержимое собственным ключом и прикрепляет его к элементу данных.
+// Содержимое читается и сохраняется частями, поэтому целиком в памяти не хранится; при
+// превышении максимального размера загрузка прерывается и транзакция откатывается.
+// Если mimeType не указан, он определяется по первой части содержимого.
+func (s *attachmentService) AddAttachment(ctx context.Context, userID, dataID uuid.UUID, filename, mimeType string, content io.Reader) (*models.Attachment, error) {
+	if err := s.validator.ValidateAttachmentFilename(filename); err != nil {
+		return nil, err
+	}
+
+	dataItem, err := s.getOwnedData(ctx, userID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptionKey, err := s.crypto.GenerateKey()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate encryption key: %w", err)
+	}
+
+	attachment := &models.Attachment{
+		ID:            uuid.New(),
+		DataID:        dataItem.ID,
+		Filename:      filename,
+		MimeType:      mimeType,
+		EncryptionKey: encryptionKey,
+		CreatedAt:     time.Now(),
+	}
+
+	err = s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.attachmentRepo.Create(ctx, attachment); err != nil {
+			return fmt.Errorf("failed to create attachment: %w", err)
+		}
+
+		if err := s.storeChunks(ctx, attachment, content); err != nil {
+			return err
+		}
+
+		if err := s.attachmentRepo.Update(ctx, attachment); err != nil {
+			return fmt.Errorf("failed to update attachment: %w", err)
+		}
+
+		return s.touch(ctx, dataItem)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	attachment.EncryptionKey = nil
+
+	return attachment, nil
+}
+
+// storeChunks читает содержимое частями по chunkSize байт, шифрует и сохраняет каждую
+// часть, заполняя размер, контрольную сумму, количество частей и MIME-тип вложения.
+// Пустое содержимое сохраняется одной пустой частью.
+func (s *attachmentService) storeChunks(ctx context.Context, attachment *models.Attachment, content io.Reader) error {
+	reader := bufio.NewReaderSize(io.LimitReader(content, s.maxSize+1), s.chunkSize)
+	hash := sha256.New()
+	buf := make([]byte, s.chunkSize)
+
+	for seq := 0; ; seq++ {
+		n, err := io.ReadFull(reader, buf)
+		if err != nil && !errors.Is(err, io.EOF) && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return fmt.Errorf("failed to read attachment: %w", err)
+		}
+
+		attachment.Size += int64(n)
+		if attachment.Size > s.maxSize {
+			return apperrors.NewRequestEntityTooLarge(fmt.Sprintf("attachment must not exceed %d bytes", s.maxSize), nil)
+		}
+
+		chunk := buf[:n]
+		if seq == 0 && attachment.MimeType == "" {
+			attachment.MimeType = http.DetectContentType(chunk)
+		}
+		hash.Write(chunk)
+
+		final := err != nil
+		if !final {
+			_, peekErr := reader.Peek(1)
+			final = errors.Is(peekErr, io.EOF)
+		}
+
+		encryptedContent, err := s.crypto.EncryptWithAAD(chunk, attachment.EncryptionKey, chunkAAD(attachment.ID, seq, final))
+		if err != nil {
+			return fmt.Errorf("failed to encrypt attachment: %w", err)
+		}
+
+		if err := s.attachmentRepo.CreateChunk(ctx, &models.AttachmentChunk{
+			AttachmentID:     attachment.ID,
+			Seq:              seq,
+			EncryptedContent: encryptedContent,
+		}); err != nil {
+			return fmt.Errorf("failed to create attachment chunk: %w", err)
+		}
+
+		if final {
+			attachment.Chunks = seq + 1
+			attachment.Checksum = hex.EncodeToString(hash.Sum(nil))
+			return nil
+		}
+	}
+}
+
+// GetAttachments получает вложения элемента данных без содержимого.
+func (s *attachmentService) GetAttachments(ctx context.Context, userID, dataID uuid.UUID) ([]*models.Attachment, error) {
+	if _, err := s.getOwnedData(ctx, userID, dataID); err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepo.GetByDataID(ctx, dataID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get attachments: %w", err)
+	}
+	return attachments, nil
+}
+
+// GetAttachment получает вложение и поток его расшифрованного содержимого. Части
+// содержимого читаются из хранилища и расшифровываются по мере чтения потока.
+// Скачивание вложения элемента с лимитом чтений засчитывается как чтение элемента;
+// элемент, исчерпавший лимит скачиванием, удаляет фоновая очистка, чтобы не прервать
+// передачу содержимого.
+func (s *attachmentService) GetAttachment(ctx context.Context, userID, dataID, attachmentID uuid.UUID) (*models.Attachment, io.Reader, error) {
+	dataItem, err := s.getOwnedData(ctx, userID, dataID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.getAttachment(ctx, dataItem, attachmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if err := countDataRead(ctx, s.dataRepo, dataItem); err != nil {
+		return nil, nil, err
+	}
+
+	var content io.Reader
+	if attachment.Chunks == 0 {
+		data, err := s.crypto.Decrypt(attachment.EncryptedContent, attachment.EncryptionKey)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to decrypt attachment: %w", err)
+		}
+		content = bytes.NewReader(data)
+	} else {
+		reader := &attachmentReader{ctx: ctx, service: s, attachment: *attachment}
+		// Первая часть читается сразу, чтобы ошибка хранилища вернулась до начала ответа.
+		if err := reader.next(); err != nil {
+			return nil, nil, err
+		}
+		content = reader
+	}
+
+	attachment.EncryptedContent = nil
+	attachment.EncryptionKey = nil
+
+	return attachment, content, nil
+}
+
+// DeleteAttachment удаляет вложение элемента данных.
+func (s *attachmentService) DeleteAttachment(ctx context.Context, userID, dataID, attachmentID uuid.UUID) error {
+	dataItem, err := s.getOwnedData(ctx, userID, dataID)
+	if err != nil {
+		return err
+	}
+
+	attachment, err := s.getAttachment(ctx, dataItem, attachmentID)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.attachmentRepo.Delete(ctx, attachment.ID); err != nil {
+			return fmt.Errorf("failed to delete attachment: %w", err)
+		}
+		return s.touch(ctx, dataItem)
+	})
+}
+
+// getAttachment получает вложение, принадлежащее элементу данных.
+func (s *attachmentService) getAttachment(ctx context.Context, dataItem *models.DataItem, attachmentID uuid.UUID) (*models.Attachment, error) {
+	attachment, err := s.attachmentRepo.GetByID(ctx, attachmentID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewNotFound(fmt.Sprintf("attachment %s not found", attachmentID), err)
+		}
+		return nil, fmt.Errorf("failed to get attachment: %w", err)
+	}
+
+	if attachment.DataID != dataItem.ID {
+		return nil, apperrors.NewNotFound(fmt.Sprintf("attachment %s not found", attachmentID), nil)
+	}
+
+	return attachment, nil
+}
+
+// getOwnedData получает элемент данных с проверкой прав доступа. Вложения элементов с
+// истекшим сроком действия или исчерпанным лимитом чтений недоступны.
+func (s *attachmentService) getOwnedData(ctx context.Context, userID, dataID uuid.UUID) (*models.DataItem, error) {
+	return getActiveUserData(ctx, s.dataRepo, userID, dataID)
+}
+
+// touch обновляет время изменения и версию элемента, чтобы изменение вложений попало
+// в синхронизацию, и сохраняет новую версию в истории.
+func (s *attachmentService) touch(ctx context.Context, dataItem *models.DataItem) error {
+	currentVersion := dataItem.Version
+	dataItem.Version++
+	dataItem.UpdatedAt = time.Now()
+	if err := s.dataRepo.Update(ctx, dataItem, currentVersion); err != nil {
+		return versionConflict(ctx, s.dataRepo, dataItem.ID, currentVersion, fmt.Errorf("failed to update data item: %w", err))
+	}
+	return recordVersion(ctx, s.versionRepo, s.versionRetention, dataItem, nil)
+}
+
+// chunkAAD возвращает дополнительные данные шифрования части вложения. Они связывают
+// шифротекст с вложением и позицией части, поэтому части нельзя переставить, подменить
+// частями другого вложения или отбросить последние из них незаметно.
+func chunkAAD(attachmentID uuid.UUID, seq int, final bool) []byte {
+	aad := make([]byte, 0, len(attachmentID)+9)
+	aad = append(aad, attachmentID[:]...)
+	aad = binary.BigEndian.AppendUint64(aad, uint64(seq))
+	if final {
+		return append(aad, 1)
+	}
+	return append(aad, 0)
+}
+
+// attachmentReader читает содержимое вложения, хранящегося по частям, расшифровывая
+// очередную часть по мере необходимости.
+type attachmentReader struct {
+	ctx        context.Context
+	service    *attachmentService
+	attachment models.Attachment
+	seq        int
+	buf        []byte
+}
+
+// Read реализует io.Reader.
+func (r *attachmentReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		if r.seq >= r.attachment.Chunks {
+			return 0, io.EOF
+		}
+		if err := r.next(); err != nil {
+			return 0, err
+		}
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
+
+// next читает и расшифровывает очередную часть содержимого.
+func (r *attachmentReader) next() error {
+	chunk, err := r.service.attachmentRepo.GetChunk(r.ctx, r.attachment.ID, r.seq)
+	if err != nil {
+		return fmt.Errorf("failed to get attachment chunk: %w", err)
+	}
+
+	final := r.seq == r.attachment.Chunks-1
+	r.buf, err = r.service.crypto.DecryptWithAAD(chunk.EncryptedContent, r.attachment.EncryptionKey, chunkAAD(r.attachment.ID, r.seq, final))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt attachment: %w", err)
+	}
+
+	r.seq++
+	return nil
+}
@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/tempizhere/vaultfactory/internal/server/auth"
 	"github.com/tempizhere/vaultfactory/internal/shared/crypto"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
 	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
 	"github.com/tempizhere/vaultfactory/internal/shared/logger"
 	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
 )
 
 // authService реализует интерфейс AuthService для аутентификации пользователей.
@@ -18,6 +21,7 @@ type authService struct {
 	sessionRepo interfaces.SessionRepository
 	crypto      *crypto.CryptoService
 	jwt         *auth.JWTService
+	policy      *validator.PasswordPolicy
 	logger      logger.Logger
 }
 
@@ -27,6 +31,7 @@ func NewAuthService(
 	sessionRepo interfaces.SessionRepository,
 	crypto *crypto.CryptoService,
 	jwt *auth.JWTService,
+	policy *validator.PasswordPolicy,
 	logger logger.Logger,
 ) interfaces.AuthService {
 	return &authService{
@@ -34,6 +39,7 @@ func NewAuthService(
 		sessionRepo: sessionRepo,
 		crypto:      crypto,
 		jwt:         jwt,
+		policy:      policy,
 		logger:      logger,
 	}
 }
@@ -42,7 +48,11 @@ func NewAuthService(
 func (s *authService) Register(ctx context.Context, email, password string) (*models.User, error) {
 	existingUser, err := s.userRepo.GetByEmail(ctx, email)
 	if err == nil && existingUser != nil {
-		return nil, fmt.Errorf("user with email %s already exists", email)
+		return nil, apperrors.NewConflict(fmt.Sprintf("user with email %s already exists", email), nil)
+	}
+
+	if err := s.policy.Validate(ctx, password, email); err != nil {
+		return nil, err
 	}
 
 	passwordHash, err := s.crypto.HashPassword(password)
@@ -66,11 +76,11 @@ func (s *authService) Register(ctx context.Context, email, password string) (*mo
 func (s *authService) Login(ctx context.Context, email, password string) (*models.User, string, string, error) {
 	user, err := s.userRepo.GetByEmail(ctx, email)
 	if err != nil {
-		return nil, "", "", fmt.Errorf("invalid credentials")
+		return nil, "", "", apperrors.NewUnauthorized("invalid credentials", nil)
 	}
 
 	if !s.crypto.VerifyPassword(password, user.PasswordHash) {
-		return nil, "", "", fmt.Errorf("invalid credentials")
+		return nil, "", "", apperrors.NewUnauthorized("invalid credentials", nil)
 	}
 
 	accessToken, err := s.jwt.GenerateToken(user)
@@ -100,16 +110,16 @@ func (s *authService) Login(ctx context.Context, email, password string) (*model
 func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (string, string, error) {
 	session, err := s.sessionRepo.GetByRefreshToken(ctx, refreshToken)
 	if err != nil {
-		return "", "", fmt.Errorf("invalid refresh token")
+		return "", "", apperrors.NewUnauthorized("invalid refresh token", nil)
 	}
 
 	if time.Now().After(session.ExpiresAt) {
-		return "", "", fmt.Errorf("refresh token expired")
+		return "", "", apperrors.NewUnauthorized("refresh token expired", nil)
 	}
 
 	user, err := s.userRepo.GetByID(ctx, session.UserID)
 	if err != nil {
-		return "", "", fmt.Errorf("user not found")
+		return "", "", apperrors.NewUnauthorized("user not found", nil)
 	}
 
 	newAccessToken, err := s.jwt.GenerateToken(user)
@@ -136,7 +146,7 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (st
 func (s *authService) Logout(ctx context.Context, refreshToken string) error {
 	session, err := s.sessionRepo.GetByRefreshToken(ctx, refreshToken)
 	if err != nil {
-		return fmt.Errorf("invalid refresh token")
+		return apperrors.NewBadRequest("invalid refresh token", nil)
 	}
 
 	if err := s.sessionRepo.Delete(ctx, session.ID); err != nil {
@@ -146,16 +156,51 @@ func (s *authService) Logout(ctx context.Context, refreshToken string) error {
 	return nil
 }
 
+// ChangePassword меняет пароль пользователя после проверки текущего пароля и политики паролей.
+// Все сессии пользователя завершаются.
+func (s *authService) ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error {
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	if !s.crypto.VerifyPassword(currentPassword, user.PasswordHash) {
+		return apperrors.NewUnauthorized("invalid credentials", nil)
+	}
+
+	if err := s.policy.Validate(ctx, newPassword, user.Email); err != nil {
+		return err
+	}
+
+	passwordHash, err := s.crypto.HashPassword(newPassword)
+	if err != nil {
+		return fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	user.PasswordHash = passwordHash
+	user.UpdatedAt = time.Now()
+
+	if err := s.userRepo.Update(ctx, user); err != nil {
+		return fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if err := s.sessionRepo.DeleteByUserID(ctx, user.ID); err != nil {
+		return fmt.Errorf("failed to delete sessions: %w", err)
+	}
+
+	return nil
+}
+
 // ValidateToken проверяет валидность access токена и возвращает пользователя.
 func (s *authService) ValidateToken(ctx context.Context, token string) (*models.User, error) {
 	claims, err := s.jwt.ValidateToken(token)
 	if err != nil {
-		return nil, fmt.Errorf("invalid token: %w", err)
+		return nil, apperrors.NewUnauthorized("invalid token", err)
 	}
 
 	user, err := s.userRepo.GetByID(ctx, claims.UserID)
 	if err != nil {
-		return nil, fmt.Errorf("user not found: %w", err)
+		return nil, apperrors.NewUnauthorized("user not found", err)
 	}
 
 	return user, nil
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 	"time"
 
@@ -12,13 +13,16 @@ import (
 	"github.com/tempizhere/vaultfactory/internal/server/auth"
 	"github.com/tempizhere/vaultfactory/internal/server/service/mocks"
 	"github.com/tempizhere/vaultfactory/internal/shared/crypto"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
 	"github.com/tempizhere/vaultfactory/internal/shared/logger"
 	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
 )
 
 func TestAuthService_Register(t *testing.T) {
 	cryptoService := crypto.NewCryptoService()
 	jwtService := auth.NewJWTService("test-secret", time.Hour)
+	passwordPolicy := validator.NewPasswordPolicy(validator.DefaultPasswordPolicyConfig(), nil)
 
 	t.Run("successful registration", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -26,11 +30,11 @@ func TestAuthService_Register(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		email := "test@example.com"
-		password := "password123"
+		password := "Quiet-Harbor-Lantern-42"
 
 		mockUserRepo.EXPECT().
 			GetByEmail(ctx, email).
@@ -57,7 +61,7 @@ func TestAuthService_Register(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		email := "existing@example.com"
@@ -77,6 +81,10 @@ func TestAuthService_Register(t *testing.T) {
 		assert.Error(t, err)
 		assert.Nil(t, user)
 		assert.Contains(t, err.Error(), "already exists")
+		var appErr *apperrors.AppError
+		if assert.ErrorAs(t, err, &appErr) {
+			assert.Equal(t, http.StatusConflict, appErr.Code)
+		}
 	})
 
 	t.Run("user creation error", func(t *testing.T) {
@@ -85,11 +93,11 @@ func TestAuthService_Register(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		email := "test2@example.com"
-		password := "password123"
+		password := "Quiet-Harbor-Lantern-42"
 
 		mockUserRepo.EXPECT().
 			GetByEmail(ctx, email).
@@ -105,11 +113,36 @@ func TestAuthService_Register(t *testing.T) {
 		assert.Nil(t, user)
 		assert.Contains(t, err.Error(), "failed to create user")
 	})
+
+	t.Run("weak password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
+
+		ctx := context.Background()
+		email := "test3@example.com"
+
+		mockUserRepo.EXPECT().
+			GetByEmail(ctx, email).
+			Return(nil, errors.New("user not found"))
+
+		user, err := authService.Register(ctx, email, "password123")
+
+		var policyErr *validator.PasswordPolicyError
+		assert.ErrorAs(t, err, &policyErr)
+		assert.Nil(t, user)
+		assert.Equal(t, "password", policyErr.Field)
+		assert.NotEmpty(t, policyErr.Feedback.Warning)
+	})
 }
 
 func TestAuthService_Login(t *testing.T) {
 	cryptoService := crypto.NewCryptoService()
 	jwtService := auth.NewJWTService("test-secret", time.Hour)
+	passwordPolicy := validator.NewPasswordPolicy(validator.DefaultPasswordPolicyConfig(), nil)
 
 	t.Run("successful login", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -117,7 +150,7 @@ func TestAuthService_Login(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		email := "test@example.com"
@@ -153,7 +186,7 @@ func TestAuthService_Login(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		email := "nonexistent@example.com"
@@ -170,6 +203,10 @@ func TestAuthService_Login(t *testing.T) {
 		assert.Empty(t, accessToken)
 		assert.Empty(t, refreshToken)
 		assert.Contains(t, err.Error(), "invalid credentials")
+		var appErr *apperrors.AppError
+		if assert.ErrorAs(t, err, &appErr) {
+			assert.Equal(t, http.StatusUnauthorized, appErr.Code)
+		}
 	})
 
 	t.Run("invalid password", func(t *testing.T) {
@@ -178,7 +215,7 @@ func TestAuthService_Login(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		email := "test@example.com"
@@ -202,12 +239,17 @@ func TestAuthService_Login(t *testing.T) {
 		assert.Empty(t, accessToken)
 		assert.Empty(t, refreshToken)
 		assert.Contains(t, err.Error(), "invalid credentials")
+		var appErr *apperrors.AppError
+		if assert.ErrorAs(t, err, &appErr) {
+			assert.Equal(t, http.StatusUnauthorized, appErr.Code)
+		}
 	})
 }
 
 func TestAuthService_RefreshToken(t *testing.T) {
 	cryptoService := crypto.NewCryptoService()
 	jwtService := auth.NewJWTService("test-secret", time.Hour)
+	passwordPolicy := validator.NewPasswordPolicy(validator.DefaultPasswordPolicyConfig(), nil)
 
 	t.Run("successful token refresh", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -215,7 +257,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		refreshToken := "valid-refresh-token"
@@ -259,7 +301,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		refreshToken := "invalid-token"
@@ -282,7 +324,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		refreshToken := "expired-token"
@@ -310,6 +352,7 @@ func TestAuthService_RefreshToken(t *testing.T) {
 func TestAuthService_Logout(t *testing.T) {
 	cryptoService := crypto.NewCryptoService()
 	jwtService := auth.NewJWTService("test-secret", time.Hour)
+	passwordPolicy := validator.NewPasswordPolicy(validator.DefaultPasswordPolicyConfig(), nil)
 
 	t.Run("successful logout", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -317,7 +360,7 @@ func TestAuthService_Logout(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		refreshToken := "valid-refresh-token"
@@ -347,7 +390,7 @@ func TestAuthService_Logout(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		refreshToken := "invalid-token"
@@ -366,6 +409,7 @@ func TestAuthService_Logout(t *testing.T) {
 func TestAuthService_ValidateToken(t *testing.T) {
 	cryptoService := crypto.NewCryptoService()
 	jwtService := auth.NewJWTService("test-secret", time.Hour)
+	passwordPolicy := validator.NewPasswordPolicy(validator.DefaultPasswordPolicyConfig(), nil)
 
 	t.Run("valid token", func(t *testing.T) {
 		ctrl := gomock.NewController(t)
@@ -373,7 +417,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		userID := uuid.New()
@@ -401,7 +445,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		invalidToken := "invalid-token"
@@ -419,7 +463,7 @@ func TestAuthService_ValidateToken(t *testing.T) {
 
 		mockUserRepo := mocks.NewMockUserRepository(ctrl)
 		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
-		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, logger.NewMockLogger())
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
 
 		ctx := context.Background()
 		userID := uuid.New()
@@ -441,3 +485,71 @@ func TestAuthService_ValidateToken(t *testing.T) {
 		assert.Contains(t, err.Error(), "user not found")
 	})
 }
+
+func TestAuthService_ChangePassword(t *testing.T) {
+	cryptoService := crypto.NewCryptoService()
+	jwtService := auth.NewJWTService("test-secret", time.Hour)
+	passwordPolicy := validator.NewPasswordPolicy(validator.DefaultPasswordPolicyConfig(), nil)
+
+	currentPassword := "Quiet-Harbor-Lantern-42"
+	hashedPassword, _ := cryptoService.HashPassword(currentPassword)
+
+	t.Run("successful password change", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
+
+		ctx := context.Background()
+		user := &models.User{ID: uuid.New(), Email: "test@example.com", PasswordHash: hashedPassword}
+
+		mockUserRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil)
+		mockUserRepo.EXPECT().Update(ctx, user).Return(nil)
+		mockSessionRepo.EXPECT().DeleteByUserID(ctx, user.ID).Return(nil)
+
+		err := authService.ChangePassword(ctx, user.ID, currentPassword, "Violet-Summit-Orchard-77")
+
+		assert.NoError(t, err)
+		assert.True(t, cryptoService.VerifyPassword("Violet-Summit-Orchard-77", user.PasswordHash))
+	})
+
+	t.Run("invalid current password", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
+
+		ctx := context.Background()
+		user := &models.User{ID: uuid.New(), Email: "test@example.com", PasswordHash: hashedPassword}
+
+		mockUserRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil)
+
+		err := authService.ChangePassword(ctx, user.ID, "wrong-password", "Violet-Summit-Orchard-77")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid credentials")
+	})
+
+	t.Run("new password violates policy", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		mockUserRepo := mocks.NewMockUserRepository(ctrl)
+		mockSessionRepo := mocks.NewMockSessionRepository(ctrl)
+		authService := NewAuthService(mockUserRepo, mockSessionRepo, cryptoService, jwtService, passwordPolicy, logger.NewMockLogger())
+
+		ctx := context.Background()
+		user := &models.User{ID: uuid.New(), Email: "test@example.com", PasswordHash: hashedPassword}
+
+		mockUserRepo.EXPECT().GetByID(ctx, user.ID).Return(user, nil)
+
+		err := authService.ChangePassword(ctx, user.ID, currentPassword, "qwerty123")
+
+		var policyErr *validator.PasswordPolicyError
+		assert.ErrorAs(t, err, &policyErr)
+	})
+}
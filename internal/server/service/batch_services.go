@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+// batchService реализует интерфейс BatchService для пакетного выполнения операций с данными.
+type batchService struct {
+	dataService interfaces.DataService
+	transactor  interfaces.Transactor
+	validator   *validator.Validator
+}
+
+// NewBatchService создает новый экземпляр BatchService. Операции выполняются через
+// dataService с теми же проверками, что и одиночные запросы, в транзакциях transactor.
+func NewBatchService(dataService interfaces.DataService, transactor interfaces.Transactor) interfaces.BatchService {
+	return &batchService{
+		dataService: dataService,
+		transactor:  transactor,
+		validator:   validator.NewValidator(),
+	}
+}
+
+// ExecuteBatch выполняет операции пакета по порядку. В атомарном режиме (по умолчанию)
+// все операции выполняются в одной транзакции и первая ошибка отменяет пакет; в
+// независимом каждая операция выполняется в своей транзакции. Ошибки операций
+// возвращаются в результатах, ошибка метода означает, что пакет не был выполнен.
+func (s *batchService) ExecuteBatch(ctx context.Context, userID uuid.UUID, batch models.DataBatch) (*models.BatchResult, error) {
+	if err := s.validator.ValidateDataBatch(batch); err != nil {
+		return nil, err
+	}
+
+	result := &models.BatchResult{Results: make([]models.BatchOperationResult, len(batch.Operations))}
+
+	if batch.Mode == models.BatchIndependent {
+		for idx, op := range batch.Operations {
+			var item *models.DataItem
+			err := s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+				var err error
+				item, err = s.execute(ctx, userID, op)
+				return err
+			})
+			if err != nil {
+				result.Results[idx].Err = err
+				continue
+			}
+			result.Results[idx].Item = item
+		}
+		result.Committed = true
+		return result, nil
+	}
+
+	failed := -1
+	err := s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		for idx, op := range batch.Operations {
+			item, err := s.execute(ctx, userID, op)
+			if err != nil {
+				failed = idx
+				return err
+			}
+			result.Results[idx].Item = item
+		}
+		return nil
+	})
+	if err == nil {
+		result.Committed = true
+		return result, nil
+	}
+
+	if failed < 0 {
+		return nil, fmt.Errorf("failed to commit batch: %w", err)
+	}
+
+	for idx := range result.Results {
+		switch {
+		case idx < failed:
+			result.Results[idx] = models.BatchOperationResult{Err: apperrors.NewFailedDependency(fmt.Sprintf("rolled back: operation %d failed", failed), nil)}
+		case idx > failed:
+			result.Results[idx] = models.BatchOperationResult{Err: apperrors.NewFailedDependency(fmt.Sprintf("not executed: operation %d failed", failed), nil)}
+		default:
+			result.Results[idx] = models.BatchOperationResult{Err: err}
+		}
+	}
+
+	return result, nil
+}
+
+// execute выполняет одну операцию пакета. Для удаления возвращается nil элемент.
+func (s *batchService) execute(ctx context.Context, userID uuid.UUID, op models.BatchOperation) (*models.DataItem, error) {
+	switch op.Op {
+	case models.BatchCreate:
+		return s.dataService.CreateData(ctx, userID, op.Type, op.Name, op.Metadata, []byte(op.Data), models.DataExpiry{
+			ExpiresAt: op.ExpiresAt,
+			MaxReads:  op.MaxReads,
+		})
+	case models.BatchUpdate:
+		return s.dataService.UpdateData(ctx, userID, op.ID, op.ExpectedVersion(), op.Name, op.Metadata, []byte(op.Data))
+	default:
+		return nil, s.dataService.DeleteData(ctx, userID, op.ID, op.ExpectedVersion())
+	}
+}
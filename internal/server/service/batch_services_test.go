@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/server/service/mocks"
+	"github.com/tempizhere/vaultfactory/internal/shared/crypto"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+// stubTransactor выполняет функцию без транзакции и считает начатые транзакции.
+type stubTransactor struct {
+	transactions int
+}
+
+func (t *stubTransactor) RunInTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	t.transactions++
+	return fn(ctx)
+}
+
+func newBatchServiceForTest(ctrl *gomock.Controller) (interfaces.BatchService, *mocks.MockDataRepository, *mocks.MockVersionRepository, *stubTransactor) {
+	dataRepo := mocks.NewMockDataRepository(ctrl)
+	versionRepo := mocks.NewMockVersionRepository(ctrl)
+	dataService := NewDataService(dataRepo, versionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), crypto.NewCryptoService(), nil, 0, 0)
+	transactor := &stubTransactor{}
+	return NewBatchService(dataService, transactor), dataRepo, versionRepo, transactor
+}
+
+func assertErrorCode(t *testing.T, err error, code int) {
+	t.Helper()
+	var appErr *apperrors.AppError
+	require.True(t, errors.As(err, &appErr), "unexpected error: %v", err)
+	assert.Equal(t, code, appErr.Code)
+}
+
+func TestBatchService_ExecuteBatch(t *testing.T) {
+	ctx := context.Background()
+	userID := uuid.New()
+	note := models.BatchOperation{Op: models.BatchCreate, Type: models.TextData, Name: "note", Data: []byte(`{"text":"hello"}`)}
+	version := func(v int64) *int64 { return &v }
+
+	t.Run("atomic batch is committed in one transaction", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, dataRepo, versionRepo, transactor := newBatchServiceForTest(ctrl)
+		existing := &models.DataItem{ID: uuid.New(), UserID: userID, Type: models.TextData, Name: "old", Version: 2}
+
+		dataRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		versionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		dataRepo.EXPECT().GetByID(ctx, existing.ID).Return(existing, nil)
+		dataRepo.EXPECT().Delete(ctx, existing.ID, int64(2)).Return(nil)
+
+		result, err := service.ExecuteBatch(ctx, userID, models.DataBatch{Operations: []models.BatchOperation{
+			note,
+			{Op: models.BatchDelete, ID: existing.ID, Version: version(2)},
+		}})
+		require.NoError(t, err)
+
+		assert.True(t, result.Committed)
+		assert.Equal(t, 1, transactor.transactions)
+		require.Len(t, result.Results, 2)
+		assert.NoError(t, result.Results[0].Err)
+		assert.Equal(t, "note", result.Results[0].Item.Name)
+		assert.NoError(t, result.Results[1].Err)
+		assert.Nil(t, result.Results[1].Item)
+	})
+
+	t.Run("atomic batch is rolled back on first error", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, dataRepo, versionRepo, _ := newBatchServiceForTest(ctrl)
+		missing := uuid.New()
+
+		dataRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		versionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		dataRepo.EXPECT().GetByID(ctx, missing).Return(nil, sql.ErrNoRows)
+
+		result, err := service.ExecuteBatch(ctx, userID, models.DataBatch{Operations: []models.BatchOperation{
+			note,
+			{Op: models.BatchUpdate, ID: missing, Name: "renamed", Data: []byte(`{"text":"hi"}`), Version: version(1)},
+			note,
+		}})
+		require.NoError(t, err)
+
+		assert.False(t, result.Committed)
+		assertErrorCode(t, result.Results[0].Err, http.StatusFailedDependency)
+		assert.Nil(t, result.Results[0].Item)
+		assertErrorCode(t, result.Results[1].Err, http.StatusNotFound)
+		assertErrorCode(t, result.Results[2].Err, http.StatusFailedDependency)
+	})
+
+	t.Run("foreign item is forbidden", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, dataRepo, _, _ := newBatchServiceForTest(ctrl)
+		foreign := &models.DataItem{ID: uuid.New(), UserID: uuid.New()}
+
+		dataRepo.EXPECT().GetByID(ctx, foreign.ID).Return(foreign, nil)
+
+		result, err := service.ExecuteBatch(ctx, userID, models.DataBatch{Operations: []models.BatchOperation{
+			{Op: models.BatchDelete, ID: foreign.ID, Version: version(1)},
+		}})
+		require.NoError(t, err)
+
+		assert.False(t, result.Committed)
+		assertErrorCode(t, result.Results[0].Err, http.StatusForbidden)
+	})
+
+	t.Run("stale version conflicts", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, dataRepo, _, _ := newBatchServiceForTest(ctrl)
+		existing := &models.DataItem{ID: uuid.New(), UserID: userID, Type: models.TextData, Name: "note", Version: 3}
+
+		dataRepo.EXPECT().GetByID(ctx, existing.ID).Return(existing, nil)
+
+		result, err := service.ExecuteBatch(ctx, userID, models.DataBatch{Operations: []models.BatchOperation{
+			{Op: models.BatchUpdate, ID: existing.ID, Name: "note", Data: []byte(`{"text":"hi"}`), Version: version(2)},
+		}})
+		require.NoError(t, err)
+
+		assert.False(t, result.Committed)
+		var conflictErr *apperrors.VersionConflictError
+		require.ErrorAs(t, result.Results[0].Err, &conflictErr)
+		assert.Equal(t, int64(3), conflictErr.Current)
+	})
+
+	t.Run("independent operations fail separately", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, dataRepo, versionRepo, transactor := newBatchServiceForTest(ctrl)
+
+		dataRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+		versionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		result, err := service.ExecuteBatch(ctx, userID, models.DataBatch{Mode: models.BatchIndependent, Operations: []models.BatchOperation{
+			{Op: models.BatchCreate, Type: models.TextData, Name: "broken", Data: []byte(`{"unknown":1}`)},
+			note,
+		}})
+		require.NoError(t, err)
+
+		assert.True(t, result.Committed)
+		assert.Equal(t, 2, transactor.transactions)
+		var validationErr *validator.ValidationError
+		assert.ErrorAs(t, result.Results[0].Err, &validationErr)
+		assert.NoError(t, result.Results[1].Err)
+		assert.Equal(t, "note", result.Results[1].Item.Name)
+	})
+
+	t.Run("invalid batch", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		service, _, _, transactor := newBatchServiceForTest(ctrl)
+
+		_, err := service.ExecuteBatch(ctx, userID, models.DataBatch{})
+
+		var validationErr *validator.ValidationError
+		assert.ErrorAs(t, err, &validationErr)
+		assert.Zero(t, transactor.transactions)
+	})
+}
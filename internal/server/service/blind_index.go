@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/blindindex"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// BlindSearchData ищет элементы данных пользователя по слепым индексам имени и
+// дополнительных полей. Токены текстового запроса вычисляются ключом пользователя;
+// токены, переданные клиентом, используются как есть, и текст запроса сервер не видит.
+func (s *dataService) BlindSearchData(ctx context.Context, userID uuid.UUID, search models.BlindSearch, page models.PageRequest) (*models.DataPage, error) {
+	key, err := s.blindIndexKey(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.ValidateBlindSearch(search); err != nil {
+		return nil, err
+	}
+	if search.Mode == "" {
+		search.Mode = models.BlindIndexExact
+	}
+
+	tokens := search.Tokens
+	if search.Query != "" {
+		tokens = blindindex.Tokens(key, search.Field, search.Mode, search.Query)
+	}
+
+	return s.findDataPage(ctx, userID, search.Filter, models.DataQuery{
+		Type:        search.Filter.Type,
+		Tags:        search.Filter.Tags,
+		BlindTokens: uniqueTokens(tokens),
+	}, page)
+}
+
+// GetBlindIndexKey возвращает ключ слепого индекса пользователя, которым клиент может
+// сам вычислять токены запросов.
+func (s *dataService) GetBlindIndexKey(ctx context.Context, userID uuid.UUID) ([]byte, error) {
+	return s.blindIndexKey(userID)
+}
+
+// RebuildBlindIndex перестраивает слепые индексы всех элементов пользователя, кроме
+// находящихся в корзине, и возвращает количество проиндексированных элементов. Нужен
+// для элементов, созданных до включения индексов, и после смены секрета сервера.
+func (s *dataService) RebuildBlindIndex(ctx context.Context, userID uuid.UUID) (int, error) {
+	if _, err := s.blindIndexKey(userID); err != nil {
+		return 0, err
+	}
+
+	items, err := s.dataRepo.Find(ctx, userID, models.DataQuery{})
+	if err != nil {
+		return 0, fmt.Errorf("failed to get user data: %w", err)
+	}
+
+	for _, item := range items {
+		item.Data, err = s.crypto.Decrypt(item.EncryptedData, item.EncryptionKey)
+		if err != nil {
+			return 0, fmt.Errorf("failed to decrypt data: %w", err)
+		}
+
+		if err := s.indexItem(ctx, item); err != nil {
+			return 0, err
+		}
+	}
+
+	return len(items), nil
+}
+
+// indexItem перестраивает слепой индекс элемента по его расшифрованному содержимому.
+// Без секрета сервера индексация пропускается.
+func (s *dataService) indexItem(ctx context.Context, item *models.DataItem) error {
+	if len(s.blindIndexSecret) == 0 {
+		return nil
+	}
+
+	key := blindindex.DeriveKey(s.blindIndexSecret, item.UserID)
+	tokens := blindindex.ItemTokens(key, blindindex.ItemValues(item.Name, item.Data))
+	if err := s.blindRepo.Replace(ctx, item.UserID, item.ID, tokens); err != nil {
+		return fmt.Errorf("failed to update blind index: %w", err)
+	}
+	return nil
+}
+
+// blindIndexKey выводит ключ слепого индекса пользователя из секрета сервера.
+func (s *dataService) blindIndexKey(userID uuid.UUID) ([]byte, error) {
+	if len(s.blindIndexSecret) == 0 {
+		return nil, apperrors.NewNotImplemented("blind index is not configured on the server", nil)
+	}
+	return blindindex.DeriveKey(s.blindIndexSecret, userID), nil
+}
+
+// uniqueTokens удаляет повторяющиеся токены, чтобы элемент должен был содержать каждый
+// токен запроса ровно один раз при подсчете совпадений.
+func uniqueTokens(tokens [][]byte) [][]byte {
+	seen := make(map[string]bool, len(tokens))
+	unique := make([][]byte, 0, len(tokens))
+	for _, token := range tokens {
+		if seen[string(token)] {
+			continue
+		}
+		seen[string(token)] = true
+		unique = append(unique, token)
+	}
+	return unique
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/server/service/mocks"
+	"github.com/tempizhere/vaultfactory/internal/shared/blindindex"
+	"github.com/tempizhere/vaultfactory/internal/shared/crypto"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+var testBlindIndexSecret = []byte("blind index secret")
+
+func containsToken(tokens [][]byte, token []byte) bool {
+	for _, t := range tokens {
+		if string(t) == string(token) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestDataService_CreateData_BuildsBlindIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	mockBlindRepo := mocks.NewMockBlindIndexRepository(ctrl)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mockBlindRepo, crypto.NewCryptoService(), testBlindIndexSecret, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	data := []byte(`{"login":"user","password":"secret","fields":[{"name":"owner","kind":"text","value":"Alice Smith"},{"name":"pin","kind":"secret","value":"1234"}]}`)
+
+	mockDataRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, item *models.DataItem) error {
+		item.ID = dataID
+		return nil
+	})
+	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	var indexed [][]byte
+	mockBlindRepo.EXPECT().Replace(ctx, userID, dataID, gomock.Any()).DoAndReturn(func(ctx context.Context, userID, dataID uuid.UUID, tokens [][]byte) error {
+		indexed = tokens
+		return nil
+	})
+
+	_, err := service.CreateData(ctx, userID, models.LoginPassword, "Prod DB", "", data, models.DataExpiry{})
+	require.NoError(t, err)
+
+	key := blindindex.DeriveKey(testBlindIndexSecret, userID)
+	assert.True(t, containsToken(indexed, blindindex.Token(key, models.BlindIndexNameField, models.BlindIndexExact, "prod db")))
+	assert.True(t, containsToken(indexed, blindindex.Token(key, models.BlindIndexNameField, models.BlindIndexToken, "prod")))
+	assert.True(t, containsToken(indexed, blindindex.Token(key, "field:owner", models.BlindIndexToken, "smith")))
+	assert.False(t, containsToken(indexed, blindindex.Token(key, "field:pin", models.BlindIndexExact, "1234")))
+}
+
+func TestDataService_BlindSearchData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), crypto.NewCryptoService(), testBlindIndexSecret, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	key := blindindex.DeriveKey(testBlindIndexSecret, userID)
+	items := []*models.DataItem{{ID: uuid.New(), UserID: userID, Name: "Prod DB", EncryptedData: []byte("x"), EncryptionKey: []byte("k")}}
+
+	t.Run("query", func(t *testing.T) {
+		mockDataRepo.EXPECT().Find(ctx, userID, defaultDataQuery(models.DataQuery{
+			Type:        models.LoginPassword,
+			BlindTokens: blindindex.Tokens(key, "field:owner", models.BlindIndexToken, "alice smith"),
+		})).Return(items, nil)
+
+		result, err := service.BlindSearchData(ctx, userID, models.BlindSearch{
+			Field:  "field:owner",
+			Mode:   models.BlindIndexToken,
+			Query:  "Alice Smith",
+			Filter: models.DataFilter{Type: models.LoginPassword},
+		}, models.PageRequest{})
+		require.NoError(t, err)
+		require.Len(t, result.Items, 1)
+		assert.Nil(t, result.Items[0].EncryptedData)
+		assert.Nil(t, result.Items[0].EncryptionKey)
+	})
+
+	t.Run("client tokens", func(t *testing.T) {
+		token := blindindex.Token(key, models.BlindIndexNameField, models.BlindIndexExact, "prod db")
+		mockDataRepo.EXPECT().Find(ctx, userID, defaultDataQuery(models.DataQuery{BlindTokens: [][]byte{token}})).Return(items, nil)
+
+		result, err := service.BlindSearchData(ctx, userID, models.BlindSearch{Tokens: [][]byte{token, token}}, models.PageRequest{})
+		require.NoError(t, err)
+		assert.Len(t, result.Items, 1)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		_, err := service.BlindSearchData(ctx, userID, models.BlindSearch{Field: "metadata", Query: "prod"}, models.PageRequest{})
+		assert.Error(t, err)
+	})
+}
+
+func TestDataService_BlindIndexDisabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewDataService(mocks.NewMockDataRepository(ctrl), mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), crypto.NewCryptoService(), nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	_, searchErr := service.BlindSearchData(ctx, userID, models.BlindSearch{Field: models.BlindIndexNameField, Query: "prod"}, models.PageRequest{})
+	_, keyErr := service.GetBlindIndexKey(ctx, userID)
+	_, rebuildErr := service.RebuildBlindIndex(ctx, userID)
+
+	for _, err := range []error{searchErr, keyErr, rebuildErr} {
+		var appErr *apperrors.AppError
+		require.True(t, errors.As(err, &appErr))
+		assert.Equal(t, http.StatusNotImplemented, appErr.Code)
+	}
+}
+
+func TestDataService_GetBlindIndexKey(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewDataService(mocks.NewMockDataRepository(ctrl), mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), crypto.NewCryptoService(), testBlindIndexSecret, 0, 0)
+
+	userID := uuid.New()
+	key, err := service.GetBlindIndexKey(context.Background(), userID)
+	require.NoError(t, err)
+	assert.Equal(t, blindindex.DeriveKey(testBlindIndexSecret, userID), key)
+}
+
+func TestDataService_RebuildBlindIndex(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockBlindRepo := mocks.NewMockBlindIndexRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mockBlindRepo, cryptoService, testBlindIndexSecret, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	key, err := cryptoService.GenerateKey()
+	require.NoError(t, err)
+
+	var items []*models.DataItem
+	for _, name := range []string{"Prod DB", "Staging DB"} {
+		data, err := json.Marshal(models.TextPayload{Text: "note"})
+		require.NoError(t, err)
+		encrypted, err := cryptoService.Encrypt(data, key)
+		require.NoError(t, err)
+		items = append(items, &models.DataItem{ID: uuid.New(), UserID: userID, Type: models.TextData, Name: name, EncryptedData: encrypted, EncryptionKey: key})
+	}
+
+	mockDataRepo.EXPECT().Find(ctx, userID, models.DataQuery{}).Return(items, nil)
+	for _, item := range items {
+		mockBlindRepo.EXPECT().Replace(ctx, userID, item.ID, gomock.Any()).Return(nil)
+	}
+
+	count, err := service.RebuildBlindIndex(ctx, userID)
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
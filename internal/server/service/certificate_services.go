@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+// certificateService реализует интерфейс CertificateService для контроля сроков действия сертификатов.
+type certificateService struct {
+	dataRepo  interfaces.DataRepository
+	validator *validator.Validator
+}
+
+// NewCertificateService создает новый экземпляр CertificateService.
+func NewCertificateService(dataRepo interfaces.DataRepository) interfaces.CertificateService {
+	return &certificateService{
+		dataRepo:  dataRepo,
+		validator: validator.NewValidator(),
+	}
+}
+
+// GetExpiring получает сертификаты, срок действия которых истекает в ближайшие days дней
+// или уже истек, начиная с истекающих раньше всех.
+func (s *certificateService) GetExpiring(ctx context.Context, userID uuid.UUID, days int) ([]*models.CertificateStatus, error) {
+	if err := s.validator.ValidateCertificateExpiryDays(days); err != nil {
+		return nil, err
+	}
+
+	items, err := s.dataRepo.GetByUserIDAndType(ctx, userID, models.Certificate)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user data: %w", err)
+	}
+
+	now := time.Now()
+	deadline := now.AddDate(0, 0, days)
+
+	var expiring []*models.CertificateStatus
+	for _, item := range items {
+		if item.Certificate == nil || item.Certificate.NotAfter.After(deadline) {
+			continue
+		}
+
+		expiring = append(expiring, &models.CertificateStatus{
+			DataID:      item.ID,
+			Name:        item.Name,
+			FolderID:    item.FolderID,
+			Certificate: item.Certificate,
+			DaysLeft:    daysUntil(now, item.Certificate.NotAfter),
+			Expired:     !item.Certificate.NotAfter.After(now),
+		})
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		return expiring[i].Certificate.NotAfter.Before(expiring[j].Certificate.NotAfter)
+	})
+
+	return expiring, nil
+}
+
+// daysUntil возвращает количество полных дней до момента t; для прошедших моментов значение отрицательное.
+func daysUntil(now, t time.Time) int {
+	left := t.Sub(now)
+	if left < 0 {
+		return -int((-left).Hours()/24) - 1
+	}
+	return int(left.Hours() / 24)
+}
@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/server/service/mocks"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+func TestCertificateService_GetExpiring(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewCertificateService(mockDataRepo)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	certificate := func(name string, notAfter time.Duration) *models.DataItem {
+		return &models.DataItem{
+			ID:          uuid.New(),
+			Name:        name,
+			Type:        models.Certificate,
+			Certificate: &models.CertificateInfo{Subject: "CN=" + name, NotAfter: time.Now().Add(notAfter)},
+		}
+	}
+
+	soon := certificate("soon", 10*24*time.Hour+time.Hour)
+	expired := certificate("expired", -5*24*time.Hour+time.Hour)
+	later := certificate("later", 90*24*time.Hour)
+
+	mockDataRepo.EXPECT().GetByUserIDAndType(ctx, userID, models.Certificate).Return([]*models.DataItem{soon, later, expired}, nil)
+
+	expiring, err := service.GetExpiring(ctx, userID, 30)
+
+	require.NoError(t, err)
+	require.Len(t, expiring, 2)
+
+	assert.Equal(t, expired.ID, expiring[0].DataID)
+	assert.True(t, expiring[0].Expired)
+	assert.Equal(t, -5, expiring[0].DaysLeft)
+
+	assert.Equal(t, soon.ID, expiring[1].DataID)
+	assert.False(t, expiring[1].Expired)
+	assert.Equal(t, 10, expiring[1].DaysLeft)
+	assert.Equal(t, "CN=soon", expiring[1].Certificate.Subject)
+}
+
+func TestCertificateService_GetExpiring_InvalidDays(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewCertificateService(mocks.NewMockDataRepository(ctrl))
+
+	_, err := service.GetExpiring(context.Background(), uuid.New(), -1)
+
+	var validationErr *validator.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+// customTypeService реализует интерфейс CustomTypeService для работы с пользовательскими типами данных.
+type customTypeService struct {
+	typeRepo  interfaces.CustomTypeRepository
+	dataRepo  interfaces.DataRepository
+	validator *validator.Validator
+}
+
+// NewCustomTypeService создает новый экземпляр CustomTypeService.
+func NewCustomTypeService(
+	typeRepo interfaces.CustomTypeRepository,
+	dataRepo interfaces.DataRepository,
+) interfaces.CustomTypeService {
+	return &customTypeService{
+		typeRepo:  typeRepo,
+		dataRepo:  dataRepo,
+		validator: validator.NewValidator(),
+	}
+}
+
+// CreateType создает новый пользовательский тип данных.
+func (s *customTypeService) CreateType(ctx context.Context, userID uuid.UUID, name, description string, fields []models.CustomField) (*models.CustomType, error) {
+	customType := &models.CustomType{
+		UserID:      userID,
+		Name:        name,
+		Description: description,
+		Fields:      fields,
+	}
+
+	if err := s.validator.ValidateCustomType(customType); err != nil {
+		return nil, err
+	}
+
+	if existing, err := s.typeRepo.GetByUserIDAndName(ctx, userID, name); err == nil && existing != nil {
+		return nil, apperrors.NewConflict(fmt.Sprintf("type %s already exists", name), nil)
+	}
+
+	if err := s.typeRepo.Create(ctx, customType); err != nil {
+		return nil, fmt.Errorf("failed to create custom type: %w", err)
+	}
+
+	return customType, nil
+}
+
+// GetTypes получает все пользовательские типы данных пользователя.
+func (s *customTypeService) GetTypes(ctx context.Context, userID uuid.UUID) ([]*models.CustomType, error) {
+	customTypes, err := s.typeRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get custom types: %w", err)
+	}
+	return customTypes, nil
+}
+
+// GetType получает пользовательский тип данных по имени.
+func (s *customTypeService) GetType(ctx context.Context, userID uuid.UUID, name string) (*models.CustomType, error) {
+	customType, err := s.typeRepo.GetByUserIDAndName(ctx, userID, name)
+	if err != nil {
+		return nil, apperrors.NewNotFound(fmt.Sprintf("type %s not found", name), err)
+	}
+	return customType, nil
+}
+
+// UpdateType обновляет описание и поля пользовательского типа данных. Имя типа не изменяется,
+// так как используется в элементах данных.
+func (s *customTypeService) UpdateType(ctx context.Context, userID uuid.UUID, name, description string, fields []models.CustomField) (*models.CustomType, error) {
+	customType, err := s.GetType(ctx, userID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	customType.Description = description
+	customType.Fields = fields
+	customType.UpdatedAt = time.Now()
+
+	if err := s.validator.ValidateCustomType(customType); err != nil {
+		return nil, err
+	}
+
+	if err := s.typeRepo.Update(ctx, customType); err != nil {
+		return nil, fmt.Errorf("failed to update custom type: %w", err)
+	}
+
+	return customType, nil
+}
+
+// DeleteType удаляет пользовательский тип данных, если он не используется элементами данных.
+func (s *customTypeService) DeleteType(ctx context.Context, userID uuid.UUID, name string) error {
+	customType, err := s.GetType(ctx, userID, name)
+	if err != nil {
+		return err
+	}
+
+	items, err := s.dataRepo.GetByUserIDAndType(ctx, userID, models.DataType(name))
+	if err != nil {
+		return fmt.Errorf("failed to check type usage: %w", err)
+	}
+	if len(items) > 0 {
+		return apperrors.NewConflict(fmt.Sprintf("type %s is used by %d data items", name, len(items)), nil)
+	}
+
+	if err := s.typeRepo.Delete(ctx, customType.ID); err != nil {
+		return fmt.Errorf("failed to delete custom type: %w", err)
+	}
+
+	return nil
+}
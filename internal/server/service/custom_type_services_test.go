@@ -0,0 +1,113 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/tempizhere/vaultfactory/internal/server/service/mocks"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+func TestCustomTypeService_CreateType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTypeRepo := mocks.NewMockCustomTypeRepository(ctrl)
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+
+	service := NewCustomTypeService(mockTypeRepo, mockDataRepo)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	fields := []models.CustomField{{Name: "key", Kind: models.FieldText, Secret: true, Required: true}}
+
+	mockTypeRepo.EXPECT().GetByUserIDAndName(ctx, userID, "api-key").Return(nil, sql.ErrNoRows)
+	mockTypeRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	result, err := service.CreateType(ctx, userID, "api-key", "API credentials", fields)
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, result.UserID)
+	assert.Equal(t, "api-key", result.Name)
+	assert.Equal(t, fields, result.Fields)
+}
+
+func TestCustomTypeService_CreateType_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewCustomTypeService(mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockDataRepository(ctrl))
+
+	result, err := service.CreateType(context.Background(), uuid.New(), "text_data", "", []models.CustomField{{Name: "key", Kind: models.FieldText}})
+
+	var validationErr *validator.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "name", validationErr.Field)
+	assert.Nil(t, result)
+}
+
+func TestCustomTypeService_CreateType_Duplicate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTypeRepo := mocks.NewMockCustomTypeRepository(ctrl)
+	service := NewCustomTypeService(mockTypeRepo, mocks.NewMockDataRepository(ctrl))
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockTypeRepo.EXPECT().GetByUserIDAndName(ctx, userID, "api-key").Return(&models.CustomType{Name: "api-key"}, nil)
+
+	_, err := service.CreateType(ctx, userID, "api-key", "", []models.CustomField{{Name: "key", Kind: models.FieldText}})
+
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusConflict, appErr.Code)
+}
+
+func TestCustomTypeService_DeleteType_InUse(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTypeRepo := mocks.NewMockCustomTypeRepository(ctrl)
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewCustomTypeService(mockTypeRepo, mockDataRepo)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockTypeRepo.EXPECT().GetByUserIDAndName(ctx, userID, "api-key").Return(&models.CustomType{ID: uuid.New(), Name: "api-key"}, nil)
+	mockDataRepo.EXPECT().GetByUserIDAndType(ctx, userID, models.DataType("api-key")).Return([]*models.DataItem{{Name: "github"}}, nil)
+
+	err := service.DeleteType(ctx, userID, "api-key")
+
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusConflict, appErr.Code)
+}
+
+func TestCustomTypeService_DeleteType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTypeRepo := mocks.NewMockCustomTypeRepository(ctrl)
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewCustomTypeService(mockTypeRepo, mockDataRepo)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	typeID := uuid.New()
+
+	mockTypeRepo.EXPECT().GetByUserIDAndName(ctx, userID, "api-key").Return(&models.CustomType{ID: typeID, Name: "api-key"}, nil)
+	mockDataRepo.EXPECT().GetByUserIDAndType(ctx, userID, models.DataType("api-key")).Return(nil, nil)
+	mockTypeRepo.EXPECT().Delete(ctx, typeID).Return(nil)
+
+	assert.NoError(t, service.DeleteType(ctx, userID, "api-key"))
+}
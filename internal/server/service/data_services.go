@@ -3,37 +3,99 @@ package service
 
 import (
 	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/certificate"
 	"github.com/tempizhere/vaultfactory/internal/shared/crypto"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
 	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
 	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
 )
 
 // dataService реализует интерфейс DataService для работы с данными пользователей.
 type dataService struct {
 	dataRepo    interfaces.DataRepository
 	versionRepo interfaces.VersionRepository
+	typeRepo    interfaces.CustomTypeRepository
+	folderRepo  interfaces.FolderRepository
+	tagRepo     interfaces.TagRepository
+	historyRepo interfaces.PasswordHistoryRepository
+	blindRepo   interfaces.BlindIndexRepository
 	crypto      *crypto.CryptoService
+	validator   *validator.Validator
+
+	// versionRetention ограничивает количество хранимых версий элемента; 0 означает без ограничений.
+	versionRetention int
+
+	// passwordHistorySize ограничивает количество хранимых предыдущих паролей; 0 означает без ограничений.
+	passwordHistorySize int
+
+	// blindIndexSecret — секрет, из которого выводятся ключи слепых индексов пользователей;
+	// пустой секрет отключает слепые индексы.
+	blindIndexSecret []byte
 }
 
-// NewDataService создает новый экземпляр DataService.
+// NewDataService создает новый экземпляр DataService. versionRetention задает, сколько
+// последних версий каждого элемента хранится, а passwordHistorySize — сколько предыдущих
+// паролей элементов login_password; 0 отключает удаление старых записей. Из blindIndexSecret
+// выводятся ключи слепых индексов; пустой секрет отключает индексацию и поиск по ним.
 func NewDataService(
 	dataRepo interfaces.DataRepository,
 	versionRepo interfaces.VersionRepository,
+	typeRepo interfaces.CustomTypeRepository,
+	folderRepo interfaces.FolderRepository,
+	tagRepo interfaces.TagRepository,
+	historyRepo interfaces.PasswordHistoryRepository,
+	blindRepo interfaces.BlindIndexRepository,
 	crypto *crypto.CryptoService,
+	blindIndexSecret []byte,
+	versionRetention int,
+	passwordHistorySize int,
 ) interfaces.DataService {
 	return &dataService{
-		dataRepo:    dataRepo,
-		versionRepo: versionRepo,
-		crypto:      crypto,
+		dataRepo:            dataRepo,
+		versionRepo:         versionRepo,
+		typeRepo:            typeRepo,
+		folderRepo:          folderRepo,
+		tagRepo:             tagRepo,
+		historyRepo:         historyRepo,
+		blindRepo:           blindRepo,
+		crypto:              crypto,
+		validator:           validator.NewValidator(),
+		versionRetention:    versionRetention,
+		passwordHistorySize: passwordHistorySize,
+		blindIndexSecret:    blindIndexSecret,
 	}
 }
 
-// CreateData создает новый элемент данных с шифрованием.
-func (s *dataService) CreateData(ctx context.Context, userID uuid.UUID, dataType models.DataType, name, metadata string, data []byte) (*models.DataItem, error) {
+// CreateData создает новый элемент данных с шифрованием. Непустой expiry делает элемент
+// самоуничтожающимся.
+func (s *dataService) CreateData(ctx context.Context, userID uuid.UUID, dataType models.DataType, name, metadata string, data []byte, expiry models.DataExpiry) (*models.DataItem, error) {
+	if err := s.validator.ValidateDataName(name); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.ValidateExpiry(expiry, time.Now()); err != nil {
+		return nil, err
+	}
+
+	data, err := s.validatePayload(ctx, userID, dataType, data)
+	if err != nil {
+		return nil, err
+	}
+
+	certInfo, err := certificateInfo(dataType, data)
+	if err != nil {
+		return nil, err
+	}
+
 	encryptionKey, err := s.crypto.GenerateKey()
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate encryption key: %w", err)
@@ -51,78 +113,197 @@ func (s *dataService) CreateData(ctx context.Context, userID uuid.UUID, dataType
 		Metadata:      metadata,
 		EncryptedData: encryptedData,
 		EncryptionKey: encryptionKey,
+		Data:          data,
 		Version:       1,
+		ExpiresAt:     expiry.ExpiresAt,
+		MaxReads:      expiry.MaxReads,
+		Certificate:   certInfo,
+	}
+
+	if dataType == models.LoginPassword {
+		now := time.Now()
+		dataItem.PasswordChangedAt = &now
 	}
 
 	if err := s.dataRepo.Create(ctx, dataItem); err != nil {
 		return nil, fmt.Errorf("failed to create data item: %w", err)
 	}
 
-	version := &models.DataVersion{
-		DataID:  dataItem.ID,
-		Version: 1,
+	if err := s.recordVersion(ctx, dataItem, nil); err != nil {
+		return nil, err
 	}
 
-	if err := s.versionRepo.Create(ctx, version); err != nil {
-		return nil, fmt.Errorf("failed to create data version: %w", err)
+	if err := s.indexItem(ctx, dataItem); err != nil {
+		return nil, err
 	}
 
 	return dataItem, nil
 }
 
-// GetData получает элемент данных по ID с проверкой прав доступа.
+// validatePayload проверяет содержимое элемента данных по схеме встроенного
+// или пользовательского типа и возвращает нормализованный JSON.
+func (s *dataService) validatePayload(ctx context.Context, userID uuid.UUID, dataType models.DataType, data []byte) ([]byte, error) {
+	if dataType.IsBuiltin() {
+		return s.validator.ValidatePayload(dataType, data)
+	}
+
+	customType, err := s.typeRepo.GetByUserIDAndName(ctx, userID, string(dataType))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, &validator.ValidationError{Field: "type", Message: fmt.Sprintf("unsupported data type: %s", dataType)}
+		}
+		return nil, fmt.Errorf("failed to get custom type: %w", err)
+	}
+
+	return s.validator.ValidateCustomPayload(customType, data)
+}
+
+// GetData получает элемент данных по ID с проверкой прав доступа. Для элементов
+// с лимитом чтений каждое получение атомарно увеличивает счетчик чтений,
+// а последнее разрешенное чтение сразу уничтожает элемент.
 func (s *dataService) GetData(ctx context.Context, userID, dataID uuid.UUID) (*models.DataItem, error) {
-	dataItem, err := s.dataRepo.GetByID(ctx, dataID)
+	dataItem, err := s.getOwnedData(ctx, userID, dataID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get data item: %w", err)
+		return nil, err
 	}
 
-	if dataItem.UserID != userID {
-		return nil, fmt.Errorf("access denied")
+	if err := countDataRead(ctx, s.dataRepo, dataItem); err != nil {
+		return nil, err
+	}
+
+	if len(dataItem.EncryptedData) > 0 {
+		decrypted, err := s.crypto.Decrypt(dataItem.EncryptedData, dataItem.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt data: %w", err)
+		}
+		dataItem.Data = decrypted
+	}
+
+	if err := s.attachTags(ctx, dataItem); err != nil {
+		return nil, err
+	}
+
+	if dataItem.MaxReads != nil && dataItem.ReadCount >= *dataItem.MaxReads {
+		// Чтение уже засчитано, поэтому при ошибке элемент удалит фоновая очистка.
+		_ = s.dataRepo.ForceDelete(ctx, dataID)
 	}
 
 	return dataItem, nil
 }
 
-// GetUserData получает все данные пользователя без зашифрованного содержимого.
-func (s *dataService) GetUserData(ctx context.Context, userID uuid.UUID) ([]*models.DataItem, error) {
-	items, err := s.dataRepo.GetByUserID(ctx, userID)
+// GetUserData получает страницу данных пользователя без зашифрованного содержимого.
+// Если в фильтре указана папка, возвращаются только элементы этой папки
+// (и вложенных папок при filter.Recursive).
+func (s *dataService) GetUserData(ctx context.Context, userID uuid.UUID, filter models.DataFilter, page models.PageRequest) (*models.DataPage, error) {
+	return s.findDataPage(ctx, userID, filter, models.DataQuery{Type: filter.Type, Tags: filter.Tags}, page)
+}
+
+// SearchData ищет элементы данных пользователя по имени и метаданным. Зашифрованное
+// содержимое в поиске не участвует. Совпадения с запросом подсвечиваются в Highlight.
+func (s *dataService) SearchData(ctx context.Context, userID uuid.UUID, search models.DataSearch, page models.PageRequest) (*models.DataPage, error) {
+	search.Query = strings.TrimSpace(search.Query)
+	if err := s.validator.ValidateDataSearch(search); err != nil {
+		return nil, err
+	}
+	if search.Match == "" {
+		search.Match = models.SearchSubstring
+	}
+
+	result, err := s.findDataPage(ctx, userID, search.Filter, models.DataQuery{
+		Type:          search.Filter.Type,
+		Tags:          search.Filter.Tags,
+		Text:          search.Query,
+		TextMatch:     search.Match,
+		CreatedAfter:  search.CreatedAfter,
+		CreatedBefore: search.CreatedBefore,
+		UpdatedAfter:  search.UpdatedAfter,
+		UpdatedBefore: search.UpdatedBefore,
+	}, page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user data: %w", err)
+		return nil, err
 	}
 
-	for _, item := range items {
-		item.EncryptedData = nil
-		item.EncryptionKey = nil
+	if search.Query != "" {
+		for _, item := range result.Items {
+			item.Highlight = highlightItem(item, search.Query, search.Match)
+		}
 	}
 
-	return items, nil
+	return result, nil
 }
 
-// GetUserDataByType получает данные пользователя определенного типа.
-func (s *dataService) GetUserDataByType(ctx context.Context, userID uuid.UUID, dataType models.DataType) ([]*models.DataItem, error) {
-	items, err := s.dataRepo.GetByUserIDAndType(ctx, userID, dataType)
+// findDataPage выбирает страницу элементов по условиям query, дополненным папкой
+// из filter и параметрами страницы, и удаляет из них зашифрованное содержимое.
+func (s *dataService) findDataPage(ctx context.Context, userID uuid.UUID, filter models.DataFilter, query models.DataQuery, page models.PageRequest) (*models.DataPage, error) {
+	if err := s.validator.ValidatePageRequest(page); err != nil {
+		return nil, err
+	}
+	page = normalizePage(page)
+
+	after, err := decodeCursor(page)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get user data by type: %w", err)
+		return nil, err
 	}
 
-	for _, item := range items {
+	query.Sort = page.Sort
+	query.Order = page.Order
+	query.After = after
+	query.Limit = page.Limit + 1
+	if filter.Folder != "" {
+		query.FolderIDs, err = s.resolveFolderIDs(ctx, userID, filter.Folder, filter.Recursive)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := s.dataRepo.Find(ctx, userID, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user data: %w", err)
+	}
+
+	result := newDataPage(items, page)
+	for _, item := range result.Items {
 		item.EncryptedData = nil
 		item.EncryptionKey = nil
 	}
 
-	return items, nil
+	if err := s.attachTags(ctx, result.Items...); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
 
-// UpdateData обновляет элемент данных с версионированием.
-func (s *dataService) UpdateData(ctx context.Context, userID, dataID uuid.UUID, name, metadata string, data []byte) (*models.DataItem, error) {
-	dataItem, err := s.dataRepo.GetByID(ctx, dataID)
+// UpdateData обновляет элемент данных с версионированием. Изменение применяется, только
+// если текущая версия элемента равна expectedVersion (models.AnyVersion — без сравнения),
+// иначе возвращается *apperrors.VersionConflictError.
+func (s *dataService) UpdateData(ctx context.Context, userID, dataID uuid.UUID, expectedVersion int64, name, metadata string, data []byte) (*models.DataItem, error) {
+	dataItem, err := getUserData(ctx, s.dataRepo, userID, dataID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get data item: %w", err)
+		return nil, err
 	}
 
-	if dataItem.UserID != userID {
-		return nil, fmt.Errorf("access denied")
+	if err := checkVersion(dataItem, expectedVersion); err != nil {
+		return nil, err
+	}
+
+	if err := s.validator.ValidateDataName(name); err != nil {
+		return nil, err
+	}
+
+	data, err = s.validatePayload(ctx, userID, dataItem.Type, data)
+	if err != nil {
+		return nil, err
+	}
+
+	certInfo, err := certificateInfo(dataItem.Type, data)
+	if err != nil {
+		return nil, err
+	}
+
+	previousPassword, err := s.trackPasswordChange(dataItem, data)
+	if err != nil {
+		return nil, err
 	}
 
 	encryptedData, err := s.crypto.Encrypt(data, dataItem.EncryptionKey)
@@ -130,57 +311,565 @@ func (s *dataService) UpdateData(ctx context.Context, userID, dataID uuid.UUID,
 		return nil, fmt.Errorf("failed to encrypt data: %w", err)
 	}
 
+	currentVersion := dataItem.Version
 	dataItem.Name = name
 	dataItem.Metadata = metadata
 	dataItem.EncryptedData = encryptedData
+	dataItem.Data = data
+	dataItem.Certificate = certInfo
+	dataItem.Version++
+	dataItem.UpdatedAt = time.Now()
+
+	if err := s.dataRepo.Update(ctx, dataItem, currentVersion); err != nil {
+		return nil, versionConflict(ctx, s.dataRepo, dataID, currentVersion, fmt.Errorf("failed to update data item: %w", err))
+	}
+
+	if err := s.recordVersion(ctx, dataItem, nil); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordPasswordHistory(ctx, previousPassword); err != nil {
+		return nil, err
+	}
+
+	if err := s.indexItem(ctx, dataItem); err != nil {
+		return nil, err
+	}
+
+	return dataItem, nil
+}
+
+// GetVersions получает историю версий элемента данных без содержимого, начиная с последней.
+func (s *dataService) GetVersions(ctx context.Context, userID, dataID uuid.UUID) ([]*models.DataVersion, error) {
+	if _, err := s.getOwnedData(ctx, userID, dataID); err != nil {
+		return nil, err
+	}
+
+	versions, err := s.versionRepo.GetByDataID(ctx, dataID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get data versions: %w", err)
+	}
+
+	for _, version := range versions {
+		version.EncryptedData = nil
+	}
+
+	return versions, nil
+}
+
+// GetVersion получает версию элемента данных с расшифрованным содержимым.
+func (s *dataService) GetVersion(ctx context.Context, userID, dataID uuid.UUID, version int64) (*models.DataVersion, error) {
+	dataItem, err := s.getOwnedData(ctx, userID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	dataVersion, err := s.getVersion(ctx, dataItem, version)
+	if err != nil {
+		return nil, err
+	}
+
+	dataVersion.EncryptedData = nil
+	return dataVersion, nil
+}
+
+// RestoreVersion восстанавливает содержимое, имя и метаданные элемента из указанной версии.
+// Восстановление не переписывает историю, а создает новую версию.
+func (s *dataService) RestoreVersion(ctx context.Context, userID, dataID uuid.UUID, version int64) (*models.DataItem, error) {
+	dataItem, err := s.getOwnedData(ctx, userID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	dataVersion, err := s.getVersion(ctx, dataItem, version)
+	if err != nil {
+		return nil, err
+	}
+
+	previousPassword, err := s.trackPasswordChange(dataItem, dataVersion.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	certInfo, err := certificateInfo(dataItem.Type, dataVersion.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	currentVersion := dataItem.Version
+	dataItem.Name = dataVersion.Name
+	dataItem.Metadata = dataVersion.Metadata
+	dataItem.EncryptedData = dataVersion.EncryptedData
+	dataItem.Data = dataVersion.Data
+	dataItem.Certificate = certInfo
 	dataItem.Version++
 	dataItem.UpdatedAt = time.Now()
 
-	if err := s.dataRepo.Update(ctx, dataItem); err != nil {
-		return nil, fmt.Errorf("failed to update data item: %w", err)
+	if err := s.dataRepo.Update(ctx, dataItem, currentVersion); err != nil {
+		return nil, versionConflict(ctx, s.dataRepo, dataID, currentVersion, fmt.Errorf("failed to update data item: %w", err))
+	}
+
+	if err := s.recordVersion(ctx, dataItem, &dataVersion.Version); err != nil {
+		return nil, err
+	}
+
+	if err := s.recordPasswordHistory(ctx, previousPassword); err != nil {
+		return nil, err
+	}
+
+	if err := s.indexItem(ctx, dataItem); err != nil {
+		return nil, err
+	}
+
+	if err := s.attachTags(ctx, dataItem); err != nil {
+		return nil, err
+	}
+
+	return dataItem, nil
+}
+
+// getVersion получает версию элемента данных и расшифровывает ее содержимое.
+// Содержимое версий элементов с лимитом чтений недоступно, чтобы его нельзя было прочитать в обход счетчика.
+func (s *dataService) getVersion(ctx context.Context, dataItem *models.DataItem, version int64) (*models.DataVersion, error) {
+	if dataItem.MaxReads != nil {
+		return nil, apperrors.NewBadRequest("version content is not available for items with a read limit", nil)
+	}
+
+	dataVersion, err := s.versionRepo.GetByDataIDAndVersion(ctx, dataItem.ID, version)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewNotFound(fmt.Sprintf("version %d not found", version), err)
+		}
+		return nil, fmt.Errorf("failed to get data version: %w", err)
+	}
+
+	// Версии, созданные до появления полной истории, не содержат данных.
+	if len(dataVersion.EncryptedData) == 0 {
+		return nil, apperrors.NewNotFound(fmt.Sprintf("content of version %d is not available", version), nil)
+	}
+
+	decrypted, err := s.crypto.Decrypt(dataVersion.EncryptedData, dataItem.EncryptionKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt data: %w", err)
+	}
+	dataVersion.Data = decrypted
+
+	return dataVersion, nil
+}
+
+// trackPasswordChange обновляет время смены пароля элемента login_password, если новое
+// содержимое data меняет пароль, и возвращает запись истории с предыдущим паролем
+// (nil, если пароль не изменился или не был задан). Вызывается до замены зашифрованного
+// содержимого элемента.
+func (s *dataService) trackPasswordChange(dataItem *models.DataItem, data []byte) (*models.PasswordHistoryEntry, error) {
+	if dataItem.Type != models.LoginPassword {
+		return nil, nil
+	}
+
+	var previous string
+	if len(dataItem.EncryptedData) > 0 {
+		current, err := s.crypto.Decrypt(dataItem.EncryptedData, dataItem.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt data: %w", err)
+		}
+		previous = loginPassword(current)
+	}
+
+	changed := previous != loginPassword(data)
+	if !changed && dataItem.PasswordChangedAt != nil {
+		return nil, nil
+	}
+
+	now := time.Now()
+	var entry *models.PasswordHistoryEntry
+	if changed && previous != "" {
+		encryptedPassword, err := s.crypto.Encrypt([]byte(previous), dataItem.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encrypt password: %w", err)
+		}
+		entry = &models.PasswordHistoryEntry{
+			DataID:            dataItem.ID,
+			EncryptedPassword: encryptedPassword,
+			SetAt:             dataItem.PasswordChangedAt,
+			ChangedAt:         now,
+		}
+	}
+
+	dataItem.PasswordChangedAt = &now
+	return entry, nil
+}
+
+// recordPasswordHistory сохраняет предыдущий пароль и удаляет записи, выходящие за пределы истории.
+func (s *dataService) recordPasswordHistory(ctx context.Context, entry *models.PasswordHistoryEntry) error {
+	if entry == nil {
+		return nil
 	}
 
+	if err := s.historyRepo.Create(ctx, entry); err != nil {
+		return fmt.Errorf("failed to save password history: %w", err)
+	}
+
+	if s.passwordHistorySize > 0 {
+		if err := s.historyRepo.DeleteOlderEntries(ctx, entry.DataID, s.passwordHistorySize); err != nil {
+			return fmt.Errorf("failed to apply password history limit: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// GetPasswordHistory получает предыдущие пароли элемента login_password, начиная с последней смены.
+func (s *dataService) GetPasswordHistory(ctx context.Context, userID, dataID uuid.UUID) ([]*models.PasswordHistoryEntry, error) {
+	dataItem, err := s.getOwnedData(ctx, userID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dataItem.Type != models.LoginPassword {
+		return nil, apperrors.NewBadRequest(fmt.Sprintf("password history is only available for %s items", models.LoginPassword), nil)
+	}
+
+	if dataItem.MaxReads != nil {
+		return nil, apperrors.NewBadRequest("password history is not available for items with a read limit", nil)
+	}
+
+	entries, err := s.historyRepo.GetByDataID(ctx, dataID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get password history: %w", err)
+	}
+
+	for _, entry := range entries {
+		password, err := s.crypto.Decrypt(entry.EncryptedPassword, dataItem.EncryptionKey)
+		if err != nil {
+			return nil, fmt.Errorf("failed to decrypt password: %w", err)
+		}
+		entry.Password = string(password)
+		entry.EncryptedPassword = nil
+	}
+
+	return entries, nil
+}
+
+// loginPassword возвращает пароль из содержимого элемента login_password.
+func loginPassword(data []byte) string {
+	var payload struct {
+		Password string `json:"password"`
+	}
+	_ = json.Unmarshal(data, &payload)
+	return payload.Password
+}
+
+// certificateInfo извлекает несекретные сведения о сертификате из содержимого элемента
+// certificate. Для остальных типов возвращает nil.
+func certificateInfo(dataType models.DataType, data []byte) (*models.CertificateInfo, error) {
+	if dataType != models.Certificate {
+		return nil, nil
+	}
+
+	var payload models.CertificatePayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	info, err := certificate.Inspect(payload.Certificate, payload.PrivateKey != "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse certificate: %w", err)
+	}
+
+	return info, nil
+}
+
+// recordVersion сохраняет текущее состояние элемента данных как новую версию
+// и удаляет версии, выходящие за пределы хранения.
+func (s *dataService) recordVersion(ctx context.Context, dataItem *models.DataItem, restoredFrom *int64) error {
+	return recordVersion(ctx, s.versionRepo, s.versionRetention, dataItem, restoredFrom)
+}
+
+// recordVersion сохраняет текущее состояние элемента данных как версию с его текущим
+// номером и оставляет не более retention последних версий (0 — без ограничений).
+// Вызывается при каждом увеличении версии элемента, в том числе без изменения
+// содержимого, чтобы любая версия из ETag была доступна в истории.
+func recordVersion(ctx context.Context, versionRepo interfaces.VersionRepository, retention int, dataItem *models.DataItem, restoredFrom *int64) error {
 	version := &models.DataVersion{
-		DataID:  dataItem.ID,
-		Version: dataItem.Version,
+		DataID:        dataItem.ID,
+		Version:       dataItem.Version,
+		Name:          dataItem.Name,
+		Metadata:      dataItem.Metadata,
+		EncryptedData: dataItem.EncryptedData,
+		RestoredFrom:  restoredFrom,
 	}
 
-	if err := s.versionRepo.Create(ctx, version); err != nil {
-		return nil, fmt.Errorf("failed to create data version: %w", err)
+	if err := versionRepo.Create(ctx, version); err != nil {
+		return fmt.Errorf("failed to create data version: %w", err)
+	}
+
+	if retention > 0 {
+		if err := versionRepo.DeleteOlderVersions(ctx, dataItem.ID, retention); err != nil {
+			return fmt.Errorf("failed to apply version retention: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// getOwnedData получает элемент данных с проверкой прав доступа. Элементы с истекшим
+// сроком действия или исчерпанным лимитом чтений считаются отсутствующими.
+func (s *dataService) getOwnedData(ctx context.Context, userID, dataID uuid.UUID) (*models.DataItem, error) {
+	return getActiveUserData(ctx, s.dataRepo, userID, dataID)
+}
+
+// SetExpiry задает или снимает условия самоуничтожения элемента данных.
+// Счетчик чтений при этом сбрасывается.
+func (s *dataService) SetExpiry(ctx context.Context, userID, dataID uuid.UUID, expiry models.DataExpiry) (*models.DataItem, error) {
+	if err := s.validator.ValidateExpiry(expiry, time.Now()); err != nil {
+		return nil, err
+	}
+
+	dataItem, err := s.getOwnedData(ctx, userID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	dataItem.ExpiresAt = expiry.ExpiresAt
+	dataItem.MaxReads = expiry.MaxReads
+	dataItem.ReadCount = 0
+	dataItem.UpdatedAt = time.Now()
+
+	if err := s.dataRepo.SetExpiry(ctx, dataItem); err != nil {
+		return nil, fmt.Errorf("failed to set data item expiry: %w", err)
+	}
+
+	dataItem.EncryptedData = nil
+	dataItem.EncryptionKey = nil
+
+	if err := s.attachTags(ctx, dataItem); err != nil {
+		return nil, err
 	}
 
 	return dataItem, nil
 }
 
-// DeleteData удаляет элемент данных с проверкой прав доступа.
-func (s *dataService) DeleteData(ctx context.Context, userID, dataID uuid.UUID) error {
-	dataItem, err := s.dataRepo.GetByID(ctx, dataID)
+// PurgeExpired окончательно удаляет элементы данных с истекшим сроком действия
+// или исчерпанным лимитом чтений.
+func (s *dataService) PurgeExpired(ctx context.Context) (int, error) {
+	count, err := s.dataRepo.PurgeExpired(ctx, time.Now())
 	if err != nil {
-		return fmt.Errorf("failed to get data item: %w", err)
+		return 0, fmt.Errorf("failed to purge self-destructed data: %w", err)
+	}
+	return count, nil
+}
+
+// MoveData перемещает элемент данных в папку. Пустой folderRef означает перемещение в корень.
+// Перемещение увеличивает версию элемента, как и изменение содержимого.
+func (s *dataService) MoveData(ctx context.Context, userID, dataID uuid.UUID, folderRef string) (*models.DataItem, error) {
+	dataItem, err := getUserData(ctx, s.dataRepo, userID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	var folderID *uuid.UUID
+	if folderRef != "" {
+		tree, err := loadFolderTree(ctx, s.folderRepo, userID)
+		if err != nil {
+			return nil, err
+		}
+		folder, err := tree.resolve(folderRef)
+		if err != nil {
+			return nil, err
+		}
+		folderID = &folder.ID
+	}
+
+	currentVersion := dataItem.Version
+	dataItem.FolderID = folderID
+	dataItem.Version++
+	dataItem.UpdatedAt = time.Now()
+
+	if err := s.dataRepo.Update(ctx, dataItem, currentVersion); err != nil {
+		return nil, versionConflict(ctx, s.dataRepo, dataID, currentVersion, fmt.Errorf("failed to move data item: %w", err))
+	}
+
+	if err := s.recordVersion(ctx, dataItem, nil); err != nil {
+		return nil, err
+	}
+
+	dataItem.EncryptedData = nil
+	dataItem.EncryptionKey = nil
+
+	return dataItem, nil
+}
+
+// attachTags заполняет списки тегов элементов данных.
+func (s *dataService) attachTags(ctx context.Context, items ...*models.DataItem) error {
+	if len(items) == 0 {
+		return nil
+	}
+
+	dataIDs := make([]uuid.UUID, 0, len(items))
+	for _, item := range items {
+		dataIDs = append(dataIDs, item.ID)
+	}
+
+	tags, err := s.tagRepo.GetNamesByDataIDs(ctx, dataIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	for _, item := range items {
+		item.Tags = tags[item.ID]
+	}
+	return nil
+}
+
+// resolveFolderIDs возвращает ID папки и, при recursive, ID всех вложенных папок.
+func (s *dataService) resolveFolderIDs(ctx context.Context, userID uuid.UUID, folderRef string, recursive bool) ([]uuid.UUID, error) {
+	tree, err := loadFolderTree(ctx, s.folderRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := tree.resolve(folderRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if !recursive {
+		return []uuid.UUID{folder.ID}, nil
+	}
+
+	var folderIDs []uuid.UUID
+	for _, f := range tree.subtree(folder) {
+		folderIDs = append(folderIDs, f.ID)
+	}
+	return folderIDs, nil
+}
+
+// DeleteData перемещает элемент данных в корзину с проверкой прав доступа, если его
+// текущая версия равна expectedVersion (models.AnyVersion — без сравнения).
+func (s *dataService) DeleteData(ctx context.Context, userID, dataID uuid.UUID, expectedVersion int64) error {
+	dataItem, err := getUserData(ctx, s.dataRepo, userID, dataID)
+	if err != nil {
+		return err
+	}
+
+	if err := checkVersion(dataItem, expectedVersion); err != nil {
+		return err
+	}
+
+	if err := s.dataRepo.Delete(ctx, dataID, dataItem.Version); err != nil {
+		return versionConflict(ctx, s.dataRepo, dataID, dataItem.Version, fmt.Errorf("failed to delete data item: %w", err))
+	}
+
+	return nil
+}
+
+// getUserData получает элемент данных и проверяет, что он принадлежит пользователю.
+// Отсутствующий элемент возвращается как ошибка NotFound, чужой — как Forbidden.
+func getUserData(ctx context.Context, dataRepo interfaces.DataRepository, userID, dataID uuid.UUID) (*models.DataItem, error) {
+	dataItem, err := dataRepo.GetByID(ctx, dataID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewNotFound(fmt.Sprintf("data item %s not found", dataID), err)
+		}
+		return nil, fmt.Errorf("failed to get data item: %w", err)
 	}
 
 	if dataItem.UserID != userID {
-		return fmt.Errorf("access denied")
+		return nil, apperrors.NewForbidden("access denied", nil)
+	}
+
+	return dataItem, nil
+}
+
+// getActiveUserData получает элемент данных пользователя, как getUserData, и возвращает
+// ошибку NotFound, если срок действия элемента истек или лимит чтений исчерпан.
+func getActiveUserData(ctx context.Context, dataRepo interfaces.DataRepository, userID, dataID uuid.UUID) (*models.DataItem, error) {
+	dataItem, err := getUserData(ctx, dataRepo, userID, dataID)
+	if err != nil {
+		return nil, err
 	}
 
-	if err := s.dataRepo.Delete(ctx, dataID); err != nil {
-		return fmt.Errorf("failed to delete data item: %w", err)
+	if dataItem.IsExpired(time.Now()) {
+		return nil, apperrors.NewNotFound(fmt.Sprintf("data item %s has expired", dataID), nil)
 	}
 
+	return dataItem, nil
+}
+
+// countDataRead атомарно засчитывает чтение элемента данных с лимитом чтений и обновляет
+// его счетчик. Если лимит успел исчерпаться конкурентно, возвращается ошибка NotFound.
+func countDataRead(ctx context.Context, dataRepo interfaces.DataRepository, dataItem *models.DataItem) error {
+	if dataItem.MaxReads == nil {
+		return nil
+	}
+
+	readCount, err := dataRepo.IncrementReadCount(ctx, dataItem.ID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return apperrors.NewNotFound(fmt.Sprintf("data item %s has expired", dataItem.ID), err)
+		}
+		return fmt.Errorf("failed to count data read: %w", err)
+	}
+	dataItem.ReadCount = readCount
 	return nil
 }
 
-// SyncData получает данные, измененные после указанного времени.
-func (s *dataService) SyncData(ctx context.Context, userID uuid.UUID, lastSync time.Time) ([]*models.DataItem, error) {
-	items, err := s.dataRepo.GetUpdatedSince(ctx, userID, lastSync)
+// checkVersion сравнивает версию элемента данных с ожидаемой клиентом.
+func checkVersion(dataItem *models.DataItem, expectedVersion int64) error {
+	if expectedVersion != models.AnyVersion && dataItem.Version != expectedVersion {
+		return &apperrors.VersionConflictError{Expected: expectedVersion, Current: dataItem.Version}
+	}
+	return nil
+}
+
+// versionConflict заменяет models.ErrVersionConflict хранилища ошибкой с актуальной версией
+// элемента данных, изменившегося конкурентно. Остальные ошибки возвращаются без изменений.
+func versionConflict(ctx context.Context, dataRepo interfaces.DataRepository, dataID uuid.UUID, expectedVersion int64, err error) error {
+	if !errors.Is(err, models.ErrVersionConflict) {
+		return err
+	}
+
+	dataItem, getErr := dataRepo.GetByID(ctx, dataID)
+	if getErr != nil {
+		if errors.Is(getErr, sql.ErrNoRows) {
+			return apperrors.NewNotFound(fmt.Sprintf("data item %s not found", dataID), getErr)
+		}
+		return fmt.Errorf("failed to get data item: %w", getErr)
+	}
+
+	return &apperrors.VersionConflictError{Expected: expectedVersion, Current: dataItem.Version}
+}
+
+// SyncData получает страницу данных, измененных после указанного времени, в порядке изменения.
+func (s *dataService) SyncData(ctx context.Context, userID uuid.UUID, lastSync time.Time, page models.PageRequest) (*models.DataPage, error) {
+	if err := s.validator.ValidatePageRequest(page); err != nil {
+		return nil, err
+	}
+	if (page.Sort != "" && page.Sort != models.SortByUpdated) || (page.Order != "" && page.Order != models.SortAsc) {
+		return nil, apperrors.NewBadRequest("sync is always ordered by update time ascending", nil)
+	}
+	page.Sort, page.Order = models.SortByUpdated, models.SortAsc
+	page = normalizePage(page)
+
+	after, err := decodeCursor(page)
+	if err != nil {
+		return nil, err
+	}
+
+	items, err := s.dataRepo.GetUpdatedSince(ctx, userID, lastSync, after, page.Limit+1)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get updated data: %w", err)
 	}
 
-	for _, item := range items {
+	result := newDataPage(items, page)
+	for _, item := range result.Items {
 		item.EncryptedData = nil
 		item.EncryptionKey = nil
 	}
 
-	return items, nil
+	if err := s.attachTags(ctx, result.Items...); err != nil {
+		return nil, err
+	}
+
+	return result, nil
 }
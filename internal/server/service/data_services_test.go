@@ -2,18 +2,40 @@ package service
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"database/sql"
+	"encoding/json"
+	"encoding/pem"
 	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
 	"testing"
 	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/tempizhere/vaultfactory/internal/server/service/mocks"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
 	"github.com/tempizhere/vaultfactory/internal/shared/crypto"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
 	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
 )
 
+// newTagRepoStub возвращает мок TagRepository, у элементов которого нет тегов.
+func newTagRepoStub(ctrl *gomock.Controller) *mocks.MockTagRepository {
+	tagRepo := mocks.NewMockTagRepository(ctrl)
+	tagRepo.EXPECT().GetNamesByDataIDs(gomock.Any(), gomock.Any()).Return(map[uuid.UUID][]string{}, nil).AnyTimes()
+	return tagRepo
+}
+
 func TestDataService_CreateData(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -22,19 +44,19 @@ func TestDataService_CreateData(t *testing.T) {
 	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
 	cryptoService := crypto.NewCryptoService()
 
-	service := NewDataService(mockDataRepo, mockVersionRepo, cryptoService)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
 
 	ctx := context.Background()
 	userID := uuid.New()
 	dataType := models.LoginPassword
 	name := "test data"
 	metadata := "test metadata"
-	data := []byte("test data content")
+	data := []byte(`{"login":"user","password":"secret"}`)
 
 	mockDataRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 
-	result, err := service.CreateData(ctx, userID, dataType, name, metadata, data)
+	result, err := service.CreateData(ctx, userID, dataType, name, metadata, data, models.DataExpiry{})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -55,18 +77,18 @@ func TestDataService_CreateData_RepositoryError(t *testing.T) {
 	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
 	cryptoService := crypto.NewCryptoService()
 
-	service := NewDataService(mockDataRepo, mockVersionRepo, cryptoService)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
 
 	ctx := context.Background()
 	userID := uuid.New()
 	dataType := models.LoginPassword
 	name := "test data"
 	metadata := "test metadata"
-	data := []byte("test data content")
+	data := []byte(`{"login":"user","password":"secret"}`)
 
 	mockDataRepo.EXPECT().Create(ctx, gomock.Any()).Return(errors.New("repository error"))
 
-	result, err := service.CreateData(ctx, userID, dataType, name, metadata, data)
+	result, err := service.CreateData(ctx, userID, dataType, name, metadata, data, models.DataExpiry{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -81,7 +103,7 @@ func TestDataService_GetData(t *testing.T) {
 	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
 	cryptoService := crypto.NewCryptoService()
 
-	service := NewDataService(mockDataRepo, mockVersionRepo, cryptoService)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -112,7 +134,7 @@ func TestDataService_GetData_AccessDenied(t *testing.T) {
 	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
 	cryptoService := crypto.NewCryptoService()
 
-	service := NewDataService(mockDataRepo, mockVersionRepo, cryptoService)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -133,6 +155,33 @@ func TestDataService_GetData_AccessDenied(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Contains(t, err.Error(), "access denied")
+	var appErr *apperrors.AppError
+	if assert.ErrorAs(t, err, &appErr) {
+		assert.Equal(t, http.StatusForbidden, appErr.Code)
+		assert.Equal(t, apperrors.CodeAccessDenied, appErr.ErrorCode)
+	}
+}
+
+func TestDataService_GetData_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), crypto.NewCryptoService(), nil, 0, 0)
+
+	ctx := context.Background()
+	dataID := uuid.New()
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(nil, fmt.Errorf("failed to get data item: %w", sql.ErrNoRows))
+
+	result, err := service.GetData(ctx, uuid.New(), dataID)
+
+	assert.Nil(t, result)
+	var appErr *apperrors.AppError
+	if assert.ErrorAs(t, err, &appErr) {
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+		assert.Equal(t, apperrors.CodeNotFound, appErr.ErrorCode)
+	}
 }
 
 func TestDataService_GetUserData(t *testing.T) {
@@ -143,7 +192,7 @@ func TestDataService_GetUserData(t *testing.T) {
 	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
 	cryptoService := crypto.NewCryptoService()
 
-	service := NewDataService(mockDataRepo, mockVersionRepo, cryptoService)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -167,14 +216,15 @@ func TestDataService_GetUserData(t *testing.T) {
 		},
 	}
 
-	mockDataRepo.EXPECT().GetByUserID(ctx, userID).Return(dataItems, nil)
+	mockDataRepo.EXPECT().Find(ctx, userID, defaultDataQuery(models.DataQuery{})).Return(dataItems, nil)
 
-	result, err := service.GetUserData(ctx, userID)
+	result, err := service.GetUserData(ctx, userID, models.DataFilter{}, models.PageRequest{})
 
 	assert.NoError(t, err)
-	assert.Len(t, result, 2)
+	assert.Len(t, result.Items, 2)
+	assert.Empty(t, result.NextCursor)
 
-	for _, item := range result {
+	for _, item := range result.Items {
 		assert.Nil(t, item.EncryptedData)
 		assert.Nil(t, item.EncryptionKey)
 	}
@@ -188,7 +238,7 @@ func TestDataService_UpdateData(t *testing.T) {
 	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
 	cryptoService := crypto.NewCryptoService()
 
-	service := NewDataService(mockDataRepo, mockVersionRepo, cryptoService)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -209,14 +259,14 @@ func TestDataService_UpdateData(t *testing.T) {
 	}
 
 	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(dataItem, nil)
-	mockDataRepo.EXPECT().Update(ctx, gomock.Any()).Return(nil)
+	mockDataRepo.EXPECT().Update(ctx, gomock.Any(), int64(1)).Return(nil)
 	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
 
 	newName := "new name"
 	newMetadata := "new metadata"
-	newData := []byte("new data content")
+	newData := []byte(`{"login":"user","password":"new-secret"}`)
 
-	result, err := service.UpdateData(ctx, userID, dataID, newName, newMetadata, newData)
+	result, err := service.UpdateData(ctx, userID, dataID, int64(1), newName, newMetadata, newData)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
@@ -233,7 +283,7 @@ func TestDataService_UpdateData_AccessDenied(t *testing.T) {
 	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
 	cryptoService := crypto.NewCryptoService()
 
-	service := NewDataService(mockDataRepo, mockVersionRepo, cryptoService)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -249,7 +299,7 @@ func TestDataService_UpdateData_AccessDenied(t *testing.T) {
 
 	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(dataItem, nil)
 
-	result, err := service.UpdateData(ctx, userID, dataID, "new name", "new metadata", []byte("new data"))
+	result, err := service.UpdateData(ctx, userID, dataID, models.AnyVersion, "new name", "new metadata", []byte("new data"))
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
@@ -264,7 +314,7 @@ func TestDataService_DeleteData(t *testing.T) {
 	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
 	cryptoService := crypto.NewCryptoService()
 
-	service := NewDataService(mockDataRepo, mockVersionRepo, cryptoService)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -278,9 +328,9 @@ func TestDataService_DeleteData(t *testing.T) {
 	}
 
 	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(dataItem, nil)
-	mockDataRepo.EXPECT().Delete(ctx, dataID).Return(nil)
+	mockDataRepo.EXPECT().Delete(ctx, dataID, int64(0)).Return(nil)
 
-	err := service.DeleteData(ctx, userID, dataID)
+	err := service.DeleteData(ctx, userID, dataID, models.AnyVersion)
 
 	assert.NoError(t, err)
 }
@@ -293,7 +343,7 @@ func TestDataService_DeleteData_AccessDenied(t *testing.T) {
 	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
 	cryptoService := crypto.NewCryptoService()
 
-	service := NewDataService(mockDataRepo, mockVersionRepo, cryptoService)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -309,7 +359,7 @@ func TestDataService_DeleteData_AccessDenied(t *testing.T) {
 
 	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(dataItem, nil)
 
-	err := service.DeleteData(ctx, userID, dataID)
+	err := service.DeleteData(ctx, userID, dataID, models.AnyVersion)
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "access denied")
@@ -323,7 +373,7 @@ func TestDataService_SyncData(t *testing.T) {
 	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
 	cryptoService := crypto.NewCryptoService()
 
-	service := NewDataService(mockDataRepo, mockVersionRepo, cryptoService)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
 
 	ctx := context.Background()
 	userID := uuid.New()
@@ -340,12 +390,950 @@ func TestDataService_SyncData(t *testing.T) {
 		},
 	}
 
-	mockDataRepo.EXPECT().GetUpdatedSince(ctx, userID, lastSync).Return(dataItems, nil)
+	mockDataRepo.EXPECT().GetUpdatedSince(ctx, userID, lastSync, nil, constants.DefaultPageSize+1).Return(dataItems, nil)
+
+	result, err := service.SyncData(ctx, userID, lastSync, models.PageRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 1)
+	assert.Nil(t, result.Items[0].EncryptedData)
+	assert.Nil(t, result.Items[0].EncryptionKey)
+
+	_, err = service.SyncData(ctx, userID, lastSync, models.PageRequest{Sort: models.SortByName})
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+}
+
+func TestDataService_CreateData_InvalidPayload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	data := []byte(`{"number":"4111111111111112","expiry":"12/99"}`)
+
+	result, err := service.CreateData(ctx, uuid.New(), models.BankCard, "card", "", data, models.DataExpiry{})
+
+	var validationErr *validator.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "data.number", validationErr.Field)
+	assert.Nil(t, result)
+}
+
+func TestDataService_GetData_Decrypts(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	payload := []byte(`{"text":"secret note"}`)
+
+	encryptionKey, _ := cryptoService.GenerateKey()
+	encryptedData, _ := cryptoService.Encrypt(payload, encryptionKey)
+
+	dataItem := &models.DataItem{
+		ID:            dataID,
+		UserID:        userID,
+		Type:          models.TextData,
+		Name:          "note",
+		EncryptedData: encryptedData,
+		EncryptionKey: encryptionKey,
+	}
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(dataItem, nil)
+
+	result, err := service.GetData(ctx, userID, dataID)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(payload), string(result.Data))
+}
+
+func TestDataService_CreateData_CustomType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	mockTypeRepo := mocks.NewMockCustomTypeRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mockTypeRepo, mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	customType := &models.CustomType{
+		UserID: userID,
+		Name:   "database",
+		Fields: []models.CustomField{
+			{Name: "dsn", Kind: models.FieldText, Secret: true, Required: true},
+			{Name: "port", Kind: models.FieldNumber},
+		},
+	}
+
+	mockTypeRepo.EXPECT().GetByUserIDAndName(ctx, userID, "database").Return(customType, nil)
+	mockDataRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	result, err := service.CreateData(ctx, userID, models.DataType("database"), "prod db", "", []byte(`{"dsn":"postgres://db","port":5432}`), models.DataExpiry{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, models.DataType("database"), result.Type)
+	assert.JSONEq(t, `{"dsn":"postgres://db","port":5432}`, string(result.Data))
+}
+
+func TestDataService_CreateData_UnknownCustomType(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	mockTypeRepo := mocks.NewMockCustomTypeRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mockTypeRepo, mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockTypeRepo.EXPECT().GetByUserIDAndName(ctx, userID, "database").Return(nil, fmt.Errorf("failed to get custom type by name: %w", sql.ErrNoRows))
+
+	result, err := service.CreateData(ctx, userID, models.DataType("database"), "prod db", "", []byte(`{"dsn":"postgres://db"}`), models.DataExpiry{})
+
+	var validationErr *validator.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "type", validationErr.Field)
+	assert.Nil(t, result)
+}
+
+func TestDataService_GetUserData_RecursiveFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockFolderRepo := mocks.NewMockFolderRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mockFolderRepo, newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	work := &models.Folder{ID: uuid.New(), UserID: userID, Name: "work"}
+	aws := &models.Folder{ID: uuid.New(), UserID: userID, ParentID: &work.ID, Name: "aws"}
+	items := []*models.DataItem{{ID: uuid.New(), UserID: userID, FolderID: &aws.ID, EncryptedData: []byte("x")}}
+
+	mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work, aws}, nil).Times(2)
+	mockDataRepo.EXPECT().Find(ctx, userID, defaultDataQuery(models.DataQuery{FolderIDs: []uuid.UUID{work.ID, aws.ID}})).Return(items, nil)
+	mockDataRepo.EXPECT().Find(ctx, userID, defaultDataQuery(models.DataQuery{FolderIDs: []uuid.UUID{work.ID}})).Return(nil, nil)
+
+	result, err := service.GetUserData(ctx, userID, models.DataFilter{Folder: "work", Recursive: true}, models.PageRequest{})
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 1)
+	assert.Nil(t, result.Items[0].EncryptedData)
+
+	result, err = service.GetUserData(ctx, userID, models.DataFilter{Folder: "work"}, models.PageRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Items)
+}
+
+func TestDataService_MoveData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	mockFolderRepo := mocks.NewMockFolderRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mockFolderRepo, newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	folder := &models.Folder{ID: uuid.New(), UserID: userID, Name: "work"}
+	dataItem := &models.DataItem{ID: uuid.New(), UserID: userID, Version: 3}
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataItem.ID).Return(dataItem, nil)
+	mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{folder}, nil)
+	mockDataRepo.EXPECT().Update(ctx, dataItem, int64(3)).Return(nil)
+	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, version *models.DataVersion) error {
+		assert.Equal(t, int64(4), version.Version)
+		return nil
+	})
+
+	result, err := service.MoveData(ctx, userID, dataItem.ID, "work")
+
+	assert.NoError(t, err)
+	assert.Equal(t, folder.ID, *result.FolderID)
+	assert.Equal(t, int64(4), result.Version)
+}
+
+func TestDataService_MoveData_VersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	key, _ := cryptoService.GenerateKey()
+
+	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	// Хранилище применяет изменение, только если ожидаемая версия совпадает с сохраненной.
+	storedVersion := int64(3)
+	newItem := func(version int64) *models.DataItem {
+		return &models.DataItem{ID: dataID, UserID: userID, Type: models.TextData, Name: "note", EncryptionKey: key, Version: version}
+	}
+	update := func(_ context.Context, item *models.DataItem, expectedVersion int64) error {
+		if expectedVersion != storedVersion {
+			return fmt.Errorf("failed to update data item: %w", models.ErrVersionConflict)
+		}
+		storedVersion = item.Version
+		return nil
+	}
+
+	// Изменение прочитало элемент до перемещения, а записывает его после.
+	gomock.InOrder(
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(newItem(3), nil),
+		mockDataRepo.EXPECT().Update(ctx, gomock.Any(), int64(3)).DoAndReturn(update),
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(newItem(3), nil),
+		mockDataRepo.EXPECT().Update(ctx, gomock.Any(), int64(3)).DoAndReturn(update),
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).DoAndReturn(func(context.Context, uuid.UUID) (*models.DataItem, error) {
+			return newItem(storedVersion), nil
+		}),
+	)
+
+	moved, err := service.MoveData(ctx, userID, dataID, "")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), moved.Version)
+
+	_, err = service.UpdateData(ctx, userID, dataID, 3, "note", "", []byte(`{"text":"hi"}`))
+
+	var conflictErr *apperrors.VersionConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, int64(3), conflictErr.Expected)
+	assert.Equal(t, int64(4), conflictErr.Current)
+}
+
+func TestDataService_GetUserData_TagFilter(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockTagRepo := mocks.NewMockTagRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), mockTagRepo, mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	item := &models.DataItem{ID: uuid.New(), UserID: userID, Type: models.LoginPassword}
+	filter := models.DataFilter{
+		Type: models.LoginPassword,
+		Tags: models.TagFilter{Include: []string{"prod", "shared"}, Exclude: []string{"expiring"}, Match: models.TagMatchAll},
+	}
+
+	mockDataRepo.EXPECT().Find(ctx, userID, defaultDataQuery(models.DataQuery{Type: filter.Type, Tags: filter.Tags})).Return([]*models.DataItem{item}, nil)
+	mockTagRepo.EXPECT().GetNamesByDataIDs(ctx, []uuid.UUID{item.ID}).Return(map[uuid.UUID][]string{item.ID: {"prod", "shared"}}, nil)
+
+	result, err := service.GetUserData(ctx, userID, filter, models.PageRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 1)
+	assert.Equal(t, []string{"prod", "shared"}, result.Items[0].Tags)
+}
+
+func TestDataService_GetUserData_Pagination(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	items := []*models.DataItem{
+		{ID: uuid.New(), UserID: userID, Name: "alpha"},
+		{ID: uuid.New(), UserID: userID, Name: "beta"},
+		{ID: uuid.New(), UserID: userID, Name: "gamma"},
+	}
+	page := models.PageRequest{Limit: 2, Sort: models.SortByName}
+
+	mockDataRepo.EXPECT().
+		Find(ctx, userID, models.DataQuery{Sort: models.SortByName, Order: models.SortAsc, Limit: 3}).
+		Return(items, nil)
+
+	first, err := service.GetUserData(ctx, userID, models.DataFilter{}, page)
+	assert.NoError(t, err)
+	assert.Equal(t, items[:2], first.Items)
+	assert.NotEmpty(t, first.NextCursor)
+
+	mockDataRepo.EXPECT().
+		Find(ctx, userID, models.DataQuery{
+			Sort:  models.SortByName,
+			Order: models.SortAsc,
+			After: &models.DataCursor{Sort: models.SortByName, Order: models.SortAsc, Name: "beta", ID: items[1].ID},
+			Limit: 3,
+		}).
+		Return(items[2:], nil)
+
+	page.Cursor = first.NextCursor
+	second, err := service.GetUserData(ctx, userID, models.DataFilter{}, page)
+	assert.NoError(t, err)
+	assert.Equal(t, items[2:], second.Items)
+	assert.Empty(t, second.NextCursor)
+
+	invalid := []models.PageRequest{
+		{Cursor: "not a cursor"},
+		{Cursor: first.NextCursor, Sort: models.SortByCreated},
+		{Limit: constants.MaxPageSize + 1},
+		{Sort: "size"},
+	}
+	for _, page := range invalid {
+		_, err := service.GetUserData(ctx, userID, models.DataFilter{}, page)
+		assert.Error(t, err, page)
+	}
+}
+
+func TestDataService_SearchData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	createdAfter := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	item := &models.DataItem{ID: uuid.New(), UserID: userID, Name: "Prod DB", Metadata: "primary", EncryptedData: []byte("x")}
+
+	mockDataRepo.EXPECT().
+		Find(ctx, userID, defaultDataQuery(models.DataQuery{
+			Type:         models.LoginPassword,
+			Text:         "prod",
+			TextMatch:    models.SearchSubstring,
+			CreatedAfter: &createdAfter,
+		})).
+		Return([]*models.DataItem{item}, nil)
+
+	result, err := service.SearchData(ctx, userID, models.DataSearch{
+		Query:        "  prod ",
+		Filter:       models.DataFilter{Type: models.LoginPassword},
+		CreatedAfter: &createdAfter,
+	}, models.PageRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Items, 1)
+	assert.Nil(t, result.Items[0].EncryptedData)
+	assert.Equal(t, &models.SearchHighlight{Name: "<mark>Prod</mark> DB"}, result.Items[0].Highlight)
+
+	_, err = service.SearchData(ctx, userID, models.DataSearch{}, models.PageRequest{})
+	var validationErr *validator.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+// defaultDataQuery дополняет условия выборки сортировкой и размером страницы по умолчанию.
+func defaultDataQuery(query models.DataQuery) models.DataQuery {
+	query.Sort = models.SortByUpdated
+	query.Order = models.SortDesc
+	query.Limit = constants.DefaultPageSize + 1
+	return query
+}
+
+func TestDataService_UpdateData_RecordsVersionContent(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 5, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	key, _ := cryptoService.GenerateKey()
+
+	dataItem := &models.DataItem{
+		ID:            dataID,
+		UserID:        userID,
+		Type:          models.TextData,
+		Name:          "note",
+		EncryptionKey: key,
+		Version:       3,
+	}
+
+	var recorded *models.DataVersion
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(dataItem, nil)
+	mockDataRepo.EXPECT().Update(ctx, dataItem, int64(3)).Return(nil)
+	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, version *models.DataVersion) error {
+		recorded = version
+		return nil
+	})
+	mockVersionRepo.EXPECT().DeleteOlderVersions(ctx, dataID, 5).Return(nil)
+
+	_, err := service.UpdateData(ctx, userID, dataID, int64(3), "renamed", "meta", []byte(`{"text":"v4"}`))
+
+	assert.NoError(t, err)
+	if assert.NotNil(t, recorded) {
+		assert.Equal(t, int64(4), recorded.Version)
+		assert.Equal(t, "renamed", recorded.Name)
+		assert.Equal(t, "meta", recorded.Metadata)
+		assert.Nil(t, recorded.RestoredFrom)
+
+		decrypted, err := cryptoService.Decrypt(recorded.EncryptedData, key)
+		assert.NoError(t, err)
+		assert.JSONEq(t, `{"text":"v4"}`, string(decrypted))
+	}
+}
+
+func TestDataService_RestoreVersion(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	key, _ := cryptoService.GenerateKey()
+	oldContent, _ := cryptoService.Encrypt([]byte(`{"text":"v1"}`), key)
+	currentContent, _ := cryptoService.Encrypt([]byte(`{"text":"v2"}`), key)
+
+	dataItem := &models.DataItem{
+		ID:            dataID,
+		UserID:        userID,
+		Type:          models.TextData,
+		Name:          "note v2",
+		EncryptedData: currentContent,
+		EncryptionKey: key,
+		Version:       2,
+	}
+	oldVersion := &models.DataVersion{
+		DataID:        dataID,
+		Version:       1,
+		Name:          "note v1",
+		Metadata:      "first",
+		EncryptedData: oldContent,
+	}
+
+	var recorded *models.DataVersion
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(dataItem, nil)
+	mockVersionRepo.EXPECT().GetByDataIDAndVersion(ctx, dataID, int64(1)).Return(oldVersion, nil)
+	mockDataRepo.EXPECT().Update(ctx, dataItem, int64(2)).Return(nil)
+	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, version *models.DataVersion) error {
+		recorded = version
+		return nil
+	})
 
-	result, err := service.SyncData(ctx, userID, lastSync)
+	result, err := service.RestoreVersion(ctx, userID, dataID, 1)
 
 	assert.NoError(t, err)
-	assert.Len(t, result, 1)
-	assert.Nil(t, result[0].EncryptedData)
-	assert.Nil(t, result[0].EncryptionKey)
+	assert.Equal(t, int64(3), result.Version)
+	assert.Equal(t, "note v1", result.Name)
+	assert.Equal(t, "first", result.Metadata)
+	assert.JSONEq(t, `{"text":"v1"}`, string(result.Data))
+	if assert.NotNil(t, recorded) && assert.NotNil(t, recorded.RestoredFrom) {
+		assert.Equal(t, int64(3), recorded.Version)
+		assert.Equal(t, int64(1), *recorded.RestoredFrom)
+	}
+}
+
+func TestDataService_GetVersion_NotFound(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: userID}, nil)
+	mockVersionRepo.EXPECT().GetByDataIDAndVersion(ctx, dataID, int64(7)).Return(nil, fmt.Errorf("failed to get data version: %w", sql.ErrNoRows))
+
+	result, err := service.GetVersion(ctx, userID, dataID, 7)
+
+	assert.Nil(t, result)
+	var appErr *apperrors.AppError
+	if assert.ErrorAs(t, err, &appErr) {
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+	}
+}
+
+func TestDataService_GetData_ReadLimit(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), crypto.NewCryptoService(), nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	maxReads := 2
+
+	newItem := func(readCount int) *models.DataItem {
+		return &models.DataItem{ID: dataID, UserID: userID, Type: models.TextData, MaxReads: &maxReads, ReadCount: readCount}
+	}
+
+	t.Run("counts read", func(t *testing.T) {
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(newItem(0), nil)
+		mockDataRepo.EXPECT().IncrementReadCount(ctx, dataID).Return(1, nil)
+
+		result, err := service.GetData(ctx, userID, dataID)
+		assert.NoError(t, err)
+		assert.Equal(t, 1, result.ReadCount)
+	})
+
+	t.Run("last read destroys item", func(t *testing.T) {
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(newItem(1), nil)
+		mockDataRepo.EXPECT().IncrementReadCount(ctx, dataID).Return(2, nil)
+		mockDataRepo.EXPECT().ForceDelete(ctx, dataID).Return(nil)
+
+		result, err := service.GetData(ctx, userID, dataID)
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.ReadCount)
+	})
+
+	t.Run("concurrent read exhausted limit", func(t *testing.T) {
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(newItem(1), nil)
+		mockDataRepo.EXPECT().IncrementReadCount(ctx, dataID).Return(0, fmt.Errorf("failed to increment read count: %w", sql.ErrNoRows))
+
+		_, err := service.GetData(ctx, userID, dataID)
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+	})
+
+	t.Run("exhausted item is not read", func(t *testing.T) {
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(newItem(2), nil)
+
+		_, err := service.GetData(ctx, userID, dataID)
+		var appErr *apperrors.AppError
+		assert.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+	})
+}
+
+func TestDataService_GetData_Expired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), crypto.NewCryptoService(), nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	expiresAt := time.Now().Add(-time.Minute)
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: userID, ExpiresAt: &expiresAt}, nil)
+
+	_, err := service.GetData(ctx, userID, dataID)
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
+
+func TestDataService_SetExpiry(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), crypto.NewCryptoService(), nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	expiresAt := time.Now().Add(time.Hour)
+	maxReads := 3
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: userID, ReadCount: 5}, nil)
+	mockDataRepo.EXPECT().SetExpiry(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, item *models.DataItem) error {
+		assert.Equal(t, &expiresAt, item.ExpiresAt)
+		assert.Equal(t, &maxReads, item.MaxReads)
+		assert.Zero(t, item.ReadCount)
+		return nil
+	})
+
+	result, err := service.SetExpiry(ctx, userID, dataID, models.DataExpiry{ExpiresAt: &expiresAt, MaxReads: &maxReads})
+	assert.NoError(t, err)
+	assert.Nil(t, result.EncryptedData)
+
+	past := time.Now().Add(-time.Hour)
+	_, err = service.SetExpiry(ctx, userID, dataID, models.DataExpiry{ExpiresAt: &past})
+	var validationErr *validator.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
+
+func TestDataService_GetVersion_ReadLimited(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), crypto.NewCryptoService(), nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	maxReads := 1
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: userID, MaxReads: &maxReads}, nil)
+
+	_, err := service.GetVersion(ctx, userID, dataID, 1)
+	var appErr *apperrors.AppError
+	assert.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestDataService_UpdateData_TracksPasswordChange(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	mockHistoryRepo := mocks.NewMockPasswordHistoryRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mockHistoryRepo, mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 3)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	encryptionKey, _ := cryptoService.GenerateKey()
+
+	newItem := func(changedAt time.Time) *models.DataItem {
+		encryptedData, _ := cryptoService.Encrypt([]byte(`{"login":"user","password":"secret"}`), encryptionKey)
+		return &models.DataItem{
+			ID:                dataID,
+			UserID:            userID,
+			Type:              models.LoginPassword,
+			Name:              "login",
+			EncryptedData:     encryptedData,
+			EncryptionKey:     encryptionKey,
+			Version:           1,
+			PasswordChangedAt: &changedAt,
+		}
+	}
+
+	mockDataRepo.EXPECT().Update(ctx, gomock.Any(), int64(1)).Return(nil).Times(2)
+	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil).Times(2)
+
+	changedAt := time.Now().Add(-100 * 24 * time.Hour)
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(newItem(changedAt), nil)
+	result, err := service.UpdateData(ctx, userID, dataID, int64(1), "login", "", []byte(`{"login":"other","password":"secret"}`))
+	require.NoError(t, err)
+	assert.True(t, result.PasswordChangedAt.Equal(changedAt), "login change must not reset password age")
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(newItem(changedAt), nil)
+	mockHistoryRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, entry *models.PasswordHistoryEntry) error {
+		password, err := cryptoService.Decrypt(entry.EncryptedPassword, encryptionKey)
+		require.NoError(t, err)
+		assert.Equal(t, "secret", string(password))
+		assert.True(t, entry.SetAt.Equal(changedAt))
+		return nil
+	})
+	mockHistoryRepo.EXPECT().DeleteOlderEntries(ctx, dataID, 3).Return(nil)
+	result, err = service.UpdateData(ctx, userID, dataID, int64(1), "login", "", []byte(`{"login":"user","password":"rotated"}`))
+	require.NoError(t, err)
+	assert.True(t, result.PasswordChangedAt.After(changedAt))
+}
+
+func TestDataService_GetPasswordHistory(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockHistoryRepo := mocks.NewMockPasswordHistoryRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mockHistoryRepo, mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	encryptionKey, _ := cryptoService.GenerateKey()
+	encryptedPassword, _ := cryptoService.Encrypt([]byte("previous"), encryptionKey)
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: userID, Type: models.LoginPassword, EncryptionKey: encryptionKey}, nil)
+	mockHistoryRepo.EXPECT().GetByDataID(ctx, dataID).Return([]*models.PasswordHistoryEntry{{DataID: dataID, EncryptedPassword: encryptedPassword}}, nil)
+
+	entries, err := service.GetPasswordHistory(ctx, userID, dataID)
+
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, "previous", entries[0].Password)
+	assert.Nil(t, entries[0].EncryptedPassword)
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: userID, Type: models.TextData}, nil)
+
+	_, err = service.GetPasswordHistory(ctx, userID, dataID)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestDataService_CreateData_Certificate(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), crypto.NewCryptoService(), nil, 0, 0)
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	notAfter := time.Now().Add(60 * 24 * time.Hour).UTC().Truncate(time.Second)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "api.internal"},
+		DNSNames:     []string{"api.internal"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+
+	data, err := json.Marshal(models.CertificatePayload{
+		Certificate: string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+	})
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	mockDataRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	result, err := service.CreateData(ctx, uuid.New(), models.Certificate, "api-tls", "", data, models.DataExpiry{})
+
+	require.NoError(t, err)
+	require.NotNil(t, result.Certificate)
+	assert.Equal(t, "CN=api.internal", result.Certificate.Subject)
+	assert.Equal(t, []string{"api.internal"}, result.Certificate.DNSNames)
+	assert.Equal(t, notAfter, result.Certificate.NotAfter)
+	assert.False(t, result.Certificate.HasPrivateKey)
+}
+
+func TestDataService_UpdateData_VersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	key, _ := cryptoService.GenerateKey()
+
+	newItem := func(version int64) *models.DataItem {
+		return &models.DataItem{ID: dataID, UserID: userID, Type: models.TextData, Name: "note", EncryptionKey: key, Version: version}
+	}
+
+	t.Run("stale expected version", func(t *testing.T) {
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(newItem(4), nil)
+
+		_, err := service.UpdateData(ctx, userID, dataID, 3, "note", "", []byte(`{"text":"hi"}`))
+
+		var conflictErr *apperrors.VersionConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, int64(4), conflictErr.Current)
+	})
+
+	t.Run("concurrent update", func(t *testing.T) {
+		gomock.InOrder(
+			mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(newItem(3), nil),
+			mockDataRepo.EXPECT().Update(ctx, gomock.Any(), int64(3)).Return(fmt.Errorf("failed to update data item: %w", models.ErrVersionConflict)),
+			mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(newItem(4), nil),
+		)
+
+		_, err := service.UpdateData(ctx, userID, dataID, 3, "note", "", []byte(`{"text":"hi"}`))
+
+		var conflictErr *apperrors.VersionConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, int64(3), conflictErr.Expected)
+		assert.Equal(t, int64(4), conflictErr.Current)
+	})
+}
+
+func TestDataService_CreateData_CustomFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	mockTypeRepo := mocks.NewMockCustomTypeRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mockTypeRepo, mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	fields := []models.ItemField{
+		{Name: "Security question", Kind: models.FieldText, Value: json.RawMessage(`"First pet's name"`)},
+		{Name: "PIN", Kind: models.FieldSecret, Value: json.RawMessage(`"1234"`)},
+		{Name: "2FA enabled", Kind: models.FieldBoolean, Value: json.RawMessage(`true`)},
+	}
+
+	// storedFields расшифровывает содержимое, переданное в хранилище, и извлекает из него поля.
+	storedFields := func(t *testing.T, item *models.DataItem) []models.ItemField {
+		decrypted, err := cryptoService.Decrypt(item.EncryptedData, item.EncryptionKey)
+		require.NoError(t, err)
+		parsed, err := models.ParseItemFields(decrypted)
+		require.NoError(t, err)
+		return parsed
+	}
+
+	t.Run("built-in type", func(t *testing.T) {
+		var stored *models.DataItem
+		mockDataRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, item *models.DataItem) error {
+			stored = item
+			return nil
+		})
+		mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		data, _ := json.Marshal(map[string]interface{}{"login": "user", "password": "secret", "fields": fields})
+		result, err := service.CreateData(ctx, userID, models.LoginPassword, "mail", "", data, models.DataExpiry{})
+		require.NoError(t, err)
+
+		parsed, err := models.ParseItemFields(result.Data)
+		require.NoError(t, err)
+		assert.Equal(t, fields, parsed)
+		assert.Equal(t, fields, storedFields(t, stored))
+	})
+
+	t.Run("custom type", func(t *testing.T) {
+		customType := &models.CustomType{UserID: userID, Name: "database", Fields: []models.CustomField{{Name: "dsn", Kind: models.FieldText, Required: true}}}
+
+		var stored *models.DataItem
+		mockTypeRepo.EXPECT().GetByUserIDAndName(ctx, userID, "database").Return(customType, nil)
+		mockDataRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, item *models.DataItem) error {
+			stored = item
+			return nil
+		})
+		mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		data, _ := json.Marshal(map[string]interface{}{"dsn": "postgres://db", "fields": fields[:1]})
+		_, err := service.CreateData(ctx, userID, models.DataType("database"), "prod db", "", data, models.DataExpiry{})
+		require.NoError(t, err)
+
+		assert.Equal(t, fields[:1], storedFields(t, stored))
+	})
+
+	t.Run("invalid field", func(t *testing.T) {
+		data := []byte(`{"text":"note","fields":[{"name":"Expires","kind":"date","value":"tomorrow"}]}`)
+
+		_, err := service.CreateData(ctx, userID, models.TextData, "note", "", data, models.DataExpiry{})
+
+		var validationErr *validator.ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "data.fields[0].value", validationErr.Field)
+	})
+}
+
+func TestDataService_UpdateData_CustomFields(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	cryptoService := crypto.NewCryptoService()
+
+	service := NewDataService(mockDataRepo, mockVersionRepo, mocks.NewMockCustomTypeRepository(ctrl), mocks.NewMockFolderRepository(ctrl), newTagRepoStub(ctrl), mocks.NewMockPasswordHistoryRepository(ctrl), mocks.NewMockBlindIndexRepository(ctrl), cryptoService, nil, 0, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+
+	encryptionKey, _ := cryptoService.GenerateKey()
+	encryptedData, _ := cryptoService.Encrypt([]byte(`{"text":"note","fields":[{"name":"PIN","kind":"secret","value":"1234"}]}`), encryptionKey)
+
+	stored := &models.DataItem{
+		ID:            dataID,
+		UserID:        userID,
+		Type:          models.TextData,
+		Name:          "note",
+		EncryptedData: encryptedData,
+		EncryptionKey: encryptionKey,
+		Version:       1,
+	}
+
+	// Изменение заменяет значение поля и добавляет новое; сохраненный элемент затем
+	// читается с обновленными полями.
+	updated, err := models.SetItemField([]byte(`{"text":"note","fields":[{"name":"PIN","kind":"secret","value":"1234"}]}`),
+		models.ItemField{Name: "PIN", Kind: models.FieldSecret, Value: json.RawMessage(`"4321"`)})
+	require.NoError(t, err)
+	updated, err = models.SetItemField(updated, models.ItemField{Name: "Site", Kind: models.FieldURL, Value: json.RawMessage(`"https://example.com"`)})
+	require.NoError(t, err)
+
+	gomock.InOrder(
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(stored, nil),
+		mockDataRepo.EXPECT().Update(ctx, gomock.Any(), int64(1)).Return(nil),
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).DoAndReturn(func(context.Context, uuid.UUID) (*models.DataItem, error) {
+			return &models.DataItem{ID: dataID, UserID: userID, Type: models.TextData, EncryptedData: stored.EncryptedData, EncryptionKey: encryptionKey, Version: stored.Version}, nil
+		}),
+	)
+	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+	_, err = service.UpdateData(ctx, userID, dataID, 1, "note", "", updated)
+	require.NoError(t, err)
+
+	result, err := service.GetData(ctx, userID, dataID)
+	require.NoError(t, err)
+
+	fields, err := models.ParseItemFields(result.Data)
+	require.NoError(t, err)
+	assert.Equal(t, []models.ItemField{
+		{Name: "PIN", Kind: models.FieldSecret, Value: json.RawMessage(`"4321"`)},
+		{Name: "Site", Kind: models.FieldURL, Value: json.RawMessage(`"https://example.com"`)},
+	}, fields)
+
+	t.Run("remove last field", func(t *testing.T) {
+		removed, err := models.RemoveItemField(updated, "PIN")
+		require.NoError(t, err)
+		removed, err = models.RemoveItemField(removed, "Site")
+		require.NoError(t, err)
+
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(stored, nil)
+		mockDataRepo.EXPECT().Update(ctx, gomock.Any(), int64(2)).Return(nil)
+		mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).Return(nil)
+
+		result, err := service.UpdateData(ctx, userID, dataID, 2, "note", "", removed)
+		require.NoError(t, err)
+
+		assert.JSONEq(t, `{"text":"note"}`, string(result.Data))
+	})
 }
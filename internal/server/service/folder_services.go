@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+// folderService реализует интерфейс FolderService для работы с папками.
+type folderService struct {
+	folderRepo interfaces.FolderRepository
+	dataRepo   interfaces.DataRepository
+	transactor interfaces.Transactor
+	validator  *validator.Validator
+}
+
+// NewFolderService создает новый экземпляр FolderService.
+func NewFolderService(
+	folderRepo interfaces.FolderRepository,
+	dataRepo interfaces.DataRepository,
+	transactor interfaces.Transactor,
+) interfaces.FolderService {
+	return &folderService{
+		folderRepo: folderRepo,
+		dataRepo:   dataRepo,
+		transactor: transactor,
+		validator:  validator.NewValidator(),
+	}
+}
+
+// CreateFolder создает папку по пути, создавая недостающие родительские папки.
+func (s *folderService) CreateFolder(ctx context.Context, userID uuid.UUID, path string) (*models.Folder, error) {
+	segments := splitFolderPath(path)
+	if len(segments) == 0 {
+		return nil, &validator.ValidationError{Field: "path", Message: "folder path is required"}
+	}
+	for _, name := range segments {
+		if err := s.validator.ValidateFolderName(name); err != nil {
+			return nil, &validator.ValidationError{Field: "path", Message: err.Error()}
+		}
+	}
+
+	tree, err := loadFolderTree(ctx, s.folderRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var current *models.Folder
+	created := false
+	for _, name := range segments {
+		var parentID *uuid.UUID
+		if current != nil {
+			parentID = &current.ID
+		}
+
+		if existing := tree.child(parentID, name); existing != nil {
+			current = existing
+			continue
+		}
+
+		folder := &models.Folder{
+			UserID:   userID,
+			ParentID: parentID,
+			Name:     name,
+		}
+		if err := s.folderRepo.Create(ctx, folder); err != nil {
+			return nil, fmt.Errorf("failed to create folder: %w", err)
+		}
+
+		tree = newFolderTree(append(tree.sorted(), folder))
+		current = folder
+		created = true
+	}
+
+	if !created {
+		return nil, apperrors.NewConflict(fmt.Sprintf("folder %s already exists", current.Path), nil)
+	}
+
+	return current, nil
+}
+
+// GetFolders получает все папки пользователя, упорядоченные по пути.
+func (s *folderService) GetFolders(ctx context.Context, userID uuid.UUID) ([]*models.Folder, error) {
+	tree, err := loadFolderTree(ctx, s.folderRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	return tree.sorted(), nil
+}
+
+// RenameFolder переименовывает папку.
+func (s *folderService) RenameFolder(ctx context.Context, userID uuid.UUID, folderRef, name string) (*models.Folder, error) {
+	if err := s.validator.ValidateFolderName(name); err != nil {
+		return nil, err
+	}
+
+	tree, err := loadFolderTree(ctx, s.folderRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := tree.resolve(folderRef)
+	if err != nil {
+		return nil, err
+	}
+
+	if existing := tree.child(folder.ParentID, name); existing != nil && existing.ID != folder.ID {
+		return nil, apperrors.NewConflict(fmt.Sprintf("folder %s already exists", existing.Path), nil)
+	}
+
+	folder.Name = name
+	folder.UpdatedAt = time.Now()
+
+	if err := s.folderRepo.Update(ctx, folder); err != nil {
+		return nil, fmt.Errorf("failed to rename folder: %w", err)
+	}
+
+	tree.updatePaths()
+	return folder, nil
+}
+
+// MoveFolder перемещает папку в другую папку. Пустой parentRef означает перемещение в корень.
+func (s *folderService) MoveFolder(ctx context.Context, userID uuid.UUID, folderRef, parentRef string) (*models.Folder, error) {
+	tree, err := loadFolderTree(ctx, s.folderRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := tree.resolve(folderRef)
+	if err != nil {
+		return nil, err
+	}
+
+	var parentID *uuid.UUID
+	if parentRef != "" {
+		parent, err := tree.resolve(parentRef)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, descendant := range tree.subtree(folder) {
+			if descendant.ID == parent.ID {
+				return nil, apperrors.NewBadRequest(fmt.Sprintf("cannot move folder %s into itself", folder.Path), nil)
+			}
+		}
+		parentID = &parent.ID
+	}
+
+	if existing := tree.child(parentID, folder.Name); existing != nil && existing.ID != folder.ID {
+		return nil, apperrors.NewConflict(fmt.Sprintf("folder %s already exists", existing.Path), nil)
+	}
+
+	folder.ParentID = parentID
+	folder.UpdatedAt = time.Now()
+
+	if err := s.folderRepo.Update(ctx, folder); err != nil {
+		return nil, fmt.Errorf("failed to move folder: %w", err)
+	}
+
+	tree.updatePaths()
+	return folder, nil
+}
+
+// DeleteFolder удаляет папку. Непустая папка удаляется только при recursive
+// вместе со всеми вложенными папками и элементами данных в одной транзакции.
+func (s *folderService) DeleteFolder(ctx context.Context, userID uuid.UUID, folderRef string, recursive bool) error {
+	return s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		return s.deleteFolder(ctx, userID, folderRef, recursive)
+	})
+}
+
+// deleteFolder удаляет папку, ее вложенные папки и элементы данных.
+func (s *folderService) deleteFolder(ctx context.Context, userID uuid.UUID, folderRef string, recursive bool) error {
+	tree, err := loadFolderTree(ctx, s.folderRepo, userID)
+	if err != nil {
+		return err
+	}
+
+	folder, err := tree.resolve(folderRef)
+	if err != nil {
+		return err
+	}
+
+	folders := tree.subtree(folder)
+	folderIDs := make([]uuid.UUID, 0, len(folders))
+	for _, f := range folders {
+		folderIDs = append(folderIDs, f.ID)
+	}
+
+	items, err := s.dataRepo.GetByUserIDAndFolderIDs(ctx, userID, folderIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get folder items: %w", err)
+	}
+
+	if !recursive && (len(folders) > 1 || len(items) > 0) {
+		return apperrors.NewConflict(fmt.Sprintf("folder %s is not empty", folder.Path), nil)
+	}
+
+	for _, item := range items {
+		if err := s.dataRepo.Delete(ctx, item.ID, item.Version); err != nil {
+			return versionConflict(ctx, s.dataRepo, item.ID, item.Version, fmt.Errorf("failed to delete data item: %w", err))
+		}
+	}
+
+	// Вложенные папки удаляются раньше родительских.
+	for i := len(folders) - 1; i >= 0; i-- {
+		if err := s.folderRepo.Delete(ctx, folders[i].ID); err != nil {
+			return fmt.Errorf("failed to delete folder: %w", err)
+		}
+	}
+
+	return nil
+}
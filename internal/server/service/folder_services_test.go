@@ -0,0 +1,237 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/server/service/mocks"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// testFolders возвращает дерево work, work/aws, work/aws/prod и personal.
+func testFolders(userID uuid.UUID) (work, aws, prod, personal *models.Folder) {
+	work = &models.Folder{ID: uuid.New(), UserID: userID, Name: "work"}
+	aws = &models.Folder{ID: uuid.New(), UserID: userID, ParentID: &work.ID, Name: "aws"}
+	prod = &models.Folder{ID: uuid.New(), UserID: userID, ParentID: &aws.ID, Name: "prod"}
+	personal = &models.Folder{ID: uuid.New(), UserID: userID, Name: "personal"}
+	return work, aws, prod, personal
+}
+
+func TestFolderService_CreateFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFolderRepo := mocks.NewMockFolderRepository(ctrl)
+	service := NewFolderService(mockFolderRepo, mocks.NewMockDataRepository(ctrl), &stubTransactor{})
+
+	ctx := context.Background()
+	userID := uuid.New()
+	work := &models.Folder{ID: uuid.New(), UserID: userID, Name: "work"}
+
+	mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work}, nil)
+	mockFolderRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, folder *models.Folder) error {
+		folder.ID = uuid.New()
+		return nil
+	}).Times(2)
+
+	result, err := service.CreateFolder(ctx, userID, "/work/aws/prod/")
+
+	require.NoError(t, err)
+	assert.Equal(t, "prod", result.Name)
+	assert.Equal(t, "work/aws/prod", result.Path)
+	assert.NotNil(t, result.ParentID)
+}
+
+func TestFolderService_CreateFolder_Exists(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFolderRepo := mocks.NewMockFolderRepository(ctrl)
+	service := NewFolderService(mockFolderRepo, mocks.NewMockDataRepository(ctrl), &stubTransactor{})
+
+	ctx := context.Background()
+	userID := uuid.New()
+	work, aws, prod, personal := testFolders(userID)
+
+	mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work, aws, prod, personal}, nil)
+
+	_, err := service.CreateFolder(ctx, userID, "work/aws")
+
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusConflict, appErr.Code)
+}
+
+func TestFolderService_GetFolders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFolderRepo := mocks.NewMockFolderRepository(ctrl)
+	service := NewFolderService(mockFolderRepo, mocks.NewMockDataRepository(ctrl), &stubTransactor{})
+
+	ctx := context.Background()
+	userID := uuid.New()
+	work, aws, prod, personal := testFolders(userID)
+
+	mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{prod, personal, aws, work}, nil)
+
+	result, err := service.GetFolders(ctx, userID)
+
+	require.NoError(t, err)
+	var paths []string
+	for _, folder := range result {
+		paths = append(paths, folder.Path)
+	}
+	assert.Equal(t, []string{"personal", "work", "work/aws", "work/aws/prod"}, paths)
+}
+
+func TestFolderService_MoveFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFolderRepo := mocks.NewMockFolderRepository(ctrl)
+	service := NewFolderService(mockFolderRepo, mocks.NewMockDataRepository(ctrl), &stubTransactor{})
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("move into another folder", func(t *testing.T) {
+		work, aws, prod, personal := testFolders(userID)
+		mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work, aws, prod, personal}, nil)
+		mockFolderRepo.EXPECT().Update(ctx, aws).Return(nil)
+
+		result, err := service.MoveFolder(ctx, userID, "work/aws", "personal")
+
+		require.NoError(t, err)
+		assert.Equal(t, "personal/aws", result.Path)
+		assert.Equal(t, personal.ID, *result.ParentID)
+	})
+
+	t.Run("move into own subfolder", func(t *testing.T) {
+		work, aws, prod, personal := testFolders(userID)
+		mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work, aws, prod, personal}, nil)
+
+		_, err := service.MoveFolder(ctx, userID, "work", prod.ID.String())
+
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.Code)
+	})
+
+	t.Run("move to root", func(t *testing.T) {
+		work, aws, prod, personal := testFolders(userID)
+		mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work, aws, prod, personal}, nil)
+		mockFolderRepo.EXPECT().Update(ctx, prod).Return(nil)
+
+		result, err := service.MoveFolder(ctx, userID, "work/aws/prod", "")
+
+		require.NoError(t, err)
+		assert.Equal(t, "prod", result.Path)
+		assert.Nil(t, result.ParentID)
+	})
+}
+
+func TestFolderService_RenameFolder_Conflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFolderRepo := mocks.NewMockFolderRepository(ctrl)
+	service := NewFolderService(mockFolderRepo, mocks.NewMockDataRepository(ctrl), &stubTransactor{})
+
+	ctx := context.Background()
+	userID := uuid.New()
+	work, aws, prod, personal := testFolders(userID)
+
+	mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work, aws, prod, personal}, nil)
+
+	_, err := service.RenameFolder(ctx, userID, "personal", "work")
+
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusConflict, appErr.Code)
+}
+
+func TestFolderService_DeleteFolder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFolderRepo := mocks.NewMockFolderRepository(ctrl)
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	transactor := &stubTransactor{}
+	service := NewFolderService(mockFolderRepo, mockDataRepo, transactor)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("not empty without recursive", func(t *testing.T) {
+		work, aws, prod, personal := testFolders(userID)
+		mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work, aws, prod, personal}, nil)
+		mockDataRepo.EXPECT().GetByUserIDAndFolderIDs(ctx, userID, []uuid.UUID{aws.ID, prod.ID}).Return(nil, nil)
+
+		err := service.DeleteFolder(ctx, userID, "work/aws", false)
+
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+	})
+
+	t.Run("recursive", func(t *testing.T) {
+		work, aws, prod, personal := testFolders(userID)
+		item := &models.DataItem{ID: uuid.New(), UserID: userID, FolderID: &prod.ID, Version: 4}
+
+		mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work, aws, prod, personal}, nil)
+		mockDataRepo.EXPECT().GetByUserIDAndFolderIDs(ctx, userID, []uuid.UUID{aws.ID, prod.ID}).Return([]*models.DataItem{item}, nil)
+		gomock.InOrder(
+			mockDataRepo.EXPECT().Delete(ctx, item.ID, int64(4)).Return(nil),
+			mockFolderRepo.EXPECT().Delete(ctx, prod.ID).Return(nil),
+			mockFolderRepo.EXPECT().Delete(ctx, aws.ID).Return(nil),
+		)
+
+		transactor.transactions = 0
+		assert.NoError(t, service.DeleteFolder(ctx, userID, "work/aws", true))
+		assert.Equal(t, 1, transactor.transactions)
+	})
+
+	t.Run("failure is returned from transaction", func(t *testing.T) {
+		work, aws, prod, personal := testFolders(userID)
+		item := &models.DataItem{ID: uuid.New(), UserID: userID, FolderID: &prod.ID, Version: 4}
+
+		mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work, aws, prod, personal}, nil)
+		mockDataRepo.EXPECT().GetByUserIDAndFolderIDs(ctx, userID, []uuid.UUID{aws.ID, prod.ID}).Return([]*models.DataItem{item}, nil)
+		gomock.InOrder(
+			mockDataRepo.EXPECT().Delete(ctx, item.ID, int64(4)).Return(nil),
+			mockFolderRepo.EXPECT().Delete(ctx, prod.ID).Return(errors.New("connection reset")),
+		)
+
+		err := service.DeleteFolder(ctx, userID, "work/aws", true)
+
+		assert.ErrorContains(t, err, "failed to delete folder")
+	})
+
+	t.Run("item changed concurrently", func(t *testing.T) {
+		work, aws, prod, personal := testFolders(userID)
+		item := &models.DataItem{ID: uuid.New(), UserID: userID, FolderID: &prod.ID, Version: 4}
+
+		mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work, aws, prod, personal}, nil)
+		mockDataRepo.EXPECT().GetByUserIDAndFolderIDs(ctx, userID, []uuid.UUID{aws.ID, prod.ID}).Return([]*models.DataItem{item}, nil)
+		gomock.InOrder(
+			mockDataRepo.EXPECT().Delete(ctx, item.ID, int64(4)).Return(fmt.Errorf("failed to delete data item: %w", models.ErrVersionConflict)),
+			mockDataRepo.EXPECT().GetByID(ctx, item.ID).Return(&models.DataItem{ID: item.ID, UserID: userID, Version: 5}, nil),
+		)
+
+		err := service.DeleteFolder(ctx, userID, "work/aws", true)
+
+		var conflictErr *apperrors.VersionConflictError
+		require.ErrorAs(t, err, &conflictErr)
+		assert.Equal(t, int64(4), conflictErr.Expected)
+		assert.Equal(t, int64(5), conflictErr.Current)
+	})
+}
@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// folderTree представляет дерево папок пользователя в памяти. Папок у пользователя
+// немного, поэтому дерево строится целиком при каждом обращении.
+type folderTree struct {
+	byID     map[uuid.UUID]*models.Folder
+	children map[uuid.UUID][]*models.Folder // uuid.Nil соответствует корню
+}
+
+// loadFolderTree загружает папки пользователя и строит дерево с вычисленными путями.
+func loadFolderTree(ctx context.Context, folderRepo interfaces.FolderRepository, userID uuid.UUID) (*folderTree, error) {
+	folders, err := folderRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get folders: %w", err)
+	}
+	return newFolderTree(folders), nil
+}
+
+func newFolderTree(folders []*models.Folder) *folderTree {
+	tree := &folderTree{
+		byID:     make(map[uuid.UUID]*models.Folder, len(folders)),
+		children: make(map[uuid.UUID][]*models.Folder),
+	}
+	for _, folder := range folders {
+		tree.byID[folder.ID] = folder
+		tree.children[parentKey(folder.ParentID)] = append(tree.children[parentKey(folder.ParentID)], folder)
+	}
+	tree.updatePaths()
+	return tree
+}
+
+// updatePaths вычисляет пути всех папок дерева.
+func (t *folderTree) updatePaths() {
+	for _, folder := range t.byID {
+		names := []string{folder.Name}
+		seen := map[uuid.UUID]bool{folder.ID: true}
+		for parentID := folder.ParentID; parentID != nil; {
+			parent, ok := t.byID[*parentID]
+			if !ok || seen[parent.ID] {
+				break
+			}
+			seen[parent.ID] = true
+			names = append(names, parent.Name)
+			parentID = parent.ParentID
+		}
+
+		for i, j := 0, len(names)-1; i < j; i, j = i+1, j-1 {
+			names[i], names[j] = names[j], names[i]
+		}
+		folder.Path = strings.Join(names, models.FolderPathSeparator)
+	}
+}
+
+// child возвращает дочернюю папку с указанным именем или nil.
+func (t *folderTree) child(parentID *uuid.UUID, name string) *models.Folder {
+	for _, folder := range t.children[parentKey(parentID)] {
+		if folder.Name == name {
+			return folder
+		}
+	}
+	return nil
+}
+
+// resolve находит папку по ID или пути вида work/aws/prod.
+func (t *folderTree) resolve(ref string) (*models.Folder, error) {
+	if id, err := uuid.Parse(ref); err == nil {
+		if folder, ok := t.byID[id]; ok {
+			return folder, nil
+		}
+		return nil, apperrors.NewNotFound(fmt.Sprintf("folder %s not found", ref), nil)
+	}
+
+	segments := splitFolderPath(ref)
+	if len(segments) == 0 {
+		return nil, apperrors.NewBadRequest("folder path is required", nil)
+	}
+
+	var current *models.Folder
+	for _, name := range segments {
+		var parentID *uuid.UUID
+		if current != nil {
+			parentID = &current.ID
+		}
+		current = t.child(parentID, name)
+		if current == nil {
+			return nil, apperrors.NewNotFound(fmt.Sprintf("folder %s not found", ref), nil)
+		}
+	}
+	return current, nil
+}
+
+// subtree возвращает папку и все вложенные в нее папки; вложенные папки идут после родительских.
+func (t *folderTree) subtree(folder *models.Folder) []*models.Folder {
+	result := []*models.Folder{folder}
+	for i := 0; i < len(result); i++ {
+		result = append(result, t.children[result[i].ID]...)
+	}
+	return result
+}
+
+// rotationFolder возвращает ближайшую к папке folderID (включая ее саму) папку с заданным
+// интервалом ротации паролей или nil.
+func (t *folderTree) rotationFolder(folderID *uuid.UUID) *models.Folder {
+	seen := make(map[uuid.UUID]bool)
+	for folderID != nil && !seen[*folderID] {
+		folder, ok := t.byID[*folderID]
+		if !ok {
+			return nil
+		}
+		if folder.RotationDays != nil {
+			return folder
+		}
+		seen[folder.ID] = true
+		folderID = folder.ParentID
+	}
+	return nil
+}
+
+// sorted возвращает все папки, упорядоченные по пути.
+func (t *folderTree) sorted() []*models.Folder {
+	folders := make([]*models.Folder, 0, len(t.byID))
+	for _, folder := range t.byID {
+		folders = append(folders, folder)
+	}
+	sort.Slice(folders, func(i, j int) bool {
+		return folders[i].Path < folders[j].Path
+	})
+	return folders
+}
+
+// splitFolderPath разбивает путь папки на имена, игнорируя лишние разделители.
+func splitFolderPath(path string) []string {
+	var segments []string
+	for _, segment := range strings.Split(path, models.FolderPathSeparator) {
+		if segment = strings.TrimSpace(segment); segment != "" {
+			segments = append(segments, segment)
+		}
+	}
+	return segments
+}
+
+func parentKey(parentID *uuid.UUID) uuid.UUID {
+	if parentID == nil {
+		return uuid.Nil
+	}
+	return *parentID
+}
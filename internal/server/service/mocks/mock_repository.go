@@ -1,5 +1,5 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/tempizhere/vaultfactory/internal/shared/interfaces (interfaces: DataRepository,VersionRepository)
+// Source: github.com/tempizhere/vaultfactory/internal/shared/interfaces (interfaces: DataRepository,VersionRepository,UserRepository,SessionRepository,CustomTypeRepository,PasswordHistoryRepository,BlindIndexRepository,FolderRepository,TagRepository,AttachmentRepository)
 
 // Package mocks is a generated GoMock package.
 package mocks
@@ -52,17 +52,46 @@ func (mr *MockDataRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock
 }
 
 // Delete mocks base method.
-func (m *MockDataRepository) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+func (m *MockDataRepository) Delete(arg0 context.Context, arg1 uuid.UUID, arg2 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Delete indicates an expected call of Delete.
-func (mr *MockDataRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockDataRepositoryMockRecorder) Delete(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDataRepository)(nil).Delete), arg0, arg1, arg2)
+}
+
+// Find mocks base method.
+func (m *MockDataRepository) Find(arg0 context.Context, arg1 uuid.UUID, arg2 models.DataQuery) ([]*models.DataItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Find", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*models.DataItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Find indicates an expected call of Find.
+func (mr *MockDataRepositoryMockRecorder) Find(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Find", reflect.TypeOf((*MockDataRepository)(nil).Find), arg0, arg1, arg2)
+}
+
+// ForceDelete mocks base method.
+func (m *MockDataRepository) ForceDelete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceDelete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForceDelete indicates an expected call of ForceDelete.
+func (mr *MockDataRepositoryMockRecorder) ForceDelete(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockDataRepository)(nil).Delete), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceDelete", reflect.TypeOf((*MockDataRepository)(nil).ForceDelete), arg0, arg1)
 }
 
 // GetByID mocks base method.
@@ -80,19 +109,19 @@ func (mr *MockDataRepositoryMockRecorder) GetByID(arg0, arg1 interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockDataRepository)(nil).GetByID), arg0, arg1)
 }
 
-// GetByUserID mocks base method.
-func (m *MockDataRepository) GetByUserID(arg0 context.Context, arg1 uuid.UUID) ([]*models.DataItem, error) {
+// GetByUserIDAndFolderIDs mocks base method.
+func (m *MockDataRepository) GetByUserIDAndFolderIDs(arg0 context.Context, arg1 uuid.UUID, arg2 []uuid.UUID) ([]*models.DataItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByUserID", arg0, arg1)
+	ret := m.ctrl.Call(m, "GetByUserIDAndFolderIDs", arg0, arg1, arg2)
 	ret0, _ := ret[0].([]*models.DataItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// GetByUserID indicates an expected call of GetByUserID.
-func (mr *MockDataRepositoryMockRecorder) GetByUserID(arg0, arg1 interface{}) *gomock.Call {
+// GetByUserIDAndFolderIDs indicates an expected call of GetByUserIDAndFolderIDs.
+func (mr *MockDataRepositoryMockRecorder) GetByUserIDAndFolderIDs(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockDataRepository)(nil).GetByUserID), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndFolderIDs", reflect.TypeOf((*MockDataRepository)(nil).GetByUserIDAndFolderIDs), arg0, arg1, arg2)
 }
 
 // GetByUserIDAndType mocks base method.
@@ -110,33 +139,151 @@ func (mr *MockDataRepositoryMockRecorder) GetByUserIDAndType(arg0, arg1, arg2 in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndType", reflect.TypeOf((*MockDataRepository)(nil).GetByUserIDAndType), arg0, arg1, arg2)
 }
 
+// GetDeletedByID mocks base method.
+func (m *MockDataRepository) GetDeletedByID(arg0 context.Context, arg1 uuid.UUID) (*models.DataItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.DataItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedByID indicates an expected call of GetDeletedByID.
+func (mr *MockDataRepositoryMockRecorder) GetDeletedByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedByID", reflect.TypeOf((*MockDataRepository)(nil).GetDeletedByID), arg0, arg1)
+}
+
+// GetDeletedByUserID mocks base method.
+func (m *MockDataRepository) GetDeletedByUserID(arg0 context.Context, arg1 uuid.UUID) ([]*models.DataItem, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeletedByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]*models.DataItem)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeletedByUserID indicates an expected call of GetDeletedByUserID.
+func (mr *MockDataRepositoryMockRecorder) GetDeletedByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeletedByUserID", reflect.TypeOf((*MockDataRepository)(nil).GetDeletedByUserID), arg0, arg1)
+}
+
 // GetUpdatedSince mocks base method.
-func (m *MockDataRepository) GetUpdatedSince(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time) ([]*models.DataItem, error) {
+func (m *MockDataRepository) GetUpdatedSince(arg0 context.Context, arg1 uuid.UUID, arg2 time.Time, arg3 *models.DataCursor, arg4 int) ([]*models.DataItem, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetUpdatedSince", arg0, arg1, arg2)
+	ret := m.ctrl.Call(m, "GetUpdatedSince", arg0, arg1, arg2, arg3, arg4)
 	ret0, _ := ret[0].([]*models.DataItem)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetUpdatedSince indicates an expected call of GetUpdatedSince.
-func (mr *MockDataRepositoryMockRecorder) GetUpdatedSince(arg0, arg1, arg2 interface{}) *gomock.Call {
+func (mr *MockDataRepositoryMockRecorder) GetUpdatedSince(arg0, arg1, arg2, arg3, arg4 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpdatedSince", reflect.TypeOf((*MockDataRepository)(nil).GetUpdatedSince), arg0, arg1, arg2, arg3, arg4)
+}
+
+// IncrementReadCount mocks base method.
+func (m *MockDataRepository) IncrementReadCount(arg0 context.Context, arg1 uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IncrementReadCount", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// IncrementReadCount indicates an expected call of IncrementReadCount.
+func (mr *MockDataRepositoryMockRecorder) IncrementReadCount(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IncrementReadCount", reflect.TypeOf((*MockDataRepository)(nil).IncrementReadCount), arg0, arg1)
+}
+
+// PurgeDeleted mocks base method.
+func (m *MockDataRepository) PurgeDeleted(arg0 context.Context, arg1 uuid.UUID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeleted", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeleted indicates an expected call of PurgeDeleted.
+func (mr *MockDataRepositoryMockRecorder) PurgeDeleted(arg0, arg1 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUpdatedSince", reflect.TypeOf((*MockDataRepository)(nil).GetUpdatedSince), arg0, arg1, arg2)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeleted", reflect.TypeOf((*MockDataRepository)(nil).PurgeDeleted), arg0, arg1)
+}
+
+// PurgeDeletedBefore mocks base method.
+func (m *MockDataRepository) PurgeDeletedBefore(arg0 context.Context, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeDeletedBefore", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeDeletedBefore indicates an expected call of PurgeDeletedBefore.
+func (mr *MockDataRepositoryMockRecorder) PurgeDeletedBefore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeDeletedBefore", reflect.TypeOf((*MockDataRepository)(nil).PurgeDeletedBefore), arg0, arg1)
+}
+
+// PurgeExpired mocks base method.
+func (m *MockDataRepository) PurgeExpired(arg0 context.Context, arg1 time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PurgeExpired", arg0, arg1)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PurgeExpired indicates an expected call of PurgeExpired.
+func (mr *MockDataRepositoryMockRecorder) PurgeExpired(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PurgeExpired", reflect.TypeOf((*MockDataRepository)(nil).PurgeExpired), arg0, arg1)
+}
+
+// Restore mocks base method.
+func (m *MockDataRepository) Restore(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Restore", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Restore indicates an expected call of Restore.
+func (mr *MockDataRepositoryMockRecorder) Restore(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Restore", reflect.TypeOf((*MockDataRepository)(nil).Restore), arg0, arg1)
+}
+
+// SetExpiry mocks base method.
+func (m *MockDataRepository) SetExpiry(arg0 context.Context, arg1 *models.DataItem) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExpiry", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExpiry indicates an expected call of SetExpiry.
+func (mr *MockDataRepositoryMockRecorder) SetExpiry(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExpiry", reflect.TypeOf((*MockDataRepository)(nil).SetExpiry), arg0, arg1)
 }
 
 // Update mocks base method.
-func (m *MockDataRepository) Update(arg0 context.Context, arg1 *models.DataItem) error {
+func (m *MockDataRepository) Update(arg0 context.Context, arg1 *models.DataItem, arg2 int64) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret := m.ctrl.Call(m, "Update", arg0, arg1, arg2)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Update indicates an expected call of Update.
-func (mr *MockDataRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+func (mr *MockDataRepositoryMockRecorder) Update(arg0, arg1, arg2 interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDataRepository)(nil).Update), arg0, arg1)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockDataRepository)(nil).Update), arg0, arg1, arg2)
 }
 
 // MockVersionRepository is a mock of VersionRepository interface.
@@ -176,6 +323,20 @@ func (mr *MockVersionRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockVersionRepository)(nil).Create), arg0, arg1)
 }
 
+// DeleteOlderVersions mocks base method.
+func (m *MockVersionRepository) DeleteOlderVersions(arg0 context.Context, arg1 uuid.UUID, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOlderVersions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOlderVersions indicates an expected call of DeleteOlderVersions.
+func (mr *MockVersionRepositoryMockRecorder) DeleteOlderVersions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOlderVersions", reflect.TypeOf((*MockVersionRepository)(nil).DeleteOlderVersions), arg0, arg1, arg2)
+}
+
 // GetByDataID mocks base method.
 func (m *MockVersionRepository) GetByDataID(arg0 context.Context, arg1 uuid.UUID) ([]*models.DataVersion, error) {
 	m.ctrl.T.Helper()
@@ -191,6 +352,21 @@ func (mr *MockVersionRepositoryMockRecorder) GetByDataID(arg0, arg1 interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDataID", reflect.TypeOf((*MockVersionRepository)(nil).GetByDataID), arg0, arg1)
 }
 
+// GetByDataIDAndVersion mocks base method.
+func (m *MockVersionRepository) GetByDataIDAndVersion(arg0 context.Context, arg1 uuid.UUID, arg2 int64) (*models.DataVersion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDataIDAndVersion", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.DataVersion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDataIDAndVersion indicates an expected call of GetByDataIDAndVersion.
+func (mr *MockVersionRepositoryMockRecorder) GetByDataIDAndVersion(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDataIDAndVersion", reflect.TypeOf((*MockVersionRepository)(nil).GetByDataIDAndVersion), arg0, arg1, arg2)
+}
+
 // GetLatestVersion mocks base method.
 func (m *MockVersionRepository) GetLatestVersion(arg0 context.Context, arg1 uuid.UUID) (*models.DataVersion, error) {
 	m.ctrl.T.Helper()
@@ -423,3 +599,529 @@ func (mr *MockSessionRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gom
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSessionRepository)(nil).Update), arg0, arg1)
 }
+
+// MockCustomTypeRepository is a mock of CustomTypeRepository interface.
+type MockCustomTypeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCustomTypeRepositoryMockRecorder
+}
+
+// MockCustomTypeRepositoryMockRecorder is the mock recorder for MockCustomTypeRepository.
+type MockCustomTypeRepositoryMockRecorder struct {
+	mock *MockCustomTypeRepository
+}
+
+// NewMockCustomTypeRepository creates a new mock instance.
+func NewMockCustomTypeRepository(ctrl *gomock.Controller) *MockCustomTypeRepository {
+	mock := &MockCustomTypeRepository{ctrl: ctrl}
+	mock.recorder = &MockCustomTypeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCustomTypeRepository) EXPECT() *MockCustomTypeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockCustomTypeRepository) Create(arg0 context.Context, arg1 *models.CustomType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockCustomTypeRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockCustomTypeRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockCustomTypeRepository) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockCustomTypeRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCustomTypeRepository)(nil).Delete), arg0, arg1)
+}
+
+// GetByUserID mocks base method.
+func (m *MockCustomTypeRepository) GetByUserID(arg0 context.Context, arg1 uuid.UUID) ([]*models.CustomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]*models.CustomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockCustomTypeRepositoryMockRecorder) GetByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockCustomTypeRepository)(nil).GetByUserID), arg0, arg1)
+}
+
+// GetByUserIDAndName mocks base method.
+func (m *MockCustomTypeRepository) GetByUserIDAndName(arg0 context.Context, arg1 uuid.UUID, arg2 string) (*models.CustomType, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.CustomType)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndName indicates an expected call of GetByUserIDAndName.
+func (mr *MockCustomTypeRepositoryMockRecorder) GetByUserIDAndName(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndName", reflect.TypeOf((*MockCustomTypeRepository)(nil).GetByUserIDAndName), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockCustomTypeRepository) Update(arg0 context.Context, arg1 *models.CustomType) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockCustomTypeRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockCustomTypeRepository)(nil).Update), arg0, arg1)
+}
+
+// MockPasswordHistoryRepository is a mock of PasswordHistoryRepository interface.
+type MockPasswordHistoryRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockPasswordHistoryRepositoryMockRecorder
+}
+
+// MockPasswordHistoryRepositoryMockRecorder is the mock recorder for MockPasswordHistoryRepository.
+type MockPasswordHistoryRepositoryMockRecorder struct {
+	mock *MockPasswordHistoryRepository
+}
+
+// NewMockPasswordHistoryRepository creates a new mock instance.
+func NewMockPasswordHistoryRepository(ctrl *gomock.Controller) *MockPasswordHistoryRepository {
+	mock := &MockPasswordHistoryRepository{ctrl: ctrl}
+	mock.recorder = &MockPasswordHistoryRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPasswordHistoryRepository) EXPECT() *MockPasswordHistoryRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockPasswordHistoryRepository) Create(arg0 context.Context, arg1 *models.PasswordHistoryEntry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockPasswordHistoryRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockPasswordHistoryRepository)(nil).Create), arg0, arg1)
+}
+
+// DeleteOlderEntries mocks base method.
+func (m *MockPasswordHistoryRepository) DeleteOlderEntries(arg0 context.Context, arg1 uuid.UUID, arg2 int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteOlderEntries", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteOlderEntries indicates an expected call of DeleteOlderEntries.
+func (mr *MockPasswordHistoryRepositoryMockRecorder) DeleteOlderEntries(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteOlderEntries", reflect.TypeOf((*MockPasswordHistoryRepository)(nil).DeleteOlderEntries), arg0, arg1, arg2)
+}
+
+// GetByDataID mocks base method.
+func (m *MockPasswordHistoryRepository) GetByDataID(arg0 context.Context, arg1 uuid.UUID) ([]*models.PasswordHistoryEntry, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDataID", arg0, arg1)
+	ret0, _ := ret[0].([]*models.PasswordHistoryEntry)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDataID indicates an expected call of GetByDataID.
+func (mr *MockPasswordHistoryRepositoryMockRecorder) GetByDataID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDataID", reflect.TypeOf((*MockPasswordHistoryRepository)(nil).GetByDataID), arg0, arg1)
+}
+
+// MockBlindIndexRepository is a mock of BlindIndexRepository interface.
+type MockBlindIndexRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockBlindIndexRepositoryMockRecorder
+}
+
+// MockBlindIndexRepositoryMockRecorder is the mock recorder for MockBlindIndexRepository.
+type MockBlindIndexRepositoryMockRecorder struct {
+	mock *MockBlindIndexRepository
+}
+
+// NewMockBlindIndexRepository creates a new mock instance.
+func NewMockBlindIndexRepository(ctrl *gomock.Controller) *MockBlindIndexRepository {
+	mock := &MockBlindIndexRepository{ctrl: ctrl}
+	mock.recorder = &MockBlindIndexRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBlindIndexRepository) EXPECT() *MockBlindIndexRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Replace mocks base method.
+func (m *MockBlindIndexRepository) Replace(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 [][]byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Replace", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Replace indicates an expected call of Replace.
+func (mr *MockBlindIndexRepositoryMockRecorder) Replace(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Replace", reflect.TypeOf((*MockBlindIndexRepository)(nil).Replace), arg0, arg1, arg2, arg3)
+}
+
+// MockFolderRepository is a mock of FolderRepository interface.
+type MockFolderRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockFolderRepositoryMockRecorder
+}
+
+// MockFolderRepositoryMockRecorder is the mock recorder for MockFolderRepository.
+type MockFolderRepositoryMockRecorder struct {
+	mock *MockFolderRepository
+}
+
+// NewMockFolderRepository creates a new mock instance.
+func NewMockFolderRepository(ctrl *gomock.Controller) *MockFolderRepository {
+	mock := &MockFolderRepository{ctrl: ctrl}
+	mock.recorder = &MockFolderRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFolderRepository) EXPECT() *MockFolderRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockFolderRepository) Create(arg0 context.Context, arg1 *models.Folder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockFolderRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockFolderRepository)(nil).Create), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockFolderRepository) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockFolderRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockFolderRepository)(nil).Delete), arg0, arg1)
+}
+
+// GetByUserID mocks base method.
+func (m *MockFolderRepository) GetByUserID(arg0 context.Context, arg1 uuid.UUID) ([]*models.Folder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Folder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockFolderRepositoryMockRecorder) GetByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockFolderRepository)(nil).GetByUserID), arg0, arg1)
+}
+
+// Update mocks base method.
+func (m *MockFolderRepository) Update(arg0 context.Context, arg1 *models.Folder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockFolderRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockFolderRepository)(nil).Update), arg0, arg1)
+}
+
+// MockTagRepository is a mock of TagRepository interface.
+type MockTagRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockTagRepositoryMockRecorder
+}
+
+// MockTagRepositoryMockRecorder is the mock recorder for MockTagRepository.
+type MockTagRepositoryMockRecorder struct {
+	mock *MockTagRepository
+}
+
+// NewMockTagRepository creates a new mock instance.
+func NewMockTagRepository(ctrl *gomock.Controller) *MockTagRepository {
+	mock := &MockTagRepository{ctrl: ctrl}
+	mock.recorder = &MockTagRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTagRepository) EXPECT() *MockTagRepositoryMockRecorder {
+	return m.recorder
+}
+
+// AddToData mocks base method.
+func (m *MockTagRepository) AddToData(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddToData", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddToData indicates an expected call of AddToData.
+func (mr *MockTagRepositoryMockRecorder) AddToData(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddToData", reflect.TypeOf((*MockTagRepository)(nil).AddToData), arg0, arg1, arg2, arg3)
+}
+
+// GetByUserID mocks base method.
+func (m *MockTagRepository) GetByUserID(arg0 context.Context, arg1 uuid.UUID) ([]*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserID", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserID indicates an expected call of GetByUserID.
+func (mr *MockTagRepositoryMockRecorder) GetByUserID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserID", reflect.TypeOf((*MockTagRepository)(nil).GetByUserID), arg0, arg1)
+}
+
+// GetByUserIDAndName mocks base method.
+func (m *MockTagRepository) GetByUserIDAndName(arg0 context.Context, arg1 uuid.UUID, arg2 string) (*models.Tag, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByUserIDAndName", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.Tag)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByUserIDAndName indicates an expected call of GetByUserIDAndName.
+func (mr *MockTagRepositoryMockRecorder) GetByUserIDAndName(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByUserIDAndName", reflect.TypeOf((*MockTagRepository)(nil).GetByUserIDAndName), arg0, arg1, arg2)
+}
+
+// GetNamesByDataIDs mocks base method.
+func (m *MockTagRepository) GetNamesByDataIDs(arg0 context.Context, arg1 []uuid.UUID) (map[uuid.UUID][]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetNamesByDataIDs", arg0, arg1)
+	ret0, _ := ret[0].(map[uuid.UUID][]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetNamesByDataIDs indicates an expected call of GetNamesByDataIDs.
+func (mr *MockTagRepositoryMockRecorder) GetNamesByDataIDs(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetNamesByDataIDs", reflect.TypeOf((*MockTagRepository)(nil).GetNamesByDataIDs), arg0, arg1)
+}
+
+// Merge mocks base method.
+func (m *MockTagRepository) Merge(arg0 context.Context, arg1 uuid.UUID, arg2 []string, arg3 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Merge", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Merge indicates an expected call of Merge.
+func (mr *MockTagRepositoryMockRecorder) Merge(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Merge", reflect.TypeOf((*MockTagRepository)(nil).Merge), arg0, arg1, arg2, arg3)
+}
+
+// RemoveFromData mocks base method.
+func (m *MockTagRepository) RemoveFromData(arg0 context.Context, arg1, arg2 uuid.UUID, arg3 []string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveFromData", arg0, arg1, arg2, arg3)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveFromData indicates an expected call of RemoveFromData.
+func (mr *MockTagRepositoryMockRecorder) RemoveFromData(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveFromData", reflect.TypeOf((*MockTagRepository)(nil).RemoveFromData), arg0, arg1, arg2, arg3)
+}
+
+// Rename mocks base method.
+func (m *MockTagRepository) Rename(arg0 context.Context, arg1 *models.Tag, arg2 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rename", arg0, arg1, arg2)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rename indicates an expected call of Rename.
+func (mr *MockTagRepositoryMockRecorder) Rename(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rename", reflect.TypeOf((*MockTagRepository)(nil).Rename), arg0, arg1, arg2)
+}
+
+// MockAttachmentRepository is a mock of AttachmentRepository interface.
+type MockAttachmentRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockAttachmentRepositoryMockRecorder
+}
+
+// MockAttachmentRepositoryMockRecorder is the mock recorder for MockAttachmentRepository.
+type MockAttachmentRepositoryMockRecorder struct {
+	mock *MockAttachmentRepository
+}
+
+// NewMockAttachmentRepository creates a new mock instance.
+func NewMockAttachmentRepository(ctrl *gomock.Controller) *MockAttachmentRepository {
+	mock := &MockAttachmentRepository{ctrl: ctrl}
+	mock.recorder = &MockAttachmentRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAttachmentRepository) EXPECT() *MockAttachmentRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Create mocks base method.
+func (m *MockAttachmentRepository) Create(arg0 context.Context, arg1 *models.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Create", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Create indicates an expected call of Create.
+func (mr *MockAttachmentRepositoryMockRecorder) Create(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Create", reflect.TypeOf((*MockAttachmentRepository)(nil).Create), arg0, arg1)
+}
+
+// CreateChunk mocks base method.
+func (m *MockAttachmentRepository) CreateChunk(arg0 context.Context, arg1 *models.AttachmentChunk) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateChunk", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateChunk indicates an expected call of CreateChunk.
+func (mr *MockAttachmentRepositoryMockRecorder) CreateChunk(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateChunk", reflect.TypeOf((*MockAttachmentRepository)(nil).CreateChunk), arg0, arg1)
+}
+
+// Delete mocks base method.
+func (m *MockAttachmentRepository) Delete(arg0 context.Context, arg1 uuid.UUID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockAttachmentRepositoryMockRecorder) Delete(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockAttachmentRepository)(nil).Delete), arg0, arg1)
+}
+
+// GetByDataID mocks base method.
+func (m *MockAttachmentRepository) GetByDataID(arg0 context.Context, arg1 uuid.UUID) ([]*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByDataID", arg0, arg1)
+	ret0, _ := ret[0].([]*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByDataID indicates an expected call of GetByDataID.
+func (mr *MockAttachmentRepositoryMockRecorder) GetByDataID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByDataID", reflect.TypeOf((*MockAttachmentRepository)(nil).GetByDataID), arg0, arg1)
+}
+
+// GetByID mocks base method.
+func (m *MockAttachmentRepository) GetByID(arg0 context.Context, arg1 uuid.UUID) (*models.Attachment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByID", arg0, arg1)
+	ret0, _ := ret[0].(*models.Attachment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByID indicates an expected call of GetByID.
+func (mr *MockAttachmentRepositoryMockRecorder) GetByID(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockAttachmentRepository)(nil).GetByID), arg0, arg1)
+}
+
+// GetChunk mocks base method.
+func (m *MockAttachmentRepository) GetChunk(arg0 context.Context, arg1 uuid.UUID, arg2 int) (*models.AttachmentChunk, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChunk", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*models.AttachmentChunk)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChunk indicates an expected call of GetChunk.
+func (mr *MockAttachmentRepositoryMockRecorder) GetChunk(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChunk", reflect.TypeOf((*MockAttachmentRepository)(nil).GetChunk), arg0, arg1, arg2)
+}
+
+// Update mocks base method.
+func (m *MockAttachmentRepository) Update(arg0 context.Context, arg1 *models.Attachment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockAttachmentRepositoryMockRecorder) Update(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockAttachmentRepository)(nil).Update), arg0, arg1)
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// normalizePage заполняет значения параметров постраничной выборки по умолчанию:
+// сортировку по времени изменения, размер страницы constants.DefaultPageSize и
+// направление asc для имени и desc для времени.
+func normalizePage(page models.PageRequest) models.PageRequest {
+	if page.Limit == 0 {
+		page.Limit = constants.DefaultPageSize
+	}
+	if page.Sort == "" {
+		page.Sort = models.SortByUpdated
+	}
+	if page.Order == "" {
+		page.Order = models.SortDesc
+		if page.Sort == models.SortByName {
+			page.Order = models.SortAsc
+		}
+	}
+	return page
+}
+
+// decodeCursor разбирает курсор страницы. Курсор должен быть выдан для той же сортировки.
+func decodeCursor(page models.PageRequest) (*models.DataCursor, error) {
+	if page.Cursor == "" {
+		return nil, nil
+	}
+
+	raw, err := base64.RawURLEncoding.DecodeString(page.Cursor)
+	if err != nil {
+		return nil, apperrors.NewBadRequest("invalid cursor", err)
+	}
+
+	var cursor models.DataCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, apperrors.NewBadRequest("invalid cursor", err)
+	}
+
+	if cursor.Sort != page.Sort || cursor.Order != page.Order {
+		return nil, apperrors.NewBadRequest("cursor was issued for a different sort order", nil)
+	}
+
+	return &cursor, nil
+}
+
+// encodeCursor кодирует позицию после элемента item в непрозрачный курсор.
+func encodeCursor(item *models.DataItem, page models.PageRequest) string {
+	cursor := models.DataCursor{Sort: page.Sort, Order: page.Order, ID: item.ID}
+	switch page.Sort {
+	case models.SortByName:
+		cursor.Name = item.Name
+	case models.SortByCreated:
+		cursor.Time = item.CreatedAt
+	default:
+		cursor.Time = item.UpdatedAt
+	}
+
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// newDataPage формирует страницу из выборки размером до page.Limit+1 элементов:
+// лишний элемент означает, что за страницей следуют другие.
+func newDataPage(items []*models.DataItem, page models.PageRequest) *models.DataPage {
+	result := &models.DataPage{Items: items}
+	if len(items) > page.Limit {
+		result.Items = items[:page.Limit]
+		result.NextCursor = encodeCursor(result.Items[page.Limit-1], page)
+	}
+	return result
+}
@@ -0,0 +1,165 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+// rotationPolicyItem обозначает интервал ротации, заданный для самого элемента.
+const rotationPolicyItem = "item"
+
+// rotationService реализует интерфейс RotationService для контроля ротации паролей.
+type rotationService struct {
+	dataRepo         interfaces.DataRepository
+	versionRepo      interfaces.VersionRepository
+	folderRepo       interfaces.FolderRepository
+	validator        *validator.Validator
+	versionRetention int
+}
+
+// NewRotationService создает новый экземпляр RotationService. versionRetention задает,
+// сколько последних версий элемента хранить (0 — без ограничений).
+func NewRotationService(
+	dataRepo interfaces.DataRepository,
+	versionRepo interfaces.VersionRepository,
+	folderRepo interfaces.FolderRepository,
+	versionRetention int,
+) interfaces.RotationService {
+	return &rotationService{
+		dataRepo:         dataRepo,
+		versionRepo:      versionRepo,
+		folderRepo:       folderRepo,
+		validator:        validator.NewValidator(),
+		versionRetention: versionRetention,
+	}
+}
+
+// SetDataRotation задает интервал ротации пароля элемента данных. nil снимает интервал элемента,
+// после чего действует интервал папки.
+func (s *rotationService) SetDataRotation(ctx context.Context, userID, dataID uuid.UUID, days *int) (*models.DataItem, error) {
+	if err := s.validator.ValidateRotationDays(days); err != nil {
+		return nil, err
+	}
+
+	dataItem, err := getUserData(ctx, s.dataRepo, userID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	if dataItem.Type != models.LoginPassword {
+		return nil, apperrors.NewBadRequest(fmt.Sprintf("password rotation is only supported for %s items", models.LoginPassword), nil)
+	}
+
+	currentVersion := dataItem.Version
+	dataItem.RotationDays = days
+	dataItem.Version++
+	dataItem.UpdatedAt = time.Now()
+
+	if err := s.dataRepo.Update(ctx, dataItem, currentVersion); err != nil {
+		return nil, versionConflict(ctx, s.dataRepo, dataItem.ID, currentVersion, fmt.Errorf("failed to update data item: %w", err))
+	}
+
+	if err := recordVersion(ctx, s.versionRepo, s.versionRetention, dataItem, nil); err != nil {
+		return nil, err
+	}
+
+	dataItem.EncryptedData = nil
+	dataItem.EncryptionKey = nil
+
+	return dataItem, nil
+}
+
+// SetFolderRotation задает интервал ротации паролей элементов папки и вложенных папок.
+// nil снимает интервал папки, после чего действует интервал родительской папки.
+func (s *rotationService) SetFolderRotation(ctx context.Context, userID uuid.UUID, folderRef string, days *int) (*models.Folder, error) {
+	if err := s.validator.ValidateRotationDays(days); err != nil {
+		return nil, err
+	}
+
+	tree, err := loadFolderTree(ctx, s.folderRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	folder, err := tree.resolve(folderRef)
+	if err != nil {
+		return nil, err
+	}
+
+	folder.RotationDays = days
+	folder.UpdatedAt = time.Now()
+
+	if err := s.folderRepo.Update(ctx, folder); err != nil {
+		return nil, fmt.Errorf("failed to update folder: %w", err)
+	}
+
+	return folder, nil
+}
+
+// GetOverdue получает элементы login_password, пароль которых не менялся дольше интервала ротации,
+// начиная с наиболее просроченных. Интервал элемента имеет приоритет над интервалом ближайшей
+// папки, для которой он задан.
+func (s *rotationService) GetOverdue(ctx context.Context, userID uuid.UUID) ([]*models.RotationStatus, error) {
+	items, err := s.dataRepo.GetByUserIDAndType(ctx, userID, models.LoginPassword)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user data: %w", err)
+	}
+
+	tree, err := loadFolderTree(ctx, s.folderRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var overdue []*models.RotationStatus
+	for _, item := range items {
+		status := rotationStatus(item, tree)
+		if status == nil || status.DueAt.After(now) {
+			continue
+		}
+		status.OverdueDays = int(now.Sub(status.DueAt).Hours() / 24)
+		overdue = append(overdue, status)
+	}
+
+	sort.Slice(overdue, func(i, j int) bool {
+		return overdue[i].DueAt.Before(overdue[j].DueAt)
+	})
+
+	return overdue, nil
+}
+
+// rotationStatus вычисляет состояние ротации пароля элемента или возвращает nil,
+// если интервал ротации для элемента не задан.
+func rotationStatus(item *models.DataItem, tree *folderTree) *models.RotationStatus {
+	days, policy := 0, ""
+	if item.RotationDays != nil {
+		days, policy = *item.RotationDays, rotationPolicyItem
+	} else if folder := tree.rotationFolder(item.FolderID); folder != nil {
+		days, policy = *folder.RotationDays, folder.Path
+	} else {
+		return nil
+	}
+
+	changedAt := item.CreatedAt
+	if item.PasswordChangedAt != nil {
+		changedAt = *item.PasswordChangedAt
+	}
+
+	return &models.RotationStatus{
+		DataID:            item.ID,
+		Name:              item.Name,
+		FolderID:          item.FolderID,
+		PasswordChangedAt: changedAt,
+		RotationDays:      days,
+		DueAt:             changedAt.AddDate(0, 0, days),
+		Policy:            policy,
+	}
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/server/service/mocks"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+func TestRotationService_GetOverdue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockFolderRepo := mocks.NewMockFolderRepository(ctrl)
+	service := NewRotationService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mockFolderRepo, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	days90, days7 := 90, 7
+
+	work := &models.Folder{ID: uuid.New(), UserID: userID, Name: "work", RotationDays: &days90}
+	prod := &models.Folder{ID: uuid.New(), UserID: userID, ParentID: &work.ID, Name: "prod"}
+
+	daysAgo := func(days int) *time.Time {
+		changedAt := time.Now().Add(-time.Duration(days) * 24 * time.Hour)
+		return &changedAt
+	}
+
+	inherited := &models.DataItem{ID: uuid.New(), Name: "inherited", FolderID: &prod.ID, PasswordChangedAt: daysAgo(100)}
+	fresh := &models.DataItem{ID: uuid.New(), Name: "fresh", FolderID: &prod.ID, PasswordChangedAt: daysAgo(10)}
+	override := &models.DataItem{ID: uuid.New(), Name: "override", FolderID: &prod.ID, RotationDays: &days7, PasswordChangedAt: daysAgo(10)}
+	unmanaged := &models.DataItem{ID: uuid.New(), Name: "unmanaged", PasswordChangedAt: daysAgo(1000)}
+
+	mockDataRepo.EXPECT().GetByUserIDAndType(ctx, userID, models.LoginPassword).Return([]*models.DataItem{inherited, fresh, override, unmanaged}, nil)
+	mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{work, prod}, nil)
+
+	overdue, err := service.GetOverdue(ctx, userID)
+
+	require.NoError(t, err)
+	require.Len(t, overdue, 2)
+
+	assert.Equal(t, inherited.ID, overdue[0].DataID)
+	assert.Equal(t, 90, overdue[0].RotationDays)
+	assert.Equal(t, "work", overdue[0].Policy)
+	assert.Equal(t, 10, overdue[0].OverdueDays)
+
+	assert.Equal(t, override.ID, overdue[1].DataID)
+	assert.Equal(t, 7, overdue[1].RotationDays)
+	assert.Equal(t, "item", overdue[1].Policy)
+	assert.Equal(t, 3, overdue[1].OverdueDays)
+}
+
+func TestRotationService_SetDataRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	service := NewRotationService(mockDataRepo, mockVersionRepo, mocks.NewMockFolderRepository(ctrl), 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	days := 30
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: userID, Type: models.LoginPassword}, nil)
+	mockDataRepo.EXPECT().Update(ctx, gomock.Any(), int64(0)).Return(nil)
+	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, version *models.DataVersion) error {
+		assert.Equal(t, int64(1), version.Version)
+		return nil
+	})
+
+	item, err := service.SetDataRotation(ctx, userID, dataID, &days)
+
+	require.NoError(t, err)
+	assert.Equal(t, &days, item.RotationDays)
+}
+
+func TestRotationService_SetDataRotation_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewRotationService(mockDataRepo, mocks.NewMockVersionRepository(ctrl), mocks.NewMockFolderRepository(ctrl), 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+
+	zero := 0
+	_, err := service.SetDataRotation(ctx, userID, dataID, &zero)
+	var validationErr *validator.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+
+	days := 30
+	mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: userID, Type: models.TextData}, nil)
+
+	_, err = service.SetDataRotation(ctx, userID, dataID, &days)
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusBadRequest, appErr.Code)
+}
+
+func TestRotationService_SetFolderRotation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockFolderRepo := mocks.NewMockFolderRepository(ctrl)
+	service := NewRotationService(mocks.NewMockDataRepository(ctrl), mocks.NewMockVersionRepository(ctrl), mockFolderRepo, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	folder := &models.Folder{ID: uuid.New(), UserID: userID, Name: "prod"}
+	days := 90
+
+	mockFolderRepo.EXPECT().GetByUserID(ctx, userID).Return([]*models.Folder{folder}, nil)
+	mockFolderRepo.EXPECT().Update(ctx, folder).Return(nil)
+
+	result, err := service.SetFolderRotation(ctx, userID, "prod", &days)
+
+	require.NoError(t, err)
+	assert.Equal(t, &days, result.RotationDays)
+}
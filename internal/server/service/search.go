@@ -0,0 +1,121 @@
+package service
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// snippetLength ограничивает длину подсвеченного фрагмента метаданных в символах.
+const snippetLength = 160
+
+// highlightItem подсвечивает совпадения запроса в имени и метаданных элемента.
+// Возвращает nil, если совпадений нет.
+func highlightItem(item *models.DataItem, query string, match models.SearchMatch) *models.SearchHighlight {
+	highlight := &models.SearchHighlight{
+		Name:     highlightName(item.Name, query, match),
+		Metadata: highlightWords(item.Metadata, query),
+	}
+	if highlight.Name == "" && highlight.Metadata == "" {
+		return nil
+	}
+	return highlight
+}
+
+// highlightName подсвечивает вхождения запроса в имя без учета регистра: все вхождения
+// для SearchSubstring и только начало имени для SearchPrefix.
+func highlightName(name, query string, match models.SearchMatch) string {
+	runes, queryRunes := []rune(name), []rune(query)
+	if len(queryRunes) == 0 {
+		return ""
+	}
+
+	var ranges [][2]int
+	for i := 0; i+len(queryRunes) <= len(runes); i++ {
+		if strings.EqualFold(string(runes[i:i+len(queryRunes)]), query) {
+			ranges = append(ranges, [2]int{i, i + len(queryRunes)})
+			i += len(queryRunes) - 1
+		}
+		if match == models.SearchPrefix {
+			break
+		}
+	}
+
+	if len(ranges) == 0 {
+		return ""
+	}
+	return markRanges(runes, ranges, 0, len(runes))
+}
+
+// highlightWords подсвечивает слова текста, совпадающие без учета регистра со словами
+// запроса, как при полнотекстовом поиске. Длинный текст сокращается до фрагмента
+// вокруг первого совпадения.
+func highlightWords(text, query string) string {
+	words := make(map[string]bool)
+	for _, word := range strings.FieldsFunc(strings.ToLower(query), isNotWordRune) {
+		words[word] = true
+	}
+	if len(words) == 0 {
+		return ""
+	}
+
+	runes := []rune(text)
+	var ranges [][2]int
+	for start := 0; start < len(runes); {
+		if isNotWordRune(runes[start]) {
+			start++
+			continue
+		}
+		end := start
+		for end < len(runes) && !isNotWordRune(runes[end]) {
+			end++
+		}
+		if words[strings.ToLower(string(runes[start:end]))] {
+			ranges = append(ranges, [2]int{start, end})
+		}
+		start = end
+	}
+
+	if len(ranges) == 0 {
+		return ""
+	}
+
+	from, to := 0, len(runes)
+	if to > snippetLength {
+		from = max(0, ranges[0][0]-snippetLength/3)
+		to = min(len(runes), from+snippetLength)
+	}
+
+	snippet := markRanges(runes, ranges, from, to)
+	if from > 0 {
+		snippet = "…" + snippet
+	}
+	if to < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+// markRanges возвращает фрагмент runes[from:to], в котором диапазоны ranges
+// обрамлены маркерами подсветки. Диапазоны, выходящие за фрагмент, пропускаются.
+func markRanges(runes []rune, ranges [][2]int, from, to int) string {
+	var b strings.Builder
+	pos := from
+	for _, r := range ranges {
+		if r[0] < from || r[1] > to {
+			continue
+		}
+		b.WriteString(string(runes[pos:r[0]]))
+		b.WriteString(models.HighlightStart)
+		b.WriteString(string(runes[r[0]:r[1]]))
+		b.WriteString(models.HighlightEnd)
+		pos = r[1]
+	}
+	b.WriteString(string(runes[pos:to]))
+	return b.String()
+}
+
+func isNotWordRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+func TestHighlightItem(t *testing.T) {
+	item := &models.DataItem{Name: "Prod DB (prod replica)", Metadata: "Primary database for the PROD cluster"}
+
+	highlight := highlightItem(item, "prod", models.SearchSubstring)
+	assert.Equal(t, &models.SearchHighlight{
+		Name:     "<mark>Prod</mark> DB (<mark>prod</mark> replica)",
+		Metadata: "Primary database for the <mark>PROD</mark> cluster",
+	}, highlight)
+
+	highlight = highlightItem(item, "prod", models.SearchPrefix)
+	assert.Equal(t, "<mark>Prod</mark> DB (prod replica)", highlight.Name)
+
+	highlight = highlightItem(item, "database cluster", models.SearchSubstring)
+	assert.Empty(t, highlight.Name)
+	assert.Equal(t, "Primary <mark>database</mark> for the PROD <mark>cluster</mark>", highlight.Metadata)
+
+	assert.Nil(t, highlightItem(item, "staging", models.SearchSubstring))
+}
+
+func TestHighlightWords_Snippet(t *testing.T) {
+	text := strings.Repeat("lorem ", 100) + "token " + strings.Repeat("ipsum ", 100)
+
+	snippet := highlightWords(text, "token")
+
+	assert.True(t, strings.HasPrefix(snippet, "…"))
+	assert.True(t, strings.HasSuffix(snippet, "…"))
+	assert.Contains(t, snippet, "<mark>token</mark>")
+	assert.LessOrEqual(t, len([]rune(snippet)), snippetLength+2+len("<mark></mark>"))
+}
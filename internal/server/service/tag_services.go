@@ -0,0 +1,199 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+// tagService реализует интерфейс TagService для работы с тегами элементов данных.
+type tagService struct {
+	tagRepo          interfaces.TagRepository
+	dataRepo         interfaces.DataRepository
+	versionRepo      interfaces.VersionRepository
+	transactor       interfaces.Transactor
+	validator        *validator.Validator
+	versionRetention int
+}
+
+// NewTagService создает новый экземпляр TagService. versionRetention задает, сколько
+// последних версий элемента хранить (0 — без ограничений).
+func NewTagService(
+	tagRepo interfaces.TagRepository,
+	dataRepo interfaces.DataRepository,
+	versionRepo interfaces.VersionRepository,
+	transactor interfaces.Transactor,
+	versionRetention int,
+) interfaces.TagService {
+	return &tagService{
+		tagRepo:          tagRepo,
+		dataRepo:         dataRepo,
+		versionRepo:      versionRepo,
+		transactor:       transactor,
+		validator:        validator.NewValidator(),
+		versionRetention: versionRetention,
+	}
+}
+
+// GetTags получает все теги пользователя.
+func (s *tagService) GetTags(ctx context.Context, userID uuid.UUID) ([]*models.Tag, error) {
+	tags, err := s.tagRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+	return tags, nil
+}
+
+// AddTags помечает элемент данных тегами. Теги и версия элемента изменяются в одной
+// транзакции, поэтому при конфликте версий теги не меняются.
+func (s *tagService) AddTags(ctx context.Context, userID, dataID uuid.UUID, tags []string) (*models.DataItem, error) {
+	tags, err := s.validator.NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	dataItem, err := s.getOwnedData(ctx, userID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.tagRepo.AddToData(ctx, userID, dataID, tags); err != nil {
+			return fmt.Errorf("failed to add tags: %w", err)
+		}
+		return s.touch(ctx, dataItem)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withTags(ctx, dataItem)
+}
+
+// RemoveTags снимает теги с элемента данных в одной транзакции с изменением его версии.
+func (s *tagService) RemoveTags(ctx context.Context, userID, dataID uuid.UUID, tags []string) (*models.DataItem, error) {
+	tags, err := s.validator.NormalizeTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	dataItem, err := s.getOwnedData(ctx, userID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.transactor.RunInTx(ctx, func(ctx context.Context) error {
+		if err := s.tagRepo.RemoveFromData(ctx, userID, dataID, tags); err != nil {
+			return fmt.Errorf("failed to remove tags: %w", err)
+		}
+		return s.touch(ctx, dataItem)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return s.withTags(ctx, dataItem)
+}
+
+// RenameTag переименовывает тег. Если тег с новым именем уже существует, следует использовать MergeTags.
+// Помеченные тегом элементы данных попадают в синхронизацию.
+func (s *tagService) RenameTag(ctx context.Context, userID uuid.UUID, name, newName string) (*models.Tag, error) {
+	names, err := s.validator.NormalizeTags([]string{name, newName})
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 1 {
+		return nil, apperrors.NewBadRequest("new tag name must differ from the current one", nil)
+	}
+
+	tag, err := s.tagRepo.GetByUserIDAndName(ctx, userID, names[0])
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewNotFound(fmt.Sprintf("tag %s not found", names[0]), err)
+		}
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	existing, err := s.tagRepo.GetByUserIDAndName(ctx, userID, names[1])
+	switch {
+	case err == nil && existing != nil:
+		return nil, apperrors.NewConflict(fmt.Sprintf("tag %s already exists, merge the tags instead", names[1]), nil)
+	case err != nil && !errors.Is(err, sql.ErrNoRows):
+		return nil, fmt.Errorf("failed to get tag: %w", err)
+	}
+
+	if err := s.tagRepo.Rename(ctx, tag, names[1]); err != nil {
+		return nil, fmt.Errorf("failed to rename tag: %w", err)
+	}
+
+	return tag, nil
+}
+
+// MergeTags переносит элементы исходных тегов на целевой тег и удаляет исходные теги.
+func (s *tagService) MergeTags(ctx context.Context, userID uuid.UUID, sources []string, target string) error {
+	sources, err := s.validator.NormalizeTags(sources)
+	if err != nil {
+		return err
+	}
+
+	targets, err := s.validator.NormalizeTags([]string{target})
+	if err != nil {
+		return err
+	}
+	target = targets[0]
+
+	filtered := sources[:0]
+	for _, source := range sources {
+		if source != target {
+			filtered = append(filtered, source)
+		}
+	}
+	if len(filtered) == 0 {
+		return &validator.ValidationError{Field: "sources", Message: "at least one source tag different from the target is required"}
+	}
+
+	if err := s.tagRepo.Merge(ctx, userID, filtered, target); err != nil {
+		return fmt.Errorf("failed to merge tags: %w", err)
+	}
+
+	return nil
+}
+
+// getOwnedData получает элемент данных с проверкой прав доступа.
+func (s *tagService) getOwnedData(ctx context.Context, userID, dataID uuid.UUID) (*models.DataItem, error) {
+	return getUserData(ctx, s.dataRepo, userID, dataID)
+}
+
+// touch обновляет время изменения и версию элемента, чтобы изменение тегов попало
+// в синхронизацию, и сохраняет новую версию в истории.
+func (s *tagService) touch(ctx context.Context, dataItem *models.DataItem) error {
+	currentVersion := dataItem.Version
+	dataItem.Version++
+	dataItem.UpdatedAt = time.Now()
+	if err := s.dataRepo.Update(ctx, dataItem, currentVersion); err != nil {
+		return versionConflict(ctx, s.dataRepo, dataItem.ID, currentVersion, fmt.Errorf("failed to update data item: %w", err))
+	}
+	return recordVersion(ctx, s.versionRepo, s.versionRetention, dataItem, nil)
+}
+
+// withTags возвращает элемент с актуальным списком тегов и без зашифрованного содержимого.
+func (s *tagService) withTags(ctx context.Context, dataItem *models.DataItem) (*models.DataItem, error) {
+	tags, err := s.tagRepo.GetNamesByDataIDs(ctx, []uuid.UUID{dataItem.ID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	dataItem.Tags = tags[dataItem.ID]
+	dataItem.EncryptedData = nil
+	dataItem.EncryptionKey = nil
+
+	return dataItem, nil
+}
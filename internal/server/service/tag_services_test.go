@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/server/service/mocks"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+func TestTagService_AddTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTagRepo := mocks.NewMockTagRepository(ctrl)
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	mockVersionRepo := mocks.NewMockVersionRepository(ctrl)
+	transactor := &stubTransactor{}
+	service := NewTagService(mockTagRepo, mockDataRepo, mockVersionRepo, transactor, 2)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataItem := &models.DataItem{ID: uuid.New(), UserID: userID, EncryptedData: []byte("x"), Version: 2}
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataItem.ID).Return(dataItem, nil)
+	mockTagRepo.EXPECT().AddToData(ctx, userID, dataItem.ID, []string{"prod", "shared-with-ops"}).Return(nil)
+	mockDataRepo.EXPECT().Update(ctx, dataItem, int64(2)).Return(nil)
+	mockVersionRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, version *models.DataVersion) error {
+		assert.Equal(t, int64(3), version.Version)
+		assert.Equal(t, []byte("x"), version.EncryptedData)
+		return nil
+	})
+	mockVersionRepo.EXPECT().DeleteOlderVersions(ctx, dataItem.ID, 2).Return(nil)
+	mockTagRepo.EXPECT().GetNamesByDataIDs(ctx, []uuid.UUID{dataItem.ID}).Return(map[uuid.UUID][]string{dataItem.ID: {"prod", "shared-with-ops"}}, nil)
+
+	result, err := service.AddTags(ctx, userID, dataItem.ID, []string{" Prod", "shared-with-ops", "prod"})
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod", "shared-with-ops"}, result.Tags)
+	assert.Nil(t, result.EncryptedData)
+	assert.Equal(t, 1, transactor.transactions)
+}
+
+func TestTagService_RemoveTags_VersionConflict(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTagRepo := mocks.NewMockTagRepository(ctrl)
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	transactor := &stubTransactor{}
+	service := NewTagService(mockTagRepo, mockDataRepo, mocks.NewMockVersionRepository(ctrl), transactor, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+
+	gomock.InOrder(
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: userID, Version: 2}, nil),
+		mockDataRepo.EXPECT().Update(ctx, gomock.Any(), int64(2)).Return(fmt.Errorf("failed to update data item: %w", models.ErrVersionConflict)),
+		mockDataRepo.EXPECT().GetByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: userID, Version: 3}, nil),
+	)
+	mockTagRepo.EXPECT().RemoveFromData(ctx, userID, dataID, []string{"prod"}).Return(nil)
+
+	_, err := service.RemoveTags(ctx, userID, dataID, []string{"prod"})
+
+	// Ошибка возвращается из транзакции, поэтому снятие тегов откатывается.
+	var conflictErr *apperrors.VersionConflictError
+	require.ErrorAs(t, err, &conflictErr)
+	assert.Equal(t, int64(3), conflictErr.Current)
+	assert.Equal(t, 1, transactor.transactions)
+}
+
+func TestTagService_AddTags_Invalid(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewTagService(mocks.NewMockTagRepository(ctrl), mocks.NewMockDataRepository(ctrl), mocks.NewMockVersionRepository(ctrl), &stubTransactor{}, 0)
+
+	_, err := service.AddTags(context.Background(), uuid.New(), uuid.New(), []string{"!prod"})
+
+	var validationErr *validator.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, "tags", validationErr.Field)
+}
+
+func TestTagService_AddTags_AccessDenied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewTagService(mocks.NewMockTagRepository(ctrl), mockDataRepo, mocks.NewMockVersionRepository(ctrl), &stubTransactor{}, 0)
+
+	ctx := context.Background()
+	dataItem := &models.DataItem{ID: uuid.New(), UserID: uuid.New()}
+
+	mockDataRepo.EXPECT().GetByID(ctx, dataItem.ID).Return(dataItem, nil)
+
+	_, err := service.AddTags(ctx, uuid.New(), dataItem.ID, []string{"prod"})
+
+	assert.EqualError(t, err, "access denied")
+}
+
+func TestTagService_RenameTag(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTagRepo := mocks.NewMockTagRepository(ctrl)
+	service := NewTagService(mockTagRepo, mocks.NewMockDataRepository(ctrl), mocks.NewMockVersionRepository(ctrl), &stubTransactor{}, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	t.Run("renamed", func(t *testing.T) {
+		tag := &models.Tag{ID: uuid.New(), UserID: userID, Name: "prod"}
+		mockTagRepo.EXPECT().GetByUserIDAndName(ctx, userID, "prod").Return(tag, nil)
+		mockTagRepo.EXPECT().GetByUserIDAndName(ctx, userID, "production").Return(nil, sql.ErrNoRows)
+		mockTagRepo.EXPECT().Rename(ctx, tag, "production").Return(nil)
+
+		_, err := service.RenameTag(ctx, userID, "prod", "production")
+		assert.NoError(t, err)
+	})
+
+	t.Run("target exists", func(t *testing.T) {
+		tag := &models.Tag{ID: uuid.New(), UserID: userID, Name: "prod"}
+		mockTagRepo.EXPECT().GetByUserIDAndName(ctx, userID, "prod").Return(tag, nil)
+		mockTagRepo.EXPECT().GetByUserIDAndName(ctx, userID, "production").Return(&models.Tag{Name: "production"}, nil)
+
+		_, err := service.RenameTag(ctx, userID, "prod", "production")
+
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusConflict, appErr.Code)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockTagRepo.EXPECT().GetByUserIDAndName(ctx, userID, "prod").Return(nil, fmt.Errorf("failed to get tag by name: %w", sql.ErrNoRows))
+
+		_, err := service.RenameTag(ctx, userID, "prod", "production")
+
+		var appErr *apperrors.AppError
+		require.ErrorAs(t, err, &appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.Code)
+	})
+
+	t.Run("database failure", func(t *testing.T) {
+		dbErr := errors.New("connection reset")
+		mockTagRepo.EXPECT().GetByUserIDAndName(ctx, userID, "prod").Return(nil, dbErr)
+
+		_, err := service.RenameTag(ctx, userID, "prod", "production")
+
+		assert.ErrorIs(t, err, dbErr)
+		var appErr *apperrors.AppError
+		assert.False(t, errors.As(err, &appErr))
+	})
+}
+
+func TestTagService_MergeTags(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockTagRepo := mocks.NewMockTagRepository(ctrl)
+	service := NewTagService(mockTagRepo, mocks.NewMockDataRepository(ctrl), mocks.NewMockVersionRepository(ctrl), &stubTransactor{}, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+
+	mockTagRepo.EXPECT().Merge(ctx, userID, []string{"prd", "production"}, "prod").Return(nil)
+
+	assert.NoError(t, service.MergeTags(ctx, userID, []string{"prd", "prod", "Production"}, "prod"))
+
+	err := service.MergeTags(ctx, userID, []string{"prod"}, "prod")
+	var validationErr *validator.ValidationError
+	assert.ErrorAs(t, err, &validationErr)
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/interfaces"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// trashService реализует интерфейс TrashService для работы с корзиной.
+type trashService struct {
+	dataRepo  interfaces.DataRepository
+	retention time.Duration
+}
+
+// NewTrashService создает новый экземпляр TrashService. Элементы хранятся в корзине
+// в течение retention; нулевое значение отключает автоматическую очистку.
+func NewTrashService(dataRepo interfaces.DataRepository, retention time.Duration) interfaces.TrashService {
+	return &trashService{
+		dataRepo:  dataRepo,
+		retention: retention,
+	}
+}
+
+// GetTrash получает элементы данных пользователя, находящиеся в корзине, без зашифрованного содержимого.
+func (s *trashService) GetTrash(ctx context.Context, userID uuid.UUID) ([]*models.DataItem, error) {
+	items, err := s.dataRepo.GetDeletedByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get trash: %w", err)
+	}
+
+	for _, item := range items {
+		item.EncryptedData = nil
+		item.EncryptionKey = nil
+	}
+
+	return items, nil
+}
+
+// RestoreData возвращает элемент данных из корзины.
+func (s *trashService) RestoreData(ctx context.Context, userID, dataID uuid.UUID) (*models.DataItem, error) {
+	dataItem, err := s.getDeletedData(ctx, userID, dataID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.dataRepo.Restore(ctx, dataID); err != nil {
+		return nil, fmt.Errorf("failed to restore data item: %w", err)
+	}
+
+	dataItem.DeletedAt = nil
+	dataItem.UpdatedAt = time.Now()
+	dataItem.EncryptedData = nil
+	dataItem.EncryptionKey = nil
+
+	return dataItem, nil
+}
+
+// PurgeData окончательно удаляет элемент данных из корзины.
+func (s *trashService) PurgeData(ctx context.Context, userID, dataID uuid.UUID) error {
+	if _, err := s.getDeletedData(ctx, userID, dataID); err != nil {
+		return err
+	}
+
+	if err := s.dataRepo.ForceDelete(ctx, dataID); err != nil {
+		return fmt.Errorf("failed to purge data item: %w", err)
+	}
+
+	return nil
+}
+
+// EmptyTrash окончательно удаляет все элементы пользователя из корзины и возвращает их количество.
+func (s *trashService) EmptyTrash(ctx context.Context, userID uuid.UUID) (int, error) {
+	count, err := s.dataRepo.PurgeDeleted(ctx, userID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to empty trash: %w", err)
+	}
+	return count, nil
+}
+
+// PurgeExpired окончательно удаляет элементы, срок хранения которых в корзине истек.
+func (s *trashService) PurgeExpired(ctx context.Context) (int, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+
+	count, err := s.dataRepo.PurgeDeletedBefore(ctx, time.Now().Add(-s.retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired trash: %w", err)
+	}
+	return count, nil
+}
+
+// getDeletedData получает элемент данных из корзины с проверкой прав доступа.
+func (s *trashService) getDeletedData(ctx context.Context, userID, dataID uuid.UUID) (*models.DataItem, error) {
+	dataItem, err := s.dataRepo.GetDeletedByID(ctx, dataID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, apperrors.NewNotFound(fmt.Sprintf("data item %s not found in trash", dataID), err)
+		}
+		return nil, fmt.Errorf("failed to get data item: %w", err)
+	}
+
+	if dataItem.UserID != userID {
+		return nil, apperrors.NewForbidden("access denied", nil)
+	}
+
+	return dataItem, nil
+}
@@ -0,0 +1,109 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/server/service/mocks"
+	apperrors "github.com/tempizhere/vaultfactory/internal/shared/errors"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+func TestTrashService_RestoreData(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewTrashService(mockDataRepo, 0)
+
+	ctx := context.Background()
+	userID := uuid.New()
+	dataID := uuid.New()
+	deletedAt := time.Now().Add(-time.Hour)
+
+	mockDataRepo.EXPECT().GetDeletedByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: userID, DeletedAt: &deletedAt}, nil)
+	mockDataRepo.EXPECT().Restore(ctx, dataID).Return(nil)
+
+	item, err := service.RestoreData(ctx, userID, dataID)
+
+	require.NoError(t, err)
+	assert.Nil(t, item.DeletedAt)
+}
+
+func TestTrashService_RestoreData_NotInTrash(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewTrashService(mockDataRepo, 0)
+
+	ctx := context.Background()
+	dataID := uuid.New()
+
+	mockDataRepo.EXPECT().GetDeletedByID(ctx, dataID).Return(nil, fmt.Errorf("failed to get deleted data item by id: %w", sql.ErrNoRows))
+
+	_, err := service.RestoreData(ctx, uuid.New(), dataID)
+
+	var appErr *apperrors.AppError
+	require.ErrorAs(t, err, &appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.Code)
+}
+
+func TestTrashService_PurgeData_AccessDenied(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewTrashService(mockDataRepo, 0)
+
+	ctx := context.Background()
+	dataID := uuid.New()
+
+	mockDataRepo.EXPECT().GetDeletedByID(ctx, dataID).Return(&models.DataItem{ID: dataID, UserID: uuid.New()}, nil)
+
+	err := service.PurgeData(ctx, uuid.New(), dataID)
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "access denied")
+}
+
+func TestTrashService_PurgeExpired(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDataRepo := mocks.NewMockDataRepository(ctrl)
+	service := NewTrashService(mockDataRepo, 24*time.Hour)
+
+	ctx := context.Background()
+	before := time.Now().Add(-24 * time.Hour)
+
+	mockDataRepo.EXPECT().PurgeDeletedBefore(ctx, gomock.Any()).DoAndReturn(func(_ context.Context, cutoff time.Time) (int, error) {
+		assert.WithinDuration(t, before, cutoff, time.Minute)
+		return 3, nil
+	})
+
+	purged, err := service.PurgeExpired(ctx)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, purged)
+}
+
+func TestTrashService_PurgeExpired_Disabled(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	service := NewTrashService(mocks.NewMockDataRepository(ctrl), 0)
+
+	purged, err := service.PurgeExpired(context.Background())
+
+	require.NoError(t, err)
+	assert.Zero(t, purged)
+}
@@ -0,0 +1,187 @@
+// Package blindindex вычисляет слепые индексы — ключевые HMAC-SHA256 от нормализованных
+// значений полей, по которым сервер может находить элементы, не видя самих значений.
+//
+// Ключ индекса свой у каждого пользователя и выводится из секрета сервера (DeriveKey),
+// поэтому одинаковые значения разных пользователей дают разные токены, а по дампу базы
+// без секрета токены нельзя перебрать по словарю. Имя поля и вид индекса входят во вход
+// HMAC, так что таблица индексов не раскрывает ни названий полей, ни того, какому полю
+// принадлежит токен.
+//
+// Поддерживаются три вида индекса:
+//   - models.BlindIndexExact — значение целиком; находит элементы с точно таким значением поля;
+//   - models.BlindIndexToken — отдельные слова; находит элементы, поле которых содержит все слова запроса;
+//   - models.BlindIndexNGram — триграммы слов; находит элементы, поле которых содержит подстроки запроса.
+//
+// Слепой индекс не скрывает всего, и выбор индексируемых полей — компромисс между
+// удобством поиска и утечкой. Тот, кто видит таблицу индексов (сервер или похититель
+// базы вместе с секретом сервера), узнает:
+//   - равенство: элементы с одинаковым значением поля имеют одинаковый токен BlindIndexExact,
+//     а элементы с общими словами — общие токены BlindIndexToken;
+//   - частоты: по числу элементов с одним токеном и распределению токенов можно угадывать
+//     частые значения (frequency analysis), особенно для полей с малым числом вариантов;
+//   - структуру: число токенов BlindIndexToken и BlindIndexNGram приблизительно раскрывает длину
+//     значения в словах и символах, а общие триграммы — сходство значений;
+//   - запросы: сервер видит, какие токены ищутся и какие элементы им соответствуют, и
+//     может сопоставлять повторяющиеся запросы.
+//
+// Токены усекаются до TokenSize байт: редкие ложные совпадения не мешают поиску, но
+// затрудняют точное сопоставление токенов. Значения с малым числом вариантов (флаги,
+// даты, PIN) индексировать не следует — перебор их по токенам тривиален при знании ключа.
+package blindindex
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/json"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// KeySize — размер ключа индекса пользователя в байтах.
+const KeySize = sha256.Size
+
+// TokenSize — размер токена индекса в байтах.
+const TokenSize = 16
+
+// NGramSize — длина n-грамм индекса BlindIndexNGram в символах.
+const NGramSize = 3
+
+// keyContext отделяет ключи индексов от других значений, выводимых из того же секрета.
+const keyContext = "vaultfactory blind index v1"
+
+// DeriveKey выводит ключ индекса пользователя из секрета сервера.
+func DeriveKey(secret []byte, userID uuid.UUID) []byte {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(keyContext))
+	mac.Write(userID[:])
+	return mac.Sum(nil)
+}
+
+// Normalize приводит значение к виду, в котором оно индексируется: нижний регистр,
+// без пробелов по краям и с одиночными пробелами между словами.
+func Normalize(value string) string {
+	return strings.Join(strings.Fields(strings.ToLower(value)), " ")
+}
+
+// Words разбивает значение на слова в нижнем регистре по символам, не являющимся
+// буквами или цифрами.
+func Words(value string) []string {
+	return strings.FieldsFunc(strings.ToLower(value), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// NGrams возвращает n-граммы длины NGramSize всех слов значения. Слова не длиннее NGramSize
+// входят целиком, поэтому запрос короче NGramSize находит только такие же короткие слова.
+func NGrams(value string) []string {
+	var grams []string
+	for _, word := range Words(value) {
+		if utf8.RuneCountInString(word) <= NGramSize {
+			grams = append(grams, word)
+			continue
+		}
+		runes := []rune(word)
+		for i := 0; i+NGramSize <= len(runes); i++ {
+			grams = append(grams, string(runes[i:i+NGramSize]))
+		}
+	}
+	return grams
+}
+
+// Terms возвращает термы значения, индексируемые для указанного вида индекса.
+func Terms(mode models.BlindIndexMode, value string) []string {
+	switch mode {
+	case models.BlindIndexToken:
+		return Words(value)
+	case models.BlindIndexNGram:
+		return NGrams(value)
+	default:
+		if normalized := Normalize(value); normalized != "" {
+			return []string{normalized}
+		}
+		return nil
+	}
+}
+
+// Token вычисляет токен терма поля для указанного вида индекса.
+func Token(key []byte, field string, mode models.BlindIndexMode, term string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write([]byte(mode))
+	mac.Write([]byte{0})
+	mac.Write([]byte(term))
+	return mac.Sum(nil)[:TokenSize]
+}
+
+// Tokens вычисляет различные токены значения поля для указанного вида индекса.
+func Tokens(key []byte, field string, mode models.BlindIndexMode, value string) [][]byte {
+	seen := make(map[string]bool)
+	var tokens [][]byte
+	for _, term := range Terms(mode, value) {
+		token := Token(key, field, mode, term)
+		if seen[string(token)] {
+			continue
+		}
+		seen[string(token)] = true
+		tokens = append(tokens, token)
+	}
+	return tokens
+}
+
+// ValueTokens вычисляет токены значения поля для всех видов индекса.
+func ValueTokens(key []byte, field, value string) [][]byte {
+	var tokens [][]byte
+	for _, mode := range models.BlindIndexModes {
+		tokens = append(tokens, Tokens(key, field, mode, value)...)
+	}
+	return tokens
+}
+
+// FieldName возвращает имя, под которым индексируется дополнительное поле элемента.
+func FieldName(name string) string {
+	return models.BlindIndexFieldPrefix + name
+}
+
+// ItemValues возвращает значения элемента, для которых строятся слепые индексы: имя
+// элемента и текстовые дополнительные поля и поля-ссылки. Скрытые поля, флаги и даты не
+// индексируются: их значения легко перебрать по токенам.
+func ItemValues(name string, data []byte) map[string]string {
+	values := map[string]string{models.BlindIndexNameField: name}
+
+	fields, err := models.ParseItemFields(data)
+	if err != nil {
+		return values
+	}
+	for _, field := range fields {
+		if field.Kind != models.FieldText && field.Kind != models.FieldURL {
+			continue
+		}
+		var value string
+		if err := json.Unmarshal(field.Value, &value); err != nil {
+			continue
+		}
+		values[FieldName(field.Name)] = value
+	}
+	return values
+}
+
+// ItemTokens вычисляет токены всех видов индекса для значений элемента.
+func ItemTokens(key []byte, values map[string]string) [][]byte {
+	seen := make(map[string]bool)
+	var tokens [][]byte
+	for field, value := range values {
+		for _, token := range ValueTokens(key, field, value) {
+			if seen[string(token)] {
+				continue
+			}
+			seen[string(token)] = true
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens
+}
@@ -0,0 +1,109 @@
+package blindindex
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+func TestDeriveKey(t *testing.T) {
+	secret := []byte("server secret")
+	alice, bob := uuid.New(), uuid.New()
+
+	key := DeriveKey(secret, alice)
+	assert.Len(t, key, KeySize)
+	assert.Equal(t, key, DeriveKey(secret, alice))
+	assert.NotEqual(t, key, DeriveKey(secret, bob))
+	assert.NotEqual(t, key, DeriveKey([]byte("other secret"), alice))
+}
+
+func TestTerms(t *testing.T) {
+	tests := []struct {
+		mode  models.BlindIndexMode
+		value string
+		want  []string
+	}{
+		{models.BlindIndexExact, "  Prod   DB ", []string{"prod db"}},
+		{models.BlindIndexExact, "   ", nil},
+		{models.BlindIndexToken, "prod-db.example.com", []string{"prod", "db", "example", "com"}},
+		{models.BlindIndexNGram, "Vault db", []string{"vau", "aul", "ult", "db"}},
+		{models.BlindIndexNGram, "Сервер", []string{"сер", "ерв", "рве", "вер"}},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, Terms(tt.mode, tt.value), "%s %q", tt.mode, tt.value)
+	}
+}
+
+func TestToken(t *testing.T) {
+	key := DeriveKey([]byte("secret"), uuid.New())
+
+	token := Token(key, models.BlindIndexNameField, models.BlindIndexExact, "prod")
+	assert.Len(t, token, TokenSize)
+	assert.Equal(t, token, Token(key, models.BlindIndexNameField, models.BlindIndexExact, "prod"))
+
+	// Одинаковое значение в разных полях и видах индекса дает разные токены.
+	assert.NotEqual(t, token, Token(key, FieldName("env"), models.BlindIndexExact, "prod"))
+	assert.NotEqual(t, token, Token(key, models.BlindIndexNameField, models.BlindIndexToken, "prod"))
+	assert.NotEqual(t, token, Token(DeriveKey([]byte("secret"), uuid.New()), models.BlindIndexNameField, models.BlindIndexExact, "prod"))
+}
+
+func TestTokens_QueryMatchesIndexedValue(t *testing.T) {
+	key := DeriveKey([]byte("secret"), uuid.New())
+	indexed := ItemTokens(key, map[string]string{models.BlindIndexNameField: "Production Database"})
+
+	contains := func(query [][]byte) bool {
+		for _, q := range query {
+			found := false
+			for _, token := range indexed {
+				if string(token) == string(q) {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+		return true
+	}
+
+	assert.True(t, contains(Tokens(key, models.BlindIndexNameField, models.BlindIndexExact, "production  DATABASE")))
+	assert.False(t, contains(Tokens(key, models.BlindIndexNameField, models.BlindIndexExact, "production")))
+	assert.True(t, contains(Tokens(key, models.BlindIndexNameField, models.BlindIndexToken, "database")))
+	assert.False(t, contains(Tokens(key, models.BlindIndexNameField, models.BlindIndexToken, "data")))
+	assert.True(t, contains(Tokens(key, models.BlindIndexNameField, models.BlindIndexNGram, "duct")))
+	assert.False(t, contains(Tokens(key, models.BlindIndexNameField, models.BlindIndexNGram, "staging")))
+}
+
+func TestTokens_Deduplicated(t *testing.T) {
+	key := DeriveKey([]byte("secret"), uuid.New())
+	assert.Len(t, Tokens(key, models.BlindIndexNameField, models.BlindIndexToken, "db db DB"), 1)
+}
+
+func TestItemValues(t *testing.T) {
+	text, _ := json.Marshal("alice")
+	url, _ := json.Marshal("https://example.com")
+	pin, _ := json.Marshal("1234")
+	data, err := json.Marshal(map[string]interface{}{
+		"username": "root",
+		"fields": []models.ItemField{
+			{Name: "owner", Kind: models.FieldText, Value: text},
+			{Name: "console", Kind: models.FieldURL, Value: url},
+			{Name: "pin", Kind: models.FieldSecret, Value: pin},
+			{Name: "active", Kind: models.FieldBoolean, Value: json.RawMessage("true")},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[string]string{
+		models.BlindIndexNameField: "prod",
+		"field:owner":              "alice",
+		"field:console":            "https://example.com",
+	}, ItemValues("prod", data))
+
+	assert.Equal(t, map[string]string{models.BlindIndexNameField: "note"}, ItemValues("note", []byte("not json")))
+}
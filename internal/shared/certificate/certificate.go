@@ -0,0 +1,131 @@
+// Package certificate предоставляет функции разбора сертификатов X.509 и закрытых ключей в формате PEM.
+package certificate
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// ParseChain разбирает цепочку сертификатов PEM. Первым должен идти сертификат конечного
+// субъекта, каждый следующий — сертификат издателя предыдущего.
+func ParseChain(data string) ([]*x509.Certificate, error) {
+	var chain []*x509.Certificate
+
+	rest := []byte(data)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("unexpected PEM block %s, only certificates are allowed", block.Type)
+		}
+
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid certificate %d: %w", len(chain)+1, err)
+		}
+		chain = append(chain, cert)
+	}
+
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("no PEM certificates found")
+	}
+	if len(bytes.TrimSpace(rest)) > 0 {
+		return nil, fmt.Errorf("unexpected data after certificates")
+	}
+
+	for i := 0; i+1 < len(chain); i++ {
+		if err := chain[i].CheckSignatureFrom(chain[i+1]); err != nil {
+			return nil, fmt.Errorf("certificate %d is not issued by certificate %d; the chain must start with the leaf certificate", i+1, i+2)
+		}
+	}
+
+	return chain, nil
+}
+
+// EncodeChain кодирует цепочку сертификатов в PEM.
+func EncodeChain(chain []*x509.Certificate) string {
+	var buf strings.Builder
+	for _, cert := range chain {
+		_ = pem.Encode(&buf, &pem.Block{Type: "CERTIFICATE", Bytes: cert.Raw})
+	}
+	return buf.String()
+}
+
+// ParsePrivateKey разбирает закрытый ключ PEM в формате PKCS#1, PKCS#8 или SEC 1.
+func ParsePrivateKey(data string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM private key found")
+	}
+	if strings.Contains(block.Type, "ENCRYPTED") {
+		return nil, fmt.Errorf("private key must not be protected by a passphrase")
+	}
+
+	var key interface{}
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %s", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+
+	return signer, nil
+}
+
+// MatchesKey сообщает, соответствует ли закрытый ключ открытому ключу сертификата.
+func MatchesKey(cert *x509.Certificate, key crypto.Signer) bool {
+	publicKey, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool })
+	return ok && publicKey.Equal(cert.PublicKey)
+}
+
+// Inspect разбирает цепочку и возвращает сведения о сертификате конечного субъекта.
+func Inspect(data string, hasPrivateKey bool) (*models.CertificateInfo, error) {
+	chain, err := ParseChain(data)
+	if err != nil {
+		return nil, err
+	}
+
+	leaf := chain[0]
+	fingerprint := sha256.Sum256(leaf.Raw)
+
+	info := &models.CertificateInfo{
+		Subject:       leaf.Subject.String(),
+		Issuer:        leaf.Issuer.String(),
+		DNSNames:      leaf.DNSNames,
+		SerialNumber:  leaf.SerialNumber.Text(16),
+		Fingerprint:   hex.EncodeToString(fingerprint[:]),
+		NotBefore:     leaf.NotBefore.UTC(),
+		NotAfter:      leaf.NotAfter.UTC(),
+		ChainLength:   len(chain),
+		HasPrivateKey: hasPrivateKey,
+	}
+	for _, ip := range leaf.IPAddresses {
+		info.IPAddresses = append(info.IPAddresses, ip.String())
+	}
+
+	return info, nil
+}
@@ -0,0 +1,100 @@
+package certificate
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// issue создает сертификат, подписанный parent (или самоподписанный, если parent равен nil).
+func issue(t *testing.T, name string, parent *x509.Certificate, parentKey *ecdsa.PrivateKey, isCA bool) (*x509.Certificate, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(30 * 24 * time.Hour),
+		IsCA:                  isCA,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	if !isCA {
+		template.DNSNames = []string{name, "www." + name}
+		template.IPAddresses = []net.IP{net.ParseIP("10.0.0.1")}
+	}
+
+	if parent == nil {
+		parent, parentKey = template, key
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	require.NoError(t, err)
+
+	cert, err := x509.ParseCertificate(der)
+	require.NoError(t, err)
+
+	return cert, key
+}
+
+func encodeKey(t *testing.T, key *ecdsa.PrivateKey) string {
+	t.Helper()
+
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}))
+}
+
+func TestInspect(t *testing.T) {
+	ca, caKey := issue(t, "Internal CA", nil, nil, true)
+	leaf, leafKey := issue(t, "api.internal", ca, caKey, false)
+
+	chain := EncodeChain([]*x509.Certificate{leaf, ca})
+
+	info, err := Inspect(chain, true)
+	require.NoError(t, err)
+	assert.Equal(t, "CN=api.internal", info.Subject)
+	assert.Equal(t, "CN=Internal CA", info.Issuer)
+	assert.Equal(t, []string{"api.internal", "www.api.internal"}, info.DNSNames)
+	assert.Equal(t, []string{"10.0.0.1"}, info.IPAddresses)
+	assert.Equal(t, 2, info.ChainLength)
+	assert.True(t, info.HasPrivateKey)
+	assert.WithinDuration(t, leaf.NotAfter, info.NotAfter, time.Second)
+	assert.Len(t, info.Fingerprint, 64)
+
+	key, err := ParsePrivateKey(encodeKey(t, leafKey))
+	require.NoError(t, err)
+	assert.True(t, MatchesKey(leaf, key))
+	assert.False(t, MatchesKey(ca, key))
+}
+
+func TestParseChain_Invalid(t *testing.T) {
+	ca, caKey := issue(t, "Internal CA", nil, nil, true)
+	leaf, _ := issue(t, "api.internal", ca, caKey, false)
+
+	_, err := ParseChain("not a certificate")
+	assert.Error(t, err)
+
+	_, err = ParseChain(EncodeChain([]*x509.Certificate{ca, leaf}))
+	assert.Error(t, err, "chain must start with the leaf")
+
+	_, err = ParseChain(EncodeChain([]*x509.Certificate{leaf}) + "trailing")
+	assert.Error(t, err)
+
+	_, err = ParsePrivateKey(EncodeChain([]*x509.Certificate{leaf}))
+	assert.Error(t, err)
+}
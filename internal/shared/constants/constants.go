@@ -19,8 +19,10 @@ const (
 	IdleTimeoutSeconds    = 60
 
 	// Password requirements
-	MinPasswordLength = 8
-	MaxNameLength     = 255
+	MinPasswordLength       = 8
+	MaxPasswordLength       = 128
+	DefaultPasswordMinScore = 2
+	MaxNameLength           = 255
 
 	// JWT
 	DefaultJWTExpireHours         = 24
@@ -29,4 +31,31 @@ const (
 	// Database
 	DefaultPageSize = 50
 	MaxPageSize     = 100
+
+	// Data versions
+	DefaultVersionRetention = 20
+
+	// Password history
+	PasswordHistorySize = 10
+
+	// Certificates
+	DefaultCertificateExpiryDays = 30
+
+	// Attachments
+	MaxAttachmentSize   = 10 << 20
+	AttachmentChunkSize = 64 << 10
+
+	// Trash
+	DefaultTrashRetentionDays = 30
+	TrashPurgeIntervalMinutes = 60
+
+	// Batch operations
+	MaxBatchOperations  = 100
+	MaxBatchRequestSize = 32 << 20
+
+	// Blind index search
+	MaxBlindSearchTokens = 64
+
+	// Self-destructing items
+	SelfDestructPurgeIntervalMinutes = 1
 )
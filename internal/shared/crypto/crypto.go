@@ -41,6 +41,12 @@ func NewCryptoService() *CryptoService {
 
 // Encrypt шифрует данные с использованием ChaCha20-Poly1305.
 func (c *CryptoService) Encrypt(data []byte, key []byte) ([]byte, error) {
+	return c.EncryptWithAAD(data, key, nil)
+}
+
+// EncryptWithAAD шифрует данные с использованием ChaCha20-Poly1305 и связывает шифротекст
+// с дополнительными данными additionalData, которые должны совпасть при расшифровке.
+func (c *CryptoService) EncryptWithAAD(data []byte, key []byte, additionalData []byte) ([]byte, error) {
 	aead, err := chacha20poly1305.New(key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cipher: %w", err)
@@ -51,12 +57,17 @@ func (c *CryptoService) Encrypt(data []byte, key []byte) ([]byte, error) {
 		return nil, fmt.Errorf("failed to generate nonce: %w", err)
 	}
 
-	ciphertext := aead.Seal(nonce, nonce, data, nil)
+	ciphertext := aead.Seal(nonce, nonce, data, additionalData)
 	return ciphertext, nil
 }
 
 // Decrypt расшифровывает данные с использованием ChaCha20-Poly1305.
 func (c *CryptoService) Decrypt(encryptedData []byte, key []byte) ([]byte, error) {
+	return c.DecryptWithAAD(encryptedData, key, nil)
+}
+
+// DecryptWithAAD расшифровывает данные, зашифрованные EncryptWithAAD с теми же дополнительными данными.
+func (c *CryptoService) DecryptWithAAD(encryptedData []byte, key []byte, additionalData []byte) ([]byte, error) {
 	aead, err := chacha20poly1305.New(key)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create cipher: %w", err)
@@ -68,7 +79,7 @@ func (c *CryptoService) Decrypt(encryptedData []byte, key []byte) ([]byte, error
 	}
 
 	nonce, ciphertext := encryptedData[:nonceSize], encryptedData[nonceSize:]
-	plaintext, err := aead.Open(nil, nonce, ciphertext, nil)
+	plaintext, err := aead.Open(nil, nonce, ciphertext, additionalData)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decrypt: %w", err)
 	}
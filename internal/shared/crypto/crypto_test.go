@@ -50,6 +50,30 @@ func TestCryptoService_EncryptDecrypt_DifferentKeys(t *testing.T) {
 	}
 }
 
+func TestCryptoService_EncryptDecryptWithAAD(t *testing.T) {
+	crypto := NewCryptoService()
+
+	testData := []byte("chunk")
+	key, _ := crypto.GenerateKey()
+
+	encrypted, err := crypto.EncryptWithAAD(testData, key, []byte("chunk-0"))
+	if err != nil {
+		t.Fatalf("Failed to encrypt: %v", err)
+	}
+
+	decrypted, err := crypto.DecryptWithAAD(encrypted, key, []byte("chunk-0"))
+	if err != nil {
+		t.Fatalf("Failed to decrypt: %v", err)
+	}
+	if string(decrypted) != string(testData) {
+		t.Fatalf("Decrypted data doesn't match original. Expected: %s, Got: %s", string(testData), string(decrypted))
+	}
+
+	if _, err := crypto.DecryptWithAAD(encrypted, key, []byte("chunk-1")); err == nil {
+		t.Fatal("Expected error when decrypting with different additional data")
+	}
+}
+
 func TestCryptoService_GenerateKey(t *testing.T) {
 	crypto := NewCryptoService()
 
@@ -162,4 +186,3 @@ func TestCryptoService_Decrypt_TooShortData(t *testing.T) {
 		t.Fatal("Expected error when decrypting too short data")
 	}
 }
-
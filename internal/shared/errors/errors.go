@@ -6,11 +6,34 @@ import (
 	"net/http"
 )
 
-// AppError представляет ошибку приложения с HTTP кодом.
+// ErrorCode - стабильный машиночитаемый код ошибки API.
+type ErrorCode string
+
+// Коды ошибок API. Значения входят в публичный контракт и не должны меняться.
+const (
+	CodeBadRequest           ErrorCode = "bad_request"
+	CodeValidationFailed     ErrorCode = "validation_failed"
+	CodeUnauthorized         ErrorCode = "unauthorized"
+	CodeAccessDenied         ErrorCode = "access_denied"
+	CodeNotFound             ErrorCode = "not_found"
+	CodeMethodNotAllowed     ErrorCode = "method_not_allowed"
+	CodeConflict             ErrorCode = "conflict"
+	CodeVersionConflict      ErrorCode = "version_conflict"
+	CodePreconditionRequired ErrorCode = "precondition_required"
+	CodeRequestTooLarge      ErrorCode = "request_too_large"
+	CodeFailedDependency     ErrorCode = "failed_dependency"
+	CodeInternal             ErrorCode = "internal_error"
+	CodeNotImplemented       ErrorCode = "not_implemented"
+)
+
+// AppError представляет ошибку приложения с HTTP кодом и машиночитаемым кодом ошибки.
 type AppError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-	Err     error  `json:"-"`
+	Code      int                    `json:"code"`
+	ErrorCode ErrorCode              `json:"error_code"`
+	Message   string                 `json:"message"`
+	Field     string                 `json:"field,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	Err       error                  `json:"-"`
 }
 
 func (e *AppError) Error() string {
@@ -20,56 +43,144 @@ func (e *AppError) Error() string {
 	return e.Message
 }
 
+// Unwrap возвращает исходную ошибку.
+func (e *AppError) Unwrap() error {
+	return e.Err
+}
+
+// ErrorResponse - конверт, в котором API возвращает ошибки.
+type ErrorResponse struct {
+	Error ErrorBody `json:"error"`
+}
+
+// ErrorBody описывает ошибку в ответе API.
+type ErrorBody struct {
+	Code      ErrorCode              `json:"code"`
+	Message   string                 `json:"message"`
+	Status    int                    `json:"status"`
+	Field     string                 `json:"field,omitempty"`
+	Details   map[string]interface{} `json:"details,omitempty"`
+	RequestID string                 `json:"request_id,omitempty"`
+}
+
+// NewValidationError создает ошибку 400 Bad Request для недопустимого значения поля.
+func NewValidationError(field, message string, err error) *AppError {
+	return &AppError{
+		Code:      http.StatusBadRequest,
+		ErrorCode: CodeValidationFailed,
+		Message:   message,
+		Field:     field,
+		Err:       err,
+	}
+}
+
 // NewBadRequest создает ошибку 400 Bad Request.
 func NewBadRequest(message string, err error) *AppError {
 	return &AppError{
-		Code:    http.StatusBadRequest,
-		Message: message,
-		Err:     err,
+		Code:      http.StatusBadRequest,
+		ErrorCode: CodeBadRequest,
+		Message:   message,
+		Err:       err,
 	}
 }
 
 // NewUnauthorized создает ошибку 401 Unauthorized.
 func NewUnauthorized(message string, err error) *AppError {
 	return &AppError{
-		Code:    http.StatusUnauthorized,
-		Message: message,
-		Err:     err,
+		Code:      http.StatusUnauthorized,
+		ErrorCode: CodeUnauthorized,
+		Message:   message,
+		Err:       err,
 	}
 }
 
 // NewForbidden создает ошибку 403 Forbidden.
 func NewForbidden(message string, err error) *AppError {
 	return &AppError{
-		Code:    http.StatusForbidden,
-		Message: message,
-		Err:     err,
+		Code:      http.StatusForbidden,
+		ErrorCode: CodeAccessDenied,
+		Message:   message,
+		Err:       err,
 	}
 }
 
 // NewNotFound создает ошибку 404 Not Found.
 func NewNotFound(message string, err error) *AppError {
 	return &AppError{
-		Code:    http.StatusNotFound,
-		Message: message,
-		Err:     err,
+		Code:      http.StatusNotFound,
+		ErrorCode: CodeNotFound,
+		Message:   message,
+		Err:       err,
 	}
 }
 
 // NewConflict создает ошибку 409 Conflict.
 func NewConflict(message string, err error) *AppError {
 	return &AppError{
-		Code:    http.StatusConflict,
-		Message: message,
-		Err:     err,
+		Code:      http.StatusConflict,
+		ErrorCode: CodeConflict,
+		Message:   message,
+		Err:       err,
+	}
+}
+
+// NewPreconditionRequired создает ошибку 428 Precondition Required.
+func NewPreconditionRequired(message string, err error) *AppError {
+	return &AppError{
+		Code:      http.StatusPreconditionRequired,
+		ErrorCode: CodePreconditionRequired,
+		Message:   message,
+		Err:       err,
+	}
+}
+
+// VersionConflictError сообщает, что элемент данных изменен после того, как клиент получил
+// ожидаемую версию. Current содержит актуальную версию для разрешения конфликта.
+type VersionConflictError struct {
+	Expected int64
+	Current  int64
+}
+
+func (e *VersionConflictError) Error() string {
+	return fmt.Sprintf("version conflict: expected version %d, current version is %d", e.Expected, e.Current)
+}
+
+// NewRequestEntityTooLarge создает ошибку 413 Request Entity Too Large.
+func NewRequestEntityTooLarge(message string, err error) *AppError {
+	return &AppError{
+		Code:      http.StatusRequestEntityTooLarge,
+		ErrorCode: CodeRequestTooLarge,
+		Message:   message,
+		Err:       err,
+	}
+}
+
+// NewFailedDependency создает ошибку 424 Failed Dependency.
+func NewFailedDependency(message string, err error) *AppError {
+	return &AppError{
+		Code:      http.StatusFailedDependency,
+		ErrorCode: CodeFailedDependency,
+		Message:   message,
+		Err:       err,
 	}
 }
 
 // NewInternalServerError создает ошибку 500 Internal Server Error.
 func NewInternalServerError(message string, err error) *AppError {
 	return &AppError{
-		Code:    http.StatusInternalServerError,
-		Message: message,
-		Err:     err,
+		Code:      http.StatusInternalServerError,
+		ErrorCode: CodeInternal,
+		Message:   message,
+		Err:       err,
+	}
+}
+
+// NewNotImplemented создает ошибку 501 Not Implemented.
+func NewNotImplemented(message string, err error) *AppError {
+	return &AppError{
+		Code:      http.StatusNotImplemented,
+		ErrorCode: CodeNotImplemented,
+		Message:   message,
+		Err:       err,
 	}
 }
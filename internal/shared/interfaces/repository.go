@@ -9,6 +9,12 @@ import (
 	"github.com/tempizhere/vaultfactory/internal/shared/models"
 )
 
+// Transactor выполняет операции нескольких репозиториев в одной транзакции: репозитории,
+// вызванные с контекстом, переданным в fn, работают внутри нее.
+type Transactor interface {
+	RunInTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
 // UserRepository определяет интерфейс для работы с пользователями.
 type UserRepository interface {
 	Create(ctx context.Context, user *models.User) error
@@ -33,11 +39,21 @@ type SessionRepository interface {
 type DataRepository interface {
 	Create(ctx context.Context, data *models.DataItem) error
 	GetByID(ctx context.Context, id uuid.UUID) (*models.DataItem, error)
-	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.DataItem, error)
 	GetByUserIDAndType(ctx context.Context, userID uuid.UUID, dataType models.DataType) ([]*models.DataItem, error)
-	Update(ctx context.Context, data *models.DataItem) error
-	Delete(ctx context.Context, id uuid.UUID) error
-	GetUpdatedSince(ctx context.Context, userID uuid.UUID, since time.Time) ([]*models.DataItem, error)
+	GetByUserIDAndFolderIDs(ctx context.Context, userID uuid.UUID, folderIDs []uuid.UUID) ([]*models.DataItem, error)
+	Find(ctx context.Context, userID uuid.UUID, query models.DataQuery) ([]*models.DataItem, error)
+	Update(ctx context.Context, data *models.DataItem, expectedVersion int64) error
+	Delete(ctx context.Context, id uuid.UUID, expectedVersion int64) error
+	GetUpdatedSince(ctx context.Context, userID uuid.UUID, since time.Time, after *models.DataCursor, limit int) ([]*models.DataItem, error)
+	GetDeletedByID(ctx context.Context, id uuid.UUID) (*models.DataItem, error)
+	GetDeletedByUserID(ctx context.Context, userID uuid.UUID) ([]*models.DataItem, error)
+	Restore(ctx context.Context, id uuid.UUID) error
+	ForceDelete(ctx context.Context, id uuid.UUID) error
+	PurgeDeleted(ctx context.Context, userID uuid.UUID) (int, error)
+	PurgeDeletedBefore(ctx context.Context, before time.Time) (int, error)
+	PurgeExpired(ctx context.Context, now time.Time) (int, error)
+	IncrementReadCount(ctx context.Context, id uuid.UUID) (int, error)
+	SetExpiry(ctx context.Context, data *models.DataItem) error
 }
 
 // VersionRepository определяет интерфейс для работы с версиями данных.
@@ -45,4 +61,57 @@ type VersionRepository interface {
 	Create(ctx context.Context, version *models.DataVersion) error
 	GetByDataID(ctx context.Context, dataID uuid.UUID) ([]*models.DataVersion, error)
 	GetLatestVersion(ctx context.Context, dataID uuid.UUID) (*models.DataVersion, error)
+	GetByDataIDAndVersion(ctx context.Context, dataID uuid.UUID, version int64) (*models.DataVersion, error)
+	DeleteOlderVersions(ctx context.Context, dataID uuid.UUID, keep int) error
+}
+
+// CustomTypeRepository определяет интерфейс для работы с пользовательскими типами данных.
+type CustomTypeRepository interface {
+	Create(ctx context.Context, customType *models.CustomType) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.CustomType, error)
+	GetByUserIDAndName(ctx context.Context, userID uuid.UUID, name string) (*models.CustomType, error)
+	Update(ctx context.Context, customType *models.CustomType) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// PasswordHistoryRepository определяет интерфейс для работы с историей паролей элементов login_password.
+type PasswordHistoryRepository interface {
+	Create(ctx context.Context, entry *models.PasswordHistoryEntry) error
+	GetByDataID(ctx context.Context, dataID uuid.UUID) ([]*models.PasswordHistoryEntry, error)
+	DeleteOlderEntries(ctx context.Context, dataID uuid.UUID, keep int) error
+}
+
+// BlindIndexRepository определяет интерфейс для работы со слепыми индексами элементов данных.
+type BlindIndexRepository interface {
+	Replace(ctx context.Context, userID, dataID uuid.UUID, tokens [][]byte) error
+}
+
+// FolderRepository определяет интерфейс для работы с папками.
+type FolderRepository interface {
+	Create(ctx context.Context, folder *models.Folder) error
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Folder, error)
+	Update(ctx context.Context, folder *models.Folder) error
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// TagRepository определяет интерфейс для работы с тегами элементов данных.
+type TagRepository interface {
+	GetByUserID(ctx context.Context, userID uuid.UUID) ([]*models.Tag, error)
+	GetByUserIDAndName(ctx context.Context, userID uuid.UUID, name string) (*models.Tag, error)
+	GetNamesByDataIDs(ctx context.Context, dataIDs []uuid.UUID) (map[uuid.UUID][]string, error)
+	AddToData(ctx context.Context, userID, dataID uuid.UUID, names []string) error
+	RemoveFromData(ctx context.Context, userID, dataID uuid.UUID, names []string) error
+	Rename(ctx context.Context, tag *models.Tag, name string) error
+	Merge(ctx context.Context, userID uuid.UUID, sources []string, target string) error
+}
+
+// AttachmentRepository определяет интерфейс для работы с вложениями элементов данных.
+type AttachmentRepository interface {
+	Create(ctx context.Context, attachment *models.Attachment) error
+	Update(ctx context.Context, attachment *models.Attachment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*models.Attachment, error)
+	GetByDataID(ctx context.Context, dataID uuid.UUID) ([]*models.Attachment, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+	CreateChunk(ctx context.Context, chunk *models.AttachmentChunk) error
+	GetChunk(ctx context.Context, attachmentID uuid.UUID, seq int) (*models.AttachmentChunk, error)
 }
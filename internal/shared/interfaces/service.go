@@ -2,6 +2,7 @@ package interfaces
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/google/uuid"
@@ -14,18 +15,34 @@ type AuthService interface {
 	Login(ctx context.Context, email, password string) (*models.User, string, string, error)
 	RefreshToken(ctx context.Context, refreshToken string) (string, string, error)
 	Logout(ctx context.Context, refreshToken string) error
+	ChangePassword(ctx context.Context, userID uuid.UUID, currentPassword, newPassword string) error
 	ValidateToken(ctx context.Context, token string) (*models.User, error)
 }
 
 // DataService определяет интерфейс для работы с данными пользователей.
 type DataService interface {
-	CreateData(ctx context.Context, userID uuid.UUID, dataType models.DataType, name, metadata string, data []byte) (*models.DataItem, error)
+	CreateData(ctx context.Context, userID uuid.UUID, dataType models.DataType, name, metadata string, data []byte, expiry models.DataExpiry) (*models.DataItem, error)
 	GetData(ctx context.Context, userID, dataID uuid.UUID) (*models.DataItem, error)
-	GetUserData(ctx context.Context, userID uuid.UUID) ([]*models.DataItem, error)
-	GetUserDataByType(ctx context.Context, userID uuid.UUID, dataType models.DataType) ([]*models.DataItem, error)
-	UpdateData(ctx context.Context, userID, dataID uuid.UUID, name, metadata string, data []byte) (*models.DataItem, error)
-	DeleteData(ctx context.Context, userID, dataID uuid.UUID) error
-	SyncData(ctx context.Context, userID uuid.UUID, lastSync time.Time) ([]*models.DataItem, error)
+	GetUserData(ctx context.Context, userID uuid.UUID, filter models.DataFilter, page models.PageRequest) (*models.DataPage, error)
+	SearchData(ctx context.Context, userID uuid.UUID, search models.DataSearch, page models.PageRequest) (*models.DataPage, error)
+	BlindSearchData(ctx context.Context, userID uuid.UUID, search models.BlindSearch, page models.PageRequest) (*models.DataPage, error)
+	GetBlindIndexKey(ctx context.Context, userID uuid.UUID) ([]byte, error)
+	RebuildBlindIndex(ctx context.Context, userID uuid.UUID) (int, error)
+	UpdateData(ctx context.Context, userID, dataID uuid.UUID, expectedVersion int64, name, metadata string, data []byte) (*models.DataItem, error)
+	MoveData(ctx context.Context, userID, dataID uuid.UUID, folderRef string) (*models.DataItem, error)
+	GetVersions(ctx context.Context, userID, dataID uuid.UUID) ([]*models.DataVersion, error)
+	GetVersion(ctx context.Context, userID, dataID uuid.UUID, version int64) (*models.DataVersion, error)
+	RestoreVersion(ctx context.Context, userID, dataID uuid.UUID, version int64) (*models.DataItem, error)
+	GetPasswordHistory(ctx context.Context, userID, dataID uuid.UUID) ([]*models.PasswordHistoryEntry, error)
+	DeleteData(ctx context.Context, userID, dataID uuid.UUID, expectedVersion int64) error
+	SyncData(ctx context.Context, userID uuid.UUID, lastSync time.Time, page models.PageRequest) (*models.DataPage, error)
+	SetExpiry(ctx context.Context, userID, dataID uuid.UUID, expiry models.DataExpiry) (*models.DataItem, error)
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+// BatchService определяет интерфейс для пакетного выполнения операций с данными.
+type BatchService interface {
+	ExecuteBatch(ctx context.Context, userID uuid.UUID, batch models.DataBatch) (*models.BatchResult, error)
 }
 
 // CryptoService определяет интерфейс для криптографических операций.
@@ -36,3 +53,59 @@ type CryptoService interface {
 	HashPassword(password string) (string, error)
 	VerifyPassword(password, hash string) bool
 }
+
+// CustomTypeService определяет интерфейс для работы с пользовательскими типами данных.
+type CustomTypeService interface {
+	CreateType(ctx context.Context, userID uuid.UUID, name, description string, fields []models.CustomField) (*models.CustomType, error)
+	GetTypes(ctx context.Context, userID uuid.UUID) ([]*models.CustomType, error)
+	GetType(ctx context.Context, userID uuid.UUID, name string) (*models.CustomType, error)
+	UpdateType(ctx context.Context, userID uuid.UUID, name, description string, fields []models.CustomField) (*models.CustomType, error)
+	DeleteType(ctx context.Context, userID uuid.UUID, name string) error
+}
+
+// FolderService определяет интерфейс для работы с папками. Папки задаются ID или путем вида work/aws/prod.
+type FolderService interface {
+	CreateFolder(ctx context.Context, userID uuid.UUID, path string) (*models.Folder, error)
+	GetFolders(ctx context.Context, userID uuid.UUID) ([]*models.Folder, error)
+	RenameFolder(ctx context.Context, userID uuid.UUID, folderRef, name string) (*models.Folder, error)
+	MoveFolder(ctx context.Context, userID uuid.UUID, folderRef, parentRef string) (*models.Folder, error)
+	DeleteFolder(ctx context.Context, userID uuid.UUID, folderRef string, recursive bool) error
+}
+
+// TagService определяет интерфейс для работы с тегами элементов данных.
+type TagService interface {
+	GetTags(ctx context.Context, userID uuid.UUID) ([]*models.Tag, error)
+	AddTags(ctx context.Context, userID, dataID uuid.UUID, tags []string) (*models.DataItem, error)
+	RemoveTags(ctx context.Context, userID, dataID uuid.UUID, tags []string) (*models.DataItem, error)
+	RenameTag(ctx context.Context, userID uuid.UUID, name, newName string) (*models.Tag, error)
+	MergeTags(ctx context.Context, userID uuid.UUID, sources []string, target string) error
+}
+
+// TrashService определяет интерфейс для работы с корзиной удаленных элементов данных.
+type TrashService interface {
+	GetTrash(ctx context.Context, userID uuid.UUID) ([]*models.DataItem, error)
+	RestoreData(ctx context.Context, userID, dataID uuid.UUID) (*models.DataItem, error)
+	PurgeData(ctx context.Context, userID, dataID uuid.UUID) error
+	EmptyTrash(ctx context.Context, userID uuid.UUID) (int, error)
+	PurgeExpired(ctx context.Context) (int, error)
+}
+
+// AttachmentService определяет интерфейс для работы с вложениями элементов данных.
+type AttachmentService interface {
+	AddAttachment(ctx context.Context, userID, dataID uuid.UUID, filename, mimeType string, content io.Reader) (*models.Attachment, error)
+	GetAttachments(ctx context.Context, userID, dataID uuid.UUID) ([]*models.Attachment, error)
+	GetAttachment(ctx context.Context, userID, dataID, attachmentID uuid.UUID) (*models.Attachment, io.Reader, error)
+	DeleteAttachment(ctx context.Context, userID, dataID, attachmentID uuid.UUID) error
+}
+
+// RotationService определяет интерфейс для контроля ротации паролей элементов login_password.
+type RotationService interface {
+	SetDataRotation(ctx context.Context, userID, dataID uuid.UUID, days *int) (*models.DataItem, error)
+	SetFolderRotation(ctx context.Context, userID uuid.UUID, folderRef string, days *int) (*models.Folder, error)
+	GetOverdue(ctx context.Context, userID uuid.UUID) ([]*models.RotationStatus, error)
+}
+
+// CertificateService определяет интерфейс для контроля сроков действия сертификатов.
+type CertificateService interface {
+	GetExpiring(ctx context.Context, userID uuid.UUID, days int) ([]*models.CertificateStatus, error)
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Attachment представляет файл, прикрепленный к элементу данных. Содержимое
+// каждого вложения шифруется собственным ключом и хранится частями в AttachmentChunk.
+type Attachment struct {
+	bun.BaseModel `bun:"table:attachments"`
+
+	ID            uuid.UUID `json:"id" bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	DataID        uuid.UUID `json:"data_id" bun:"data_id,type:uuid,notnull"`
+	Filename      string    `json:"filename" bun:"filename,notnull"`
+	MimeType      string    `json:"mime_type" bun:"mime_type,notnull"`
+	Size          int64     `json:"size" bun:"size,notnull"`
+	Checksum      string    `json:"checksum" bun:"checksum,notnull"` // SHA-256 исходного содержимого в hex
+	EncryptionKey []byte    `json:"-" bun:"encryption_key,notnull"`
+	CreatedAt     time.Time `json:"created_at" bun:"created_at,default:now()"`
+
+	// Chunks содержит количество частей содержимого. Вложения, загруженные до хранения
+	// по частям, имеют 0 частей и хранят содержимое целиком в EncryptedContent.
+	Chunks           int    `json:"-" bun:"chunks,notnull,default:0"`
+	EncryptedContent []byte `json:"-" bun:"encrypted_content"`
+}
+
+// AttachmentChunk содержит зашифрованную часть содержимого вложения. Шифротекст части
+// связан с ID вложения, номером части и признаком последней части.
+type AttachmentChunk struct {
+	bun.BaseModel `bun:"table:attachment_chunks"`
+
+	AttachmentID     uuid.UUID `bun:"attachment_id,pk,type:uuid"`
+	Seq              int       `bun:"seq,pk"`
+	EncryptedContent []byte    `bun:"encrypted_content,notnull"`
+}
@@ -0,0 +1,76 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BatchOperationKind определяет вид операции пакета.
+type BatchOperationKind string
+
+const (
+	BatchCreate BatchOperationKind = "create" // Создание элемента данных
+	BatchUpdate BatchOperationKind = "update" // Изменение элемента данных
+	BatchDelete BatchOperationKind = "delete" // Перемещение элемента данных в корзину
+)
+
+// BatchMode определяет, как выполняются операции пакета.
+type BatchMode string
+
+const (
+	// BatchAtomic выполняет все операции в одной транзакции: ошибка любой операции
+	// отменяет весь пакет.
+	BatchAtomic BatchMode = "atomic"
+	// BatchIndependent выполняет каждую операцию в отдельной транзакции: ошибки одних
+	// операций не отменяют другие.
+	BatchIndependent BatchMode = "independent"
+)
+
+// BatchOperation описывает одну операцию пакета. Type, ExpiresAt и MaxReads используются
+// только при создании, ID, Version и Force — при изменении и удалении. Version содержит
+// ожидаемую версию элемента и обязательна, если не задан Force; Force отключает сравнение,
+// как If-Match: *.
+type BatchOperation struct {
+	Op        BatchOperationKind `json:"op"`
+	ID        uuid.UUID          `json:"id,omitempty"`
+	Type      DataType           `json:"type,omitempty"`
+	Name      string             `json:"name,omitempty"`
+	Metadata  string             `json:"metadata,omitempty"`
+	Data      json.RawMessage    `json:"data,omitempty"`
+	ExpiresAt *time.Time         `json:"expires_at,omitempty"`
+	MaxReads  *int               `json:"max_reads,omitempty"`
+	Version   *int64             `json:"version,omitempty"`
+	Force     bool               `json:"force,omitempty"`
+}
+
+// ExpectedVersion возвращает ожидаемую версию элемента для операции изменения или удаления.
+func (op BatchOperation) ExpectedVersion() int64 {
+	if op.Force || op.Version == nil {
+		return AnyVersion
+	}
+	return *op.Version
+}
+
+// DataBatch содержит операции пакета и режим их выполнения.
+type DataBatch struct {
+	Mode       BatchMode        `json:"mode,omitempty"`
+	Operations []BatchOperation `json:"operations"`
+}
+
+// BatchOperationResult содержит результат операции пакета: созданный или измененный
+// элемент либо ошибку. Операции атомарного пакета, отмененные или не выполненные из-за
+// ошибки другой операции, получают ошибку 424 Failed Dependency.
+type BatchOperationResult struct {
+	Item *DataItem
+	Err  error
+}
+
+// BatchResult содержит результаты операций пакета в порядке операций. Committed
+// сообщает, что изменения атомарного пакета сохранены; в независимом режиме он всегда
+// true, так как каждая успешная операция сохраняется отдельно.
+type BatchResult struct {
+	Committed bool
+	Results   []BatchOperationResult
+}
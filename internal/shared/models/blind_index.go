@@ -0,0 +1,46 @@
+package models
+
+import (
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// BlindIndexMode определяет вид слепого индекса поля.
+type BlindIndexMode string
+
+const (
+	BlindIndexExact BlindIndexMode = "exact" // Значение целиком
+	BlindIndexToken BlindIndexMode = "token" // Отдельные слова значения
+	BlindIndexNGram BlindIndexMode = "ngram" // Триграммы слов значения
+)
+
+// BlindIndexModes содержит все виды слепого индекса.
+var BlindIndexModes = []BlindIndexMode{BlindIndexExact, BlindIndexToken, BlindIndexNGram}
+
+// BlindIndexNameField — поле слепого индекса для имени элемента. Дополнительные поля
+// индексируются под именем с префиксом BlindIndexFieldPrefix.
+const (
+	BlindIndexNameField   = "name"
+	BlindIndexFieldPrefix = "field:"
+)
+
+// BlindIndexEntry представляет токен слепого индекса элемента данных. Токен не раскрывает
+// ни значения, ни поля, из которого он вычислен.
+type BlindIndexEntry struct {
+	bun.BaseModel `bun:"table:data_blind_indexes"`
+
+	DataID uuid.UUID `bun:"data_id,pk,type:uuid"`
+	UserID uuid.UUID `bun:"user_id,type:uuid,notnull"`
+	Token  []byte    `bun:"token,pk"`
+}
+
+// BlindSearch определяет условия поиска по слепым индексам. Запрос задается либо
+// открытым текстом Query, из которого токены вычисляет сервер, либо токенами Tokens,
+// вычисленными клиентом ключом пользователя, — тогда сервер не видит текста запроса.
+type BlindSearch struct {
+	Field  string         // Поле: BlindIndexNameField или BlindIndexFieldPrefix + имя поля
+	Mode   BlindIndexMode // Вид индекса; пустое значение означает BlindIndexExact
+	Query  string         // Текст запроса
+	Tokens [][]byte       // Токены запроса; элемент должен содержать их все
+	Filter DataFilter     // Фильтр по типу, папке и тегам
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CertificateInfo содержит несекретные сведения о сертификате конечного субъекта.
+type CertificateInfo struct {
+	Subject       string    `json:"subject"`
+	Issuer        string    `json:"issuer"`
+	DNSNames      []string  `json:"dns_names,omitempty"`
+	IPAddresses   []string  `json:"ip_addresses,omitempty"`
+	SerialNumber  string    `json:"serial_number"`
+	Fingerprint   string    `json:"fingerprint"` // SHA-256 от DER представления сертификата
+	NotBefore     time.Time `json:"not_before"`
+	NotAfter      time.Time `json:"not_after"`
+	ChainLength   int       `json:"chain_length"`
+	HasPrivateKey bool      `json:"has_private_key"`
+}
+
+// CertificateStatus описывает сертификат, срок действия которого истекает или истек.
+type CertificateStatus struct {
+	DataID      uuid.UUID        `json:"data_id"`
+	Name        string           `json:"name"`
+	FolderID    *uuid.UUID       `json:"folder_id,omitempty"`
+	Certificate *CertificateInfo `json:"certificate"`
+	DaysLeft    int              `json:"days_left"` // Отрицательное значение для истекших сертификатов
+	Expired     bool             `json:"expired"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// FieldKind определяет тип значения поля пользовательского типа данных.
+type FieldKind string
+
+const (
+	FieldText      FieldKind = "text"      // Однострочный текст
+	FieldMultiline FieldKind = "multiline" // Многострочный текст
+	FieldNumber    FieldKind = "number"    // Число
+	FieldBoolean   FieldKind = "boolean"   // Логическое значение
+	FieldDate      FieldKind = "date"      // Дата в формате YYYY-MM-DD или RFC 3339
+	FieldURL       FieldKind = "url"       // Абсолютный URL
+	FieldEmail     FieldKind = "email"     // Email адрес
+)
+
+// FieldKinds содержит все поддерживаемые типы полей.
+var FieldKinds = []FieldKind{FieldText, FieldMultiline, FieldNumber, FieldBoolean, FieldDate, FieldURL, FieldEmail}
+
+// CustomField описывает поле пользовательского типа данных.
+type CustomField struct {
+	Name     string    `json:"name"`
+	Kind     FieldKind `json:"kind"`
+	Secret   bool      `json:"secret,omitempty"`
+	Required bool      `json:"required,omitempty"`
+}
+
+// CustomType представляет пользовательский тип данных (шаблон элемента) со списком полей.
+type CustomType struct {
+	bun.BaseModel `bun:"table:custom_types"`
+
+	ID          uuid.UUID     `json:"id" bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	UserID      uuid.UUID     `json:"user_id" bun:"user_id,type:uuid,notnull,unique:custom_types_user_id_name"`
+	Name        string        `json:"name" bun:"name,notnull,unique:custom_types_user_id_name"`
+	Description string        `json:"description" bun:"description"`
+	Fields      []CustomField `json:"fields" bun:"fields,type:jsonb,notnull"`
+	CreatedAt   time.Time     `json:"created_at" bun:"created_at,default:now()"`
+	UpdatedAt   time.Time     `json:"updated_at" bun:"updated_at,default:now()"`
+}
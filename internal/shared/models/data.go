@@ -2,6 +2,8 @@
 package models
 
 import (
+	"encoding/json"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
@@ -16,34 +18,154 @@ const (
 	TextData      DataType = "text_data"      // Текстовые данные
 	BinaryData    DataType = "binary_data"    // Бинарные данные
 	BankCard      DataType = "bank_card"      // Банковские карты
+	SSHKey        DataType = "ssh_key"        // SSH ключи
+	TOTP          DataType = "totp"           // Секреты одноразовых паролей (2FA)
+	Certificate   DataType = "certificate"    // Сертификаты X.509 и закрытые ключи
+	EnvBundle     DataType = "env_bundle"     // Наборы переменных окружения
 )
 
+// IsBuiltin сообщает, является ли тип данных встроенным (в отличие от пользовательских типов).
+func (t DataType) IsBuiltin() bool {
+	switch t {
+	case LoginPassword, TextData, BinaryData, BankCard, SSHKey, TOTP, Certificate, EnvBundle:
+		return true
+	default:
+		return false
+	}
+}
+
+// DataFilter определяет условия выборки элементов данных пользователя.
+type DataFilter struct {
+	Type      DataType  // Тип данных; пустое значение означает все типы
+	Folder    string    // ID или путь папки; пустое значение означает все элементы
+	Recursive bool      // Включать элементы вложенных папок
+	Tags      TagFilter // Фильтр по тегам
+}
+
+// DataQuery определяет условия выборки элементов данных в хранилище.
+type DataQuery struct {
+	Type      DataType
+	FolderIDs []uuid.UUID
+	Tags      TagFilter
+
+	// Text отбирает элементы, имя которых совпадает с текстом по TextMatch или метаданные
+	// которых содержат слова текста (полнотекстовый поиск).
+	Text      string
+	TextMatch SearchMatch
+
+	// BlindTokens отбирает элементы, слепой индекс которых содержит все токены.
+	BlindTokens [][]byte
+
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+
+	Sort  DataSort    // Поле сортировки; пустое значение означает SortByUpdated
+	Order SortOrder   // Направление сортировки; пустое значение означает SortDesc
+	After *DataCursor // Выбирать элементы, следующие за курсором
+	Limit int         // Максимальное количество элементов; 0 означает без ограничения
+}
+
+// AnyVersion вместо ожидаемой версии означает изменение элемента данных без сравнения
+// с версией клиента (If-Match: *). Конкурентные изменения при этом все равно не теряются:
+// хранилище сравнивает версию с той, что прочитал сервер.
+const AnyVersion int64 = 0
+
+// ErrVersionConflict возвращается хранилищем, если версия элемента данных не совпадает
+// с ожидаемой: элемент изменен или удален после того, как клиент его получил.
+var ErrVersionConflict = errors.New("data item version conflict")
+
 // DataItem представляет элемент данных пользователя.
 type DataItem struct {
 	bun.BaseModel `bun:"table:data_items"`
 
-	ID            uuid.UUID `json:"id" bun:"id,pk,type:uuid,default:gen_random_uuid()"`
-	UserID        uuid.UUID `json:"user_id" bun:"user_id,type:uuid,notnull"`
-	Type          DataType  `json:"type" bun:"type,notnull"`
-	Name          string    `json:"name" bun:"name,notnull"`
-	Metadata      string    `json:"metadata" bun:"metadata"`
-	EncryptedData []byte    `json:"-" bun:"encrypted_data,notnull"`
-	EncryptionKey []byte    `json:"-" bun:"encryption_key,notnull"`
-	CreatedAt     time.Time `json:"created_at" bun:"created_at,default:now()"`
-	UpdatedAt     time.Time `json:"updated_at" bun:"updated_at,default:now()"`
-	Version       int64     `json:"version" bun:"version,default:1"`
+	ID            uuid.UUID  `json:"id" bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	UserID        uuid.UUID  `json:"user_id" bun:"user_id,type:uuid,notnull"`
+	FolderID      *uuid.UUID `json:"folder_id,omitempty" bun:"folder_id,type:uuid"`
+	Type          DataType   `json:"type" bun:"type,notnull"`
+	Name          string     `json:"name" bun:"name,notnull"`
+	Metadata      string     `json:"metadata" bun:"metadata"`
+	EncryptedData []byte     `json:"-" bun:"encrypted_data,notnull"`
+	EncryptionKey []byte     `json:"-" bun:"encryption_key,notnull"`
+	CreatedAt     time.Time  `json:"created_at" bun:"created_at,default:now()"`
+	UpdatedAt     time.Time  `json:"updated_at" bun:"updated_at,default:now()"`
+	Version       int64      `json:"version" bun:"version,default:1"`
+
+	// DeletedAt содержит время перемещения элемента в корзину; элементы в корзине
+	// исключаются из обычных выборок.
+	DeletedAt *time.Time `json:"deleted_at,omitempty" bun:"deleted_at,soft_delete,nullzero"`
+
+	// ExpiresAt и MaxReads задают самоуничтожение элемента: по истечении срока или
+	// после MaxReads чтений содержимого элемент удаляется безвозвратно.
+	ExpiresAt *time.Time `json:"expires_at,omitempty" bun:"expires_at,nullzero"`
+	MaxReads  *int       `json:"max_reads,omitempty" bun:"max_reads"`
+	ReadCount int        `json:"read_count" bun:"read_count,notnull,default:0"`
+
+	// PasswordChangedAt содержит время последней смены пароля элемента login_password;
+	// в отличие от UpdatedAt не меняется при изменении остальных полей.
+	PasswordChangedAt *time.Time `json:"password_changed_at,omitempty" bun:"password_changed_at,nullzero"`
+
+	// RotationDays задает интервал ротации пароля элемента в днях и имеет приоритет над интервалом папки.
+	RotationDays *int `json:"rotation_days,omitempty" bun:"rotation_days"`
+
+	// Certificate содержит несекретные сведения о сертификате элемента certificate. Хранится
+	// в открытом виде, чтобы отображаться в списках и отчете об истекающих сертификатах.
+	Certificate *CertificateInfo `json:"certificate,omitempty" bun:"certificate,type:jsonb,nullzero"`
+
+	// Data содержит расшифрованное содержимое, заполняется только при получении отдельного элемента.
+	Data json.RawMessage `json:"data,omitempty" bun:"-"`
+
+	// Tags содержит имена тегов элемента и заполняется сервисом.
+	Tags []string `json:"tags,omitempty" bun:"-"`
+
+	// Highlight содержит подсвеченные совпадения с поисковым запросом и заполняется только при поиске.
+	Highlight *SearchHighlight `json:"highlight,omitempty" bun:"-"`
 
 	User *User `json:"user,omitempty" bun:"rel:belongs-to,join:user_id=id"`
 }
 
-// DataVersion представляет версию элемента данных для синхронизации.
+// IsExpired сообщает, что срок действия элемента истек или лимит чтений исчерпан.
+func (d *DataItem) IsExpired(now time.Time) bool {
+	if d.ExpiresAt != nil && !d.ExpiresAt.After(now) {
+		return true
+	}
+	return d.MaxReads != nil && d.ReadCount >= *d.MaxReads
+}
+
+// DataExpiry задает условия самоуничтожения элемента данных. Пустые значения
+// означают отсутствие соответствующего ограничения.
+type DataExpiry struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	MaxReads  *int       `json:"max_reads,omitempty"`
+}
+
+// DataTombstone фиксирует окончательное удаление элемента данных, чтобы клиенты
+// узнали о нем при синхронизации.
+type DataTombstone struct {
+	bun.BaseModel `bun:"table:data_tombstones"`
+
+	DataID    uuid.UUID `bun:"data_id,pk,type:uuid"`
+	UserID    uuid.UUID `bun:"user_id,type:uuid,notnull"`
+	DeletedAt time.Time `bun:"deleted_at,notnull,default:now()"`
+}
+
+// DataVersion представляет версию элемента данных. Версия хранит зашифрованное
+// содержимое, имя и метаданные элемента на момент ее создания.
 type DataVersion struct {
 	bun.BaseModel `bun:"table:data_versions"`
 
-	ID        uuid.UUID `json:"id" bun:"id,pk,type:uuid,default:gen_random_uuid()"`
-	DataID    uuid.UUID `json:"data_id" bun:"data_id,type:uuid,notnull"`
-	Version   int64     `json:"version" bun:"version,notnull"`
-	CreatedAt time.Time `json:"created_at" bun:"created_at,default:now()"`
+	ID            uuid.UUID `json:"id" bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	DataID        uuid.UUID `json:"data_id" bun:"data_id,type:uuid,notnull"`
+	Version       int64     `json:"version" bun:"version,notnull"`
+	Name          string    `json:"name" bun:"name"`
+	Metadata      string    `json:"metadata" bun:"metadata"`
+	EncryptedData []byte    `json:"-" bun:"encrypted_data"`
+	RestoredFrom  *int64    `json:"restored_from,omitempty" bun:"restored_from"`
+	CreatedAt     time.Time `json:"created_at" bun:"created_at,default:now()"`
+
+	// Data содержит расшифрованное содержимое версии, заполняется только при получении отдельной версии.
+	Data json.RawMessage `json:"data,omitempty" bun:"-"`
 
 	DataItem *DataItem `json:"data_item,omitempty" bun:"rel:belongs-to,join:data_id=id"`
 }
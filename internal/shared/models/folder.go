@@ -0,0 +1,30 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// FolderPathSeparator разделяет имена папок в пути вида work/aws/prod.
+const FolderPathSeparator = "/"
+
+// Folder представляет папку для группировки элементов данных. Папки образуют дерево
+// через ParentID; корневые папки не имеют родителя.
+type Folder struct {
+	bun.BaseModel `bun:"table:folders"`
+
+	ID        uuid.UUID  `json:"id" bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	UserID    uuid.UUID  `json:"user_id" bun:"user_id,type:uuid,notnull"`
+	ParentID  *uuid.UUID `json:"parent_id,omitempty" bun:"parent_id,type:uuid"`
+	Name      string     `json:"name" bun:"name,notnull"`
+	CreatedAt time.Time  `json:"created_at" bun:"created_at,default:now()"`
+	UpdatedAt time.Time  `json:"updated_at" bun:"updated_at,default:now()"`
+
+	// RotationDays задает интервал ротации паролей элементов папки и вложенных папок в днях.
+	RotationDays *int `json:"rotation_days,omitempty" bun:"rotation_days"`
+
+	// Path содержит полный путь папки и вычисляется сервисом.
+	Path string `json:"path" bun:"-"`
+}
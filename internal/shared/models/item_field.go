@@ -0,0 +1,104 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// FieldSecret — скрытое значение (PIN, ответ на секретный вопрос), маскируется при выводе.
+const FieldSecret FieldKind = "secret"
+
+// ItemFieldKinds содержит типы, допустимые для дополнительных полей элемента.
+var ItemFieldKinds = []FieldKind{FieldText, FieldSecret, FieldURL, FieldBoolean, FieldDate}
+
+// ItemFieldsKey — ключ, под которым дополнительные поля хранятся в содержимом элемента.
+const ItemFieldsKey = "fields"
+
+// ItemField представляет дополнительное поле, добавленное пользователем к элементу данных
+// любого типа, например секретный вопрос для логина или PIN банковской карты.
+type ItemField struct {
+	Name  string          `json:"name"`
+	Kind  FieldKind       `json:"kind"`
+	Value json.RawMessage `json:"value"`
+}
+
+// IsSecret сообщает, что значение поля должно маскироваться при выводе.
+func (f ItemField) IsSecret() bool {
+	return f.Kind == FieldSecret
+}
+
+// ItemFieldSet содержит дополнительные поля, встраиваемые в полезную нагрузку встроенных типов.
+type ItemFieldSet struct {
+	Fields []ItemField `json:"fields,omitempty"`
+}
+
+// CustomFields возвращает дополнительные поля элемента.
+func (s *ItemFieldSet) CustomFields() []ItemField {
+	return s.Fields
+}
+
+// ParseItemFields извлекает дополнительные поля из JSON содержимого элемента любого типа.
+func ParseItemFields(data []byte) ([]ItemField, error) {
+	var set ItemFieldSet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("invalid custom fields: %w", err)
+	}
+	return set.Fields, nil
+}
+
+// SetItemField добавляет поле в содержимое элемента или заменяет поле с тем же именем.
+// Остальное содержимое элемента сохраняется без изменений.
+func SetItemField(data []byte, field ItemField) ([]byte, error) {
+	return updateItemFields(data, func(fields []ItemField) ([]ItemField, error) {
+		for idx := range fields {
+			if fields[idx].Name == field.Name {
+				fields[idx] = field
+				return fields, nil
+			}
+		}
+		return append(fields, field), nil
+	})
+}
+
+// RemoveItemField удаляет поле с указанным именем из содержимого элемента.
+func RemoveItemField(data []byte, name string) ([]byte, error) {
+	return updateItemFields(data, func(fields []ItemField) ([]ItemField, error) {
+		for idx := range fields {
+			if fields[idx].Name == name {
+				return append(fields[:idx], fields[idx+1:]...), nil
+			}
+		}
+		return nil, fmt.Errorf("field %s not found", name)
+	})
+}
+
+func updateItemFields(data []byte, update func([]ItemField) ([]ItemField, error)) ([]byte, error) {
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(data, &values); err != nil || values == nil {
+		return nil, fmt.Errorf("invalid payload: expected JSON object")
+	}
+
+	var fields []ItemField
+	if raw, ok := values[ItemFieldsKey]; ok {
+		if err := json.Unmarshal(raw, &fields); err != nil {
+			return nil, fmt.Errorf("invalid custom fields: %w", err)
+		}
+	}
+
+	fields, err := update(fields)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(fields) == 0 {
+		delete(values, ItemFieldsKey)
+	} else {
+		raw, err := json.Marshal(fields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode custom fields: %w", err)
+		}
+		values[ItemFieldsKey] = raw
+	}
+
+	return json.Marshal(values)
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DataSort определяет поле сортировки списка элементов данных.
+type DataSort string
+
+const (
+	SortByName    DataSort = "name"    // По имени
+	SortByCreated DataSort = "created" // По времени создания
+	SortByUpdated DataSort = "updated" // По времени последнего изменения
+)
+
+// SortOrder определяет направление сортировки.
+type SortOrder string
+
+const (
+	SortAsc  SortOrder = "asc"
+	SortDesc SortOrder = "desc"
+)
+
+// PageRequest определяет параметры постраничной выборки элементов данных.
+type PageRequest struct {
+	Limit  int       // Размер страницы; 0 означает размер по умолчанию
+	Cursor string    // Непрозрачный курсор, полученный с предыдущей страницей
+	Sort   DataSort  // Поле сортировки; пустое значение означает SortByUpdated
+	Order  SortOrder // Направление; по умолчанию asc для имени и desc для времени
+}
+
+// DataPage содержит страницу элементов данных и курсор следующей страницы.
+type DataPage struct {
+	Items      []*DataItem
+	NextCursor string // Пустое значение означает последнюю страницу
+}
+
+// DataCursor указывает позицию в упорядоченном списке: ключ сортировки и ID
+// последнего элемента предыдущей страницы.
+type DataCursor struct {
+	Sort  DataSort  `json:"s"`
+	Order SortOrder `json:"o"`
+	Name  string    `json:"n,omitempty"`
+	Time  time.Time `json:"t,omitempty"`
+	ID    uuid.UUID `json:"id"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// PasswordHistoryEntry представляет один из предыдущих паролей элемента login_password.
+// Пароль хранится зашифрованным ключом элемента данных.
+type PasswordHistoryEntry struct {
+	bun.BaseModel `bun:"table:password_history"`
+
+	ID                uuid.UUID  `json:"id" bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	DataID            uuid.UUID  `json:"data_id" bun:"data_id,type:uuid,notnull"`
+	EncryptedPassword []byte     `json:"-" bun:"encrypted_password,notnull"`
+	SetAt             *time.Time `json:"set_at,omitempty" bun:"set_at,nullzero"`
+	ChangedAt         time.Time  `json:"changed_at" bun:"changed_at,notnull,default:now()"`
+
+	// Password содержит расшифрованный пароль и заполняется сервисом.
+	Password string `json:"password" bun:"-"`
+}
@@ -0,0 +1,156 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Payload представляет расшифрованное содержимое элемента данных определенного типа.
+type Payload interface {
+	DataType() DataType
+	CustomFields() []ItemField
+}
+
+// LoginPasswordPayload содержит данные элемента типа login_password.
+type LoginPasswordPayload struct {
+	Login    string `json:"login"`
+	Password string `json:"password"`
+	URL      string `json:"url,omitempty"`
+	Notes    string `json:"notes,omitempty"`
+
+	ItemFieldSet
+}
+
+// TextPayload содержит данные элемента типа text_data.
+type TextPayload struct {
+	Text string `json:"text"`
+
+	ItemFieldSet
+}
+
+// BinaryPayload содержит данные элемента типа binary_data.
+type BinaryPayload struct {
+	Filename string `json:"filename"`
+	MimeType string `json:"mime_type,omitempty"`
+	Content  []byte `json:"content"` // Кодируется в JSON как base64
+
+	ItemFieldSet
+}
+
+// BankCardPayload содержит данные элемента типа bank_card.
+type BankCardPayload struct {
+	Number string `json:"number"`
+	Holder string `json:"holder,omitempty"`
+	Expiry string `json:"expiry"` // Срок действия в формате MM/YY
+	CVV    string `json:"cvv,omitempty"`
+	Brand  string `json:"brand,omitempty"` // Платежная система, определяется по номеру карты
+
+	ItemFieldSet
+}
+
+// SSHKeyPayload содержит данные элемента типа ssh_key. Тип ключа, открытый ключ
+// и отпечаток вычисляются по закрытому ключу.
+type SSHKeyPayload struct {
+	PrivateKey  string `json:"private_key"`
+	PublicKey   string `json:"public_key,omitempty"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+	KeyType     string `json:"key_type,omitempty"`
+	Comment     string `json:"comment,omitempty"`
+
+	ItemFieldSet
+}
+
+// TOTPPayload содержит данные элемента типа totp. Параметры можно передать
+// отдельными полями или URI формата otpauth://, из которого они извлекаются.
+type TOTPPayload struct {
+	URI       string `json:"uri,omitempty"`
+	Secret    string `json:"secret"`
+	Issuer    string `json:"issuer,omitempty"`
+	Account   string `json:"account,omitempty"`
+	Algorithm string `json:"algorithm,omitempty"`
+	Digits    int    `json:"digits,omitempty"`
+	Period    int    `json:"period,omitempty"` // Период смены кода в секундах
+
+	ItemFieldSet
+}
+
+// CertificatePayload содержит данные элемента типа certificate.
+type CertificatePayload struct {
+	Certificate string `json:"certificate"` // Цепочка PEM, начиная с сертификата конечного субъекта
+	PrivateKey  string `json:"private_key,omitempty"`
+
+	ItemFieldSet
+}
+
+// EnvBundlePayload содержит данные элемента типа env_bundle — набор переменных окружения сервиса.
+type EnvBundlePayload struct {
+	Variables map[string]string `json:"variables"`
+
+	ItemFieldSet
+}
+
+// DataType возвращает тип данных полезной нагрузки.
+func (p *LoginPasswordPayload) DataType() DataType { return LoginPassword }
+
+// DataType возвращает тип данных полезной нагрузки.
+func (p *TextPayload) DataType() DataType { return TextData }
+
+// DataType возвращает тип данных полезной нагрузки.
+func (p *BinaryPayload) DataType() DataType { return BinaryData }
+
+// DataType возвращает тип данных полезной нагрузки.
+func (p *BankCardPayload) DataType() DataType { return BankCard }
+
+// DataType возвращает тип данных полезной нагрузки.
+func (p *SSHKeyPayload) DataType() DataType { return SSHKey }
+
+// DataType возвращает тип данных полезной нагрузки.
+func (p *TOTPPayload) DataType() DataType { return TOTP }
+
+// DataType возвращает тип данных полезной нагрузки.
+func (p *CertificatePayload) DataType() DataType { return Certificate }
+
+// DataType возвращает тип данных полезной нагрузки.
+func (p *EnvBundlePayload) DataType() DataType { return EnvBundle }
+
+// NewPayload создает пустую полезную нагрузку для указанного типа данных.
+func NewPayload(dataType DataType) (Payload, error) {
+	switch dataType {
+	case LoginPassword:
+		return &LoginPasswordPayload{}, nil
+	case TextData:
+		return &TextPayload{}, nil
+	case BinaryData:
+		return &BinaryPayload{}, nil
+	case BankCard:
+		return &BankCardPayload{}, nil
+	case SSHKey:
+		return &SSHKeyPayload{}, nil
+	case TOTP:
+		return &TOTPPayload{}, nil
+	case Certificate:
+		return &CertificatePayload{}, nil
+	case EnvBundle:
+		return &EnvBundlePayload{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported data type: %s", dataType)
+	}
+}
+
+// ParsePayload разбирает JSON содержимое элемента данных в структуру, соответствующую типу.
+// Неизвестные поля считаются ошибкой.
+func ParsePayload(dataType DataType, data []byte) (Payload, error) {
+	payload, err := NewPayload(dataType)
+	if err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(payload); err != nil {
+		return nil, fmt.Errorf("invalid %s payload: %w", dataType, err)
+	}
+
+	return payload, nil
+}
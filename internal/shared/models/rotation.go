@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// RotationStatus описывает состояние ротации пароля элемента данных.
+type RotationStatus struct {
+	DataID            uuid.UUID  `json:"data_id"`
+	Name              string     `json:"name"`
+	FolderID          *uuid.UUID `json:"folder_id,omitempty"`
+	PasswordChangedAt time.Time  `json:"password_changed_at"`
+	RotationDays      int        `json:"rotation_days"`
+	DueAt             time.Time  `json:"due_at"`
+	OverdueDays       int        `json:"overdue_days"`
+
+	// Policy указывает, откуда взят интервал ротации: "item" или путь папки.
+	Policy string `json:"policy"`
+}
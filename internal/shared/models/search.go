@@ -0,0 +1,37 @@
+package models
+
+import "time"
+
+// SearchMatch определяет способ сопоставления запроса с именем элемента.
+type SearchMatch string
+
+const (
+	SearchSubstring SearchMatch = "substring" // Имя содержит запрос
+	SearchPrefix    SearchMatch = "prefix"    // Имя начинается с запроса
+)
+
+// HighlightStart и HighlightEnd обрамляют совпадения с запросом в подсвеченных фрагментах.
+const (
+	HighlightStart = "<mark>"
+	HighlightEnd   = "</mark>"
+)
+
+// DataSearch определяет условия поиска элементов данных. Поиск выполняется только по
+// открытым полям элемента — имени и метаданным; зашифрованное содержимое сервер не видит.
+type DataSearch struct {
+	Query  string      // Текст запроса: подстрока или префикс имени, слова метаданных
+	Match  SearchMatch // Способ сопоставления с именем; пустое значение означает SearchSubstring
+	Filter DataFilter  // Фильтр по типу, папке и тегам
+
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	UpdatedAfter  *time.Time
+	UpdatedBefore *time.Time
+}
+
+// SearchHighlight содержит фрагменты полей элемента, в которых совпадения с запросом
+// обрамлены HighlightStart и HighlightEnd. Пустое поле означает отсутствие совпадений в нем.
+type SearchHighlight struct {
+	Name     string `json:"name,omitempty"`
+	Metadata string `json:"metadata,omitempty"`
+}
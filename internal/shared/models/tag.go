@@ -0,0 +1,89 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// TagMatch определяет, как сочетаются теги фильтра.
+type TagMatch string
+
+const (
+	TagMatchAny TagMatch = "any" // Элемент содержит хотя бы один из тегов
+	TagMatchAll TagMatch = "all" // Элемент содержит все теги
+)
+
+// TagExcludePrefix отмечает исключаемый тег в фильтре, например !expiring.
+const TagExcludePrefix = "!"
+
+// Tag представляет тег пользователя. Имена тегов уникальны в пределах пользователя.
+type Tag struct {
+	bun.BaseModel `bun:"table:tags"`
+
+	ID        uuid.UUID `json:"id" bun:"id,pk,type:uuid,default:gen_random_uuid()"`
+	UserID    uuid.UUID `json:"user_id" bun:"user_id,type:uuid,notnull,unique:tags_user_id_name"`
+	Name      string    `json:"name" bun:"name,notnull,unique:tags_user_id_name"`
+	CreatedAt time.Time `json:"created_at" bun:"created_at,default:now()"`
+
+	// ItemCount содержит количество помеченных тегом элементов и заполняется при выборке списка тегов.
+	ItemCount int `json:"item_count" bun:"item_count,scanonly"`
+}
+
+// DataItemTag связывает элемент данных с тегом.
+type DataItemTag struct {
+	bun.BaseModel `bun:"table:data_item_tags"`
+
+	DataID uuid.UUID `bun:"data_id,pk,type:uuid"`
+	TagID  uuid.UUID `bun:"tag_id,pk,type:uuid"`
+}
+
+// TagFilter определяет фильтрацию элементов данных по тегам.
+type TagFilter struct {
+	Include []string // Теги, которые должен содержать элемент
+	Exclude []string // Теги, которых не должно быть у элемента
+	Match   TagMatch // Сочетание тегов Include; по умолчанию TagMatchAny
+}
+
+// IsEmpty сообщает, что фильтр не задает условий.
+func (f TagFilter) IsEmpty() bool {
+	return len(f.Include) == 0 && len(f.Exclude) == 0
+}
+
+// ParseTagFilter формирует фильтр по тегам из значений вида "work,!archive": теги
+// приводятся к нижнему регистру, теги с префиксом TagExcludePrefix исключаются.
+// Пустое значение match означает TagMatchAny.
+func ParseTagFilter(values []string, match TagMatch) (TagFilter, error) {
+	filter := TagFilter{Match: match}
+
+	switch filter.Match {
+	case "":
+		filter.Match = TagMatchAny
+	case TagMatchAny, TagMatchAll:
+	default:
+		return TagFilter{}, fmt.Errorf("tag_match must be %q or %q", TagMatchAny, TagMatchAll)
+	}
+
+	for _, value := range values {
+		for _, tag := range strings.Split(value, ",") {
+			tag = strings.ToLower(strings.TrimSpace(tag))
+			if excluded, ok := strings.CutPrefix(tag, TagExcludePrefix); ok {
+				if excluded != "" {
+					filter.Exclude = append(filter.Exclude, excluded)
+				}
+				continue
+			}
+			if tag != "" {
+				filter.Include = append(filter.Include, tag)
+			}
+		}
+	}
+
+	if filter.IsEmpty() {
+		return TagFilter{}, nil
+	}
+	return filter, nil
+}
@@ -0,0 +1,486 @@
+// Package codegen генерирует типизированный клиент API по спецификации OpenAPI.
+//
+// Сгенерированный код рассчитан на пакет, в котором определены Client с методами do и
+// doJSON, функция decodeResponse и тип RequestEditorFn (см. internal/client/api).
+package codegen
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/openapi"
+)
+
+// Header открывает сгенерированный файл.
+const Header = "// Code generated by openapi-gen. DO NOT EDIT."
+
+// initialisms содержит части имен, которые записываются заглавными буквами.
+var initialisms = map[string]bool{"id": true, "ip": true, "dns": true, "url": true, "json": true, "api": true}
+
+var pathVariable = regexp.MustCompile(`\{([^{}]+)\}`)
+
+type generator struct {
+	doc     *openapi.Document
+	buf     bytes.Buffer
+	imports map[string]string
+}
+
+// Generate возвращает исходный код клиента для пакета packageName: типы схем без x-go-type,
+// структуры необязательных параметров и методы Client для каждой операции.
+func Generate(doc *openapi.Document, packageName string) ([]byte, error) {
+	g := &generator{doc: doc, imports: make(map[string]string)}
+	g.use("context")
+	g.use("net/http")
+
+	if err := g.generateTypes(); err != nil {
+		return nil, err
+	}
+	for _, op := range doc.Operations() {
+		if err := g.generateOperation(op); err != nil {
+			return nil, fmt.Errorf("%s %s: %w", op.Method, op.Path, err)
+		}
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "%s\n\npackage %s\n\nimport (\n", Header, packageName)
+	paths := make([]string, 0, len(g.imports))
+	for importPath := range g.imports {
+		paths = append(paths, importPath)
+	}
+	sort.Slice(paths, func(i, j int) bool {
+		// Стандартная библиотека перед остальными пакетами.
+		if isStandard(paths[i]) != isStandard(paths[j]) {
+			return isStandard(paths[i])
+		}
+		return paths[i] < paths[j]
+	})
+	for idx, importPath := range paths {
+		if idx > 0 && isStandard(paths[idx-1]) && !isStandard(importPath) {
+			out.WriteString("\n")
+		}
+		if alias := g.imports[importPath]; alias != "" {
+			fmt.Fprintf(&out, "\t%s %q\n", alias, importPath)
+		} else {
+			fmt.Fprintf(&out, "\t%q\n", importPath)
+		}
+	}
+	out.WriteString(")\n")
+	out.Write(g.buf.Bytes())
+
+	source, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("failed to format generated code: %w", err)
+	}
+	return source, nil
+}
+
+func isStandard(importPath string) bool {
+	return !strings.Contains(strings.SplitN(importPath, "/", 2)[0], ".")
+}
+
+func (g *generator) printf(format string, args ...interface{}) {
+	fmt.Fprintf(&g.buf, format, args...)
+}
+
+func (g *generator) use(importPath string) {
+	g.imports[importPath] = ""
+}
+
+// generateTypes генерирует структуры для объектных схем компонентов без x-go-type.
+func (g *generator) generateTypes() error {
+	names := make([]string, 0, len(g.doc.Components.Schemas))
+	for name := range g.doc.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		schema := g.doc.Components.Schemas[name]
+		if schema.GoType != "" {
+			continue
+		}
+		if schema.Type != "object" || schema.Properties == nil {
+			return fmt.Errorf("schema %s: only object schemas are supported without x-go-type", name)
+		}
+
+		g.printf("\n")
+		g.comment(name, schema.Description)
+		g.printf("type %s struct {\n", name)
+		for _, property := range schema.Properties.Names {
+			propertySchema := schema.Properties.Schemas[property]
+			required := schema.IsRequired(property)
+
+			if description := g.doc.Resolve(propertySchema).Description; description != "" && propertySchema.Ref == "" {
+				g.printf("\t// %s\n", description)
+			}
+			tag := property
+			if !required {
+				tag += ",omitempty"
+			}
+			g.printf("\t%s %s `json:%q`\n", goName(property), g.fieldType(propertySchema, required), tag)
+		}
+		g.printf("}\n")
+	}
+
+	return nil
+}
+
+// comment выводит комментарий к объявлению name из описания схемы или операции.
+func (g *generator) comment(name, description string) {
+	if description == "" {
+		return
+	}
+	g.printf("// %s - %s\n", name, lowerFirst(description))
+}
+
+// goType возвращает тип Go для схемы без учета обязательности.
+func (g *generator) goType(schema *openapi.Schema) string {
+	if schema.Ref != "" {
+		name := openapi.SchemaName(schema.Ref)
+		if target := g.doc.Components.Schemas[name]; target.GoType != "" {
+			return g.externalType(target)
+		}
+		return name
+	}
+
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			g.use("time")
+			return "time.Time"
+		case "byte":
+			return "[]byte"
+		}
+		return "string"
+	case "integer":
+		if schema.Format == "int64" {
+			return "int64"
+		}
+		return "int"
+	case "number":
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		element := g.goType(schema.Items)
+		if g.isObject(schema.Items) {
+			element = "*" + element
+		}
+		return "[]" + element
+	case "object":
+		if schema.AdditionalProperties != nil && *schema.AdditionalProperties {
+			return "map[string]interface{}"
+		}
+	}
+
+	g.use("encoding/json")
+	return "json.RawMessage"
+}
+
+// externalType возвращает тип из x-go-type и добавляет импорт его пакета.
+func (g *generator) externalType(schema *openapi.Schema) string {
+	if schema.GoTypeImport != "" {
+		alias := strings.SplitN(schema.GoType, ".", 2)[0]
+		if path.Base(schema.GoTypeImport) == alias {
+			alias = ""
+		}
+		g.imports[schema.GoTypeImport] = alias
+	}
+	return schema.GoType
+}
+
+// isObject сообщает, что схема описывает объект с известными полями; такие значения
+// передаются по указателю.
+func (g *generator) isObject(schema *openapi.Schema) bool {
+	resolved := g.doc.Resolve(schema)
+	return resolved != nil && resolved.Type == "object" && (resolved.Properties != nil || resolved.GoType != "")
+}
+
+// fieldType возвращает тип поля структуры. Объекты, а также необязательные и допускающие
+// null скалярные значения, кроме строк, представляются указателями.
+func (g *generator) fieldType(schema *openapi.Schema, required bool) string {
+	goType := g.goType(schema)
+	if g.isObject(schema) {
+		return "*" + goType
+	}
+
+	resolved := g.doc.Resolve(schema)
+	switch {
+	case resolved.Nullable && !strings.HasPrefix(goType, "[]") && goType != "json.RawMessage":
+		return "*" + goType
+	case !required && isScalar(goType) && goType != "string":
+		return "*" + goType
+	}
+	return goType
+}
+
+func isScalar(goType string) bool {
+	switch goType {
+	case "string", "int", "int64", "float64", "bool", "time.Time":
+		return true
+	}
+	return false
+}
+
+// formatValue возвращает выражение, преобразующее значение expr параметра в строку.
+func (g *generator) formatValue(goType, expr string) string {
+	switch goType {
+	case "int":
+		g.use("strconv")
+		return "strconv.Itoa(" + expr + ")"
+	case "int64":
+		g.use("strconv")
+		return "strconv.FormatInt(" + expr + ", 10)"
+	case "float64":
+		g.use("strconv")
+		return "strconv.FormatFloat(" + expr + ", 'f', -1, 64)"
+	case "bool":
+		g.use("strconv")
+		return "strconv.FormatBool(" + expr + ")"
+	case "time.Time":
+		return expr + ".Format(time.RFC3339)"
+	}
+	return expr
+}
+
+// successResponse возвращает ответ операции с наименьшим успешным статусом.
+func successResponse(op openapi.OperationRef) (*openapi.Response, error) {
+	var statuses []string
+	for status := range op.Responses {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("no success response")
+	}
+	sort.Strings(statuses)
+	return op.Responses[statuses[0]], nil
+}
+
+func (g *generator) generateOperation(op openapi.OperationRef) error {
+	name := op.OperationID
+
+	var pathParams, optionalParams []*openapi.Parameter
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			pathParams = append(pathParams, param)
+		case "query", "header":
+			optionalParams = append(optionalParams, param)
+		default:
+			return fmt.Errorf("unsupported parameter location %s", param.In)
+		}
+	}
+
+	if len(optionalParams) > 0 {
+		g.printf("\n// %sParams содержит параметры запроса и заголовки операции %s.\n", name, name)
+		g.printf("type %sParams struct {\n", name)
+		for _, param := range optionalParams {
+			if param.Description != "" {
+				g.printf("\t// %s\n", param.Description)
+			}
+			g.printf("\t%s %s\n", goName(param.Name), g.fieldType(param.Schema, param.Required))
+		}
+		g.printf("}\n")
+	}
+
+	// Аргументы метода.
+	args := []string{"ctx context.Context"}
+	for _, param := range pathParams {
+		args = append(args, fmt.Sprintf("%s %s", param.Name, g.goType(param.Schema)))
+	}
+	if len(optionalParams) > 0 {
+		args = append(args, fmt.Sprintf("params *%sParams", name))
+	}
+
+	bodyArg := "nil"
+	binaryBody := false
+	if op.RequestBody != nil {
+		if media, ok := op.RequestBody.Content["application/json"]; ok {
+			args = append(args, "body "+g.goType(media.Schema))
+			bodyArg = "body"
+		} else if _, ok := op.RequestBody.Content["*/*"]; ok {
+			g.use("io")
+			args = append(args, "contentType string", "body io.Reader")
+			binaryBody = true
+		} else {
+			return fmt.Errorf("unsupported request body")
+		}
+	}
+	args = append(args, "editors ...RequestEditorFn")
+
+	// Результат метода.
+	response, err := successResponse(op)
+	if err != nil {
+		return err
+	}
+	var resultType, resultValue string
+	binaryResponse := false
+	if media, ok := response.Content["application/json"]; ok {
+		resultType = g.goType(media.Schema)
+		resultValue = "result"
+		if g.isObject(media.Schema) {
+			resultValue = "&result"
+		}
+	} else if _, ok := response.Content["*/*"]; ok {
+		binaryResponse = true
+	}
+
+	returns := "error"
+	switch {
+	case binaryResponse:
+		returns = "(*http.Response, error)"
+	case resultType != "" && resultValue == "&result":
+		returns = "(*" + resultType + ", error)"
+	case resultType != "":
+		returns = "(" + resultType + ", error)"
+	}
+
+	summary := lowerFirst(strings.TrimSuffix(op.Summary, "."))
+	g.printf("\n// %s выполняет операцию %s %s: %s.\n", name, op.Method, op.Path, summary)
+	if op.Description != "" {
+		g.printf("// %s\n", op.Description)
+	}
+	if op.Public() {
+		g.printf("// Операция не требует аутентификации.\n")
+	}
+	if binaryResponse {
+		g.printf("// Тело ответа читается и закрывается вызывающей стороной.\n")
+	}
+	g.printf("func (c *Client) %s(%s) %s {\n", name, strings.Join(args, ", "), returns)
+
+	g.printf("\tpath := %s\n", g.pathExpr(op.Path, pathParams))
+
+	queryArg, headerArg := "nil", "nil"
+	if len(optionalParams) > 0 {
+		for _, param := range optionalParams {
+			if param.In == "query" && queryArg == "nil" {
+				g.use("net/url")
+				g.printf("\tquery := url.Values{}\n")
+				queryArg = "query"
+			}
+			if param.In == "header" && headerArg == "nil" {
+				g.printf("\theader := http.Header{}\n")
+				headerArg = "header"
+			}
+		}
+		g.printf("\tif params != nil {\n")
+		for _, param := range optionalParams {
+			target := queryArg
+			method := "Set"
+			if param.In == "header" {
+				target = headerArg
+			}
+			field := "params." + goName(param.Name)
+			schema := g.doc.Resolve(param.Schema)
+			fieldType := g.fieldType(param.Schema, param.Required)
+
+			switch {
+			case schema.Type == "array":
+				method = "Add"
+				g.printf("\t\tfor _, value := range %s {\n", field)
+				g.printf("\t\t\t%s.%s(%q, %s)\n", target, method, param.Name, g.formatValue(g.goType(schema.Items), "value"))
+				g.printf("\t\t}\n")
+			case strings.HasPrefix(fieldType, "*"):
+				g.printf("\t\tif %s != nil {\n", field)
+				g.printf("\t\t\t%s.Set(%q, %s)\n", target, param.Name, g.formatValue(strings.TrimPrefix(fieldType, "*"), "*"+field))
+				g.printf("\t\t}\n")
+			case !param.Required && fieldType == "string":
+				g.printf("\t\tif %s != \"\" {\n", field)
+				g.printf("\t\t\t%s.Set(%q, %s)\n", target, param.Name, field)
+				g.printf("\t\t}\n")
+			default:
+				g.printf("\t\t%s.Set(%q, %s)\n", target, param.Name, g.formatValue(fieldType, field))
+			}
+		}
+		g.printf("\t}\n")
+	}
+
+	switch {
+	case binaryResponse:
+		g.printf("\treturn c.do(ctx, %q, path, %s, %s, nil, \"\", editors)\n}\n", op.Method, queryArg, headerArg)
+	case binaryBody:
+		g.printf("\tresp, err := c.do(ctx, %q, path, %s, %s, body, contentType, editors)\n", op.Method, queryArg, headerArg)
+		if resultType == "" {
+			g.printf("\tif err != nil {\n\t\treturn err\n\t}\n\treturn decodeResponse(resp, nil)\n}\n")
+			break
+		}
+		g.printf("\tif err != nil {\n\t\treturn nil, err\n\t}\n")
+		g.printf("\tvar result %s\n", resultType)
+		g.printf("\tif err := decodeResponse(resp, &result); err != nil {\n\t\treturn nil, err\n\t}\n")
+		g.printf("\treturn %s, nil\n}\n", resultValue)
+	case resultType == "":
+		g.printf("\treturn c.doJSON(ctx, %q, path, %s, %s, %s, nil, editors)\n}\n", op.Method, queryArg, headerArg, bodyArg)
+	default:
+		g.printf("\tvar result %s\n", resultType)
+		g.printf("\tif err := c.doJSON(ctx, %q, path, %s, %s, %s, &result, editors); err != nil {\n\t\treturn nil, err\n\t}\n", op.Method, queryArg, headerArg, bodyArg)
+		g.printf("\treturn %s, nil\n}\n", resultValue)
+	}
+	return nil
+}
+
+// pathExpr возвращает выражение, формирующее путь операции из шаблона и параметров пути.
+func (g *generator) pathExpr(template string, params []*openapi.Parameter) string {
+	types := make(map[string]string, len(params))
+	for _, param := range params {
+		types[param.Name] = g.goType(param.Schema)
+	}
+
+	var parts []string
+	last := 0
+	for _, match := range pathVariable.FindAllStringSubmatchIndex(template, -1) {
+		if match[0] > last {
+			parts = append(parts, fmt.Sprintf("%q", template[last:match[0]]))
+		}
+		name := template[match[2]:match[3]]
+		if goType := types[name]; goType == "string" {
+			g.use("net/url")
+			parts = append(parts, "url.PathEscape("+name+")")
+		} else {
+			parts = append(parts, g.formatValue(goType, name))
+		}
+		last = match[1]
+	}
+	if last < len(template) {
+		parts = append(parts, fmt.Sprintf("%q", template[last:]))
+	}
+
+	return strings.Join(parts, " + ")
+}
+
+// goName преобразует имя свойства или параметра в экспортируемое имя Go.
+func goName(name string) string {
+	var result strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if initialisms[strings.ToLower(part)] {
+			result.WriteString(strings.ToUpper(part))
+			continue
+		}
+		runes := []rune(part)
+		runes[0] = unicode.ToUpper(runes[0])
+		result.WriteString(string(runes))
+	}
+	return result.String()
+}
+
+func lowerFirst(s string) string {
+	runes := []rune(s)
+	if len(runes) > 1 && unicode.IsUpper(runes[1]) {
+		return s
+	}
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+		break
+	}
+	return string(runes)
+}
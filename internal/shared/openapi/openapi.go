@@ -0,0 +1,400 @@
+// Package openapi содержит спецификацию OpenAPI 3 для API сервера и средства работы с ней:
+// загрузку, поиск операций и проверку значений по схемам.
+package openapi
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+//go:embed openapi.json
+var specJSON []byte
+
+// Методы HTTP в порядке, в котором операции пути перечисляются в спецификации.
+var methods = []string{"GET", "PUT", "POST", "DELETE"}
+
+// Document описывает спецификацию OpenAPI в объеме, используемом сервером и генератором клиента.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Servers    []Server             `json:"servers"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+}
+
+// Server описывает адрес сервера; URL задает общий префикс путей API.
+type Server struct {
+	URL string `json:"url"`
+}
+
+// Components содержит переиспользуемые схемы, параметры и ответы.
+type Components struct {
+	Schemas    map[string]*Schema    `json:"schemas"`
+	Parameters map[string]*Parameter `json:"parameters"`
+	Responses  map[string]*Response  `json:"responses"`
+}
+
+// PathItem содержит операции одного пути.
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Put    *Operation `json:"put,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+}
+
+// Operation описывает операцию API.
+type Operation struct {
+	OperationID string                 `json:"operationId"`
+	Summary     string                 `json:"summary"`
+	Description string                 `json:"description"`
+	Tags        []string               `json:"tags"`
+	Security    *[]map[string][]string `json:"security"`
+	Parameters  []*Parameter           `json:"parameters"`
+	RequestBody *RequestBody           `json:"requestBody"`
+	Responses   map[string]*Response   `json:"responses"`
+}
+
+// Public сообщает, что операция доступна без аутентификации.
+func (o *Operation) Public() bool {
+	return o.Security != nil && len(*o.Security) == 0
+}
+
+// Parameter описывает параметр пути, запроса или заголовок.
+type Parameter struct {
+	Ref         string  `json:"$ref"`
+	Name        string  `json:"name"`
+	In          string  `json:"in"`
+	Description string  `json:"description"`
+	Required    bool    `json:"required"`
+	Schema      *Schema `json:"schema"`
+}
+
+// RequestBody описывает тело запроса.
+type RequestBody struct {
+	Description string                `json:"description"`
+	Required    bool                  `json:"required"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// Response описывает ответ операции.
+type Response struct {
+	Ref         string                `json:"$ref"`
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers"`
+	Content     map[string]*MediaType `json:"content"`
+}
+
+// Header описывает заголовок ответа.
+type Header struct {
+	Description string  `json:"description"`
+	Schema      *Schema `json:"schema"`
+}
+
+// MediaType описывает содержимое тела определенного типа.
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+// Schema описывает схему значения. GoType и GoTypeImport задают тип Go, которым
+// генератор клиента представляет схему вместо генерируемой структуры.
+type Schema struct {
+	Ref                  string      `json:"$ref"`
+	Type                 string      `json:"type"`
+	Format               string      `json:"format"`
+	Description          string      `json:"description"`
+	Enum                 []string    `json:"enum"`
+	Nullable             bool        `json:"nullable"`
+	Required             []string    `json:"required"`
+	Properties           *Properties `json:"properties"`
+	AdditionalProperties *bool       `json:"additionalProperties"`
+	Items                *Schema     `json:"items"`
+	Minimum              *float64    `json:"minimum"`
+	Maximum              *float64    `json:"maximum"`
+	MinLength            *int        `json:"minLength"`
+	MaxLength            *int        `json:"maxLength"`
+	MinItems             *int        `json:"minItems"`
+	MaxItems             *int        `json:"maxItems"`
+	Pattern              string      `json:"pattern"`
+	GoType               string      `json:"x-go-type"`
+	GoTypeImport         string      `json:"x-go-type-import"`
+
+	pattern *regexp.Regexp
+}
+
+// IsRequired сообщает, что свойство name обязательно.
+func (s *Schema) IsRequired(name string) bool {
+	for _, required := range s.Required {
+		if required == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Properties содержит свойства объекта в порядке их описания в спецификации.
+type Properties struct {
+	Names   []string
+	Schemas map[string]*Schema
+}
+
+// UnmarshalJSON разбирает свойства, сохраняя их порядок.
+func (p *Properties) UnmarshalJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+		return fmt.Errorf("properties must be an object")
+	}
+
+	p.Schemas = make(map[string]*Schema)
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		name := token.(string)
+
+		var schema Schema
+		if err := decoder.Decode(&schema); err != nil {
+			return fmt.Errorf("property %s: %w", name, err)
+		}
+		p.Names = append(p.Names, name)
+		p.Schemas[name] = &schema
+	}
+
+	return nil
+}
+
+// OperationRef связывает операцию с ее методом и путем.
+type OperationRef struct {
+	Method string
+	Path   string
+	*Operation
+}
+
+// JSON возвращает спецификацию в том виде, в котором она опубликована.
+func JSON() []byte {
+	return specJSON
+}
+
+// Load разбирает встроенную спецификацию, подставляет ссылки на параметры и ответы
+// и проверяет, что ссылки на схемы разрешаются, а идентификаторы операций уникальны.
+func Load() (*Document, error) {
+	return Parse(specJSON)
+}
+
+// Parse разбирает спецификацию из data так же, как Load.
+func Parse(data []byte) (*Document, error) {
+	var doc Document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse specification: %w", err)
+	}
+
+	if err := doc.resolve(); err != nil {
+		return nil, err
+	}
+
+	return &doc, nil
+}
+
+// BasePath возвращает префикс путей API из адреса первого сервера.
+func (d *Document) BasePath() string {
+	if len(d.Servers) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(d.Servers[0].URL, "/")
+}
+
+// Operation возвращает операцию по методу и шаблону пути без префикса API.
+func (d *Document) Operation(method, path string) *Operation {
+	item, ok := d.Paths[path]
+	if !ok {
+		return nil
+	}
+	return item.operation(method)
+}
+
+// Operations возвращает все операции, упорядоченные по пути и методу.
+func (d *Document) Operations() []OperationRef {
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var operations []OperationRef
+	for _, path := range paths {
+		for _, method := range methods {
+			if op := d.Paths[path].operation(method); op != nil {
+				operations = append(operations, OperationRef{Method: method, Path: path, Operation: op})
+			}
+		}
+	}
+	return operations
+}
+
+// SchemaName возвращает имя схемы компонента, на которую ссылается ref.
+func SchemaName(ref string) string {
+	return strings.TrimPrefix(ref, "#/components/schemas/")
+}
+
+// Resolve возвращает схему, на которую ссылается schema, или саму схему, если она не является ссылкой.
+func (d *Document) Resolve(schema *Schema) *Schema {
+	for schema != nil && schema.Ref != "" {
+		schema = d.Components.Schemas[SchemaName(schema.Ref)]
+	}
+	return schema
+}
+
+func (item *PathItem) operation(method string) *Operation {
+	switch strings.ToUpper(method) {
+	case "GET":
+		return item.Get
+	case "PUT":
+		return item.Put
+	case "POST":
+		return item.Post
+	case "DELETE":
+		return item.Delete
+	}
+	return nil
+}
+
+// resolve подставляет ссылки на компоненты параметров и ответов и проверяет схемы.
+func (d *Document) resolve() error {
+	for name, schema := range d.Components.Schemas {
+		if err := d.checkSchema(schema); err != nil {
+			return fmt.Errorf("schema %s: %w", name, err)
+		}
+	}
+	for name, param := range d.Components.Parameters {
+		if err := d.checkSchema(param.Schema); err != nil {
+			return fmt.Errorf("parameter %s: %w", name, err)
+		}
+	}
+
+	operationIDs := make(map[string]string)
+	for _, op := range d.Operations() {
+		where := op.Method + " " + op.Path
+		if op.OperationID == "" {
+			return fmt.Errorf("%s: operationId is required", where)
+		}
+		if other, ok := operationIDs[op.OperationID]; ok {
+			return fmt.Errorf("%s: operationId %s is already used by %s", where, op.OperationID, other)
+		}
+		operationIDs[op.OperationID] = where
+
+		for idx, param := range op.Parameters {
+			if param.Ref != "" {
+				resolved, ok := d.Components.Parameters[strings.TrimPrefix(param.Ref, "#/components/parameters/")]
+				if !ok {
+					return fmt.Errorf("%s: unresolved parameter %s", where, param.Ref)
+				}
+				op.Parameters[idx] = resolved
+				continue
+			}
+			if err := d.checkSchema(param.Schema); err != nil {
+				return fmt.Errorf("%s: parameter %s: %w", where, param.Name, err)
+			}
+		}
+
+		if err := checkPathParameters(op); err != nil {
+			return fmt.Errorf("%s: %w", where, err)
+		}
+
+		if op.RequestBody != nil {
+			for contentType, media := range op.RequestBody.Content {
+				if err := d.checkSchema(media.Schema); err != nil {
+					return fmt.Errorf("%s: request body %s: %w", where, contentType, err)
+				}
+			}
+		}
+
+		for status, response := range op.Responses {
+			if response.Ref != "" {
+				resolved, ok := d.Components.Responses[strings.TrimPrefix(response.Ref, "#/components/responses/")]
+				if !ok {
+					return fmt.Errorf("%s: unresolved response %s", where, response.Ref)
+				}
+				op.Responses[status] = resolved
+				response = resolved
+			}
+			for contentType, media := range response.Content {
+				if err := d.checkSchema(media.Schema); err != nil {
+					return fmt.Errorf("%s: response %s %s: %w", where, status, contentType, err)
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// pathVariable выделяет переменные из шаблона пути.
+var pathVariable = regexp.MustCompile(`\{([^{}]+)\}`)
+
+// checkPathParameters проверяет, что каждая переменная пути описана параметром, и наоборот.
+func checkPathParameters(op OperationRef) error {
+	variables := make(map[string]bool)
+	for _, match := range pathVariable.FindAllStringSubmatch(op.Path, -1) {
+		variables[match[1]] = true
+	}
+
+	for _, param := range op.Parameters {
+		if param.In != "path" {
+			continue
+		}
+		if !variables[param.Name] {
+			return fmt.Errorf("path parameter %s is not in the path", param.Name)
+		}
+		if !param.Required {
+			return fmt.Errorf("path parameter %s must be required", param.Name)
+		}
+		delete(variables, param.Name)
+	}
+
+	for name := range variables {
+		return fmt.Errorf("path variable %s is not described", name)
+	}
+
+	return nil
+}
+
+// checkSchema проверяет, что ссылки схемы разрешаются, и компилирует шаблоны строк.
+func (d *Document) checkSchema(schema *Schema) error {
+	if schema == nil {
+		return nil
+	}
+	if schema.Ref != "" {
+		if _, ok := d.Components.Schemas[SchemaName(schema.Ref)]; !ok {
+			return fmt.Errorf("unresolved schema %s", schema.Ref)
+		}
+		return nil
+	}
+
+	if schema.Pattern != "" && schema.pattern == nil {
+		pattern, err := regexp.Compile(schema.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern: %w", err)
+		}
+		schema.pattern = pattern
+	}
+
+	for _, name := range schema.Required {
+		if schema.Properties == nil || schema.Properties.Schemas[name] == nil {
+			return fmt.Errorf("required property %s is not described", name)
+		}
+	}
+
+	if schema.Properties != nil {
+		for _, name := range schema.Properties.Names {
+			if err := d.checkSchema(schema.Properties.Schemas[name]); err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+		}
+	}
+
+	return d.checkSchema(schema.Items)
+}
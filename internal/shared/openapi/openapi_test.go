@@ -0,0 +1,157 @@
+package openapi
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+func TestLoad(t *testing.T) {
+	doc, err := Load()
+	require.NoError(t, err)
+
+	assert.Equal(t, "/api/v1", doc.BasePath())
+
+	op := doc.Operation("PUT", "/data/{id}")
+	require.NotNil(t, op)
+	assert.Equal(t, "UpdateData", op.OperationID)
+	assert.False(t, op.Public())
+
+	// Ссылки на параметры и ответы подставляются при загрузке.
+	require.Len(t, op.Parameters, 2)
+	assert.Equal(t, "id", op.Parameters[0].Name)
+	assert.Equal(t, "If-Match", op.Parameters[1].Name)
+	assert.NotNil(t, op.Responses["default"].Content["application/json"])
+
+	assert.True(t, doc.Operation("POST", "/auth/login").Public())
+	assert.Nil(t, doc.Operation("PATCH", "/data/{id}"))
+
+	schema := doc.Components.Schemas["CreateDataRequest"]
+	assert.Equal(t, []string{"type", "name", "metadata", "data", "expires_at", "max_reads"}, schema.Properties.Names)
+}
+
+func TestParse_InvalidSpecification(t *testing.T) {
+	tests := []struct {
+		name  string
+		spec  string
+		error string
+	}{
+		{
+			name:  "unresolved schema",
+			spec:  `{"paths":{"/a":{"get":{"operationId":"A","responses":{"200":{"description":"","content":{"application/json":{"schema":{"$ref":"#/components/schemas/Missing"}}}}}}}}}`,
+			error: "unresolved schema",
+		},
+		{
+			name:  "duplicate operationId",
+			spec:  `{"paths":{"/a":{"get":{"operationId":"A"}},"/b":{"get":{"operationId":"A"}}}}`,
+			error: "operationId A is already used",
+		},
+		{
+			name:  "undescribed path variable",
+			spec:  `{"paths":{"/a/{id}":{"get":{"operationId":"A"}}}}`,
+			error: "path variable id is not described",
+		},
+		{
+			name:  "undescribed required property",
+			spec:  `{"components":{"schemas":{"A":{"type":"object","required":["id"],"properties":{}}}}}`,
+			error: "required property id is not described",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.spec))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.error)
+		})
+	}
+}
+
+func decodeValue(t *testing.T, data string) interface{} {
+	t.Helper()
+
+	var value interface{}
+	require.NoError(t, json.Unmarshal([]byte(data), &value))
+	return numbers(value)
+}
+
+// numbers заменяет числа float64 на json.Number, как при декодировании с UseNumber.
+func numbers(value interface{}) interface{} {
+	switch v := value.(type) {
+	case float64:
+		data, _ := json.Marshal(v)
+		return json.Number(data)
+	case []interface{}:
+		for idx := range v {
+			v[idx] = numbers(v[idx])
+		}
+	case map[string]interface{}:
+		for key := range v {
+			v[key] = numbers(v[key])
+		}
+	}
+	return value
+}
+
+func TestDocument_ValidateValue(t *testing.T) {
+	doc, err := Load()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name   string
+		schema string
+		value  string
+		valid  bool
+		field  string
+	}{
+		{name: "valid", schema: "SetExpiryRequest", value: `{"expires_at":"2030-01-01T00:00:00Z","max_reads":3}`, valid: true},
+		{name: "nullable", schema: "SetRotationRequest", value: `{"rotation_days":null}`, valid: true},
+		{name: "not nullable", schema: "MoveDataRequest", value: `{"folder":null}`, field: "folder"},
+		{name: "date-time", schema: "SetExpiryRequest", value: `{"expires_at":"tomorrow"}`, field: "expires_at"},
+		{name: "integer", schema: "SetExpiryRequest", value: `{"max_reads":1.5}`, field: "max_reads"},
+		{name: "uuid", schema: "BatchOperation", value: `{"op":"delete","id":"42"}`, field: "id"},
+		{name: "max items", schema: "DataBatch", value: `{"operations":[` + strings.TrimSuffix(strings.Repeat(`{"op":"create"},`, 101), ",") + `]}`, field: "operations"},
+		{name: "root type", schema: "DataBatch", value: `[]`},
+		{name: "any value", schema: "CreateDataRequest", value: `{"type":"text_data","name":"note","data":[1,"two",null]}`, valid: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := doc.ValidateValue(&Schema{Ref: "#/components/schemas/" + tt.schema}, decodeValue(t, tt.value), "")
+			if tt.valid {
+				assert.NoError(t, err)
+				return
+			}
+
+			var validationErr *validator.ValidationError
+			require.True(t, errors.As(err, &validationErr), "expected validation error, got %v", err)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
+
+func TestDocument_ValidateParameter(t *testing.T) {
+	doc, err := Load()
+	require.NoError(t, err)
+
+	recursive := doc.Components.Parameters["Recursive"]
+	assert.NoError(t, doc.ValidateParameter(recursive, []string{"true"}))
+	assert.NoError(t, doc.ValidateParameter(recursive, nil))
+	assert.Error(t, doc.ValidateParameter(recursive, []string{"yes"}))
+
+	limit := doc.Components.Parameters["Limit"]
+	assert.NoError(t, doc.ValidateParameter(limit, []string{"100"}))
+	assert.NoError(t, doc.ValidateParameter(limit, []string{""}))
+	assert.Error(t, doc.ValidateParameter(limit, []string{"101"}))
+	assert.Error(t, doc.ValidateParameter(limit, []string{"ten"}))
+
+	version := doc.Components.Parameters["Version"]
+	err = doc.ValidateParameter(version, nil)
+	require.Error(t, err)
+	assert.Equal(t, "version is required", err.Error())
+}
@@ -0,0 +1,256 @@
+package openapi
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/validator"
+)
+
+// ValidateValue проверяет значение, декодированное из JSON с UseNumber, по схеме.
+// field задает имя проверяемого поля в ошибке; пустое имя означает тело запроса целиком.
+func (d *Document) ValidateValue(schema *Schema, value interface{}, field string) error {
+	schema = d.Resolve(schema)
+	if schema == nil {
+		return nil
+	}
+
+	if value == nil {
+		if schema.Nullable || schema.Type == "" {
+			return nil
+		}
+		return fieldError(field, "must not be null")
+	}
+
+	switch schema.Type {
+	case "string":
+		str, ok := value.(string)
+		if !ok {
+			return fieldError(field, "must be a string")
+		}
+		return d.validateString(schema, str, field)
+	case "integer", "number":
+		number, ok := value.(json.Number)
+		if !ok {
+			return fieldError(field, "must be a number")
+		}
+		return validateNumber(schema, number, field)
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			return fieldError(field, "must be a boolean")
+		}
+	case "array":
+		items, ok := value.([]interface{})
+		if !ok {
+			return fieldError(field, "must be an array")
+		}
+		return d.validateArray(schema, items, field)
+	case "object":
+		object, ok := value.(map[string]interface{})
+		if !ok {
+			return fieldError(field, "must be an object")
+		}
+		return d.validateObject(schema, object, field)
+	}
+
+	return nil
+}
+
+// ValidateParameter проверяет значения параметра пути, запроса или заголовка. Значения
+// преобразуются по типу схемы; повторяющийся параметр запроса образует массив.
+func (d *Document) ValidateParameter(param *Parameter, values []string) error {
+	schema := d.Resolve(param.Schema)
+	if schema == nil {
+		return nil
+	}
+
+	if len(values) == 0 || (schema.Type != "array" && values[0] == "") {
+		if param.Required {
+			return fieldError(param.Name, "is required")
+		}
+		return nil
+	}
+
+	if schema.Type == "array" {
+		items := make([]interface{}, 0, len(values))
+		for idx, value := range values {
+			item, err := parseParameterValue(d.Resolve(schema.Items), value, fmt.Sprintf("%s[%d]", param.Name, idx))
+			if err != nil {
+				return err
+			}
+			items = append(items, item)
+		}
+		return d.ValidateValue(schema, items, param.Name)
+	}
+
+	value, err := parseParameterValue(schema, values[0], param.Name)
+	if err != nil {
+		return err
+	}
+	return d.ValidateValue(schema, value, param.Name)
+}
+
+// parseParameterValue преобразует строковое значение параметра к значению, которое
+// получилось бы при декодировании JSON.
+func parseParameterValue(schema *Schema, value, field string) (interface{}, error) {
+	if schema == nil {
+		return value, nil
+	}
+
+	switch schema.Type {
+	case "integer", "number":
+		return json.Number(value), nil
+	case "boolean":
+		switch value {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		}
+		return nil, fieldError(field, "must be true or false")
+	}
+	return value, nil
+}
+
+func (d *Document) validateString(schema *Schema, value, field string) error {
+	if len(schema.Enum) > 0 {
+		found := false
+		for _, allowed := range schema.Enum {
+			if value == allowed {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return fieldError(field, "must be one of: "+strings.Join(schema.Enum, ", "))
+		}
+	}
+
+	length := utf8.RuneCountInString(value)
+	if schema.MinLength != nil && length < *schema.MinLength {
+		if *schema.MinLength == 1 {
+			return fieldError(field, "is required")
+		}
+		return fieldError(field, fmt.Sprintf("must be at least %d characters long", *schema.MinLength))
+	}
+	if schema.MaxLength != nil && length > *schema.MaxLength {
+		return fieldError(field, fmt.Sprintf("must be at most %d characters long", *schema.MaxLength))
+	}
+
+	if schema.pattern != nil && !schema.pattern.MatchString(value) {
+		return fieldError(field, "has invalid format")
+	}
+
+	switch schema.Format {
+	case "uuid":
+		if _, err := uuid.Parse(value); err != nil {
+			return fieldError(field, "must be a valid UUID")
+		}
+	case "date-time":
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			return fieldError(field, "must be an RFC3339 date-time")
+		}
+	case "byte":
+		if _, err := base64.StdEncoding.DecodeString(value); err != nil {
+			return fieldError(field, "must be base64 encoded")
+		}
+	}
+
+	return nil
+}
+
+func validateNumber(schema *Schema, value json.Number, field string) error {
+	var number float64
+	if schema.Type == "integer" {
+		integer, err := value.Int64()
+		if err != nil {
+			return fieldError(field, "must be an integer")
+		}
+		number = float64(integer)
+	} else {
+		parsed, err := value.Float64()
+		if err != nil {
+			return fieldError(field, "must be a number")
+		}
+		number = parsed
+	}
+
+	if schema.Minimum != nil && number < *schema.Minimum {
+		return fieldError(field, fmt.Sprintf("must be at least %v", *schema.Minimum))
+	}
+	if schema.Maximum != nil && number > *schema.Maximum {
+		return fieldError(field, fmt.Sprintf("must be at most %v", *schema.Maximum))
+	}
+
+	return nil
+}
+
+func (d *Document) validateArray(schema *Schema, items []interface{}, field string) error {
+	if schema.MinItems != nil && len(items) < *schema.MinItems {
+		return fieldError(field, fmt.Sprintf("must contain at least %d items", *schema.MinItems))
+	}
+	if schema.MaxItems != nil && len(items) > *schema.MaxItems {
+		return fieldError(field, fmt.Sprintf("must contain at most %d items", *schema.MaxItems))
+	}
+
+	for idx, item := range items {
+		if err := d.ValidateValue(schema.Items, item, fmt.Sprintf("%s[%d]", field, idx)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *Document) validateObject(schema *Schema, object map[string]interface{}, field string) error {
+	for _, name := range schema.Required {
+		if _, ok := object[name]; !ok {
+			return fieldError(joinField(field, name), "is required")
+		}
+	}
+
+	if schema.Properties == nil {
+		return nil
+	}
+
+	for _, name := range schema.Properties.Names {
+		value, ok := object[name]
+		if !ok {
+			continue
+		}
+		if err := d.ValidateValue(schema.Properties.Schemas[name], value, joinField(field, name)); err != nil {
+			return err
+		}
+	}
+
+	if schema.AdditionalProperties != nil && !*schema.AdditionalProperties {
+		for name := range object {
+			if _, ok := schema.Properties.Schemas[name]; !ok {
+				return fieldError(joinField(field, name), "is not allowed")
+			}
+		}
+	}
+
+	return nil
+}
+
+// fieldError создает ошибку валидации поля; сообщение начинается с имени поля.
+func fieldError(field, message string) error {
+	subject := field
+	if subject == "" {
+		subject = "request body"
+	}
+	return &validator.ValidationError{Field: field, Message: subject + " " + message}
+}
+
+func joinField(parent, name string) string {
+	if parent == "" {
+		return name
+	}
+	return parent + "." + name
+}
@@ -0,0 +1,141 @@
+// Package sshkey предоставляет функции для генерации и разбора SSH ключей.
+package sshkey
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Algorithm определяет алгоритм генерируемого ключа.
+type Algorithm string
+
+const (
+	Ed25519 Algorithm = "ed25519"
+	RSA     Algorithm = "rsa"
+	ECDSA   Algorithm = "ecdsa"
+)
+
+const (
+	defaultRSABits   = 4096
+	minRSABits       = 2048
+	defaultECDSABits = 256
+)
+
+// KeyInfo содержит сведения о ключе, вычисленные по закрытому ключу.
+type KeyInfo struct {
+	Type        string // Тип ключа в формате SSH, например ssh-ed25519
+	PublicKey   string // Открытый ключ в формате authorized_keys
+	Fingerprint string // Отпечаток SHA256 открытого ключа
+}
+
+// Generate создает новый закрытый ключ указанного алгоритма и возвращает его
+// в формате OpenSSH PEM. Нулевое значение bits означает размер по умолчанию.
+func Generate(algorithm Algorithm, bits int, comment string) (string, error) {
+	var key crypto.PrivateKey
+	var err error
+
+	switch algorithm {
+	case Ed25519, "":
+		_, key, err = ed25519.GenerateKey(rand.Reader)
+	case RSA:
+		if bits == 0 {
+			bits = defaultRSABits
+		}
+		if bits < minRSABits {
+			return "", fmt.Errorf("rsa key must be at least %d bits", minRSABits)
+		}
+		key, err = rsa.GenerateKey(rand.Reader, bits)
+	case ECDSA:
+		curve, curveErr := ecdsaCurve(bits)
+		if curveErr != nil {
+			return "", curveErr
+		}
+		key, err = ecdsa.GenerateKey(curve, rand.Reader)
+	default:
+		return "", fmt.Errorf("unsupported key algorithm: %s", algorithm)
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to generate key: %w", err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(key, comment)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode key: %w", err)
+	}
+
+	return string(pem.EncodeToMemory(block)), nil
+}
+
+// ParsePrivateKey разбирает закрытый ключ в формате OpenSSH, PKCS#1, PKCS#8 или SEC 1.
+// Ключи, защищенные парольной фразой, не поддерживаются: хранилище само шифрует содержимое.
+func ParsePrivateKey(privateKey string) (crypto.PrivateKey, error) {
+	key, err := ssh.ParseRawPrivateKey([]byte(privateKey))
+	if err != nil {
+		var missing *ssh.PassphraseMissingError
+		if errors.As(err, &missing) {
+			return nil, fmt.Errorf("private key must not be protected by a passphrase")
+		}
+		return nil, fmt.Errorf("invalid private key: %w", err)
+	}
+
+	// Ключи ed25519 разбираются в указатель, а ssh.NewPublicKey ожидает значение.
+	if edKey, ok := key.(*ed25519.PrivateKey); ok {
+		return *edKey, nil
+	}
+
+	return key, nil
+}
+
+// Inspect вычисляет тип, открытый ключ и отпечаток по закрытому ключу.
+func Inspect(privateKey string) (*KeyInfo, error) {
+	key, err := ParsePrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("unsupported private key: %w", err)
+	}
+
+	publicKey := signer.PublicKey()
+	return &KeyInfo{
+		Type:        publicKey.Type(),
+		PublicKey:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+		Fingerprint: ssh.FingerprintSHA256(publicKey),
+	}, nil
+}
+
+// SamePublicKey сообщает, что открытый ключ в формате authorized_keys совпадает с ожидаемым.
+// Комментарий открытого ключа не учитывается.
+func SamePublicKey(authorizedKey, expected string) (bool, error) {
+	parsed, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+	if err != nil {
+		return false, fmt.Errorf("invalid public key: %w", err)
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(parsed))) == expected, nil
+}
+
+func ecdsaCurve(bits int) (elliptic.Curve, error) {
+	switch bits {
+	case 0, defaultECDSABits:
+		return elliptic.P256(), nil
+	case 384:
+		return elliptic.P384(), nil
+	case 521:
+		return elliptic.P521(), nil
+	default:
+		return nil, fmt.Errorf("ecdsa key must be 256, 384 or 521 bits")
+	}
+}
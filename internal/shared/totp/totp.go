@@ -0,0 +1,189 @@
+// Package totp реализует одноразовые пароли на основе времени (RFC 6238)
+// и разбор URI формата otpauth://.
+package totp
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"hash"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Algorithm определяет хеш-функцию HMAC.
+type Algorithm string
+
+const (
+	SHA1   Algorithm = "SHA1"
+	SHA256 Algorithm = "SHA256"
+	SHA512 Algorithm = "SHA512"
+)
+
+const (
+	DefaultDigits = 6
+	DefaultPeriod = 30
+
+	minDigits = 6
+	maxDigits = 8
+	minPeriod = 15
+	maxPeriod = 300
+)
+
+// Params содержит параметры генерации кодов.
+type Params struct {
+	Secret    string    // Секрет в кодировке base32 без заполнения
+	Issuer    string    // Сервис, выдавший секрет
+	Account   string    // Учетная запись в сервисе
+	Algorithm Algorithm // Хеш-функция HMAC
+	Digits    int       // Количество цифр кода
+	Period    int       // Период смены кода в секундах
+}
+
+// ParseURI разбирает URI вида otpauth://totp/Issuer:account?secret=...&issuer=...
+func ParseURI(uri string) (*Params, error) {
+	parsed, err := url.Parse(strings.TrimSpace(uri))
+	if err != nil || parsed.Scheme != "otpauth" {
+		return nil, fmt.Errorf("uri must use otpauth:// scheme")
+	}
+
+	if !strings.EqualFold(parsed.Host, "totp") {
+		return nil, fmt.Errorf("only totp uris are supported, got %s", parsed.Host)
+	}
+
+	query := parsed.Query()
+	params := &Params{
+		Secret:    query.Get("secret"),
+		Issuer:    query.Get("issuer"),
+		Algorithm: Algorithm(strings.ToUpper(query.Get("algorithm"))),
+	}
+
+	label := strings.TrimPrefix(parsed.Path, "/")
+	if issuer, account, found := strings.Cut(label, ":"); found {
+		params.Account = strings.TrimSpace(account)
+		if params.Issuer == "" {
+			params.Issuer = strings.TrimSpace(issuer)
+		}
+	} else {
+		params.Account = strings.TrimSpace(label)
+	}
+
+	if value := query.Get("digits"); value != "" {
+		if params.Digits, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("digits must be a number")
+		}
+	}
+
+	if value := query.Get("period"); value != "" {
+		if params.Period, err = strconv.Atoi(value); err != nil {
+			return nil, fmt.Errorf("period must be a number")
+		}
+	}
+
+	return params, nil
+}
+
+// Normalize проверяет параметры, подставляет значения по умолчанию и приводит
+// секрет к каноническому виду.
+func (p *Params) Normalize() error {
+	secret := strings.ToUpper(strings.NewReplacer(" ", "", "-", "").Replace(p.Secret))
+	secret = strings.TrimRight(secret, "=")
+	if secret == "" {
+		return fmt.Errorf("secret is required")
+	}
+	if _, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret); err != nil {
+		return fmt.Errorf("secret must be base32 encoded")
+	}
+	p.Secret = secret
+
+	if p.Algorithm == "" {
+		p.Algorithm = SHA1
+	}
+	if p.Algorithm != SHA1 && p.Algorithm != SHA256 && p.Algorithm != SHA512 {
+		return fmt.Errorf("unsupported algorithm: %s", p.Algorithm)
+	}
+
+	if p.Digits == 0 {
+		p.Digits = DefaultDigits
+	}
+	if p.Digits < minDigits || p.Digits > maxDigits {
+		return fmt.Errorf("digits must be between %d and %d", minDigits, maxDigits)
+	}
+
+	if p.Period == 0 {
+		p.Period = DefaultPeriod
+	}
+	if p.Period < minPeriod || p.Period > maxPeriod {
+		return fmt.Errorf("period must be between %d and %d seconds", minPeriod, maxPeriod)
+	}
+
+	return nil
+}
+
+// URI возвращает параметры в виде URI otpauth://, пригодного для импорта в приложения.
+func (p *Params) URI() string {
+	label := p.Account
+	if p.Issuer != "" {
+		label = p.Issuer + ":" + p.Account
+	}
+
+	query := url.Values{}
+	query.Set("secret", p.Secret)
+	if p.Issuer != "" {
+		query.Set("issuer", p.Issuer)
+	}
+	query.Set("algorithm", string(p.Algorithm))
+	query.Set("digits", strconv.Itoa(p.Digits))
+	query.Set("period", strconv.Itoa(p.Period))
+
+	uri := url.URL{Scheme: "otpauth", Host: "totp", Path: "/" + label, RawQuery: query.Encode()}
+	return uri.String()
+}
+
+// Code вычисляет код на момент now и возвращает время до его смены.
+// Параметры должны быть нормализованы.
+func (p *Params) Code(now time.Time) (string, time.Duration, error) {
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(p.Secret)
+	if err != nil {
+		return "", 0, fmt.Errorf("secret must be base32 encoded")
+	}
+
+	period := int64(p.Period)
+	counter := now.Unix() / period
+	remaining := time.Duration(period-now.Unix()%period) * time.Second
+
+	var message [8]byte
+	binary.BigEndian.PutUint64(message[:], uint64(counter))
+
+	mac := hmac.New(p.hash(), key)
+	mac.Write(message[:])
+	sum := mac.Sum(nil)
+
+	// Динамическое усечение (RFC 4226, раздел 5.3).
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < p.Digits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", p.Digits, value%modulo), remaining, nil
+}
+
+func (p *Params) hash() func() hash.Hash {
+	switch p.Algorithm {
+	case SHA256:
+		return sha256.New
+	case SHA512:
+		return sha512.New
+	default:
+		return sha1.New
+	}
+}
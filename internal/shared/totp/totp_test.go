@@ -0,0 +1,93 @@
+package totp
+
+import (
+	"encoding/base32"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Тестовые значения из приложения B RFC 6238.
+func TestParams_Code_RFC6238(t *testing.T) {
+	encode := func(secret string) string {
+		return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte(secret))
+	}
+	secrets := map[Algorithm]string{
+		SHA1:   encode("12345678901234567890"),
+		SHA256: encode("12345678901234567890123456789012"),
+		SHA512: encode("1234567890123456789012345678901234567890123456789012345678901234"),
+	}
+
+	tests := []struct {
+		unix      int64
+		algorithm Algorithm
+		code      string
+	}{
+		{59, SHA1, "94287082"},
+		{59, SHA256, "46119246"},
+		{59, SHA512, "90693936"},
+		{1111111109, SHA1, "07081804"},
+		{1234567890, SHA256, "91819424"},
+		{2000000000, SHA512, "38618901"},
+	}
+
+	for _, tt := range tests {
+		params := &Params{Secret: secrets[tt.algorithm], Algorithm: tt.algorithm, Digits: 8, Period: 30}
+
+		code, remaining, err := params.Code(time.Unix(tt.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, tt.code, code, "%s at %d", tt.algorithm, tt.unix)
+		assert.Equal(t, time.Duration(30-tt.unix%30)*time.Second, remaining)
+	}
+}
+
+func TestParseURI(t *testing.T) {
+	params, err := ParseURI("otpauth://totp/GitHub:octocat?secret=jbsw y3dp ehpk 3pxp&digits=8&period=60&algorithm=sha256")
+	require.NoError(t, err)
+	require.NoError(t, params.Normalize())
+
+	assert.Equal(t, "GitHub", params.Issuer)
+	assert.Equal(t, "octocat", params.Account)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", params.Secret)
+	assert.Equal(t, SHA256, params.Algorithm)
+	assert.Equal(t, 8, params.Digits)
+	assert.Equal(t, 60, params.Period)
+
+	roundTrip, err := ParseURI(params.URI())
+	require.NoError(t, err)
+	require.NoError(t, roundTrip.Normalize())
+	assert.Equal(t, params, roundTrip)
+}
+
+func TestParseURI_Defaults(t *testing.T) {
+	params, err := ParseURI("otpauth://totp/alice@example.com?secret=JBSWY3DPEHPK3PXP&issuer=Example")
+	require.NoError(t, err)
+	require.NoError(t, params.Normalize())
+
+	assert.Equal(t, "Example", params.Issuer)
+	assert.Equal(t, "alice@example.com", params.Account)
+	assert.Equal(t, SHA1, params.Algorithm)
+	assert.Equal(t, DefaultDigits, params.Digits)
+	assert.Equal(t, DefaultPeriod, params.Period)
+}
+
+func TestInvalidParams(t *testing.T) {
+	_, err := ParseURI("https://example.com")
+	assert.Error(t, err)
+
+	_, err = ParseURI("otpauth://hotp/Example?secret=JBSWY3DPEHPK3PXP&counter=1")
+	assert.Error(t, err)
+
+	invalid := []*Params{
+		{Secret: ""},
+		{Secret: "not base32!"},
+		{Secret: "JBSWY3DPEHPK3PXP", Algorithm: "MD5"},
+		{Secret: "JBSWY3DPEHPK3PXP", Digits: 4},
+		{Secret: "JBSWY3DPEHPK3PXP", Period: 5},
+	}
+	for _, params := range invalid {
+		assert.Error(t, params.Normalize(), "%+v", params)
+	}
+}
@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// ValidateDataBatch проверяет режим и состав пакета операций. Содержимое операций
+// проверяется при их выполнении так же, как в одиночных запросах.
+func (v *Validator) ValidateDataBatch(batch models.DataBatch) error {
+	switch batch.Mode {
+	case "", models.BatchAtomic, models.BatchIndependent:
+	default:
+		return &ValidationError{Field: "mode", Message: "mode must be atomic or independent"}
+	}
+
+	if len(batch.Operations) == 0 {
+		return &ValidationError{Field: "operations", Message: "at least one operation is required"}
+	}
+	if len(batch.Operations) > constants.MaxBatchOperations {
+		return &ValidationError{Field: "operations", Message: fmt.Sprintf("batch must not exceed %d operations", constants.MaxBatchOperations)}
+	}
+
+	for idx, op := range batch.Operations {
+		field := fmt.Sprintf("operations[%d]", idx)
+		switch op.Op {
+		case models.BatchCreate:
+			if op.Type == "" || op.Name == "" {
+				return &ValidationError{Field: field, Message: "type and name are required"}
+			}
+		case models.BatchUpdate, models.BatchDelete:
+			if op.ID == uuid.Nil {
+				return &ValidationError{Field: field + ".id", Message: "id is required"}
+			}
+			switch {
+			case op.Force && op.Version != nil:
+				return &ValidationError{Field: field + ".version", Message: "version and force are mutually exclusive"}
+			case op.Force:
+			case op.Version == nil:
+				return &ValidationError{Field: field + ".version", Message: "expected version is required"}
+			case *op.Version <= 0:
+				return &ValidationError{Field: field + ".version", Message: "version must be positive"}
+			}
+		default:
+			return &ValidationError{Field: field + ".op", Message: "op must be one of: create, update, delete"}
+		}
+	}
+
+	return nil
+}
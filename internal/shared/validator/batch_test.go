@@ -0,0 +1,53 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+func TestValidator_ValidateDataBatch(t *testing.T) {
+	v := NewValidator()
+	create := models.BatchOperation{Op: models.BatchCreate, Type: models.TextData, Name: "note"}
+	version := int64(2)
+	remove := models.BatchOperation{Op: models.BatchDelete, ID: uuid.New(), Version: &version}
+
+	assert.NoError(t, v.ValidateDataBatch(models.DataBatch{Operations: []models.BatchOperation{create, remove}}))
+	assert.NoError(t, v.ValidateDataBatch(models.DataBatch{Mode: models.BatchIndependent, Operations: []models.BatchOperation{create}}))
+	assert.NoError(t, v.ValidateDataBatch(models.DataBatch{Operations: []models.BatchOperation{{Op: models.BatchDelete, ID: uuid.New(), Force: true}}}))
+	zero := int64(0)
+
+	tooMany := make([]models.BatchOperation, constants.MaxBatchOperations+1)
+	for i := range tooMany {
+		tooMany[i] = create
+	}
+
+	invalid := []struct {
+		name  string
+		batch models.DataBatch
+		field string
+	}{
+		{"unknown mode", models.DataBatch{Mode: "eventual", Operations: []models.BatchOperation{create}}, "mode"},
+		{"empty", models.DataBatch{}, "operations"},
+		{"too many", models.DataBatch{Operations: tooMany}, "operations"},
+		{"unknown op", models.DataBatch{Operations: []models.BatchOperation{create, {Op: "upsert"}}}, "operations[1].op"},
+		{"create without name", models.DataBatch{Operations: []models.BatchOperation{{Op: models.BatchCreate, Type: models.TextData}}}, "operations[0]"},
+		{"update without id", models.DataBatch{Operations: []models.BatchOperation{{Op: models.BatchUpdate, Name: "note"}}}, "operations[0].id"},
+		{"delete without version", models.DataBatch{Operations: []models.BatchOperation{create, {Op: models.BatchDelete, ID: uuid.New()}}}, "operations[1].version"},
+		{"zero version", models.DataBatch{Operations: []models.BatchOperation{{Op: models.BatchUpdate, ID: uuid.New(), Name: "note", Version: &zero}}}, "operations[0].version"},
+		{"version with force", models.DataBatch{Operations: []models.BatchOperation{{Op: models.BatchDelete, ID: uuid.New(), Version: &version, Force: true}}}, "operations[0].version"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateDataBatch(tt.batch)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package validator
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/blindindex"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// ValidateBlindSearch проверяет условия поиска по слепым индексам: запрос задается либо
+// текстом, либо токенами, вычисленными клиентом. Поле и вид индекса проверяются только для
+// текстового запроса — в токены клиента они уже входят.
+func (v *Validator) ValidateBlindSearch(search models.BlindSearch) error {
+	if (search.Query == "") == (len(search.Tokens) == 0) {
+		return &ValidationError{Field: "q", Message: "either query or tokens are required"}
+	}
+
+	if search.Query != "" {
+		if search.Field != models.BlindIndexNameField &&
+			(!strings.HasPrefix(search.Field, models.BlindIndexFieldPrefix) || search.Field == models.BlindIndexFieldPrefix) {
+			return &ValidationError{Field: "field", Message: fmt.Sprintf("field must be %s or %s<name>", models.BlindIndexNameField, models.BlindIndexFieldPrefix)}
+		}
+
+		switch search.Mode {
+		case "", models.BlindIndexExact, models.BlindIndexToken, models.BlindIndexNGram:
+		default:
+			return &ValidationError{Field: "mode", Message: "mode must be one of: exact, token, ngram"}
+		}
+	}
+
+	if utf8.RuneCountInString(search.Query) > maxSearchQueryLength {
+		return &ValidationError{Field: "q", Message: fmt.Sprintf("query must not exceed %d characters", maxSearchQueryLength)}
+	}
+	if search.Query != "" && len(blindindex.Terms(search.Mode, search.Query)) == 0 {
+		return &ValidationError{Field: "q", Message: "query contains no searchable words"}
+	}
+
+	if len(search.Tokens) > constants.MaxBlindSearchTokens {
+		return &ValidationError{Field: "token", Message: fmt.Sprintf("at most %d tokens are allowed", constants.MaxBlindSearchTokens)}
+	}
+	for _, token := range search.Tokens {
+		if len(token) != blindindex.TokenSize {
+			return &ValidationError{Field: "token", Message: fmt.Sprintf("token must be %d bytes", blindindex.TokenSize)}
+		}
+	}
+
+	return nil
+}
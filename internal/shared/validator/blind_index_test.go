@@ -0,0 +1,57 @@
+package validator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/shared/blindindex"
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+func TestValidator_ValidateBlindSearch(t *testing.T) {
+	v := NewValidator()
+	token := bytes.Repeat([]byte{1}, blindindex.TokenSize)
+
+	valid := []models.BlindSearch{
+		{Field: models.BlindIndexNameField, Query: "prod db"},
+		{Field: "field:owner", Mode: models.BlindIndexToken, Query: "alice"},
+		{Tokens: [][]byte{token}},
+	}
+	for _, search := range valid {
+		assert.NoError(t, v.ValidateBlindSearch(search))
+	}
+
+	tooMany := make([][]byte, constants.MaxBlindSearchTokens+1)
+	for i := range tooMany {
+		tooMany[i] = token
+	}
+
+	invalid := []struct {
+		name   string
+		search models.BlindSearch
+		field  string
+	}{
+		{"unknown field", models.BlindSearch{Field: "metadata", Query: "prod"}, "field"},
+		{"empty field name", models.BlindSearch{Field: models.BlindIndexFieldPrefix, Query: "prod"}, "field"},
+		{"unknown mode", models.BlindSearch{Field: models.BlindIndexNameField, Mode: "regex", Query: "prod"}, "mode"},
+		{"no query", models.BlindSearch{Field: models.BlindIndexNameField}, "q"},
+		{"query and tokens", models.BlindSearch{Field: models.BlindIndexNameField, Query: "prod", Tokens: [][]byte{token}}, "q"},
+		{"no words", models.BlindSearch{Field: models.BlindIndexNameField, Mode: models.BlindIndexToken, Query: "--"}, "q"},
+		{"too long", models.BlindSearch{Field: models.BlindIndexNameField, Query: strings.Repeat("a", maxSearchQueryLength+1)}, "q"},
+		{"short token", models.BlindSearch{Tokens: [][]byte{{1, 2}}}, "token"},
+		{"too many tokens", models.BlindSearch{Tokens: tooMany}, "token"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateBlindSearch(tt.search)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package validator
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha1" //nolint:gosec // SHA-1 используется форматом базы Pwned Passwords, а не для защиты данных
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+const breachPrefixLength = 5
+
+// BreachChecker проверяет пароль по базе утёкших паролей.
+type BreachChecker interface {
+	// BreachCount возвращает количество вхождений пароля в утечки (0 — не найден).
+	BreachCount(ctx context.Context, password string) (int, error)
+}
+
+// FileBreachChecker проверяет пароли по локальной базе SHA-1 хешей в формате Pwned Passwords.
+// Поиск выполняется по схеме k-anonymity: по первым 5 символам хеша выбирается диапазон,
+// внутри которого сравниваются только суффиксы.
+type FileBreachChecker struct {
+	dir    string                    // Каталог с файлами диапазонов <PREFIX>.txt
+	ranges map[string]map[string]int // Диапазоны, загруженные из единого файла HASH:COUNT
+}
+
+// NewFileBreachChecker создает FileBreachChecker.
+// path может указывать на каталог с файлами диапазонов (строки SUFFIX:COUNT)
+// или на единый файл с полными хешами (строки HASH:COUNT).
+func NewFileBreachChecker(path string) (*FileBreachChecker, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached passwords dataset: %w", err)
+	}
+
+	if info.IsDir() {
+		return &FileBreachChecker{dir: path}, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open breached passwords dataset: %w", err)
+	}
+	defer file.Close()
+
+	entries, err := parseBreachEntries(file, 40)
+	if err != nil {
+		return nil, err
+	}
+
+	ranges := make(map[string]map[string]int)
+	for hash, count := range entries {
+		prefix, suffix := hash[:breachPrefixLength], hash[breachPrefixLength:]
+		if ranges[prefix] == nil {
+			ranges[prefix] = make(map[string]int)
+		}
+		ranges[prefix][suffix] = count
+	}
+
+	return &FileBreachChecker{ranges: ranges}, nil
+}
+
+// BreachCount возвращает количество вхождений пароля в базу утечек.
+func (c *FileBreachChecker) BreachCount(ctx context.Context, password string) (int, error) {
+	if err := ctx.Err(); err != nil {
+		return 0, err
+	}
+
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // см. комментарий к импорту
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	prefix, suffix := hash[:breachPrefixLength], hash[breachPrefixLength:]
+
+	suffixes, err := c.lookupRange(prefix)
+	if err != nil {
+		return 0, err
+	}
+
+	return suffixes[suffix], nil
+}
+
+// lookupRange возвращает суффиксы хешей для указанного префикса.
+func (c *FileBreachChecker) lookupRange(prefix string) (map[string]int, error) {
+	if c.ranges != nil {
+		return c.ranges[prefix], nil
+	}
+
+	for _, name := range []string{prefix + ".txt", prefix} {
+		file, err := os.Open(filepath.Join(c.dir, name))
+		if errors.Is(err, os.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to open breach range %s: %w", prefix, err)
+		}
+		defer file.Close()
+
+		return parseBreachEntries(file, 40-breachPrefixLength)
+	}
+
+	return nil, nil
+}
+
+// parseBreachEntries разбирает строки вида HASH:COUNT, где длина хеша равна hashLength.
+func parseBreachEntries(r io.Reader, hashLength int) (map[string]int, error) {
+	entries := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		hash, countStr, found := strings.Cut(line, ":")
+		if len(hash) != hashLength {
+			return nil, fmt.Errorf("invalid breach dataset line: %q", line)
+		}
+
+		count := 1
+		if found {
+			parsed, err := strconv.Atoi(strings.TrimSpace(countStr))
+			if err != nil {
+				return nil, fmt.Errorf("invalid breach count in line %q: %w", line, err)
+			}
+			count = parsed
+		}
+
+		entries[strings.ToUpper(hash)] = count
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read breach dataset: %w", err)
+	}
+
+	return entries, nil
+}
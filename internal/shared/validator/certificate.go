@@ -0,0 +1,16 @@
+package validator
+
+import "fmt"
+
+// maxCertificateExpiryDays ограничивает окно отчета об истекающих сертификатах десятью годами.
+const maxCertificateExpiryDays = 3650
+
+// ValidateCertificateExpiryDays проверяет окно отчета об истекающих сертификатах в днях.
+// Нулевое значение означает только уже истекшие сертификаты.
+func (v *Validator) ValidateCertificateExpiryDays(days int) error {
+	if days < 0 || days > maxCertificateExpiryDays {
+		return &ValidationError{Field: "days", Message: fmt.Sprintf("days must be between 0 and %d", maxCertificateExpiryDays)}
+	}
+
+	return nil
+}
@@ -0,0 +1,183 @@
+package validator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+const maxCustomFields = 50
+
+var (
+	customTypeNameRegex  = regexp.MustCompile(`^[a-z][a-z0-9_-]{1,49}$`)
+	customFieldNameRegex = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]{0,63}$`)
+	emailRegex           = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+)
+
+// ValidateCustomType проверяет определение пользовательского типа данных.
+func (v *Validator) ValidateCustomType(customType *models.CustomType) error {
+	if !customTypeNameRegex.MatchString(customType.Name) {
+		return &ValidationError{Field: "name", Message: "type name must start with a letter and contain only lowercase letters, digits, '-' and '_' (2-50 characters)"}
+	}
+
+	if models.DataType(customType.Name).IsBuiltin() {
+		return &ValidationError{Field: "name", Message: fmt.Sprintf("type name %s is reserved", customType.Name)}
+	}
+
+	if len(customType.Fields) == 0 {
+		return &ValidationError{Field: "fields", Message: "at least one field is required"}
+	}
+
+	if len(customType.Fields) > maxCustomFields {
+		return &ValidationError{Field: "fields", Message: fmt.Sprintf("type must have at most %d fields", maxCustomFields)}
+	}
+
+	seen := make(map[string]struct{}, len(customType.Fields))
+	for idx, field := range customType.Fields {
+		fieldPath := fmt.Sprintf("fields[%d]", idx)
+
+		if !customFieldNameRegex.MatchString(field.Name) {
+			return &ValidationError{Field: fieldPath + ".name", Message: "field name must be an identifier of at most 64 characters"}
+		}
+
+		if field.Name == models.ItemFieldsKey {
+			return &ValidationError{Field: fieldPath + ".name", Message: fmt.Sprintf("field name %s is reserved for custom fields", field.Name)}
+		}
+
+		if _, exists := seen[field.Name]; exists {
+			return &ValidationError{Field: fieldPath + ".name", Message: fmt.Sprintf("duplicate field %s", field.Name)}
+		}
+		seen[field.Name] = struct{}{}
+
+		if !isFieldKind(field.Kind) {
+			return &ValidationError{Field: fieldPath + ".kind", Message: fmt.Sprintf("unsupported field kind: %s", field.Kind)}
+		}
+	}
+
+	return nil
+}
+
+// ValidateCustomPayload проверяет содержимое элемента пользовательского типа
+// и возвращает нормализованный JSON.
+func (v *Validator) ValidateCustomPayload(customType *models.CustomType, data []byte) ([]byte, error) {
+	var values map[string]json.RawMessage
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if err := decoder.Decode(&values); err != nil || values == nil {
+		return nil, &ValidationError{Field: "data", Message: fmt.Sprintf("invalid %s payload: expected JSON object", customType.Name)}
+	}
+
+	var itemFields []models.ItemField
+	if raw, ok := values[models.ItemFieldsKey]; ok {
+		delete(values, models.ItemFieldsKey)
+		parsed, err := validateRawItemFields(raw)
+		if err != nil {
+			return nil, err
+		}
+		itemFields = parsed
+	}
+
+	fields := make(map[string]models.CustomField, len(customType.Fields))
+	for _, field := range customType.Fields {
+		fields[field.Name] = field
+	}
+
+	for name := range values {
+		if _, ok := fields[name]; !ok {
+			return nil, &ValidationError{Field: "data." + name, Message: fmt.Sprintf("unknown field %s for type %s", name, customType.Name)}
+		}
+	}
+
+	for _, field := range customType.Fields {
+		raw, present := values[field.Name]
+		if !present || string(raw) == "null" {
+			if field.Required {
+				return nil, &ValidationError{Field: "data." + field.Name, Message: fmt.Sprintf("%s is required", field.Name)}
+			}
+			delete(values, field.Name)
+			continue
+		}
+
+		if err := validateFieldValue(field, raw); err != nil {
+			return nil, &ValidationError{Field: "data." + field.Name, Message: err.Error()}
+		}
+	}
+
+	if len(itemFields) > 0 {
+		raw, err := json.Marshal(itemFields)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode custom fields: %w", err)
+		}
+		values[models.ItemFieldsKey] = raw
+	}
+
+	normalized, err := json.Marshal(values)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return normalized, nil
+}
+
+// validateFieldValue проверяет значение поля на соответствие его типу.
+func validateFieldValue(field models.CustomField, raw json.RawMessage) error {
+	switch field.Kind {
+	case models.FieldNumber:
+		var number float64
+		if err := json.Unmarshal(raw, &number); err != nil {
+			return fmt.Errorf("%s must be a number", field.Name)
+		}
+		return nil
+	case models.FieldBoolean:
+		var flag bool
+		if err := json.Unmarshal(raw, &flag); err != nil {
+			return fmt.Errorf("%s must be a boolean", field.Name)
+		}
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(raw, &value); err != nil {
+		return fmt.Errorf("%s must be a string", field.Name)
+	}
+
+	if value == "" {
+		if field.Required {
+			return fmt.Errorf("%s is required", field.Name)
+		}
+		return nil
+	}
+
+	switch field.Kind {
+	case models.FieldDate:
+		if _, err := time.Parse("2006-01-02", value); err != nil {
+			if _, err := time.Parse(time.RFC3339, value); err != nil {
+				return fmt.Errorf("%s must be a date in YYYY-MM-DD or RFC 3339 format", field.Name)
+			}
+		}
+	case models.FieldURL:
+		parsed, err := url.Parse(value)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return fmt.Errorf("%s must be an absolute URL", field.Name)
+		}
+	case models.FieldEmail:
+		if !emailRegex.MatchString(value) {
+			return fmt.Errorf("%s must be a valid email address", field.Name)
+		}
+	}
+
+	return nil
+}
+
+func isFieldKind(kind models.FieldKind) bool {
+	for _, known := range models.FieldKinds {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
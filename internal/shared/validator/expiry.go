@@ -0,0 +1,21 @@
+package validator
+
+import (
+	"time"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// ValidateExpiry проверяет условия самоуничтожения элемента данных: срок действия
+// должен быть в будущем, а лимит чтений положительным.
+func (v *Validator) ValidateExpiry(expiry models.DataExpiry, now time.Time) error {
+	if expiry.ExpiresAt != nil && !expiry.ExpiresAt.After(now) {
+		return &ValidationError{Field: "expires_at", Message: "expiration time must be in the future"}
+	}
+
+	if expiry.MaxReads != nil && *expiry.MaxReads < 1 {
+		return &ValidationError{Field: "max_reads", Message: "max reads must be at least 1"}
+	}
+
+	return nil
+}
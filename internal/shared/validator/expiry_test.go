@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+func TestValidator_ValidateExpiry(t *testing.T) {
+	v := NewValidator()
+	now := time.Now()
+	future := now.Add(time.Hour)
+	past := now.Add(-time.Minute)
+	one, zero := 1, 0
+
+	assert.NoError(t, v.ValidateExpiry(models.DataExpiry{}, now))
+	assert.NoError(t, v.ValidateExpiry(models.DataExpiry{ExpiresAt: &future, MaxReads: &one}, now))
+
+	invalid := []struct {
+		name   string
+		expiry models.DataExpiry
+		field  string
+	}{
+		{"expired", models.DataExpiry{ExpiresAt: &past}, "expires_at"},
+		{"now", models.DataExpiry{ExpiresAt: &now}, "expires_at"},
+		{"zero reads", models.DataExpiry{MaxReads: &zero}, "max_reads"},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateExpiry(tt.expiry, now)
+			var validationErr *ValidationError
+			if assert.ErrorAs(t, err, &validationErr) {
+				assert.Equal(t, tt.field, validationErr.Field)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+const maxItemFieldNameLength = 64
+
+// validateItemFields проверяет дополнительные поля элемента: имена должны быть
+// непустыми и уникальными, а значения соответствовать типу поля.
+func validateItemFields(fields []models.ItemField) error {
+	if len(fields) > maxCustomFields {
+		return &ValidationError{Field: "data.fields", Message: fmt.Sprintf("item must have at most %d custom fields", maxCustomFields)}
+	}
+
+	seen := make(map[string]struct{}, len(fields))
+	for idx, field := range fields {
+		fieldPath := fmt.Sprintf("data.fields[%d]", idx)
+
+		if strings.TrimSpace(field.Name) == "" || field.Name != strings.TrimSpace(field.Name) {
+			return &ValidationError{Field: fieldPath + ".name", Message: "field name must not be empty or have leading or trailing spaces"}
+		}
+
+		if utf8.RuneCountInString(field.Name) > maxItemFieldNameLength {
+			return &ValidationError{Field: fieldPath + ".name", Message: fmt.Sprintf("field name must be at most %d characters", maxItemFieldNameLength)}
+		}
+
+		if _, exists := seen[field.Name]; exists {
+			return &ValidationError{Field: fieldPath + ".name", Message: fmt.Sprintf("duplicate field %s", field.Name)}
+		}
+		seen[field.Name] = struct{}{}
+
+		if !isItemFieldKind(field.Kind) {
+			return &ValidationError{Field: fieldPath + ".kind", Message: fmt.Sprintf("unsupported field kind: %s", field.Kind)}
+		}
+
+		if len(field.Value) == 0 || string(field.Value) == "null" {
+			return &ValidationError{Field: fieldPath + ".value", Message: fmt.Sprintf("%s is required", field.Name)}
+		}
+
+		definition := models.CustomField{Name: field.Name, Kind: field.Kind, Required: true}
+		if err := validateFieldValue(definition, field.Value); err != nil {
+			return &ValidationError{Field: fieldPath + ".value", Message: err.Error()}
+		}
+	}
+
+	return nil
+}
+
+// validateRawItemFields разбирает и проверяет дополнительные поля элемента пользовательского типа.
+func validateRawItemFields(raw json.RawMessage) ([]models.ItemField, error) {
+	var fields []models.ItemField
+	if err := json.Unmarshal(raw, &fields); err != nil {
+		return nil, &ValidationError{Field: "data.fields", Message: "fields must be an array of {name, kind, value} objects"}
+	}
+
+	if err := validateItemFields(fields); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func isItemFieldKind(kind models.FieldKind) bool {
+	for _, known := range models.ItemFieldKinds {
+		if kind == known {
+			return true
+		}
+	}
+	return false
+}
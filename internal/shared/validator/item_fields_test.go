@@ -0,0 +1,84 @@
+package validator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+func TestValidator_ValidatePayload_CustomFields(t *testing.T) {
+	v := NewValidator()
+
+	t.Run("fields are kept on builtin type", func(t *testing.T) {
+		data := `{"login":"user","password":"secret","fields":[` +
+			`{"name":"Security question","kind":"text","value":"First pet"},` +
+			`{"name":"Answer","kind":"secret","value":"Rex"},` +
+			`{"name":"2FA enabled","kind":"boolean","value":true},` +
+			`{"name":"Recovery","kind":"url","value":"https://example.com/recover"},` +
+			`{"name":"Created","kind":"date","value":"2024-01-31"}]}`
+
+		normalized, err := v.ValidatePayload(models.LoginPassword, []byte(data))
+		require.NoError(t, err)
+
+		fields, err := models.ParseItemFields(normalized)
+		require.NoError(t, err)
+		require.Len(t, fields, 5)
+		assert.True(t, fields[1].IsSecret())
+		assert.JSONEq(t, `true`, string(fields[2].Value))
+	})
+
+	t.Run("fields are kept on custom type", func(t *testing.T) {
+		customType := &models.CustomType{
+			Name:   "api-key",
+			Fields: []models.CustomField{{Name: "key", Kind: models.FieldText, Required: true}},
+		}
+		data := `{"key":"abc","fields":[{"name":"PIN","kind":"secret","value":"1234"}]}`
+
+		normalized, err := v.ValidateCustomPayload(customType, []byte(data))
+		require.NoError(t, err)
+
+		var values map[string]json.RawMessage
+		require.NoError(t, json.Unmarshal(normalized, &values))
+		assert.JSONEq(t, `[{"name":"PIN","kind":"secret","value":"1234"}]`, string(values["fields"]))
+	})
+
+	invalid := []struct {
+		name   string
+		fields string
+		field  string
+	}{
+		{"empty name", `[{"name":" ","kind":"text","value":"x"}]`, "data.fields[0].name"},
+		{"long name", `[{"name":"` + strings.Repeat("a", 65) + `","kind":"text","value":"x"}]`, "data.fields[0].name"},
+		{"duplicate name", `[{"name":"PIN","kind":"secret","value":"1"},{"name":"PIN","kind":"text","value":"2"}]`, "data.fields[1].name"},
+		{"unsupported kind", `[{"name":"Port","kind":"number","value":5432}]`, "data.fields[0].kind"},
+		{"missing value", `[{"name":"PIN","kind":"secret"}]`, "data.fields[0].value"},
+		{"bad url", `[{"name":"Site","kind":"url","value":"example"}]`, "data.fields[0].value"},
+		{"bad boolean", `[{"name":"Active","kind":"boolean","value":"yes"}]`, "data.fields[0].value"},
+		{"bad date", `[{"name":"Issued","kind":"date","value":"31.01.2024"}]`, "data.fields[0].value"},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.ValidatePayload(models.TextData, []byte(`{"text":"note","fields":`+tt.fields+`}`))
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+
+	t.Run("reserved custom type field name", func(t *testing.T) {
+		err := v.ValidateCustomType(&models.CustomType{
+			Name:   "api-key",
+			Fields: []models.CustomField{{Name: "fields", Kind: models.FieldText}},
+		})
+
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "fields[0].name", validationErr.Field)
+	})
+}
@@ -0,0 +1,30 @@
+package validator
+
+import (
+	"fmt"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// ValidatePageRequest проверяет размер страницы и параметры сортировки постраничной выборки.
+// Курсор проверяется при разборе, так как его формат известен только сервису данных.
+func (v *Validator) ValidatePageRequest(page models.PageRequest) error {
+	if page.Limit < 0 || page.Limit > constants.MaxPageSize {
+		return &ValidationError{Field: "limit", Message: fmt.Sprintf("limit must be between 1 and %d", constants.MaxPageSize)}
+	}
+
+	switch page.Sort {
+	case "", models.SortByName, models.SortByCreated, models.SortByUpdated:
+	default:
+		return &ValidationError{Field: "sort", Message: "sort must be one of: name, created, updated"}
+	}
+
+	switch page.Order {
+	case "", models.SortAsc, models.SortDesc:
+	default:
+		return &ValidationError{Field: "order", Message: "order must be asc or desc"}
+	}
+
+	return nil
+}
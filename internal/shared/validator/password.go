@@ -0,0 +1,163 @@
+package validator
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/constants"
+)
+
+// PasswordPolicyConfig содержит параметры политики паролей.
+type PasswordPolicyConfig struct {
+	MinLength       int      // Минимальная длина пароля
+	MaxLength       int      // Максимальная длина пароля
+	MinScore        int      // Минимальная оценка стойкости (0..4)
+	BannedPasswords []string // Запрещённые пароли
+}
+
+// DefaultPasswordPolicyConfig возвращает параметры политики паролей по умолчанию.
+func DefaultPasswordPolicyConfig() PasswordPolicyConfig {
+	return PasswordPolicyConfig{
+		MinLength: constants.MinPasswordLength,
+		MaxLength: constants.MaxPasswordLength,
+		MinScore:  constants.DefaultPasswordMinScore,
+	}
+}
+
+// PasswordFeedback содержит структурированную обратную связь о пароле для клиента.
+type PasswordFeedback struct {
+	Score       int      `json:"score"`
+	MinScore    int      `json:"min_score"`
+	Warning     string   `json:"warning,omitempty"`
+	Suggestions []string `json:"suggestions,omitempty"`
+	Banned      bool     `json:"banned,omitempty"`
+	Breached    bool     `json:"breached,omitempty"`
+	BreachCount int      `json:"breach_count,omitempty"`
+}
+
+// PasswordPolicyError представляет нарушение политики паролей с обратной связью.
+type PasswordPolicyError struct {
+	ValidationError
+	Feedback PasswordFeedback `json:"feedback"`
+}
+
+// PasswordPolicy проверяет пароли на соответствие политике.
+type PasswordPolicy struct {
+	config     PasswordPolicyConfig
+	banned     map[string]struct{}
+	dictionary map[string]int
+	breach     BreachChecker
+}
+
+// NewPasswordPolicy создает новый экземпляр PasswordPolicy.
+// breach может быть nil, тогда проверка по утёкшим паролям не выполняется.
+func NewPasswordPolicy(config PasswordPolicyConfig, breach BreachChecker) *PasswordPolicy {
+	banned := make(map[string]struct{}, len(config.BannedPasswords))
+	dictionary := make(map[string]int, len(commonPasswords)+len(config.BannedPasswords))
+	for word, rank := range commonPasswords {
+		dictionary[word] = rank
+	}
+	for _, word := range config.BannedPasswords {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" {
+			continue
+		}
+		banned[word] = struct{}{}
+		dictionary[word] = 1
+	}
+
+	return &PasswordPolicy{
+		config:     config,
+		banned:     banned,
+		dictionary: dictionary,
+		breach:     breach,
+	}
+}
+
+// LoadBannedPasswords читает список запрещённых паролей из файла (по одному на строку).
+func LoadBannedPasswords(path string) ([]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open banned passwords file: %w", err)
+	}
+	defer file.Close()
+
+	var passwords []string
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		passwords = append(passwords, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read banned passwords file: %w", err)
+	}
+
+	return passwords, nil
+}
+
+// Validate проверяет пароль на соответствие политике.
+// userInputs содержит данные пользователя (например, email), которые не должны входить в пароль.
+func (p *PasswordPolicy) Validate(ctx context.Context, password string, userInputs ...string) error {
+	if password == "" {
+		return &ValidationError{Field: "password", Message: "password is required"}
+	}
+
+	length := len([]rune(password))
+	if length < p.config.MinLength {
+		return p.policyError(fmt.Sprintf("password must be at least %d characters long", p.config.MinLength), PasswordFeedback{
+			MinScore:    p.config.MinScore,
+			Warning:     "Short passwords are easy to guess",
+			Suggestions: []string{"Use a longer password"},
+		})
+	}
+	if p.config.MaxLength > 0 && length > p.config.MaxLength {
+		return &ValidationError{Field: "password", Message: fmt.Sprintf("password must be at most %d characters long", p.config.MaxLength)}
+	}
+
+	strength := estimateStrength(password, p.dictionary, userInputs)
+	feedback := PasswordFeedback{
+		Score:       strength.Score,
+		MinScore:    p.config.MinScore,
+		Warning:     strength.Warning,
+		Suggestions: strength.Suggestions,
+	}
+
+	if _, ok := p.banned[strings.ToLower(password)]; ok {
+		feedback.Banned = true
+		feedback.Warning = "This password is not allowed"
+		return p.policyError("password is on the banned list", feedback)
+	}
+
+	if strength.Score < p.config.MinScore {
+		return p.policyError("password is too weak", feedback)
+	}
+
+	if p.breach != nil {
+		count, err := p.breach.BreachCount(ctx, password)
+		if err != nil {
+			return fmt.Errorf("failed to check breached passwords: %w", err)
+		}
+		if count > 0 {
+			feedback.Breached = true
+			feedback.BreachCount = count
+			feedback.Warning = "This password has appeared in a data breach"
+			feedback.Suggestions = []string{"Choose a password you have not used anywhere else"}
+			return p.policyError("password has appeared in a data breach", feedback)
+		}
+	}
+
+	return nil
+}
+
+func (p *PasswordPolicy) policyError(message string, feedback PasswordFeedback) *PasswordPolicyError {
+	return &PasswordPolicyError{
+		ValidationError: ValidationError{Field: "password", Message: message},
+		Feedback:        feedback,
+	}
+}
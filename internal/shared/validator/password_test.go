@@ -0,0 +1,137 @@
+package validator
+
+import (
+	"context"
+	"crypto/sha1" //nolint:gosec // формат базы Pwned Passwords
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sha1Hex(password string) string {
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // формат базы Pwned Passwords
+	return strings.ToUpper(hex.EncodeToString(sum[:]))
+}
+
+func TestEstimateStrength(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		maxScore int
+		minScore int
+		warning  string
+	}{
+		{name: "common password", password: "password", maxScore: 0, warning: "This is a top-10 common password"},
+		{name: "l33t common password", password: "P@ssw0rd", maxScore: 1, warning: "This is similar to a commonly used password"},
+		{name: "keyboard pattern", password: "zxcvbnm,./", maxScore: 1},
+		{name: "sequence", password: "abcdefgh", maxScore: 1, warning: "Sequences like abc or 6543 are easy to guess"},
+		{name: "repeat", password: "aaaaaaaaaa", maxScore: 1, warning: `Repeats like "aaa" are easy to guess`},
+		{name: "email part", password: "johnsmith99", maxScore: 1, warning: "Passwords containing your email address are easy to guess"},
+		{name: "passphrase", password: "Quiet-Harbor-Lantern-42", minScore: 4},
+		{name: "random", password: "x7#Kp9!mQz", minScore: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := EstimateStrength(tt.password, "john.smith@example.com")
+
+			if tt.minScore > 0 {
+				assert.GreaterOrEqual(t, result.Score, tt.minScore)
+				assert.Empty(t, result.Warning)
+				return
+			}
+			assert.LessOrEqual(t, result.Score, tt.maxScore)
+			assert.NotEmpty(t, result.Suggestions)
+			if tt.warning != "" {
+				assert.Equal(t, tt.warning, result.Warning)
+			}
+		})
+	}
+}
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	ctx := context.Background()
+	config := DefaultPasswordPolicyConfig()
+	config.BannedPasswords = []string{"VaultFactory-Corp-2024"}
+	policy := NewPasswordPolicy(config, nil)
+
+	t.Run("strong password", func(t *testing.T) {
+		assert.NoError(t, policy.Validate(ctx, "Quiet-Harbor-Lantern-42", "user@example.com"))
+	})
+
+	t.Run("empty password", func(t *testing.T) {
+		var validationErr *ValidationError
+		assert.ErrorAs(t, policy.Validate(ctx, ""), &validationErr)
+	})
+
+	t.Run("too short", func(t *testing.T) {
+		var policyErr *PasswordPolicyError
+		require.ErrorAs(t, policy.Validate(ctx, "x7#Kp9"), &policyErr)
+		assert.Contains(t, policyErr.Error(), "at least 8 characters")
+	})
+
+	t.Run("too weak", func(t *testing.T) {
+		var policyErr *PasswordPolicyError
+		require.ErrorAs(t, policy.Validate(ctx, "password123"), &policyErr)
+		assert.Equal(t, "password is too weak", policyErr.Error())
+		assert.Equal(t, config.MinScore, policyErr.Feedback.MinScore)
+		assert.Less(t, policyErr.Feedback.Score, config.MinScore)
+	})
+
+	t.Run("banned password", func(t *testing.T) {
+		var policyErr *PasswordPolicyError
+		require.ErrorAs(t, policy.Validate(ctx, "vaultfactory-corp-2024"), &policyErr)
+		assert.True(t, policyErr.Feedback.Banned)
+	})
+}
+
+func TestPasswordPolicy_Breached(t *testing.T) {
+	ctx := context.Background()
+	breached := "Quiet-Harbor-Lantern-42"
+	hash := sha1Hex(breached)
+
+	t.Run("single dataset file", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "pwned.txt")
+		content := fmt.Sprintf("%s:42\n%s:3\n", hash, sha1Hex("another-password"))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+
+		checker, err := NewFileBreachChecker(path)
+		require.NoError(t, err)
+		policy := NewPasswordPolicy(DefaultPasswordPolicyConfig(), checker)
+
+		var policyErr *PasswordPolicyError
+		require.ErrorAs(t, policy.Validate(ctx, breached), &policyErr)
+		assert.True(t, policyErr.Feedback.Breached)
+		assert.Equal(t, 42, policyErr.Feedback.BreachCount)
+
+		assert.NoError(t, policy.Validate(ctx, "Violet-Summit-Orchard-77"))
+	})
+
+	t.Run("range directory", func(t *testing.T) {
+		dir := t.TempDir()
+		content := fmt.Sprintf("%s:7\n", hash[5:])
+		require.NoError(t, os.WriteFile(filepath.Join(dir, hash[:5]+".txt"), []byte(content), 0600))
+
+		checker, err := NewFileBreachChecker(dir)
+		require.NoError(t, err)
+
+		count, err := checker.BreachCount(ctx, breached)
+		require.NoError(t, err)
+		assert.Equal(t, 7, count)
+
+		count, err = checker.BreachCount(ctx, "Violet-Summit-Orchard-77")
+		require.NoError(t, err)
+		assert.Zero(t, count)
+	})
+
+	t.Run("missing dataset", func(t *testing.T) {
+		_, err := NewFileBreachChecker(filepath.Join(t.TempDir(), "missing.txt"))
+		assert.Error(t, err)
+	})
+}
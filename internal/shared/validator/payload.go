@@ -0,0 +1,370 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/certificate"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/sshkey"
+	"github.com/tempizhere/vaultfactory/internal/shared/totp"
+)
+
+// CardBrand определяет платежную систему банковской карты.
+type CardBrand string
+
+const (
+	CardBrandUnknown    CardBrand = "unknown"
+	CardBrandVisa       CardBrand = "visa"
+	CardBrandMastercard CardBrand = "mastercard"
+	CardBrandAmex       CardBrand = "amex"
+	CardBrandDiscover   CardBrand = "discover"
+	CardBrandDiners     CardBrand = "diners"
+	CardBrandJCB        CardBrand = "jcb"
+	CardBrandUnionPay   CardBrand = "unionpay"
+	CardBrandMir        CardBrand = "mir"
+	CardBrandMaestro    CardBrand = "maestro"
+)
+
+// maxEnvVariables ограничивает количество переменных в одном наборе env_bundle.
+const maxEnvVariables = 500
+
+var envNameRegex = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// cardBrandRanges содержит диапазоны префиксов номеров карт (IIN) в порядке проверки.
+var cardBrandRanges = []struct {
+	brand    CardBrand
+	from, to int
+	digits   int
+}{
+	{CardBrandMir, 2200, 2204, 4},
+	{CardBrandMastercard, 2221, 2720, 4},
+	{CardBrandMastercard, 51, 55, 2},
+	{CardBrandAmex, 34, 34, 2},
+	{CardBrandAmex, 37, 37, 2},
+	{CardBrandJCB, 3528, 3589, 4},
+	{CardBrandDiners, 300, 305, 3},
+	{CardBrandDiners, 36, 36, 2},
+	{CardBrandDiners, 38, 39, 2},
+	{CardBrandDiscover, 6011, 6011, 4},
+	{CardBrandDiscover, 644, 649, 3},
+	{CardBrandDiscover, 65, 65, 2},
+	{CardBrandUnionPay, 62, 62, 2},
+	{CardBrandMaestro, 50, 50, 2},
+	{CardBrandMaestro, 56, 58, 2},
+	{CardBrandMaestro, 6, 6, 1},
+	{CardBrandVisa, 4, 4, 1},
+}
+
+// ValidatePayload проверяет содержимое элемента данных на соответствие схеме типа
+// и возвращает нормализованный JSON.
+func (v *Validator) ValidatePayload(dataType models.DataType, data []byte) ([]byte, error) {
+	payload, err := models.ParsePayload(dataType, data)
+	if err != nil {
+		if _, typeErr := models.NewPayload(dataType); typeErr != nil {
+			return nil, &ValidationError{Field: "type", Message: typeErr.Error()}
+		}
+		return nil, &ValidationError{Field: "data", Message: err.Error()}
+	}
+
+	switch p := payload.(type) {
+	case *models.LoginPasswordPayload:
+		err = validateLoginPassword(p)
+	case *models.TextPayload:
+		err = validateText(p)
+	case *models.BinaryPayload:
+		err = validateBinary(p)
+	case *models.BankCardPayload:
+		err = validateBankCard(p, time.Now())
+	case *models.SSHKeyPayload:
+		err = validateSSHKey(p)
+	case *models.TOTPPayload:
+		err = validateTOTP(p)
+	case *models.CertificatePayload:
+		err = validateCertificate(p)
+	case *models.EnvBundlePayload:
+		err = validateEnvBundle(p)
+	}
+	if err == nil {
+		err = validateItemFields(payload.CustomFields())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	normalized, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode payload: %w", err)
+	}
+
+	return normalized, nil
+}
+
+func validateLoginPassword(p *models.LoginPasswordPayload) error {
+	if p.Password == "" {
+		return &ValidationError{Field: "data.password", Message: "password is required"}
+	}
+
+	if p.URL != "" {
+		parsed, err := url.Parse(p.URL)
+		if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+			return &ValidationError{Field: "data.url", Message: "url must be an absolute URL"}
+		}
+	}
+
+	return nil
+}
+
+func validateText(p *models.TextPayload) error {
+	if p.Text == "" {
+		return &ValidationError{Field: "data.text", Message: "text is required"}
+	}
+	return nil
+}
+
+func validateBinary(p *models.BinaryPayload) error {
+	if strings.TrimSpace(p.Filename) == "" {
+		return &ValidationError{Field: "data.filename", Message: "filename is required"}
+	}
+
+	if len(p.Content) == 0 {
+		return &ValidationError{Field: "data.content", Message: "content is required"}
+	}
+
+	if p.MimeType == "" {
+		p.MimeType = http.DetectContentType(p.Content)
+	}
+
+	return nil
+}
+
+func validateBankCard(p *models.BankCardPayload, now time.Time) error {
+	number := strings.Map(func(r rune) rune {
+		if r == ' ' || r == '-' {
+			return -1
+		}
+		return r
+	}, p.Number)
+
+	if len(number) < 12 || len(number) > 19 || !isDigits(number) {
+		return &ValidationError{Field: "data.number", Message: "card number must contain 12 to 19 digits"}
+	}
+
+	if !LuhnValid(number) {
+		return &ValidationError{Field: "data.number", Message: "card number failed checksum validation"}
+	}
+
+	expiry, err := parseCardExpiry(p.Expiry)
+	if err != nil {
+		return &ValidationError{Field: "data.expiry", Message: err.Error()}
+	}
+	if now.After(expiry) {
+		return &ValidationError{Field: "data.expiry", Message: "card has expired"}
+	}
+
+	brand := DetectCardBrand(number)
+	if p.CVV != "" {
+		cvvLength := 3
+		if brand == CardBrandAmex {
+			cvvLength = 4
+		}
+		if len(p.CVV) != cvvLength || !isDigits(p.CVV) {
+			return &ValidationError{Field: "data.cvv", Message: fmt.Sprintf("cvv must contain %d digits", cvvLength)}
+		}
+	}
+
+	p.Number = number
+	p.Expiry = expiry.Format("01/06")
+	p.Brand = string(brand)
+
+	return nil
+}
+
+func validateSSHKey(p *models.SSHKeyPayload) error {
+	if strings.TrimSpace(p.PrivateKey) == "" {
+		return &ValidationError{Field: "data.private_key", Message: "private key is required"}
+	}
+
+	info, err := sshkey.Inspect(p.PrivateKey)
+	if err != nil {
+		return &ValidationError{Field: "data.private_key", Message: err.Error()}
+	}
+
+	if p.PublicKey != "" {
+		same, err := sshkey.SamePublicKey(p.PublicKey, info.PublicKey)
+		if err != nil {
+			return &ValidationError{Field: "data.public_key", Message: err.Error()}
+		}
+		if !same {
+			return &ValidationError{Field: "data.public_key", Message: "public key does not match private key"}
+		}
+	}
+
+	p.PrivateKey = strings.TrimSpace(p.PrivateKey) + "\n"
+	p.PublicKey = info.PublicKey
+	p.Fingerprint = info.Fingerprint
+	p.KeyType = info.Type
+	p.Comment = strings.TrimSpace(p.Comment)
+
+	return nil
+}
+
+func validateTOTP(p *models.TOTPPayload) error {
+	params := &totp.Params{
+		Secret:    p.Secret,
+		Issuer:    p.Issuer,
+		Account:   p.Account,
+		Algorithm: totp.Algorithm(strings.ToUpper(p.Algorithm)),
+		Digits:    p.Digits,
+		Period:    p.Period,
+	}
+
+	if p.URI != "" {
+		parsed, err := totp.ParseURI(p.URI)
+		if err != nil {
+			return &ValidationError{Field: "data.uri", Message: err.Error()}
+		}
+		params = parsed
+	}
+
+	if err := params.Normalize(); err != nil {
+		field := "data"
+		if p.URI != "" {
+			field = "data.uri"
+		}
+		return &ValidationError{Field: field, Message: err.Error()}
+	}
+
+	p.Secret = params.Secret
+	p.Issuer = params.Issuer
+	p.Account = params.Account
+	p.Algorithm = string(params.Algorithm)
+	p.Digits = params.Digits
+	p.Period = params.Period
+	p.URI = params.URI()
+
+	return nil
+}
+
+func validateCertificate(p *models.CertificatePayload) error {
+	chain, err := certificate.ParseChain(p.Certificate)
+	if err != nil {
+		return &ValidationError{Field: "data.certificate", Message: err.Error()}
+	}
+
+	if strings.TrimSpace(p.PrivateKey) != "" {
+		key, err := certificate.ParsePrivateKey(p.PrivateKey)
+		if err != nil {
+			return &ValidationError{Field: "data.private_key", Message: err.Error()}
+		}
+		if !certificate.MatchesKey(chain[0], key) {
+			return &ValidationError{Field: "data.private_key", Message: "private key does not match certificate"}
+		}
+		p.PrivateKey = strings.TrimSpace(p.PrivateKey) + "\n"
+	} else {
+		p.PrivateKey = ""
+	}
+
+	p.Certificate = certificate.EncodeChain(chain)
+
+	return nil
+}
+
+func validateEnvBundle(p *models.EnvBundlePayload) error {
+	if len(p.Variables) == 0 {
+		return &ValidationError{Field: "data.variables", Message: "at least one variable is required"}
+	}
+
+	if len(p.Variables) > maxEnvVariables {
+		return &ValidationError{Field: "data.variables", Message: fmt.Sprintf("must not contain more than %d variables", maxEnvVariables)}
+	}
+
+	for name, value := range p.Variables {
+		if !envNameRegex.MatchString(name) {
+			return &ValidationError{Field: "data.variables." + name, Message: "name must consist of letters, digits and underscores and must not start with a digit"}
+		}
+		if strings.ContainsRune(value, 0) {
+			return &ValidationError{Field: "data.variables." + name, Message: "value must not contain NUL characters"}
+		}
+	}
+
+	return nil
+}
+
+// parseCardExpiry разбирает срок действия карты в формате MM/YY или MM/YYYY
+// и возвращает момент окончания срока действия.
+func parseCardExpiry(value string) (time.Time, error) {
+	monthStr, yearStr, found := strings.Cut(strings.TrimSpace(value), "/")
+	if !found {
+		return time.Time{}, fmt.Errorf("expiry must be in MM/YY format")
+	}
+
+	month, err := strconv.Atoi(monthStr)
+	if err != nil || month < 1 || month > 12 {
+		return time.Time{}, fmt.Errorf("expiry month must be between 01 and 12")
+	}
+
+	year, err := strconv.Atoi(yearStr)
+	if err != nil || (len(yearStr) != 2 && len(yearStr) != 4) {
+		return time.Time{}, fmt.Errorf("expiry must be in MM/YY format")
+	}
+	if len(yearStr) == 2 {
+		year += 2000
+	}
+
+	// Карта действительна до конца указанного месяца.
+	return time.Date(year, time.Month(month)+1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Nanosecond), nil
+}
+
+// LuhnValid проверяет номер по алгоритму Луна.
+func LuhnValid(number string) bool {
+	sum := 0
+	double := false
+	for i := len(number) - 1; i >= 0; i-- {
+		digit := int(number[i] - '0')
+		if digit < 0 || digit > 9 {
+			return false
+		}
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// DetectCardBrand определяет платежную систему по номеру карты.
+func DetectCardBrand(number string) CardBrand {
+	for _, r := range cardBrandRanges {
+		if len(number) < r.digits {
+			continue
+		}
+		prefix, err := strconv.Atoi(number[:r.digits])
+		if err != nil {
+			return CardBrandUnknown
+		}
+		if prefix >= r.from && prefix <= r.to {
+			return r.brand
+		}
+	}
+	return CardBrandUnknown
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
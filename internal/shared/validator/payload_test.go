@@ -0,0 +1,156 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+	"github.com/tempizhere/vaultfactory/internal/shared/sshkey"
+)
+
+func TestLuhnValid(t *testing.T) {
+	assert.True(t, LuhnValid("4111111111111111"))
+	assert.True(t, LuhnValid("378282246310005"))
+	assert.False(t, LuhnValid("4111111111111112"))
+	assert.False(t, LuhnValid("41111111111a1111"))
+}
+
+func TestDetectCardBrand(t *testing.T) {
+	tests := map[string]CardBrand{
+		"4111111111111111": CardBrandVisa,
+		"5555555555554444": CardBrandMastercard,
+		"2223003122003222": CardBrandMastercard,
+		"378282246310005":  CardBrandAmex,
+		"6011111111111117": CardBrandDiscover,
+		"3566002020360505": CardBrandJCB,
+		"30569309025904":   CardBrandDiners,
+		"6200000000000005": CardBrandUnionPay,
+		"2200000000000004": CardBrandMir,
+		"6759649826438453": CardBrandMaestro,
+		"9999999999999995": CardBrandUnknown,
+	}
+
+	for number, brand := range tests {
+		assert.Equal(t, brand, DetectCardBrand(number), number)
+	}
+}
+
+func TestValidator_ValidatePayload(t *testing.T) {
+	v := NewValidator()
+	nextYear := time.Now().Year()%100 + 1
+
+	t.Run("bank card is normalized", func(t *testing.T) {
+		data := fmt.Sprintf(`{"number":"4111 1111 1111 1111","holder":"IVAN IVANOV","expiry":"12/%02d","cvv":"123"}`, nextYear)
+
+		normalized, err := v.ValidatePayload(models.BankCard, []byte(data))
+		require.NoError(t, err)
+
+		var card models.BankCardPayload
+		require.NoError(t, json.Unmarshal(normalized, &card))
+		assert.Equal(t, "4111111111111111", card.Number)
+		assert.Equal(t, string(CardBrandVisa), card.Brand)
+	})
+
+	t.Run("binary mime type is detected", func(t *testing.T) {
+		normalized, err := v.ValidatePayload(models.BinaryData, []byte(`{"filename":"note.txt","content":"aGVsbG8="}`))
+		require.NoError(t, err)
+
+		var binary models.BinaryPayload
+		require.NoError(t, json.Unmarshal(normalized, &binary))
+		assert.Equal(t, "text/plain; charset=utf-8", binary.MimeType)
+		assert.Equal(t, []byte("hello"), binary.Content)
+	})
+
+	t.Run("ssh key details are derived", func(t *testing.T) {
+		privateKey, err := sshkey.Generate(sshkey.Ed25519, 0, "")
+		require.NoError(t, err)
+		info, err := sshkey.Inspect(privateKey)
+		require.NoError(t, err)
+
+		data, err := json.Marshal(map[string]string{"private_key": privateKey, "comment": "deploy@ci"})
+		require.NoError(t, err)
+
+		normalized, err := v.ValidatePayload(models.SSHKey, data)
+		require.NoError(t, err)
+
+		var key models.SSHKeyPayload
+		require.NoError(t, json.Unmarshal(normalized, &key))
+		assert.Equal(t, "ssh-ed25519", key.KeyType)
+		assert.Equal(t, info.PublicKey, key.PublicKey)
+		assert.Equal(t, info.Fingerprint, key.Fingerprint)
+		assert.Equal(t, "deploy@ci", key.Comment)
+
+		otherKey, err := sshkey.Generate(sshkey.Ed25519, 0, "")
+		require.NoError(t, err)
+		otherInfo, err := sshkey.Inspect(otherKey)
+		require.NoError(t, err)
+
+		data, err = json.Marshal(map[string]string{"private_key": privateKey, "public_key": otherInfo.PublicKey})
+		require.NoError(t, err)
+
+		_, err = v.ValidatePayload(models.SSHKey, data)
+		var validationErr *ValidationError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "data.public_key", validationErr.Field)
+	})
+
+	t.Run("totp uri is parsed", func(t *testing.T) {
+		data := `{"uri":"otpauth://totp/GitHub:octocat?secret=jbswy3dpehpk3pxp&digits=8"}`
+
+		normalized, err := v.ValidatePayload(models.TOTP, []byte(data))
+		require.NoError(t, err)
+
+		var payload models.TOTPPayload
+		require.NoError(t, json.Unmarshal(normalized, &payload))
+		assert.Equal(t, "JBSWY3DPEHPK3PXP", payload.Secret)
+		assert.Equal(t, "GitHub", payload.Issuer)
+		assert.Equal(t, "octocat", payload.Account)
+		assert.Equal(t, "SHA1", payload.Algorithm)
+		assert.Equal(t, 8, payload.Digits)
+		assert.Equal(t, 30, payload.Period)
+		assert.Contains(t, payload.URI, "secret=JBSWY3DPEHPK3PXP")
+	})
+
+	invalid := []struct {
+		name     string
+		dataType models.DataType
+		data     string
+		field    string
+	}{
+		{"unknown type", models.DataType("unknown"), `{}`, "type"},
+		{"unknown field", models.TextData, `{"text":"a","extra":1}`, "data"},
+		{"malformed json", models.TextData, `not json`, "data"},
+		{"missing password", models.LoginPassword, `{"login":"user"}`, "data.password"},
+		{"relative url", models.LoginPassword, `{"login":"user","password":"p","url":"example.com"}`, "data.url"},
+		{"empty text", models.TextData, `{"text":""}`, "data.text"},
+		{"missing filename", models.BinaryData, `{"content":"aGVsbG8="}`, "data.filename"},
+		{"bad luhn", models.BankCard, `{"number":"4111111111111112","expiry":"12/99"}`, "data.number"},
+		{"short number", models.BankCard, `{"number":"4111","expiry":"12/99"}`, "data.number"},
+		{"expired card", models.BankCard, `{"number":"4111111111111111","expiry":"01/20"}`, "data.expiry"},
+		{"bad expiry month", models.BankCard, `{"number":"4111111111111111","expiry":"13/99"}`, "data.expiry"},
+		{"amex cvv length", models.BankCard, `{"number":"378282246310005","expiry":"12/99","cvv":"123"}`, "data.cvv"},
+		{"missing private key", models.SSHKey, `{"comment":"deploy"}`, "data.private_key"},
+		{"bad private key", models.SSHKey, `{"private_key":"not a key"}`, "data.private_key"},
+		{"totp missing secret", models.TOTP, `{"issuer":"GitHub"}`, "data"},
+		{"totp bad digits", models.TOTP, `{"secret":"JBSWY3DPEHPK3PXP","digits":4}`, "data"},
+		{"totp hotp uri", models.TOTP, `{"uri":"otpauth://hotp/GitHub?secret=JBSWY3DPEHPK3PXP"}`, "data.uri"},
+		{"bad certificate", models.Certificate, `{"certificate":"not a certificate"}`, "data.certificate"},
+		{"totp bad algorithm", models.TOTP, `{"uri":"otpauth://totp/GitHub?secret=JBSWY3DPEHPK3PXP&algorithm=MD5"}`, "data.uri"},
+		{"empty env bundle", models.EnvBundle, `{"variables":{}}`, "data.variables"},
+		{"bad env variable name", models.EnvBundle, `{"variables":{"1PORT":"80"}}`, "data.variables.1PORT"},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.ValidatePayload(tt.dataType, []byte(tt.data))
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package validator
+
+import "fmt"
+
+// maxRotationDays ограничивает интервал ротации паролей десятью годами.
+const maxRotationDays = 3650
+
+// ValidateRotationDays проверяет интервал ротации пароля. nil означает отсутствие интервала.
+func (v *Validator) ValidateRotationDays(days *int) error {
+	if days == nil {
+		return nil
+	}
+
+	if *days < 1 || *days > maxRotationDays {
+		return &ValidationError{Field: "rotation_days", Message: fmt.Sprintf("rotation interval must be between 1 and %d days", maxRotationDays)}
+	}
+
+	return nil
+}
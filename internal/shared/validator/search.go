@@ -0,0 +1,40 @@
+package validator
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+// maxSearchQueryLength ограничивает длину поискового запроса в символах.
+const maxSearchQueryLength = 200
+
+// ValidateDataSearch проверяет условия поиска элементов данных. Поиск без запроса
+// допускается, если задан хотя бы один фильтр.
+func (v *Validator) ValidateDataSearch(search models.DataSearch) error {
+	if utf8.RuneCountInString(search.Query) > maxSearchQueryLength {
+		return &ValidationError{Field: "q", Message: fmt.Sprintf("query must not exceed %d characters", maxSearchQueryLength)}
+	}
+
+	switch search.Match {
+	case "", models.SearchSubstring, models.SearchPrefix:
+	default:
+		return &ValidationError{Field: "match", Message: "match must be substring or prefix"}
+	}
+
+	if search.Query == "" && search.Filter.Type == "" && search.Filter.Folder == "" && search.Filter.Tags.IsEmpty() &&
+		search.CreatedAfter == nil && search.CreatedBefore == nil && search.UpdatedAfter == nil && search.UpdatedBefore == nil {
+		return &ValidationError{Field: "q", Message: "query or at least one filter is required"}
+	}
+
+	if search.CreatedAfter != nil && search.CreatedBefore != nil && !search.CreatedAfter.Before(*search.CreatedBefore) {
+		return &ValidationError{Field: "created_before", Message: "created_before must be later than created_after"}
+	}
+
+	if search.UpdatedAfter != nil && search.UpdatedBefore != nil && !search.UpdatedAfter.Before(*search.UpdatedBefore) {
+		return &ValidationError{Field: "updated_before", Message: "updated_before must be later than updated_after"}
+	}
+
+	return nil
+}
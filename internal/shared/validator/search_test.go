@@ -0,0 +1,47 @@
+package validator
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tempizhere/vaultfactory/internal/shared/models"
+)
+
+func TestValidator_ValidateDataSearch(t *testing.T) {
+	v := NewValidator()
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+
+	valid := []models.DataSearch{
+		{Query: "prod"},
+		{Query: "prod", Match: models.SearchPrefix},
+		{Filter: models.DataFilter{Type: models.TOTP}},
+		{UpdatedAfter: &earlier, UpdatedBefore: &now},
+	}
+	for _, search := range valid {
+		assert.NoError(t, v.ValidateDataSearch(search))
+	}
+
+	invalid := []struct {
+		name   string
+		search models.DataSearch
+		field  string
+	}{
+		{"empty", models.DataSearch{}, "q"},
+		{"too long", models.DataSearch{Query: strings.Repeat("a", maxSearchQueryLength+1)}, "q"},
+		{"unknown match", models.DataSearch{Query: "prod", Match: "regex"}, "match"},
+		{"reversed range", models.DataSearch{CreatedAfter: &now, CreatedBefore: &earlier}, "created_before"},
+	}
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			err := v.ValidateDataSearch(tt.search)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, tt.field, validationErr.Field)
+		})
+	}
+}
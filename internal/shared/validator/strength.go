@@ -0,0 +1,489 @@
+package validator
+
+import (
+	_ "embed"
+	"math"
+	"strings"
+	"unicode"
+)
+
+//go:embed common_passwords.txt
+var commonPasswordsList string
+
+// commonPasswords содержит ранги распространённых паролей (1 — самый популярный).
+var commonPasswords = rankedDictionary(strings.Split(commonPasswordsList, "\n"))
+
+// bruteforceBits — оценка энтропии одного символа, не покрытого ни одним шаблоном.
+var bruteforceBits = math.Log2(10)
+
+// keyboardRows описывает раскладку QWERTY для поиска клавиатурных шаблонов.
+var keyboardRows = []string{
+	"`1234567890-=",
+	"qwertyuiop[]\\",
+	"asdfghjkl;'",
+	"zxcvbnm,./",
+}
+
+// leetSubstitutions содержит распространённые замены символов в стиле l33t.
+var leetSubstitutions = map[rune]rune{
+	'4': 'a', '@': 'a', '8': 'b', '(': 'c', '3': 'e', '6': 'g', '1': 'i',
+	'!': 'i', '|': 'i', '0': 'o', '$': 's', '5': 's', '7': 't', '+': 't', '2': 'z',
+}
+
+// StrengthResult содержит оценку стойкости пароля в стиле zxcvbn.
+type StrengthResult struct {
+	Score       int      `json:"score"`                 // Оценка от 0 (очень слабый) до 4 (очень стойкий)
+	Entropy     float64  `json:"entropy"`               // Оценка энтропии в битах
+	Warning     string   `json:"warning,omitempty"`     // Основная проблема пароля
+	Suggestions []string `json:"suggestions,omitempty"` // Рекомендации по улучшению
+}
+
+type matchKind int
+
+const (
+	matchDictionary matchKind = iota
+	matchUserInput
+	matchSequence
+	matchRepeat
+	matchKeyboard
+	matchYear
+)
+
+// strengthMatch описывает найденный в пароле шаблон на отрезке [i, j).
+type strengthMatch struct {
+	i, j     int
+	kind     matchKind
+	entropy  float64
+	rank     int
+	leet     bool
+	reversed bool
+	upper    bool
+}
+
+// EstimateStrength оценивает стойкость пароля по словарю распространённых паролей.
+// userInputs содержит данные пользователя (например, email), которые не должны входить в пароль.
+func EstimateStrength(password string, userInputs ...string) StrengthResult {
+	return estimateStrength(password, commonPasswords, userInputs)
+}
+
+// estimateStrength находит шаблоны в пароле и выбирает их комбинацию с минимальной энтропией.
+func estimateStrength(password string, dictionary map[string]int, userInputs []string) StrengthResult {
+	runes := []rune(password)
+	if len(runes) > 100 {
+		runes = runes[:100]
+	}
+	n := len(runes)
+	if n == 0 {
+		return StrengthResult{Score: 0, Warning: "Password is empty"}
+	}
+
+	var matches []strengthMatch
+	matches = append(matches, dictionaryMatches(runes, dictionary, matchDictionary)...)
+	matches = append(matches, dictionaryMatches(runes, userInputDictionary(userInputs), matchUserInput)...)
+	matches = append(matches, sequenceMatches(runes)...)
+	matches = append(matches, repeatMatches(runes)...)
+	matches = append(matches, keyboardMatches(runes)...)
+	matches = append(matches, yearMatches(runes)...)
+
+	best := make([]float64, n+1)
+	chosen := make([]*strengthMatch, n+1)
+	for k := 1; k <= n; k++ {
+		best[k] = best[k-1] + bruteforceBits
+		chosen[k] = nil
+		for idx := range matches {
+			m := &matches[idx]
+			if m.j != k {
+				continue
+			}
+			if candidate := best[m.i] + m.entropy; candidate < best[k] {
+				best[k] = candidate
+				chosen[k] = m
+			}
+		}
+	}
+
+	var path []*strengthMatch
+	for k := n; k > 0; {
+		if chosen[k] == nil {
+			k--
+			continue
+		}
+		path = append(path, chosen[k])
+		k = chosen[k].i
+	}
+
+	entropy := best[n]
+	result := StrengthResult{
+		Score:   scoreFromEntropy(entropy),
+		Entropy: math.Round(entropy*100) / 100,
+	}
+	result.Warning, result.Suggestions = strengthFeedback(result.Score, n, path)
+
+	return result
+}
+
+// scoreFromEntropy переводит энтропию в оценку 0..4 по порогам количества попыток zxcvbn.
+func scoreFromEntropy(entropy float64) int {
+	guessesLog10 := entropy * math.Log10(2)
+	switch {
+	case guessesLog10 < 3:
+		return 0
+	case guessesLog10 < 6:
+		return 1
+	case guessesLog10 < 8:
+		return 2
+	case guessesLog10 < 10:
+		return 3
+	default:
+		return 4
+	}
+}
+
+// rankedDictionary строит словарь слово -> ранг из списка строк.
+func rankedDictionary(words []string) map[string]int {
+	dict := make(map[string]int, len(words))
+	rank := 1
+	for _, word := range words {
+		word = strings.ToLower(strings.TrimSpace(word))
+		if word == "" {
+			continue
+		}
+		if _, exists := dict[word]; !exists {
+			dict[word] = rank
+			rank++
+		}
+	}
+	return dict
+}
+
+// userInputDictionary разбивает пользовательские данные на токены для поиска в пароле.
+func userInputDictionary(userInputs []string) map[string]int {
+	var tokens []string
+	for _, input := range userInputs {
+		input = strings.ToLower(strings.TrimSpace(input))
+		if input == "" {
+			continue
+		}
+		tokens = append(tokens, input)
+		tokens = append(tokens, strings.FieldsFunc(input, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})...)
+	}
+
+	filtered := tokens[:0]
+	for _, token := range tokens {
+		if len([]rune(token)) >= 3 {
+			filtered = append(filtered, token)
+		}
+	}
+	return rankedDictionary(filtered)
+}
+
+// dictionaryMatches ищет словарные слова, в том числе с l33t-заменами и в обратном порядке.
+func dictionaryMatches(runes []rune, dictionary map[string]int, kind matchKind) []strengthMatch {
+	if len(dictionary) == 0 {
+		return nil
+	}
+
+	lower := []rune(strings.ToLower(string(runes)))
+	unleet := make([]rune, len(lower))
+	for idx, r := range lower {
+		if sub, ok := leetSubstitutions[r]; ok {
+			unleet[idx] = sub
+		} else {
+			unleet[idx] = r
+		}
+	}
+
+	var matches []strengthMatch
+	for i := 0; i < len(runes); i++ {
+		for j := i + 3; j <= len(runes); j++ {
+			candidates := []struct {
+				word     string
+				leet     bool
+				reversed bool
+			}{
+				{string(lower[i:j]), false, false},
+				{string(unleet[i:j]), string(unleet[i:j]) != string(lower[i:j]), false},
+				{reverseString(string(lower[i:j])), false, true},
+			}
+			for _, c := range candidates {
+				rank, ok := dictionary[c.word]
+				if !ok {
+					continue
+				}
+				m := strengthMatch{i: i, j: j, kind: kind, rank: rank, leet: c.leet, reversed: c.reversed}
+				m.entropy = math.Log2(float64(rank)) + uppercaseBits(runes[i:j])
+				m.upper = uppercaseBits(runes[i:j]) > 0
+				if c.leet {
+					m.entropy += float64(countDiff(lower[i:j], unleet[i:j]))
+				}
+				if c.reversed {
+					m.entropy++
+				}
+				matches = append(matches, m)
+				break
+			}
+		}
+	}
+	return matches
+}
+
+// uppercaseBits оценивает дополнительную энтропию от заглавных букв в слове.
+func uppercaseBits(word []rune) float64 {
+	var upper, lower int
+	for _, r := range word {
+		switch {
+		case unicode.IsUpper(r):
+			upper++
+		case unicode.IsLower(r):
+			lower++
+		}
+	}
+	if upper == 0 {
+		return 0
+	}
+	if lower == 0 || (upper == 1 && unicode.IsUpper(word[0])) {
+		return 1
+	}
+
+	var variations float64
+	for k := 1; k <= upper && k <= upper+lower; k++ {
+		variations += binomial(upper+lower, k)
+	}
+	return math.Log2(variations)
+}
+
+// sequenceMatches ищет последовательности вида abc, 6543.
+func sequenceMatches(runes []rune) []strengthMatch {
+	var matches []strengthMatch
+	i := 0
+	for i < len(runes)-2 {
+		delta := runes[i+1] - runes[i]
+		if (delta != 1 && delta != -1) || charClass(runes[i]) != charClass(runes[i+1]) || charClass(runes[i]) == 0 {
+			i++
+			continue
+		}
+		j := i + 2
+		for j < len(runes) && runes[j]-runes[j-1] == delta && charClass(runes[j]) == charClass(runes[i]) {
+			j++
+		}
+		if j-i >= 3 {
+			var base float64
+			switch {
+			case strings.ContainsRune("aAzZ019", runes[i]):
+				base = 2
+			case unicode.IsDigit(runes[i]):
+				base = math.Log2(10)
+			default:
+				base = math.Log2(26)
+			}
+			entropy := base + math.Log2(float64(j-i))
+			if delta < 0 {
+				entropy++
+			}
+			matches = append(matches, strengthMatch{i: i, j: j, kind: matchSequence, entropy: entropy})
+		}
+		i = j - 1
+	}
+	return matches
+}
+
+// repeatMatches ищет повторяющиеся символы вида aaa.
+func repeatMatches(runes []rune) []strengthMatch {
+	var matches []strengthMatch
+	for i := 0; i < len(runes); {
+		j := i + 1
+		for j < len(runes) && runes[j] == runes[i] {
+			j++
+		}
+		if j-i >= 3 {
+			entropy := math.Log2(float64(charCardinality(runes[i]) * (j - i)))
+			matches = append(matches, strengthMatch{i: i, j: j, kind: matchRepeat, entropy: entropy})
+		}
+		i = j
+	}
+	return matches
+}
+
+// keyboardMatches ищет пути по соседним клавишам вида qwerty, 1qaz2wsx.
+func keyboardMatches(runes []rune) []strengthMatch {
+	var matches []strengthMatch
+	lower := []rune(strings.ToLower(string(runes)))
+	for i := 0; i < len(lower)-3; i++ {
+		j := i + 1
+		turns := 0
+		lastDirection := [2]int{}
+		for j < len(lower) {
+			direction, ok := keyboardDirection(lower[j-1], lower[j])
+			if !ok {
+				break
+			}
+			if j > i+1 && direction != lastDirection {
+				turns++
+			}
+			lastDirection = direction
+			j++
+		}
+		if j-i >= 4 {
+			entropy := math.Log2(47) + math.Log2(float64(j-i)) + float64(turns)*2
+			matches = append(matches, strengthMatch{i: i, j: j, kind: matchKeyboard, entropy: entropy})
+		}
+	}
+	return matches
+}
+
+// yearMatches ищет годы 1900-2039.
+func yearMatches(runes []rune) []strengthMatch {
+	var matches []strengthMatch
+	for i := 0; i+4 <= len(runes); i++ {
+		s := string(runes[i : i+4])
+		if (strings.HasPrefix(s, "19") || strings.HasPrefix(s, "20")) &&
+			unicode.IsDigit(runes[i+2]) && unicode.IsDigit(runes[i+3]) &&
+			(s[:2] == "19" || s[2] <= '3') {
+			matches = append(matches, strengthMatch{i: i, j: i + 4, kind: matchYear, entropy: math.Log2(140)})
+		}
+	}
+	return matches
+}
+
+// keyboardDirection возвращает направление между соседними клавишами.
+func keyboardDirection(a, b rune) ([2]int, bool) {
+	ra, ca, okA := keyboardPosition(a)
+	rb, cb, okB := keyboardPosition(b)
+	if !okA || !okB || a == b {
+		return [2]int{}, false
+	}
+	dr, dc := rb-ra, cb-ca
+	if dr < -1 || dr > 1 || dc < -1 || dc > 1 {
+		return [2]int{}, false
+	}
+	// Ряды клавиатуры смещены, поэтому диагональ (-1, -1) и (1, 1) не являются соседями.
+	if (dr == -1 && dc == -1) || (dr == 1 && dc == 1) {
+		return [2]int{}, false
+	}
+	return [2]int{dr, dc}, true
+}
+
+func keyboardPosition(r rune) (int, int, bool) {
+	for row, keys := range keyboardRows {
+		if col := strings.IndexRune(keys, r); col >= 0 {
+			return row, col, true
+		}
+	}
+	return 0, 0, false
+}
+
+// strengthFeedback формирует предупреждение и рекомендации по найденным шаблонам.
+func strengthFeedback(score, length int, path []*strengthMatch) (string, []string) {
+	if score >= 3 {
+		return "", nil
+	}
+
+	suggestions := []string{"Add another word or two. Uncommon words are better."}
+	if len(path) == 0 {
+		if length < 12 {
+			return "Short passwords are easy to guess", append(suggestions, "Use a longer password")
+		}
+		return "", suggestions
+	}
+
+	longest := path[0]
+	for _, m := range path[1:] {
+		if m.j-m.i > longest.j-longest.i {
+			longest = m
+		}
+	}
+
+	var warning string
+	switch longest.kind {
+	case matchDictionary:
+		switch {
+		case longest.i == 0 && longest.j == length && longest.rank <= 10 && !longest.leet && !longest.reversed:
+			warning = "This is a top-10 common password"
+		case longest.i == 0 && longest.j == length && !longest.leet && !longest.reversed:
+			warning = "This is a very common password"
+		default:
+			warning = "This is similar to a commonly used password"
+		}
+		if longest.upper {
+			suggestions = append(suggestions, "Capitalization doesn't help very much")
+		}
+		if longest.leet {
+			suggestions = append(suggestions, "Predictable substitutions like '@' instead of 'a' don't help very much")
+		}
+		if longest.reversed {
+			suggestions = append(suggestions, "Reversed words aren't much harder to guess")
+		}
+	case matchUserInput:
+		warning = "Passwords containing your email address are easy to guess"
+		suggestions = append(suggestions, "Avoid personal information in passwords")
+	case matchSequence:
+		warning = "Sequences like abc or 6543 are easy to guess"
+		suggestions = append(suggestions, "Avoid sequences")
+	case matchRepeat:
+		warning = `Repeats like "aaa" are easy to guess`
+		suggestions = append(suggestions, "Avoid repeated words and characters")
+	case matchKeyboard:
+		warning = "Straight rows of keys are easy to guess"
+		suggestions = append(suggestions, "Use a longer keyboard pattern with more turns")
+	case matchYear:
+		warning = "Recent years are easy to guess"
+		suggestions = append(suggestions, "Avoid recent years and years that are associated with you")
+	}
+
+	return warning, suggestions
+}
+
+func charClass(r rune) int {
+	switch {
+	case r >= 'a' && r <= 'z':
+		return 1
+	case r >= 'A' && r <= 'Z':
+		return 2
+	case r >= '0' && r <= '9':
+		return 3
+	default:
+		return 0
+	}
+}
+
+func charCardinality(r rune) int {
+	switch charClass(r) {
+	case 1, 2:
+		return 26
+	case 3:
+		return 10
+	default:
+		return 33
+	}
+}
+
+func countDiff(a, b []rune) int {
+	diff := 0
+	for idx := range a {
+		if a[idx] != b[idx] {
+			diff++
+		}
+	}
+	return diff
+}
+
+func reverseString(s string) string {
+	runes := []rune(s)
+	for i, j := 0, len(runes)-1; i < j; i, j = i+1, j-1 {
+		runes[i], runes[j] = runes[j], runes[i]
+	}
+	return string(runes)
+}
+
+func binomial(n, k int) float64 {
+	if k > n {
+		return 0
+	}
+	result := 1.0
+	for i := 1; i <= k; i++ {
+		result = result * float64(n-k+i) / float64(i)
+	}
+	return result
+}
@@ -0,0 +1,39 @@
+package validator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const maxTagsPerRequest = 50
+
+var tagNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9_.:-]{0,63}$`)
+
+// NormalizeTags приводит имена тегов к нижнему регистру, удаляет повторы и проверяет их формат.
+func (v *Validator) NormalizeTags(tags []string) ([]string, error) {
+	if len(tags) == 0 {
+		return nil, &ValidationError{Field: "tags", Message: "at least one tag is required"}
+	}
+
+	if len(tags) > maxTagsPerRequest {
+		return nil, &ValidationError{Field: "tags", Message: fmt.Sprintf("at most %d tags are allowed", maxTagsPerRequest)}
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !tagNameRegex.MatchString(tag) {
+			return nil, &ValidationError{Field: "tags", Message: fmt.Sprintf("invalid tag %q: tags must start with a letter or digit and contain only letters, digits, '_', '.', ':' and '-' (up to 64 characters)", tag)}
+		}
+
+		if _, ok := seen[tag]; ok {
+			continue
+		}
+		seen[tag] = struct{}{}
+		normalized = append(normalized, tag)
+	}
+
+	return normalized, nil
+}
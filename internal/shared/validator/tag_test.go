@@ -0,0 +1,37 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidator_NormalizeTags(t *testing.T) {
+	v := NewValidator()
+
+	tags, err := v.NormalizeTags([]string{" Prod ", "aws", "prod", "env:staging"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"prod", "aws", "env:staging"}, tags)
+
+	invalid := []struct {
+		name string
+		tags []string
+	}{
+		{"empty list", nil},
+		{"empty tag", []string{"prod", " "}},
+		{"leading dash", []string{"-prod"}},
+		{"space inside", []string{"two words"}},
+		{"exclude prefix", []string{"!prod"}},
+	}
+
+	for _, tt := range invalid {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := v.NormalizeTags(tt.tags)
+
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			assert.Equal(t, "tags", validationErr.Field)
+		})
+	}
+}
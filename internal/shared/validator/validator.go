@@ -28,27 +28,52 @@ func (v *Validator) ValidateEmail(email string) error {
 	return nil
 }
 
-// ValidatePassword проверяет корректность пароля.
-func (v *Validator) ValidatePassword(password string) error {
-	if password == "" {
-		return &ValidationError{Field: "password", Message: "password is required"}
+// ValidateDataName проверяет корректность имени данных.
+func (v *Validator) ValidateDataName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return &ValidationError{Field: "name", Message: "name is required"}
 	}
 
-	if len(password) < 8 {
-		return &ValidationError{Field: "password", Message: "password must be at least 8 characters long"}
+	if len(name) > 255 {
+		return &ValidationError{Field: "name", Message: "name must be less than 255 characters"}
 	}
 
 	return nil
 }
 
-// ValidateDataName проверяет корректность имени данных.
-func (v *Validator) ValidateDataName(name string) error {
+// ValidateFolderName проверяет корректность имени папки.
+func (v *Validator) ValidateFolderName(name string) error {
 	if strings.TrimSpace(name) == "" {
-		return &ValidationError{Field: "name", Message: "name is required"}
+		return &ValidationError{Field: "name", Message: "folder name is required"}
+	}
+
+	if strings.TrimSpace(name) != name {
+		return &ValidationError{Field: "name", Message: "folder name must not start or end with spaces"}
+	}
+
+	if strings.Contains(name, "/") {
+		return &ValidationError{Field: "name", Message: "folder name must not contain '/'"}
 	}
 
 	if len(name) > 255 {
-		return &ValidationError{Field: "name", Message: "name must be less than 255 characters"}
+		return &ValidationError{Field: "name", Message: "folder name must be less than 255 characters"}
+	}
+
+	return nil
+}
+
+// ValidateAttachmentFilename проверяет корректность имени файла вложения.
+func (v *Validator) ValidateAttachmentFilename(filename string) error {
+	if strings.TrimSpace(filename) == "" {
+		return &ValidationError{Field: "filename", Message: "filename is required"}
+	}
+
+	if strings.ContainsAny(filename, "/\\") || filename == "." || filename == ".." {
+		return &ValidationError{Field: "filename", Message: "filename must not contain path separators"}
+	}
+
+	if len(filename) > 255 {
+		return &ValidationError{Field: "filename", Message: "filename must be less than 255 characters"}
 	}
 
 	return nil
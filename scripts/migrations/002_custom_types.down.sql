@@ -0,0 +1,9 @@
+-- Drop indexes for custom_types table
+DROP INDEX IF EXISTS idx_custom_types_user_id;
+
+-- Drop custom_types table
+DROP TABLE IF EXISTS custom_types;
+
+-- Remove data items of user-defined types and restore the type constraint
+DELETE FROM data_items WHERE type NOT IN ('login_password', 'text_data', 'binary_data', 'bank_card');
+ALTER TABLE data_items ADD CONSTRAINT data_items_type_check CHECK (type IN ('login_password', 'text_data', 'binary_data', 'bank_card'));
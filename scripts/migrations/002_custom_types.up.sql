@@ -0,0 +1,17 @@
+-- Allow user-defined data types
+ALTER TABLE data_items DROP CONSTRAINT IF EXISTS data_items_type_check;
+
+-- Create custom_types table
+CREATE TABLE custom_types (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    description TEXT,
+    fields JSONB NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT custom_types_user_id_name UNIQUE (user_id, name)
+);
+
+-- Create indexes for custom_types table
+CREATE INDEX idx_custom_types_user_id ON custom_types(user_id);
@@ -0,0 +1,12 @@
+-- Drop indexes for data_items folder reference
+DROP INDEX IF EXISTS idx_data_items_user_id_folder_id;
+
+-- Drop indexes for folders table
+DROP INDEX IF EXISTS idx_folders_parent_id;
+DROP INDEX IF EXISTS idx_folders_user_id;
+
+-- Remove folder reference from data_items table
+ALTER TABLE data_items DROP COLUMN IF EXISTS folder_id;
+
+-- Drop folders table
+DROP TABLE IF EXISTS folders;
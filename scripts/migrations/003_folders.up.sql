@@ -0,0 +1,19 @@
+-- Create folders table
+CREATE TABLE folders (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    parent_id UUID REFERENCES folders(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Add folder reference to data_items table
+ALTER TABLE data_items ADD COLUMN folder_id UUID REFERENCES folders(id) ON DELETE SET NULL;
+
+-- Create indexes for folders table
+CREATE INDEX idx_folders_user_id ON folders(user_id);
+CREATE INDEX idx_folders_parent_id ON folders(parent_id);
+
+-- Create indexes for data_items folder reference
+CREATE INDEX idx_data_items_user_id_folder_id ON data_items(user_id, folder_id);
@@ -0,0 +1,6 @@
+-- Drop indexes for data_item_tags table
+DROP INDEX IF EXISTS idx_data_item_tags_tag_id;
+
+-- Drop tables in reverse order (due to foreign key constraints)
+DROP TABLE IF EXISTS data_item_tags;
+DROP TABLE IF EXISTS tags;
@@ -0,0 +1,18 @@
+-- Create tags table
+CREATE TABLE tags (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW(),
+    CONSTRAINT tags_user_id_name UNIQUE (user_id, name)
+);
+
+-- Create data_item_tags table
+CREATE TABLE data_item_tags (
+    data_id UUID NOT NULL REFERENCES data_items(id) ON DELETE CASCADE,
+    tag_id UUID NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (data_id, tag_id)
+);
+
+-- Create indexes for data_item_tags table
+CREATE INDEX idx_data_item_tags_tag_id ON data_item_tags(tag_id);
@@ -0,0 +1,8 @@
+-- Drop unique index for data_versions table
+DROP INDEX IF EXISTS idx_data_versions_data_id_version_unique;
+
+-- Drop content columns from data_versions table
+ALTER TABLE data_versions DROP COLUMN IF EXISTS restored_from;
+ALTER TABLE data_versions DROP COLUMN IF EXISTS encrypted_data;
+ALTER TABLE data_versions DROP COLUMN IF EXISTS metadata;
+ALTER TABLE data_versions DROP COLUMN IF EXISTS name;
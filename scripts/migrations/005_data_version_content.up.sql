@@ -0,0 +1,8 @@
+-- Store full content in data_versions table
+ALTER TABLE data_versions ADD COLUMN name VARCHAR(255);
+ALTER TABLE data_versions ADD COLUMN metadata TEXT;
+ALTER TABLE data_versions ADD COLUMN encrypted_data BYTEA;
+ALTER TABLE data_versions ADD COLUMN restored_from BIGINT;
+
+-- Each version number is unique per data item
+CREATE UNIQUE INDEX idx_data_versions_data_id_version_unique ON data_versions(data_id, version);
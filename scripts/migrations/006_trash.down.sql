@@ -0,0 +1,5 @@
+-- Drop indexes for trash lookups and purging
+DROP INDEX IF EXISTS idx_data_items_deleted_at;
+
+-- Remove soft delete from data_items table
+ALTER TABLE data_items DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,5 @@
+-- Add soft delete to data_items table
+ALTER TABLE data_items ADD COLUMN deleted_at TIMESTAMP WITH TIME ZONE;
+
+-- Create indexes for trash lookups and purging
+CREATE INDEX idx_data_items_deleted_at ON data_items(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,5 @@
+-- Drop indexes for attachments table
+DROP INDEX IF EXISTS idx_attachments_data_id;
+
+-- Drop attachments table
+DROP TABLE IF EXISTS attachments;
@@ -0,0 +1,15 @@
+-- Create attachments table
+CREATE TABLE attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    data_id UUID NOT NULL REFERENCES data_items(id) ON DELETE CASCADE,
+    filename VARCHAR(255) NOT NULL,
+    mime_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    checksum VARCHAR(64) NOT NULL,
+    encrypted_content BYTEA NOT NULL,
+    encryption_key BYTEA NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW()
+);
+
+-- Create indexes for attachments table
+CREATE INDEX idx_attachments_data_id ON attachments(data_id);
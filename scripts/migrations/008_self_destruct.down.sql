@@ -0,0 +1,13 @@
+-- Drop indexes for data_tombstones table
+DROP INDEX IF EXISTS idx_data_tombstones_user_id_deleted_at;
+
+-- Drop data_tombstones table
+DROP TABLE IF EXISTS data_tombstones;
+
+-- Drop index for purging expired data items
+DROP INDEX IF EXISTS idx_data_items_expires_at;
+
+-- Remove self-destruct settings from data_items table
+ALTER TABLE data_items DROP COLUMN IF EXISTS read_count;
+ALTER TABLE data_items DROP COLUMN IF EXISTS max_reads;
+ALTER TABLE data_items DROP COLUMN IF EXISTS expires_at;
@@ -0,0 +1,17 @@
+-- Add self-destruct settings to data_items table
+ALTER TABLE data_items ADD COLUMN expires_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE data_items ADD COLUMN max_reads INTEGER CHECK (max_reads > 0);
+ALTER TABLE data_items ADD COLUMN read_count INTEGER NOT NULL DEFAULT 0;
+
+-- Create index for purging expired data items
+CREATE INDEX idx_data_items_expires_at ON data_items(expires_at) WHERE expires_at IS NOT NULL;
+
+-- Create data_tombstones table for propagating purged data items to clients
+CREATE TABLE data_tombstones (
+    data_id UUID PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    deleted_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for data_tombstones table
+CREATE INDEX idx_data_tombstones_user_id_deleted_at ON data_tombstones(user_id, deleted_at);
@@ -0,0 +1,6 @@
+-- Remove password rotation interval from folders table
+ALTER TABLE folders DROP COLUMN IF EXISTS rotation_days;
+
+-- Remove password rotation tracking from data_items table
+ALTER TABLE data_items DROP COLUMN IF EXISTS rotation_days;
+ALTER TABLE data_items DROP COLUMN IF EXISTS password_changed_at;
@@ -0,0 +1,9 @@
+-- Add password rotation tracking to data_items table
+ALTER TABLE data_items ADD COLUMN password_changed_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE data_items ADD COLUMN rotation_days INTEGER CHECK (rotation_days > 0);
+
+-- Existing passwords are assumed to have changed with the last update
+UPDATE data_items SET password_changed_at = updated_at WHERE type = 'login_password';
+
+-- Add password rotation interval to folders table
+ALTER TABLE folders ADD COLUMN rotation_days INTEGER CHECK (rotation_days > 0);
@@ -0,0 +1,5 @@
+-- Drop indexes for password_history table
+DROP INDEX IF EXISTS idx_password_history_data_id_changed_at;
+
+-- Drop password_history table
+DROP TABLE IF EXISTS password_history;
@@ -0,0 +1,11 @@
+-- Create password_history table
+CREATE TABLE password_history (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    data_id UUID NOT NULL REFERENCES data_items(id) ON DELETE CASCADE,
+    encrypted_password BYTEA NOT NULL,
+    set_at TIMESTAMP WITH TIME ZONE,
+    changed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Create indexes for password_history table
+CREATE INDEX idx_password_history_data_id_changed_at ON password_history(data_id, changed_at DESC);
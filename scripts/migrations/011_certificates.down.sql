@@ -0,0 +1,2 @@
+-- Remove certificate details from data_items table
+ALTER TABLE data_items DROP COLUMN IF EXISTS certificate;
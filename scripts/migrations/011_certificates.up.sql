@@ -0,0 +1,2 @@
+-- Add non-secret certificate details to data_items table
+ALTER TABLE data_items ADD COLUMN certificate JSONB;
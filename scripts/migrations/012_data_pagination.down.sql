@@ -0,0 +1,5 @@
+-- Drop cursor-based pagination indexes
+DROP INDEX IF EXISTS idx_data_tombstones_user_id_deleted_at_data_id;
+DROP INDEX IF EXISTS idx_data_items_user_id_name_id;
+DROP INDEX IF EXISTS idx_data_items_user_id_created_at_id;
+DROP INDEX IF EXISTS idx_data_items_user_id_updated_at_id;
@@ -0,0 +1,5 @@
+-- Create indexes for cursor-based pagination of data items and sync
+CREATE INDEX idx_data_items_user_id_updated_at_id ON data_items(user_id, updated_at, id);
+CREATE INDEX idx_data_items_user_id_created_at_id ON data_items(user_id, created_at, id);
+CREATE INDEX idx_data_items_user_id_name_id ON data_items(user_id, name, id);
+CREATE INDEX idx_data_tombstones_user_id_deleted_at_data_id ON data_tombstones(user_id, deleted_at, data_id);
@@ -0,0 +1,3 @@
+-- Remove full-text search over data item metadata
+DROP INDEX IF EXISTS idx_data_items_metadata_tsv;
+ALTER TABLE data_items DROP COLUMN IF EXISTS metadata_tsv;
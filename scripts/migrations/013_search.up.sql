@@ -0,0 +1,5 @@
+-- Add full-text search over data item metadata
+ALTER TABLE data_items ADD COLUMN metadata_tsv TSVECTOR GENERATED ALWAYS AS (to_tsvector('simple', coalesce(metadata, ''))) STORED;
+
+-- Create indexes for data item search
+CREATE INDEX idx_data_items_metadata_tsv ON data_items USING GIN (metadata_tsv);
@@ -0,0 +1,5 @@
+-- Drop indexes for data_blind_indexes table
+DROP INDEX IF EXISTS idx_data_blind_indexes_user_id_token;
+
+-- Drop data_blind_indexes table
+DROP TABLE IF EXISTS data_blind_indexes;
@@ -0,0 +1,10 @@
+-- Create data_blind_indexes table
+CREATE TABLE data_blind_indexes (
+    data_id UUID NOT NULL REFERENCES data_items(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token BYTEA NOT NULL,
+    PRIMARY KEY (data_id, token)
+);
+
+-- Create indexes for data_blind_indexes table
+CREATE INDEX idx_data_blind_indexes_user_id_token ON data_blind_indexes(user_id, token);
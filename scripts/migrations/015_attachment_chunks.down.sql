@@ -0,0 +1,9 @@
+-- Drop attachment_chunks table
+DROP TABLE IF EXISTS attachment_chunks;
+
+-- Chunked attachments have no content without attachment_chunks table
+DELETE FROM attachments WHERE encrypted_content IS NULL;
+
+-- Remove chunk count from attachments table
+ALTER TABLE attachments ALTER COLUMN encrypted_content SET NOT NULL;
+ALTER TABLE attachments DROP COLUMN IF EXISTS chunks;
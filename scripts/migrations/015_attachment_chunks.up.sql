@@ -0,0 +1,12 @@
+-- Attachment content is stored in chunks; attachments uploaded earlier keep
+-- their whole content in encrypted_content
+ALTER TABLE attachments ADD COLUMN chunks INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE attachments ALTER COLUMN encrypted_content DROP NOT NULL;
+
+-- Create attachment_chunks table
+CREATE TABLE attachment_chunks (
+    attachment_id UUID NOT NULL REFERENCES attachments(id) ON DELETE CASCADE,
+    seq INTEGER NOT NULL,
+    encrypted_content BYTEA NOT NULL,
+    PRIMARY KEY (attachment_id, seq)
+);